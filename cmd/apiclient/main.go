@@ -0,0 +1,37 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/openapi"
+)
+
+// Generates the Go client of the HTTP API from the OpenAPI document,
+// used by `go generate`
+func main() {
+	spec := flag.String("spec", "openapi.json", "path to the OpenAPI document")
+	packageName := flag.String("package", "", "package name of the client")
+	out := flag.String("out", "", "path to the generated file")
+	flag.Parse()
+	if err := run(*spec, *packageName, *out); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(spec string, packageName string, out string) error {
+	if packageName == "" || out == "" {
+		return fmt.Errorf("package and out flags are required")
+	}
+	data, err := os.ReadFile(spec)
+	if err != nil {
+		return err
+	}
+	src, err := openapi.GenerateClient(data, packageName)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(out, src, 0o644)
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	webpush_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/webpush"
+)
+
+// Prints a new VAPID key pair, the private key goes to `WEB_PUSH_VAPID_PRIVATE_KEY`,
+// the web app receives the public key from the API
+func main() {
+	privateKey, err := webpush_adapters.GenerateVapidPrivateKey()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	vapid, err := webpush_adapters.NewVapid(privateKey, "mailto:")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	fmt.Printf("private key: %s\npublic key: %s\n", privateKey, vapid.PublicKey())
+}
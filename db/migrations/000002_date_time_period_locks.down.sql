@@ -0,0 +1,3 @@
+DROP TABLE slot_hold;
+
+DROP TABLE date_time_period_lock;
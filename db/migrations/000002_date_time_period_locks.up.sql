@@ -0,0 +1,18 @@
+CREATE TABLE date_time_period_lock (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  owner TEXT NOT NULL,
+  period_start TEXT NOT NULL,
+  period_end TEXT NOT NULL,
+  expires_at INTEGER NOT NULL
+);
+
+CREATE INDEX date_time_period_lock_period_idx ON date_time_period_lock (period_start, period_end);
+
+CREATE TABLE slot_hold (
+  owner TEXT PRIMARY KEY,
+  period_start TEXT NOT NULL,
+  period_end TEXT NOT NULL,
+  expires_at INTEGER NOT NULL
+);
+
+CREATE INDEX slot_hold_period_start_idx ON slot_hold (period_start);
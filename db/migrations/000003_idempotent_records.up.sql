@@ -0,0 +1,7 @@
+CREATE TABLE idempotent_record (
+  key TEXT PRIMARY KEY,
+  record TEXT,
+  expires_at INTEGER NOT NULL
+);
+
+CREATE INDEX idempotent_record_expires_at_idx ON idempotent_record (expires_at);
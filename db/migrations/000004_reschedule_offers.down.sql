@@ -0,0 +1 @@
+DROP TABLE reschedule_offer;
@@ -0,0 +1,7 @@
+CREATE TABLE reschedule_offer (
+  id TEXT PRIMARY KEY,
+  offer TEXT NOT NULL,
+  created_at INTEGER NOT NULL
+);
+
+CREATE INDEX reschedule_offer_created_at_idx ON reschedule_offer (created_at);
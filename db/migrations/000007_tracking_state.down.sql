@@ -0,0 +1,3 @@
+DROP TABLE tracking_state;
+
+DROP TABLE tracking_records;
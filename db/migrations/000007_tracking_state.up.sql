@@ -0,0 +1,10 @@
+CREATE TABLE tracking_records (
+  id TEXT PRIMARY KEY,
+  record TEXT NOT NULL
+);
+
+CREATE TABLE tracking_state (
+  id INTEGER PRIMARY KEY CHECK (id = 1),
+  cursor INTEGER NOT NULL,
+  reconciled_at INTEGER NOT NULL
+);
@@ -0,0 +1,14 @@
+CREATE TABLE audit_log (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  record_id TEXT NOT NULL,
+  action TEXT NOT NULL,
+  actor_type TEXT NOT NULL,
+  actor_identity TEXT NOT NULL DEFAULT '',
+  before TEXT,
+  after TEXT,
+  reason TEXT NOT NULL DEFAULT '',
+  occurred_at INTEGER NOT NULL
+);
+
+CREATE INDEX audit_log_record_id_idx ON audit_log (record_id);
+CREATE INDEX audit_log_occurred_at_idx ON audit_log (occurred_at);
@@ -0,0 +1,4 @@
+CREATE TABLE customer_notification_channel (
+  customer_id TEXT PRIMARY KEY,
+  channel TEXT NOT NULL
+);
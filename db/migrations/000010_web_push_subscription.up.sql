@@ -0,0 +1,8 @@
+CREATE TABLE web_push_subscription (
+  endpoint TEXT PRIMARY KEY,
+  customer_identity TEXT NOT NULL,
+  p256dh TEXT NOT NULL,
+  auth TEXT NOT NULL
+);
+
+CREATE INDEX web_push_subscription_customer_identity_idx ON web_push_subscription (customer_identity);
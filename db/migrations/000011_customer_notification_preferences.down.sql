@@ -0,0 +1,9 @@
+DROP TABLE customer_notification_preferences;
+
+DELETE FROM customer_notification_channel WHERE channels LIKE 'web_push%';
+
+UPDATE customer_notification_channel
+SET channels = substr(channels, 1, instr(channels, ',') - 1)
+WHERE instr(channels, ',') > 0;
+
+ALTER TABLE customer_notification_channel RENAME COLUMN channels TO channel;
@@ -0,0 +1,9 @@
+-- The chosen channel becomes the first item of the channels list
+ALTER TABLE customer_notification_channel RENAME COLUMN channel TO channels;
+
+CREATE TABLE customer_notification_preferences (
+  customer_id TEXT PRIMARY KEY,
+  muted_events TEXT NOT NULL DEFAULT '',
+  quiet_hours_start INTEGER,
+  quiet_hours_end INTEGER
+);
@@ -0,0 +1,5 @@
+CREATE TABLE customer_locale (
+  customer_identity TEXT PRIMARY KEY,
+  preferred TEXT NOT NULL DEFAULT '',
+  detected TEXT NOT NULL DEFAULT ''
+);
@@ -0,0 +1 @@
+DROP TABLE deferred_notification;
@@ -0,0 +1,8 @@
+CREATE TABLE deferred_notification (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  payload BLOB NOT NULL,
+  channels TEXT NOT NULL,
+  send_at INTEGER NOT NULL
+);
+
+CREATE INDEX deferred_notification_send_at_idx ON deferred_notification (send_at);
@@ -9,6 +9,7 @@ require (
 	github.com/jomei/notionapi v1.13.2
 	github.com/telegram-mini-apps/init-data-golang v1.1.5
 	github.com/x0k/vert v0.0.0-20240519105809-532dac6d76e0
+	modernc.org/sqlite v1.33.1
 )
 
 require (
@@ -22,12 +23,10 @@ require (
 	go.uber.org/atomic v1.11.0 // indirect
 	golang.org/x/exp v0.0.0-20240909161429-701f63a606c0 // indirect
 	golang.org/x/sys v0.25.0 // indirect
-	golang.org/x/tools v0.25.0 // indirect
 	modernc.org/gc/v3 v3.0.0-20240801135723-a856999a2e4a // indirect
 	modernc.org/libc v1.61.0 // indirect
 	modernc.org/mathutil v1.6.0 // indirect
 	modernc.org/memory v1.8.0 // indirect
-	modernc.org/sqlite v1.33.1 // indirect
 	modernc.org/strutil v1.2.0 // indirect
 	modernc.org/token v1.1.0 // indirect
 )
@@ -0,0 +1,113 @@
+package http_adapters
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	initdata "github.com/telegram-mini-apps/init-data-golang"
+	telegram_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/telegram"
+	vk_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/vk"
+)
+
+var ErrNoTelegramUser = errors.New("init data does not contain a user")
+
+const (
+	telegramAuthScheme = "tma"
+	vkAuthScheme       = "vk"
+)
+
+type identityContextKey[T any] struct{}
+
+func WithIdentity[T any](ctx context.Context, identity T) context.Context {
+	return context.WithValue(ctx, identityContextKey[T]{}, identity)
+}
+
+func IdentityFromContext[T any](ctx context.Context) (T, bool) {
+	identity, ok := ctx.Value(identityContextKey[T]{}).(T)
+	return identity, ok
+}
+
+// Parses `Authorization: <scheme> <credentials>` header,
+// returns false when the header has another scheme
+func authorizationCredentials(r *http.Request, scheme string) (string, bool) {
+	s, credentials, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(s, scheme) {
+		return "", false
+	}
+	return strings.TrimSpace(credentials), true
+}
+
+// Authenticates requests with `Authorization: <scheme> <credentials>` header
+// and puts the resolved identity into the request context.
+// Requests with another scheme are passed through unauthenticated.
+func credentialsAuth[T any](
+	scheme string,
+	authenticate func(credentials string) (T, error),
+	next http.Handler,
+) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		credentials, ok := authorizationCredentials(r, scheme)
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+		identity, err := authenticate(credentials)
+		if err != nil {
+			NewJSONError(http.StatusUnauthorized).Write(w)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(WithIdentity(r.Context(), identity)))
+	})
+}
+
+// Authenticates a Telegram Mini App user by the `Authorization: tma <initData>` header
+func TelegramInitDataAuth[T any](
+	parser telegram_adapters.InitDataParser,
+	resolve func(initdata.InitData) (T, error),
+	next http.Handler,
+) http.Handler {
+	return credentialsAuth(telegramAuthScheme, func(credentials string) (T, error) {
+		if err := parser.Validate(credentials); err != nil {
+			return *new(T), err
+		}
+		data, err := parser.Parse(credentials)
+		if err != nil {
+			return *new(T), err
+		}
+		if data.User.ID == 0 {
+			return *new(T), ErrNoTelegramUser
+		}
+		return resolve(data)
+	}, next)
+}
+
+// Authenticates a VK Mini App user by the `Authorization: vk <launch params>` header
+func VkLaunchParamsAuth[T any](
+	parser vk_adapters.LaunchParamsParser,
+	resolve func(vk_adapters.LaunchParams) (T, error),
+	next http.Handler,
+) http.Handler {
+	return credentialsAuth(vkAuthScheme, func(credentials string) (T, error) {
+		if err := parser.Validate(credentials); err != nil {
+			return *new(T), err
+		}
+		params, err := parser.Parse(credentials)
+		if err != nil {
+			return *new(T), err
+		}
+		return resolve(params)
+	}, next)
+}
+
+// Rejects requests without an authenticated identity
+func RequireIdentity[T any](next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := IdentityFromContext[T](r.Context()); !ok {
+			NewJSONError(http.StatusUnauthorized).Write(w)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,64 @@
+package http_adapters
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	initdata "github.com/telegram-mini-apps/init-data-golang"
+	telegram_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/telegram"
+)
+
+func TestTelegramInitDataAuth(t *testing.T) {
+	const token = "123:token"
+	authDate := time.Now()
+	signed := func(query url.Values) string {
+		hash, err := initdata.SignQueryString(query.Encode(), token, authDate)
+		if err != nil {
+			t.Fatal(err)
+		}
+		query.Set("auth_date", strconv.FormatInt(authDate.Unix(), 10))
+		query.Set("hash", hash)
+		return query.Encode()
+	}
+	handler := TelegramInitDataAuth(
+		telegram_adapters.NewInitDataParser(token, time.Hour),
+		func(data initdata.InitData) (int64, error) {
+			return data.User.ID, nil
+		},
+		RequireIdentity[int64](http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id, _ := IdentityFromContext[int64](r.Context())
+			w.Write([]byte(strconv.FormatInt(id, 10)))
+		})),
+	)
+	cases := []struct {
+		name          string
+		authorization string
+		status        int
+		body          string
+	}{
+		{"no header", "", http.StatusUnauthorized, ""},
+		{"valid", "tma " + signed(url.Values{"user": {`{"id":42}`}}), http.StatusOK, "42"},
+		{"no user", "tma " + signed(url.Values{"query_id": {"q"}}), http.StatusUnauthorized, ""},
+		{"tampered", "tma " + signed(url.Values{"user": {`{"id":42}`}}) + "&query_id=q", http.StatusUnauthorized, ""},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			if c.authorization != "" {
+				r.Header.Set("Authorization", c.authorization)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+			if w.Code != c.status {
+				t.Fatalf("status = %d, want %d", w.Code, c.status)
+			}
+			if c.body != "" && w.Body.String() != c.body {
+				t.Errorf("body = %q, want %q", w.Body.String(), c.body)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package http_adapters
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+type ErrorDTO struct {
+	Error string `json:"error"`
+}
+
+type JSONResponse struct {
+	Status int
+	Body   any
+}
+
+func NewJSONResponse(status int, body any) JSONResponse {
+	return JSONResponse{
+		Status: status,
+		Body:   body,
+	}
+}
+
+func NewJSONError(status int) JSONResponse {
+	return NewJSONResponse(status, ErrorDTO{
+		Error: http.StatusText(status),
+	})
+}
+
+func (r JSONResponse) Write(w http.ResponseWriter) error {
+	if r.Body == nil {
+		w.WriteHeader(r.Status)
+		return nil
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(r.Status)
+	return json.NewEncoder(w).Encode(r.Body)
+}
@@ -0,0 +1,34 @@
+package pubsub_adapters
+
+import (
+	"slices"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/pubsub"
+)
+
+// Persists events of the given types instead of publishing them,
+// they are delivered later by the outbox relay
+type OutboxPublisher[T pubsub.EventType] struct {
+	publisher pubsub.Publisher[T]
+	saver     func(pubsub.Event[T]) error
+	types     []T
+}
+
+func NewOutboxPublisher[T pubsub.EventType](
+	publisher pubsub.Publisher[T],
+	saver func(pubsub.Event[T]) error,
+	types ...T,
+) *OutboxPublisher[T] {
+	return &OutboxPublisher[T]{
+		publisher: publisher,
+		saver:     saver,
+		types:     types,
+	}
+}
+
+func (p *OutboxPublisher[T]) Publish(event pubsub.Event[T]) error {
+	if slices.Contains(p.types, event.Type()) {
+		return p.saver(event)
+	}
+	return p.publisher.Publish(event)
+}
@@ -8,15 +8,21 @@ import (
 	"github.com/x0k/veterinary-clinic-backend/internal/lib/pubsub"
 )
 
-type handler[T pubsub.EventType, E pubsub.Event[T]] chan<- E
+type handler[T pubsub.EventType, E pubsub.Event[T]] struct {
+	channel chan<- E
+	done    <-chan struct{}
+}
 
-func (h handler[T, E]) Type() T {
+func (h *handler[T, E]) Type() T {
 	var e E
 	return e.Type()
 }
 
-func (h handler[T, E]) Handle(event pubsub.Event[T]) {
-	h <- event.(E)
+func (h *handler[T, E]) Handle(event pubsub.Event[T]) {
+	select {
+	case h.channel <- event.(E):
+	case <-h.done:
+	}
 }
 
 func Subscribe[T pubsub.EventType, E pubsub.Event[T]](
@@ -24,11 +30,14 @@ func Subscribe[T pubsub.EventType, E pubsub.Event[T]](
 	preStopper module.PreStopper,
 ) <-chan E {
 	channel := make(chan E)
-	h := handler[T, E](channel)
+	done := make(chan struct{})
+	h := &handler[T, E]{channel: channel, done: done}
 	unSubscribe := subs.AddHandler(h)
 	preStopper.PreStop(module.NewHook(
 		fmt.Sprintf("event_handler_%v", h.Type()),
 		func(_ context.Context) error {
+			// Unblocks pending deliveries before the channel is closed
+			close(done)
 			unSubscribe()
 			close(channel)
 			return nil
@@ -0,0 +1,77 @@
+package sms_adapters
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+var ErrUnexpectedStatus = errors.New("unexpected status")
+
+type Message struct {
+	// International format, e.g. +79991234567
+	Phone string
+	Text  string
+}
+
+// SMS provider
+type Gateway interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+type httpGatewayRequest struct {
+	To   string `json:"to"`
+	Text string `json:"text"`
+	From string `json:"from,omitempty"`
+}
+
+// Posts messages as JSON `{"to", "text", "from"}` with an optional bearer token,
+// any 2xx response is treated as accepted
+type HttpGateway struct {
+	client *http.Client
+	url    string
+	token  string
+	from   string
+}
+
+func NewHttpGateway(client *http.Client, url string, token string, from string) *HttpGateway {
+	return &HttpGateway{
+		client: client,
+		url:    url,
+		token:  token,
+		from:   from,
+	}
+}
+
+func (g *HttpGateway) Send(ctx context.Context, msg Message) error {
+	body, err := json.Marshal(httpGatewayRequest{
+		To:   msg.Phone,
+		Text: msg.Text,
+		From: g.from,
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, g.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if g.token != "" {
+		req.Header.Set("Authorization", "Bearer "+g.token)
+	}
+	res, err := g.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		text, _ := io.ReadAll(io.LimitReader(res.Body, 512))
+		return fmt.Errorf("%w: %d %s", ErrUnexpectedStatus, res.StatusCode, text)
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package sms_adapters
+
+import "context"
+
+type Sender struct {
+	gateway       Gateway
+	transliterate bool
+	maxSegments   int
+}
+
+// Transliteration keeps Cyrillic texts in GSM-7 which fits
+// more than twice as many characters into a segment
+func NewSender(gateway Gateway, transliterate bool, maxSegments int) *Sender {
+	return &Sender{
+		gateway:       gateway,
+		transliterate: transliterate,
+		maxSegments:   max(maxSegments, 1),
+	}
+}
+
+func (s *Sender) Send(ctx context.Context, msg Message) error {
+	if s.transliterate {
+		msg.Text = Transliterate(msg.Text)
+	}
+	msg.Text = Truncate(msg.Text, s.maxSegments)
+	return s.gateway.Send(ctx, msg)
+}
@@ -0,0 +1,69 @@
+package sms_adapters
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestTransliterate(t *testing.T) {
+	got := Transliterate("Запись «Щенок» — 10:00 №5")
+	want := `Zapis "Shchenok" - 10:00 N5`
+	if got != want {
+		t.Errorf("Transliterate() = %q, want %q", got, want)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		name        string
+		text        string
+		maxSegments int
+		wantLength  int
+	}{
+		{"short gsm", "Hello", 1, 5},
+		{"long gsm", strings.Repeat("a", 200), 1, 160},
+		{"long gsm in two segments", strings.Repeat("a", 400), 2, 306},
+		{"long ucs2", strings.Repeat("я", 100), 1, 70},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Truncate(tt.text, tt.maxSegments)
+			if l := len([]rune(got)); l != tt.wantLength {
+				t.Errorf("len(Truncate()) = %d, want %d", l, tt.wantLength)
+			}
+			if s := Segments(got); s > tt.maxSegments {
+				t.Errorf("Segments(Truncate()) = %d, want <= %d", s, tt.maxSegments)
+			}
+		})
+	}
+}
+
+func TestSender(t *testing.T) {
+	var received httpGatewayRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer srv.Close()
+	sender := NewSender(NewHttpGateway(srv.Client(), srv.URL, "token", "Clinic"), true, 1)
+	if err := sender.Send(context.Background(), Message{Phone: "+79991234567", Text: "Привет"}); err != nil {
+		t.Fatal(err)
+	}
+	want := httpGatewayRequest{To: "+79991234567", Text: "Privet", From: "Clinic"}
+	if received != want {
+		t.Errorf("received = %+v, want %+v", received, want)
+	}
+	badGateway := NewHttpGateway(srv.Client(), srv.URL, "", "")
+	if err := badGateway.Send(context.Background(), Message{}); err == nil {
+		t.Error("expected an error for the rejected request")
+	}
+}
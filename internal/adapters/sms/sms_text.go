@@ -0,0 +1,111 @@
+package sms_adapters
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf16"
+)
+
+const (
+	gsm7Basic    = "@£$¥èéùìòÇ\nØø\rÅåΔ_ΦΓΛΩΠΨΣΘΞÆæßÉ !\"#¤%&'()*+,-./0123456789:;<=>?¡ABCDEFGHIJKLMNOPQRSTUVWXYZÄÖÑÜ§¿abcdefghijklmnopqrstuvwxyzäöñüà"
+	gsm7Extended = "^{}\\[~]|€\f"
+
+	gsm7SegmentSeptets          = 160
+	gsm7MultipartSegmentSeptets = 153
+	ucs2SegmentUnits            = 70
+	ucs2MultipartSegmentUnits   = 67
+)
+
+var transliteration = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e",
+	'ж': "zh", 'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m",
+	'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u",
+	'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "",
+	'ы': "y", 'ь': "", 'э': "e", 'ю': "yu", 'я': "ya",
+	'«': "\"", '»': "\"", '„': "\"", '“': "\"", '”': "\"", '‘': "'", '’': "'",
+	'—': "-", '–': "-", '…': "...", '№': "N", '\u00a0': " ",
+}
+
+// Replaces Cyrillic letters and typographic symbols with GSM-7 ones,
+// other unsupported characters are replaced by "?"
+func Transliterate(text string) string {
+	sb := strings.Builder{}
+	sb.Grow(len(text))
+	for _, r := range text {
+		if strings.ContainsRune(gsm7Basic, r) || strings.ContainsRune(gsm7Extended, r) {
+			sb.WriteRune(r)
+			continue
+		}
+		lower := unicode.ToLower(r)
+		latin, ok := transliteration[lower]
+		if !ok {
+			sb.WriteRune('?')
+			continue
+		}
+		if lower != r && latin != "" {
+			sb.WriteString(strings.ToUpper(latin[:1]))
+			sb.WriteString(latin[1:])
+		} else {
+			sb.WriteString(latin)
+		}
+	}
+	return sb.String()
+}
+
+// Number of septets of the GSM-7 encoded text
+func gsm7Length(text string) (int, bool) {
+	length := 0
+	for _, r := range text {
+		switch {
+		case strings.ContainsRune(gsm7Basic, r):
+			length++
+		case strings.ContainsRune(gsm7Extended, r):
+			length += 2
+		default:
+			return 0, false
+		}
+	}
+	return length, true
+}
+
+func ucs2Length(text string) int {
+	return len(utf16.Encode([]rune(text)))
+}
+
+func segmentLimits(text string) (length int, single int, multipart int, measure func(string) int) {
+	if l, ok := gsm7Length(text); ok {
+		return l, gsm7SegmentSeptets, gsm7MultipartSegmentSeptets, func(s string) int {
+			l, _ := gsm7Length(s)
+			return l
+		}
+	}
+	return ucs2Length(text), ucs2SegmentUnits, ucs2MultipartSegmentUnits, ucs2Length
+}
+
+// Number of SMS segments required to deliver the text
+func Segments(text string) int {
+	length, single, multipart, _ := segmentLimits(text)
+	if length <= single {
+		return 1
+	}
+	return (length + multipart - 1) / multipart
+}
+
+// Cuts the text to fit into the given number of segments
+func Truncate(text string, maxSegments int) string {
+	length, single, multipart, measure := segmentLimits(text)
+	limit := single
+	if maxSegments > 1 {
+		limit = multipart * maxSegments
+	}
+	if length <= limit {
+		return text
+	}
+	const ellipsis = "..."
+	limit -= len(ellipsis)
+	runes := []rune(text)
+	for len(runes) > 0 && measure(string(runes)) > limit {
+		runes = runes[:len(runes)-1]
+	}
+	return strings.TrimRightFunc(string(runes), unicode.IsSpace) + ellipsis
+}
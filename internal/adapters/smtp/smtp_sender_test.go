@@ -0,0 +1,151 @@
+package smtp_adapters
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"strings"
+	"testing"
+)
+
+type sinkMessage struct {
+	from string
+	to   []string
+	data string
+}
+
+// Accepts a single message without extensions and authentication
+func newSmtpSink(t *testing.T) (string, <-chan sinkMessage) {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { l.Close() })
+	messages := make(chan sinkMessage, 1)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		reply := func(line string) {
+			io.WriteString(conn, line+"\r\n")
+		}
+		reply("220 sink")
+		var msg sinkMessage
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			cmd := strings.ToUpper(strings.TrimSpace(line))
+			switch {
+			case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+				reply("250 sink")
+			case strings.HasPrefix(cmd, "MAIL FROM:"):
+				msg.from = strings.Trim(strings.TrimSpace(line)[len("MAIL FROM:"):], "<>")
+				reply("250 ok")
+			case strings.HasPrefix(cmd, "RCPT TO:"):
+				msg.to = append(msg.to, strings.Trim(strings.TrimSpace(line)[len("RCPT TO:"):], "<>"))
+				reply("250 ok")
+			case cmd == "DATA":
+				reply("354 go ahead")
+				var data strings.Builder
+				for {
+					line, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if line == ".\r\n" {
+						break
+					}
+					data.WriteString(strings.TrimPrefix(line, "."))
+				}
+				msg.data = data.String()
+				messages <- msg
+				reply("250 queued")
+			case cmd == "QUIT":
+				reply("221 bye")
+				return
+			default:
+				reply("502 unsupported")
+			}
+		}
+	}()
+	return l.Addr().String(), messages
+}
+
+func TestSender(t *testing.T) {
+	addr, messages := newSmtpSink(t)
+	sender, err := NewSender(addr, "", "", "Клиника <clinic@example.com>")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = sender.Send(context.Background(), Message{
+		To:      []mail.Address{{Name: "Иван", Address: "ivan@example.com"}},
+		Subject: "Запись на прием",
+		Text:    "Вы записаны на прием",
+		Attachments: []Attachment{{
+			Name:        "appointment.ics",
+			ContentType: "text/calendar; charset=utf-8",
+			Data:        []byte("BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n"),
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := <-messages
+	if msg.from != "clinic@example.com" {
+		t.Errorf("from = %q", msg.from)
+	}
+	if len(msg.to) != 1 || msg.to[0] != "ivan@example.com" {
+		t.Errorf("to = %v", msg.to)
+	}
+	parsed, err := mail.ReadMessage(strings.NewReader(msg.data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if subject != "Запись на прием" {
+		t.Errorf("subject = %q", subject)
+	}
+	mediaType, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mediaType != "multipart/mixed" {
+		t.Fatalf("media type = %q", mediaType)
+	}
+	mr := multipart.NewReader(parsed.Body, params["boundary"])
+	var parts []string
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Quoted-printable parts are decoded by the reader
+		body, err := io.ReadAll(part)
+		if err != nil {
+			t.Fatal(err)
+		}
+		parts = append(parts, part.Header.Get("Content-Type"))
+		if part.FileName() == "" && string(body) != "Вы записаны на прием" {
+			t.Errorf("text = %q", body)
+		}
+	}
+	if len(parts) != 2 || !strings.HasPrefix(parts[1], "text/calendar") {
+		t.Errorf("parts = %v", parts)
+	}
+}
@@ -0,0 +1,26 @@
+package sqlite_adapters
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/module"
+)
+
+func Open(path string) (*sql.DB, error) {
+	return sql.Open(
+		"sqlite",
+		fmt.Sprintf(
+			"file:%s?_txlock=immediate&_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)",
+			path,
+		),
+	)
+}
+
+func NewService(name string, db *sql.DB) module.Service {
+	return module.NewService(name, func(ctx context.Context) error {
+		<-ctx.Done()
+		return db.Close()
+	})
+}
@@ -0,0 +1,5 @@
+//go:build !js
+
+package sqlite_adapters
+
+import _ "modernc.org/sqlite"
@@ -0,0 +1,45 @@
+package telegram_adapters
+
+import (
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/i18n"
+	"gopkg.in/telebot.v3"
+)
+
+const printerKey = "i18n_printer"
+
+// Should be called by a middleware, so that handlers render
+// localized responses in the locale of the sender
+func SetPrinter(c telebot.Context, p i18n.Printer) {
+	c.Set(printerKey, p)
+}
+
+func ChatPrinter(c telebot.Context) i18n.Printer {
+	p, _ := c.Get(printerKey).(i18n.Printer)
+	return p
+}
+
+// Localized responses are rendered on sending, when the locale is known
+
+type LocalizedTextResponses func(p i18n.Printer) TextResponses
+
+func (r LocalizedTextResponses) Send(c telebot.Context) error {
+	return r(ChatPrinter(c)).Send(c)
+}
+
+func (r LocalizedTextResponses) Edit(c telebot.Context) error {
+	return r(ChatPrinter(c)).Edit(c)
+}
+
+type LocalizedResponse func(p i18n.Printer) Response
+
+func (r LocalizedResponse) Send(c telebot.Context) error {
+	return r(ChatPrinter(c)).Send(c)
+}
+
+type LocalizedQueryResponse func(p i18n.Printer) QueryResponse
+
+type LocalizedCallbackResponse func(p i18n.Printer) CallbackResponse
+
+func (r LocalizedCallbackResponse) Respond(c telebot.Context) error {
+	return c.Respond(r(ChatPrinter(c)).Response)
+}
@@ -29,3 +29,31 @@ func (m TextMessages) Send(bot *telebot.Bot) error {
 	}
 	return nil
 }
+
+// Messages are delivered without a sound
+func (m TextMessages) Silent() Message {
+	messages := make([]SendableText, len(m.messages))
+	for i, msg := range m.messages {
+		options := telebot.SendOptions{}
+		if msg.Options != nil {
+			options = *msg.Options
+		}
+		options.DisableNotification = true
+		messages[i] = SendableText{
+			Text:    msg.Text,
+			Options: &options,
+		}
+	}
+	return TextMessages{
+		recipient: m.recipient,
+		messages:  messages,
+	}
+}
+
+// Returns the message as is when it can not be silent
+func Silent(msg Message) Message {
+	if s, ok := msg.(interface{ Silent() Message }); ok {
+		return s.Silent()
+	}
+	return msg
+}
@@ -61,12 +61,22 @@ func (responses TextResponses) Edit(c telebot.Context) error {
 	return nil
 }
 
-var escapeRegExp = regexp.MustCompile(`([.!-])`)
+// Reserved characters of MarkdownV2
+var escapeRegExp = regexp.MustCompile("([_*\\[\\]()~`>#+\\-=|{}.!\\\\])")
 
 func EscapeMarkdownString(text string) string {
 	return escapeRegExp.ReplaceAllString(text, "\\$1")
 }
 
+type DocumentResponse struct {
+	Document *telebot.Document
+	Options  *telebot.SendOptions
+}
+
+func (r DocumentResponse) Send(c telebot.Context) error {
+	return c.Send(r.Document, r.Options)
+}
+
 type QueryResponse struct {
 	Result telebot.Result
 }
@@ -0,0 +1,40 @@
+package telegram_adapters
+
+import (
+	"sync"
+
+	"gopkg.in/telebot.v3"
+)
+
+// Handles the text if the sender has a pending input, reports whether
+// the text was consumed
+type TextInput func(c telebot.Context) (bool, error)
+
+// The bot accepts only one `OnText` handler, so free text is routed
+// to the inputs which are awaited from the sender
+type TextInputs struct {
+	mu     sync.RWMutex
+	inputs []TextInput
+}
+
+func NewTextInputs() *TextInputs {
+	return &TextInputs{}
+}
+
+func (t *TextInputs) Add(input TextInput) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.inputs = append(t.inputs, input)
+}
+
+func (t *TextInputs) Handle(c telebot.Context) error {
+	t.mu.RLock()
+	inputs := t.inputs
+	t.mu.RUnlock()
+	for _, input := range inputs {
+		if ok, err := input(c); ok || err != nil {
+			return err
+		}
+	}
+	return nil
+}
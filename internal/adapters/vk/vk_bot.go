@@ -0,0 +1,380 @@
+package vk_adapters
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+const (
+	confirmationUpdateType = "confirmation"
+	messageNewUpdateType   = "message_new"
+	messageEventUpdateType = "message_event"
+)
+
+// Handles messages without a known command
+const OnText = "\atext"
+
+// Payload of the "Start" button of a community chat
+const StartCommand = "start"
+
+const (
+	maxUpdateSize = 1 * 1024 * 1024
+	queueSize     = 64
+	// Should cover the updates which VK may repeat
+	rememberedEventsCount = 1024
+)
+
+type HandlerFunc func(c *Context) error
+
+type MiddlewareFunc func(next HandlerFunc) HandlerFunc
+
+type update struct {
+	Type    string          `json:"type"`
+	EventId string          `json:"event_id"`
+	GroupId int64           `json:"group_id"`
+	Secret  string          `json:"secret"`
+	Object  json.RawMessage `json:"object"`
+}
+
+type incomingMessage struct {
+	ConversationMessageId int64  `json:"conversation_message_id"`
+	FromId                int64  `json:"from_id"`
+	PeerId                int64  `json:"peer_id"`
+	Text                  string `json:"text"`
+	Payload               string `json:"payload"`
+}
+
+type clientInfo struct {
+	LangId int `json:"lang_id"`
+}
+
+// Languages of the VK interface by `lang_id`
+var languageCodes = map[int]string{
+	0: "ru",
+	1: "uk",
+	2: "be",
+	3: "en",
+	4: "es",
+	5: "fi",
+	6: "de",
+	7: "it",
+}
+
+type messageEvent struct {
+	UserId                int64   `json:"user_id"`
+	PeerId                int64   `json:"peer_id"`
+	EventId               string  `json:"event_id"`
+	Payload               Payload `json:"payload"`
+	ConversationMessageId int64   `json:"conversation_message_id"`
+}
+
+type Context struct {
+	ctx                   context.Context
+	client                *Client
+	userId                int64
+	peerId                int64
+	text                  string
+	payload               Payload
+	eventId               string
+	conversationMessageId int64
+	languageCode          string
+	answered              bool
+	values                map[string]any
+}
+
+func (c *Context) Context() context.Context {
+	return c.ctx
+}
+
+func (c *Context) Sender() int64 {
+	return c.userId
+}
+
+func (c *Context) Text() string {
+	return c.text
+}
+
+func (c *Context) Data() string {
+	return c.payload.Data
+}
+
+// Language of the user's client, known only for messages
+func (c *Context) LanguageCode() string {
+	return c.languageCode
+}
+
+func (c *Context) Set(key string, value any) {
+	if c.values == nil {
+		c.values = map[string]any{}
+	}
+	c.values[key] = value
+}
+
+func (c *Context) Get(key string) any {
+	return c.values[key]
+}
+
+// Arguments of a text command
+func (c *Context) Args() []string {
+	fields := strings.Fields(c.text)
+	if len(fields) == 0 {
+		return nil
+	}
+	return fields[1:]
+}
+
+func (c *Context) Profile() (User, error) {
+	return c.client.User(c.ctx, c.userId)
+}
+
+func (c *Context) Send(text string, keyboard *Keyboard) error {
+	return c.client.SendMessage(c.ctx, c.peerId, text, keyboard)
+}
+
+// Edits the message with the pressed callback button,
+// sends a new message for text commands
+func (c *Context) Edit(text string, keyboard *Keyboard) error {
+	if c.eventId == "" {
+		return c.Send(text, keyboard)
+	}
+	return c.client.EditMessage(c.ctx, c.peerId, c.conversationMessageId, text, keyboard)
+}
+
+func (c *Context) Delete() error {
+	if c.eventId == "" {
+		return nil
+	}
+	return c.client.DeleteMessage(c.ctx, c.peerId, c.conversationMessageId)
+}
+
+func (c *Context) Respond(text string) error {
+	if c.eventId == "" {
+		if text == "" {
+			return nil
+		}
+		return c.Send(text, nil)
+	}
+	if c.answered {
+		return nil
+	}
+	c.answered = true
+	return c.client.SendMessageEventAnswer(c.ctx, c.eventId, c.userId, c.peerId, text)
+}
+
+// Community bot which receives updates from the Callback API,
+// updates are handled by `Start` after they are acknowledged
+type Bot struct {
+	client           *Client
+	secret           CallbackSecret
+	confirmationCode ConfirmationCode
+	onError          func(error, *Context)
+	handlersMu       sync.RWMutex
+	handlers         map[string]HandlerFunc
+	middleware       []MiddlewareFunc
+	updates          chan update
+	eventsMu         sync.Mutex
+	events           map[string]struct{}
+	eventsOrder      []string
+}
+
+func NewBot(
+	client *Client,
+	secret CallbackSecret,
+	confirmationCode ConfirmationCode,
+	onError func(error, *Context),
+) *Bot {
+	return &Bot{
+		client:           client,
+		secret:           secret,
+		confirmationCode: confirmationCode,
+		onError:          onError,
+		handlers:         map[string]HandlerFunc{},
+		updates:          make(chan update, queueSize),
+		events:           make(map[string]struct{}, rememberedEventsCount),
+		eventsOrder:      make([]string, 0, rememberedEventsCount),
+	}
+}
+
+func (b *Bot) Client() *Client {
+	return b.client
+}
+
+// Handler is selected by the command of the button payload
+// or by the text of the message, e.g. "/start"
+func (b *Bot) Handle(command string, handler HandlerFunc) {
+	b.handlersMu.Lock()
+	defer b.handlersMu.Unlock()
+	b.handlers[strings.ToLower(command)] = handler
+}
+
+// Middleware is applied to every update, including ones without a handler
+func (b *Bot) Use(middleware ...MiddlewareFunc) {
+	b.handlersMu.Lock()
+	defer b.handlersMu.Unlock()
+	b.middleware = append(b.middleware, middleware...)
+}
+
+func (b *Bot) handler(command string) (HandlerFunc, bool) {
+	b.handlersMu.RLock()
+	defer b.handlersMu.RUnlock()
+	h, ok := b.handlers[strings.ToLower(command)]
+	return h, ok
+}
+
+// VK repeats the update until "ok" is returned in time,
+// so updates are acknowledged before they are handled
+// and handler errors are reported to `onError` only
+func (b *Bot) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	var u update
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxUpdateSize)).Decode(&u); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if b.secret == "" || subtle.ConstantTimeCompare([]byte(u.Secret), []byte(b.secret)) != 1 {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	if u.Type == confirmationUpdateType {
+		io.WriteString(w, string(b.confirmationCode))
+		return
+	}
+	if !b.remember(u.EventId) {
+		io.WriteString(w, "ok")
+		return
+	}
+	select {
+	case b.updates <- u:
+		io.WriteString(w, "ok")
+	default:
+		// VK will repeat the update later
+		b.forget(u.EventId)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+}
+
+// Handles acknowledged updates one by one until the context is done
+func (b *Bot) Start(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case u := <-b.updates:
+			c, command, err := b.context(ctx, u)
+			if err != nil {
+				b.onError(err, nil)
+			} else if c != nil {
+				b.dispatch(c, command)
+			}
+		}
+	}
+}
+
+// Returns false for the already received event
+func (b *Bot) remember(eventId string) bool {
+	if eventId == "" {
+		return true
+	}
+	b.eventsMu.Lock()
+	defer b.eventsMu.Unlock()
+	if _, ok := b.events[eventId]; ok {
+		return false
+	}
+	if len(b.eventsOrder) == rememberedEventsCount {
+		delete(b.events, b.eventsOrder[0])
+		b.eventsOrder = b.eventsOrder[1:]
+	}
+	b.events[eventId] = struct{}{}
+	b.eventsOrder = append(b.eventsOrder, eventId)
+	return true
+}
+
+func (b *Bot) forget(eventId string) {
+	if eventId == "" {
+		return
+	}
+	b.eventsMu.Lock()
+	defer b.eventsMu.Unlock()
+	delete(b.events, eventId)
+}
+
+func (b *Bot) context(ctx context.Context, u update) (*Context, string, error) {
+	switch u.Type {
+	case messageNewUpdateType:
+		var obj struct {
+			Message    incomingMessage `json:"message"`
+			ClientInfo clientInfo      `json:"client_info"`
+		}
+		if err := json.Unmarshal(u.Object, &obj); err != nil {
+			return nil, "", err
+		}
+		c := &Context{
+			ctx:          ctx,
+			client:       b.client,
+			userId:       obj.Message.FromId,
+			peerId:       obj.Message.PeerId,
+			text:         obj.Message.Text,
+			languageCode: languageCodes[obj.ClientInfo.LangId],
+		}
+		if obj.Message.Payload != "" {
+			if err := json.Unmarshal([]byte(obj.Message.Payload), &c.payload); err != nil {
+				return nil, "", err
+			}
+		}
+		if c.payload.Command != "" {
+			return c, c.payload.Command, nil
+		}
+		fields := strings.Fields(c.text)
+		if len(fields) == 0 {
+			return c, OnText, nil
+		}
+		return c, fields[0], nil
+	case messageEventUpdateType:
+		var event messageEvent
+		if err := json.Unmarshal(u.Object, &event); err != nil {
+			return nil, "", err
+		}
+		return &Context{
+			ctx:                   ctx,
+			client:                b.client,
+			userId:                event.UserId,
+			peerId:                event.PeerId,
+			payload:               event.Payload,
+			eventId:               event.EventId,
+			conversationMessageId: event.ConversationMessageId,
+		}, event.Payload.Command, nil
+	default:
+		return nil, "", nil
+	}
+}
+
+func (b *Bot) dispatch(c *Context, command string) {
+	h, ok := b.handler(command)
+	// Outdated buttons are ignored
+	if !ok && c.eventId == "" {
+		h, ok = b.handler(OnText)
+	}
+	if !ok {
+		h = func(*Context) error { return nil }
+	}
+	b.handlersMu.RLock()
+	for i := len(b.middleware) - 1; i >= 0; i-- {
+		h = b.middleware[i](h)
+	}
+	b.handlersMu.RUnlock()
+	err := h(c)
+	// Callback buttons keep loading until the event is answered
+	err = errors.Join(err, c.Respond(""))
+	if err != nil {
+		b.onError(err, c)
+	}
+}
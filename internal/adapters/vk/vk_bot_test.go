@@ -0,0 +1,204 @@
+package vk_adapters
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type apiCall struct {
+	method string
+	params url.Values
+}
+
+// Local stand-in for the VK API which records calls
+func newApiStandIn(t *testing.T) (*httptest.Server, func() []apiCall) {
+	var (
+		mu    sync.Mutex
+		calls []apiCall
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Error(err)
+		}
+		mu.Lock()
+		calls = append(calls, apiCall{
+			method: strings.TrimPrefix(r.URL.Path, "/"),
+			params: r.PostForm,
+		})
+		mu.Unlock()
+		w.Write([]byte(`{"response":1}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, func() []apiCall {
+		mu.Lock()
+		defer mu.Unlock()
+		return slices.Clone(calls)
+	}
+}
+
+// Updates are handled asynchronously, so the calls are awaited
+func waitCalls(t *testing.T, calls func() []apiCall, count int) []apiCall {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		got := calls()
+		if len(got) >= count || time.Now().After(deadline) {
+			return got
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func startBot(t *testing.T, bot *Bot) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		bot.Start(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+}
+
+func post(bot *Bot, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	bot.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+	return w
+}
+
+func TestBot(t *testing.T) {
+	api, calls := newApiStandIn(t)
+	handlerErrs := make(chan error, 10)
+	bot := NewBot(
+		NewClient(api.Client(), api.URL, "token"),
+		"secret",
+		"confirm",
+		func(err error, _ *Context) { handlerErrs <- err },
+	)
+	bot.Handle("/start", func(c *Context) error {
+		return TextResponses{NewText("Привет!", NewInlineKeyboard(
+			[]Button{CallbackButton{Label: "Услуги", Command: "services"}.Button()},
+		))}.Send(c)
+	})
+	bot.Handle("services", func(c *Context) error {
+		return TextResponses{NewText("Услуги: " + c.Data())}.Edit(c)
+	})
+	startBot(t, bot)
+
+	if w := post(bot, `{"type":"confirmation","secret":"secret"}`); w.Body.String() != "confirm" {
+		t.Errorf("confirmation = %q", w.Body.String())
+	}
+	if w := post(bot, `{"type":"message_new","secret":"wrong"}`); w.Code != http.StatusForbidden {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusForbidden)
+	}
+
+	if w := post(bot, `{"type":"message_new","event_id":"u1","secret":"secret","object":{"message":{"from_id":1,"peer_id":1,"text":"/start"}}}`); w.Body.String() != "ok" {
+		t.Fatalf("message_new = %q", w.Body.String())
+	}
+	if w := post(bot, `{"type":"message_event","event_id":"u2","secret":"secret","object":{"user_id":1,"peer_id":1,"event_id":"e1","conversation_message_id":7,"payload":{"command":"services","data":"all"}}}`); w.Body.String() != "ok" {
+		t.Fatalf("message_event = %q", w.Body.String())
+	}
+
+	want := []string{"messages.send", "messages.edit", "messages.sendMessageEventAnswer"}
+	got := waitCalls(t, calls, len(want))
+	select {
+	case err := <-handlerErrs:
+		t.Fatal(err)
+	default:
+	}
+	if len(got) != len(want) {
+		t.Fatalf("calls = %v, want %v", got, want)
+	}
+	for i, c := range got {
+		if c.method != want[i] {
+			t.Errorf("call %d = %s, want %s", i, c.method, want[i])
+		}
+		if c.params.Get("access_token") != "token" {
+			t.Errorf("call %d is not authorized", i)
+		}
+	}
+	if !strings.Contains(got[0].params.Get("keyboard"), `"callback"`) {
+		t.Errorf("keyboard = %s", got[0].params.Get("keyboard"))
+	}
+	if got[1].params.Get("message") != "Услуги: all" || got[1].params.Get("conversation_message_id") != "7" {
+		t.Errorf("edit = %v", got[1].params)
+	}
+	if got[2].params.Get("event_id") != "e1" {
+		t.Errorf("event answer = %v", got[2].params)
+	}
+}
+
+func TestBotSkipsRepeatedUpdates(t *testing.T) {
+	api, calls := newApiStandIn(t)
+	bot := NewBot(NewClient(api.Client(), api.URL, "token"), "secret", "confirm", func(err error, _ *Context) {
+		t.Error(err)
+	})
+	bot.Handle("/start", func(c *Context) error {
+		return c.Send("Привет!", nil)
+	})
+	bot.Handle("/stop", func(c *Context) error {
+		return c.Send("Пока!", nil)
+	})
+	startBot(t, bot)
+
+	body := `{"type":"message_new","event_id":"u1","secret":"secret","object":{"message":{"from_id":1,"peer_id":1,"text":"/start"}}}`
+	for range 3 {
+		if w := post(bot, body); w.Body.String() != "ok" {
+			t.Fatalf("message_new = %q", w.Body.String())
+		}
+	}
+	post(bot, `{"type":"message_new","event_id":"u2","secret":"secret","object":{"message":{"from_id":1,"peer_id":1,"text":"/stop"}}}`)
+
+	// Updates are handled in order, so repeats would precede the last one
+	got := waitCalls(t, calls, 2)
+	if len(got) != 2 || got[0].params.Get("message") != "Привет!" || got[1].params.Get("message") != "Пока!" {
+		t.Errorf("calls = %v", got)
+	}
+}
+
+func TestBotRejectsUpdatesWithoutSecret(t *testing.T) {
+	bot := NewBot(NewClient(http.DefaultClient, "http://localhost", "token"), "", "confirm", func(err error, _ *Context) {
+		t.Error(err)
+	})
+	for _, body := range []string{
+		`{"type":"confirmation"}`,
+		`{"type":"message_new","secret":"","object":{"message":{"from_id":1,"peer_id":1,"text":"/start"}}}`,
+	} {
+		if w := post(bot, body); w.Code != http.StatusForbidden {
+			t.Errorf("%s status = %d, want %d", body, w.Code, http.StatusForbidden)
+		}
+	}
+}
+
+func TestBotMiddleware(t *testing.T) {
+	api, calls := newApiStandIn(t)
+	bot := NewBot(NewClient(api.Client(), api.URL, "token"), "secret", "confirm", func(err error, _ *Context) {
+		t.Error(err)
+	})
+	bot.Use(func(next HandlerFunc) HandlerFunc {
+		return func(c *Context) error {
+			c.Set("language", c.LanguageCode())
+			return next(c)
+		}
+	})
+	bot.Handle(OnText, func(c *Context) error {
+		return c.Send(c.Get("language").(string), nil)
+	})
+	startBot(t, bot)
+
+	post(bot, `{"type":"message_new","event_id":"u1","secret":"secret","object":{"message":{"from_id":1,"peer_id":1,"text":"hello"},"client_info":{"lang_id":3}}}`)
+
+	got := waitCalls(t, calls, 1)
+	if len(got) != 1 || got[0].params.Get("message") != "en" {
+		t.Errorf("calls = %v", got)
+	}
+}
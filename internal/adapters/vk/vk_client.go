@@ -0,0 +1,161 @@
+package vk_adapters
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+type ApiError struct {
+	Code    int    `json:"error_code"`
+	Message string `json:"error_msg"`
+}
+
+func (e *ApiError) Error() string {
+	return fmt.Sprintf("vk api error %d: %s", e.Code, e.Message)
+}
+
+type Client struct {
+	httpClient *http.Client
+	apiUrl     string
+	token      AccessToken
+}
+
+func NewClient(httpClient *http.Client, apiUrl string, token AccessToken) *Client {
+	return &Client{
+		httpClient: httpClient,
+		apiUrl:     strings.TrimSuffix(apiUrl, "/"),
+		token:      token,
+	}
+}
+
+func (c *Client) Call(ctx context.Context, method string, params url.Values, result any) error {
+	params.Set("access_token", string(c.token))
+	params.Set("v", ApiVersion)
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		c.apiUrl+"/"+method,
+		strings.NewReader(params.Encode()),
+	)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: unexpected status %d", method, res.StatusCode)
+	}
+	var body struct {
+		Response json.RawMessage `json:"response"`
+		Error    *ApiError       `json:"error"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return fmt.Errorf("%s: %w", method, err)
+	}
+	if body.Error != nil {
+		return fmt.Errorf("%s: %w", method, body.Error)
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(body.Response, result)
+}
+
+func (c *Client) SendMessage(ctx context.Context, peerId int64, text string, keyboard *Keyboard) error {
+	params := url.Values{}
+	params.Set("peer_id", strconv.FormatInt(peerId, 10))
+	// Used by VK to deduplicate messages
+	params.Set("random_id", strconv.FormatInt(int64(rand.Int32()), 10))
+	params.Set("message", text)
+	if err := setKeyboard(params, keyboard); err != nil {
+		return err
+	}
+	return c.Call(ctx, "messages.send", params, nil)
+}
+
+func (c *Client) EditMessage(
+	ctx context.Context,
+	peerId int64,
+	conversationMessageId int64,
+	text string,
+	keyboard *Keyboard,
+) error {
+	params := url.Values{}
+	params.Set("peer_id", strconv.FormatInt(peerId, 10))
+	params.Set("conversation_message_id", strconv.FormatInt(conversationMessageId, 10))
+	params.Set("message", text)
+	if err := setKeyboard(params, keyboard); err != nil {
+		return err
+	}
+	return c.Call(ctx, "messages.edit", params, nil)
+}
+
+func (c *Client) DeleteMessage(ctx context.Context, peerId int64, conversationMessageId int64) error {
+	params := url.Values{}
+	params.Set("peer_id", strconv.FormatInt(peerId, 10))
+	params.Set("cmids", strconv.FormatInt(conversationMessageId, 10))
+	params.Set("delete_for_all", "1")
+	return c.Call(ctx, "messages.delete", params, nil)
+}
+
+// Stops the loading indicator of a callback button,
+// the text is shown as a snackbar when it is not empty
+func (c *Client) SendMessageEventAnswer(
+	ctx context.Context,
+	eventId string,
+	userId int64,
+	peerId int64,
+	text string,
+) error {
+	params := url.Values{}
+	params.Set("event_id", eventId)
+	params.Set("user_id", strconv.FormatInt(userId, 10))
+	params.Set("peer_id", strconv.FormatInt(peerId, 10))
+	if text != "" {
+		data, err := json.Marshal(map[string]string{
+			"type": "show_snackbar",
+			"text": text,
+		})
+		if err != nil {
+			return err
+		}
+		params.Set("event_data", string(data))
+	}
+	return c.Call(ctx, "messages.sendMessageEventAnswer", params, nil)
+}
+
+func (c *Client) User(ctx context.Context, userId int64) (User, error) {
+	params := url.Values{}
+	params.Set("user_ids", strconv.FormatInt(userId, 10))
+	params.Set("fields", "domain")
+	var users []User
+	if err := c.Call(ctx, "users.get", params, &users); err != nil {
+		return User{}, err
+	}
+	if len(users) == 0 {
+		return User{}, fmt.Errorf("users.get: user %d is not found", userId)
+	}
+	return users[0], nil
+}
+
+func setKeyboard(params url.Values, keyboard *Keyboard) error {
+	if keyboard == nil {
+		return nil
+	}
+	data, err := json.Marshal(keyboard)
+	if err != nil {
+		return err
+	}
+	params.Set("keyboard", string(data))
+	return nil
+}
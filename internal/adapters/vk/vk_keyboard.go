@@ -0,0 +1,81 @@
+package vk_adapters
+
+import "encoding/json"
+
+type ButtonColor string
+
+const (
+	PrimaryButtonColor   ButtonColor = "primary"
+	SecondaryButtonColor ButtonColor = "secondary"
+	PositiveButtonColor  ButtonColor = "positive"
+	NegativeButtonColor  ButtonColor = "negative"
+)
+
+// Inline keyboards are limited to 10 buttons
+const MaxInlineKeyboardButtons = 10
+
+type Payload struct {
+	Command string `json:"command"`
+	Data    string `json:"data,omitempty"`
+}
+
+type ButtonAction struct {
+	Type    string `json:"type"`
+	Label   string `json:"label,omitempty"`
+	Payload string `json:"payload,omitempty"`
+	Link    string `json:"link,omitempty"`
+}
+
+type Button struct {
+	Action ButtonAction `json:"action"`
+	Color  ButtonColor  `json:"color,omitempty"`
+}
+
+type Keyboard struct {
+	OneTime bool       `json:"one_time"`
+	Inline  bool       `json:"inline"`
+	Buttons [][]Button `json:"buttons"`
+}
+
+func NewInlineKeyboard(rows ...[]Button) *Keyboard {
+	return &Keyboard{
+		Inline:  true,
+		Buttons: rows,
+	}
+}
+
+// Button which sends `message_event` with the command in the payload
+type CallbackButton struct {
+	Label   string
+	Command string
+	Color   ButtonColor
+}
+
+func (b CallbackButton) With(data string) Button {
+	payload, _ := json.Marshal(Payload{
+		Command: b.Command,
+		Data:    data,
+	})
+	return Button{
+		Action: ButtonAction{
+			Type:    "callback",
+			Label:   b.Label,
+			Payload: string(payload),
+		},
+		Color: b.Color,
+	}
+}
+
+func (b CallbackButton) Button() Button {
+	return b.With("")
+}
+
+func NewLinkButton(label string, link string) Button {
+	return Button{
+		Action: ButtonAction{
+			Type:  "open_link",
+			Label: label,
+			Link:  link,
+		},
+	}
+}
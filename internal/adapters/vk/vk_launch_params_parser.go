@@ -0,0 +1,118 @@
+package vk_adapters
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrSignMissing         = errors.New("sign is missing")
+	ErrSignInvalid         = errors.New("sign is invalid")
+	ErrLaunchParamsExpired = errors.New("launch params are expired")
+	ErrUserIdMissing       = errors.New("user id is missing")
+)
+
+const launchParamsPrefix = "vk_"
+
+type AppSecret string
+
+type LaunchParams struct {
+	UserId   string
+	AppId    string
+	Platform string
+	Language string
+	Ts       time.Time
+}
+
+type LaunchParamsParser interface {
+	Validate(query string) error
+	Parse(query string) (LaunchParams, error)
+}
+
+type launchParamsParser struct {
+	appSecret AppSecret
+	expiredIn time.Duration
+}
+
+func NewLaunchParamsParser(appSecret AppSecret, expiredIn time.Duration) LaunchParamsParser {
+	return &launchParamsParser{
+		appSecret: appSecret,
+		expiredIn: expiredIn,
+	}
+}
+
+// Checks the `sign` of `vk_*` parameters of the mini app launch URL.
+// Expiration is not checked when `expiredIn` is zero.
+func (p *launchParamsParser) Validate(query string) error {
+	values, err := url.ParseQuery(strings.TrimPrefix(query, "?"))
+	if err != nil {
+		return err
+	}
+	sign := values.Get("sign")
+	if sign == "" {
+		return ErrSignMissing
+	}
+	if !hmac.Equal([]byte(sign), []byte(Sign(values, p.appSecret))) {
+		return ErrSignInvalid
+	}
+	if p.expiredIn > 0 {
+		ts, err := launchTime(values)
+		if err != nil {
+			return err
+		}
+		if ts.Add(p.expiredIn).Before(time.Now()) {
+			return ErrLaunchParamsExpired
+		}
+	}
+	return nil
+}
+
+func (p *launchParamsParser) Parse(query string) (LaunchParams, error) {
+	values, err := url.ParseQuery(strings.TrimPrefix(query, "?"))
+	if err != nil {
+		return LaunchParams{}, err
+	}
+	userId := values.Get("vk_user_id")
+	if userId == "" {
+		return LaunchParams{}, ErrUserIdMissing
+	}
+	ts, err := launchTime(values)
+	if err != nil {
+		return LaunchParams{}, err
+	}
+	return LaunchParams{
+		UserId:   userId,
+		AppId:    values.Get("vk_app_id"),
+		Platform: values.Get("vk_platform"),
+		Language: values.Get("vk_language"),
+		Ts:       ts,
+	}, nil
+}
+
+// Signs sorted `vk_*` parameters with the secret key of the app
+func Sign(values url.Values, appSecret AppSecret) string {
+	params := url.Values{}
+	for key, v := range values {
+		if strings.HasPrefix(key, launchParamsPrefix) {
+			params[key] = v
+		}
+	}
+	// Encode sorts the parameters by key
+	mac := hmac.New(sha256.New, []byte(appSecret))
+	mac.Write([]byte(params.Encode()))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func launchTime(values url.Values) (time.Time, error) {
+	ts, err := strconv.ParseInt(values.Get("vk_ts"), 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(ts, 0), nil
+}
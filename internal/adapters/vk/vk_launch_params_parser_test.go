@@ -0,0 +1,49 @@
+package vk_adapters
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestLaunchParamsParser(t *testing.T) {
+	// Example from the VK Mini Apps documentation
+	const (
+		secret = "wvl68m4dR1UpLrVRli"
+		query  = "vk_user_id=494075&vk_app_id=6736218&vk_is_app_user=1&vk_are_notifications_enabled=1&vk_language=ru&vk_access_token_settings=&vk_platform=android&sign=htQFduJpLxz7ribXRZpDFUH-XEUhC9rBPTJkjUFEkRA"
+	)
+	if err := NewLaunchParamsParser(secret, 0).Validate(query); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if err := NewLaunchParamsParser(secret, 0).Validate(query + "&vk_ref=other"); !errors.Is(err, ErrSignInvalid) {
+		t.Errorf("Validate() error = %v, want %v", err, ErrSignInvalid)
+	}
+
+	signed := func(ts time.Time) string {
+		values := url.Values{
+			"vk_user_id": {"42"},
+			"vk_app_id":  {"1"},
+			"vk_ts":      {strconv.FormatInt(ts.Unix(), 10)},
+			"utm_source": {"ignored"},
+		}
+		values.Set("sign", Sign(values, secret))
+		return values.Encode()
+	}
+	parser := NewLaunchParamsParser(secret, time.Hour)
+	fresh := signed(time.Now())
+	if err := parser.Validate(fresh); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	params, err := parser.Parse(fresh)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if params.UserId != "42" || params.AppId != "1" {
+		t.Errorf("Parse() = %+v", params)
+	}
+	if err := parser.Validate(signed(time.Now().Add(-2 * time.Hour))); !errors.Is(err, ErrLaunchParamsExpired) {
+		t.Errorf("Validate() error = %v, want %v", err, ErrLaunchParamsExpired)
+	}
+}
@@ -0,0 +1,34 @@
+package vk_adapters
+
+import "github.com/x0k/veterinary-clinic-backend/internal/lib/i18n"
+
+const printerKey = "i18n_printer"
+
+// Should be called by a middleware, so that handlers render
+// localized responses in the locale of the sender
+func SetPrinter(c *Context, p i18n.Printer) {
+	c.Set(printerKey, p)
+}
+
+func ChatPrinter(c *Context) i18n.Printer {
+	p, _ := c.Get(printerKey).(i18n.Printer)
+	return p
+}
+
+// Localized responses are rendered on sending, when the locale is known
+
+type LocalizedTextResponses func(p i18n.Printer) TextResponses
+
+func (r LocalizedTextResponses) Send(c *Context) error {
+	return r(ChatPrinter(c)).Send(c)
+}
+
+func (r LocalizedTextResponses) Edit(c *Context) error {
+	return r(ChatPrinter(c)).Edit(c)
+}
+
+type LocalizedEventResponse func(p i18n.Printer) EventResponse
+
+func (r LocalizedEventResponse) Send(c *Context) error {
+	return r(ChatPrinter(c)).Send(c)
+}
@@ -0,0 +1,22 @@
+package vk_adapters
+
+const (
+	ApiVersion    = "5.199"
+	DefaultApiUrl = "https://api.vk.com/method"
+)
+
+// Access key of the community
+type AccessToken string
+
+// Secret key of the Callback API server
+type CallbackSecret string
+
+// String which should be returned to confirm the Callback API server
+type ConfirmationCode string
+
+type User struct {
+	Id        int64  `json:"id"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Domain    string `json:"domain"`
+}
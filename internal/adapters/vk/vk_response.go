@@ -0,0 +1,121 @@
+package vk_adapters
+
+import (
+	"context"
+	"encoding/json"
+)
+
+type Message interface {
+	Send(ctx context.Context, client *Client) error
+}
+
+type Response interface {
+	Send(c *Context) error
+}
+
+type Text struct {
+	Text     string
+	Keyboard *Keyboard
+}
+
+func NewText(text string, keyboard ...*Keyboard) Text {
+	if len(keyboard) == 0 {
+		return Text{Text: text}
+	}
+	return Text{
+		Text:     text,
+		Keyboard: keyboard[0],
+	}
+}
+
+type TextMessages struct {
+	peerId   int64
+	messages []Text
+}
+
+func NewTextMessages(peerId int64, messages ...Text) TextMessages {
+	return TextMessages{
+		peerId:   peerId,
+		messages: messages,
+	}
+}
+
+type textDTO struct {
+	Text     string    `json:"text"`
+	Keyboard *Keyboard `json:"keyboard,omitempty"`
+}
+
+type textMessagesDTO struct {
+	PeerId   int64     `json:"peerId"`
+	Messages []textDTO `json:"messages"`
+}
+
+// Messages are stored to be sent later
+func (m TextMessages) MarshalJSON() ([]byte, error) {
+	dto := textMessagesDTO{
+		PeerId:   m.peerId,
+		Messages: make([]textDTO, len(m.messages)),
+	}
+	for i, msg := range m.messages {
+		dto.Messages[i] = textDTO(msg)
+	}
+	return json.Marshal(dto)
+}
+
+func (m *TextMessages) UnmarshalJSON(data []byte) error {
+	var dto textMessagesDTO
+	if err := json.Unmarshal(data, &dto); err != nil {
+		return err
+	}
+	m.peerId = dto.PeerId
+	m.messages = make([]Text, len(dto.Messages))
+	for i, msg := range dto.Messages {
+		m.messages[i] = Text(msg)
+	}
+	return nil
+}
+
+func (m TextMessages) Send(ctx context.Context, client *Client) error {
+	for _, msg := range m.messages {
+		if err := client.SendMessage(ctx, m.peerId, msg.Text, msg.Keyboard); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type TextResponses []Text
+
+func (rs TextResponses) Send(c *Context) error {
+	for _, r := range rs {
+		if err := c.Send(r.Text, r.Keyboard); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Replaces the message with the pressed button by the first response
+func (rs TextResponses) Edit(c *Context) error {
+	if len(rs) == 0 {
+		return nil
+	}
+	if err := c.Edit(rs[0].Text, rs[0].Keyboard); err != nil {
+		return err
+	}
+	for i := 1; i < len(rs); i++ {
+		if err := c.Send(rs[i].Text, rs[i].Keyboard); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Shown as a snackbar for callback buttons and as a message otherwise
+type EventResponse struct {
+	Text string
+}
+
+func (r EventResponse) Send(c *Context) error {
+	return c.Respond(r.Text)
+}
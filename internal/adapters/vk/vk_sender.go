@@ -0,0 +1,17 @@
+package vk_adapters
+
+import "context"
+
+type Sender struct {
+	client *Client
+}
+
+func NewSender(client *Client) *Sender {
+	return &Sender{
+		client: client,
+	}
+}
+
+func (s *Sender) Send(ctx context.Context, msg Message) error {
+	return msg.Send(ctx, s.client)
+}
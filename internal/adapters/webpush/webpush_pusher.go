@@ -0,0 +1,91 @@
+package webpush_adapters
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+var ErrUnexpectedStatus = errors.New("unexpected status")
+
+// The push service will never deliver to the subscription again
+var ErrSubscriptionGone = errors.New("push subscription is gone")
+
+// Fields of the browser `PushSubscription`
+type Subscription struct {
+	Endpoint string
+	P256dh   string
+	Auth     string
+}
+
+// Payload for the service worker of the web app
+type Notification struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+	// Notifications with the same tag replace each other
+	Tag string `json:"tag,omitempty"`
+	Url string `json:"url,omitempty"`
+	// Notification is shown without a sound and vibration
+	Silent bool `json:"silent,omitempty"`
+}
+
+type Pusher struct {
+	client *http.Client
+	vapid  *Vapid
+	ttl    time.Duration
+}
+
+// Push services drop notifications which are not delivered within `ttl`
+func NewPusher(client *http.Client, vapid *Vapid, ttl time.Duration) *Pusher {
+	return &Pusher{
+		client: client,
+		vapid:  vapid,
+		ttl:    ttl,
+	}
+}
+
+func (p *Pusher) PublicKey() string {
+	return p.vapid.PublicKey()
+}
+
+func (p *Pusher) Push(ctx context.Context, subscription Subscription, notification Notification) error {
+	payload, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+	body, err := encrypt(payload, subscription.P256dh, subscription.Auth)
+	if err != nil {
+		return err
+	}
+	authorization, err := p.vapid.authorization(subscription.Endpoint, time.Now())
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("TTL", strconv.FormatInt(int64(p.ttl/time.Second), 10))
+	req.Header.Set("Authorization", authorization)
+	res, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound || res.StatusCode == http.StatusGone {
+		return ErrSubscriptionGone
+	}
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		text, _ := io.ReadAll(io.LimitReader(res.Body, 512))
+		return fmt.Errorf("%w: %d %s", ErrUnexpectedStatus, res.StatusCode, text)
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package webpush_adapters
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type userAgent struct {
+	key  *ecdh.PrivateKey
+	auth []byte
+}
+
+func newUserAgent(t *testing.T) *userAgent {
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	auth := make([]byte, 16)
+	if _, err := rand.Read(auth); err != nil {
+		t.Fatal(err)
+	}
+	return &userAgent{key: key, auth: auth}
+}
+
+func (ua *userAgent) subscription(endpoint string) Subscription {
+	return Subscription{
+		Endpoint: endpoint,
+		P256dh:   base64.RawURLEncoding.EncodeToString(ua.key.PublicKey().Bytes()),
+		Auth:     base64.RawURLEncoding.EncodeToString(ua.auth),
+	}
+}
+
+func (ua *userAgent) decrypt(t *testing.T, body []byte) []byte {
+	salt := body[:16]
+	if rs := binary.BigEndian.Uint32(body[16:20]); rs != recordSize {
+		t.Fatalf("record size = %d", rs)
+	}
+	idLen := int(body[20])
+	asPublicBytes := body[21 : 21+idLen]
+	asPublic, err := ecdh.P256().NewPublicKey(asPublicBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecdhSecret, err := ua.key.ECDH(asPublic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyInfo := append([]byte("WebPush: info\x00"), ua.key.PublicKey().Bytes()...)
+	keyInfo = append(keyInfo, asPublicBytes...)
+	ikm := hkdf(ua.auth, ecdhSecret, keyInfo, 32)
+	block, err := aes.NewCipher(hkdf(salt, ikm, []byte("Content-Encoding: aes128gcm\x00"), 16))
+	if err != nil {
+		t.Fatal(err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nonce := hkdf(salt, ikm, []byte("Content-Encoding: nonce\x00"), 12)
+	plaintext, err := gcm.Open(nil, nonce, body[21+idLen:], nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plaintext[len(plaintext)-1] != 0x02 {
+		t.Fatalf("missing record delimiter")
+	}
+	return plaintext[:len(plaintext)-1]
+}
+
+func verifyAuthorization(t *testing.T, header string, publicKey string, audience string) {
+	t.Helper()
+	token, key, ok := strings.Cut(strings.TrimPrefix(header, "vapid t="), ", k=")
+	if !ok || key != publicKey {
+		t.Fatalf("Authorization = %q", header)
+	}
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		t.Fatalf("token = %q", token)
+	}
+	claimsJson, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	var claims vapidClaims
+	if err := json.Unmarshal(claimsJson, &claims); err != nil {
+		t.Fatal(err)
+	}
+	if claims.Aud != audience {
+		t.Errorf("aud = %q, want %q", claims.Aud, audience)
+	}
+	pub, err := base64.RawURLEncoding.DecodeString(publicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if !ecdsa.Verify(
+		&ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(pub[1:33]),
+			Y:     new(big.Int).SetBytes(pub[33:]),
+		},
+		hash[:],
+		new(big.Int).SetBytes(signature[:32]),
+		new(big.Int).SetBytes(signature[32:]),
+	) {
+		t.Error("invalid token signature")
+	}
+}
+
+func TestPusher(t *testing.T) {
+	privateKey, err := GenerateVapidPrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	vapid, err := NewVapid(privateKey, "mailto:clinic@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ua := newUserAgent(t)
+	var received Notification
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/gone" {
+			w.WriteHeader(http.StatusGone)
+			return
+		}
+		verifyAuthorization(t, r.Header.Get("Authorization"), vapid.PublicKey(), "http://"+r.Host)
+		if r.Header.Get("TTL") != "3600" {
+			t.Errorf("TTL = %q", r.Header.Get("TTL"))
+		}
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := json.Unmarshal(ua.decrypt(t, body), &received); err != nil {
+			t.Fatal(err)
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	pusher := NewPusher(server.Client(), vapid, time.Hour)
+	notification := Notification{Title: "Запись", Body: "Завтра в 10:00", Tag: "record-1"}
+	if err := pusher.Push(context.Background(), ua.subscription(server.URL+"/push"), notification); err != nil {
+		t.Fatal(err)
+	}
+	if received != notification {
+		t.Errorf("received %v, want %v", received, notification)
+	}
+	err = pusher.Push(context.Background(), ua.subscription(server.URL+"/gone"), notification)
+	if !errors.Is(err, ErrSubscriptionGone) {
+		t.Errorf("Push() error = %v, want %v", err, ErrSubscriptionGone)
+	}
+}
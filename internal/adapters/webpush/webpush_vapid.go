@@ -0,0 +1,111 @@
+package webpush_adapters
+
+import (
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strings"
+	"time"
+)
+
+var ErrInvalidVapidKey = errors.New("invalid vapid key")
+var ErrInvalidVapidSubject = errors.New("vapid subject should be a mailto: or https: url")
+
+// Tokens are valid for 24 hours at most, see RFC 8292
+const vapidTokenExpiry = 12 * time.Hour
+
+// Application server identification, see RFC 8292
+type Vapid struct {
+	key       *ecdsa.PrivateKey
+	publicKey string
+	subject   string
+}
+
+// `privateKey` is the base64url encoded P-256 scalar,
+// use `GenerateVapidPrivateKey` to create one
+func NewVapid(privateKey string, subject string) (*Vapid, error) {
+	if !strings.HasPrefix(subject, "mailto:") && !strings.HasPrefix(subject, "https:") {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidVapidSubject, subject)
+	}
+	d, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(privateKey, "="))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidVapidKey, err)
+	}
+	ecdhKey, err := ecdh.P256().NewPrivateKey(d)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidVapidKey, err)
+	}
+	pub := ecdhKey.PublicKey().Bytes()
+	return &Vapid{
+		key: &ecdsa.PrivateKey{
+			PublicKey: ecdsa.PublicKey{
+				Curve: elliptic.P256(),
+				X:     new(big.Int).SetBytes(pub[1:33]),
+				Y:     new(big.Int).SetBytes(pub[33:]),
+			},
+			D: new(big.Int).SetBytes(d),
+		},
+		publicKey: base64.RawURLEncoding.EncodeToString(pub),
+		subject:   subject,
+	}, nil
+}
+
+func GenerateVapidPrivateKey() (string, error) {
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(key.Bytes()), nil
+}
+
+// Base64url encoded uncompressed point, the `applicationServerKey`
+// of the browser subscription
+func (v *Vapid) PublicKey() string {
+	return v.publicKey
+}
+
+type vapidClaims struct {
+	Aud string `json:"aud"`
+	Exp int64  `json:"exp"`
+	Sub string `json:"sub"`
+}
+
+var vapidTokenHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"typ":"JWT","alg":"ES256"}`))
+
+func (v *Vapid) authorization(endpoint string, now time.Time) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(vapidClaims{
+		Aud: u.Scheme + "://" + u.Host,
+		Exp: now.Add(vapidTokenExpiry).Unix(),
+		Sub: v.subject,
+	})
+	if err != nil {
+		return "", err
+	}
+	unsigned := vapidTokenHeader + "." + base64.RawURLEncoding.EncodeToString(claims)
+	hash := sha256.Sum256([]byte(unsigned))
+	r, s, err := ecdsa.Sign(rand.Reader, v.key, hash[:])
+	if err != nil {
+		return "", err
+	}
+	signature := make([]byte, 64)
+	r.FillBytes(signature[:32])
+	s.FillBytes(signature[32:])
+	return fmt.Sprintf(
+		"vapid t=%s.%s, k=%s",
+		unsigned,
+		base64.RawURLEncoding.EncodeToString(signature),
+		v.publicKey,
+	), nil
+}
@@ -1,6 +1,7 @@
 package app
 
 import (
+	"errors"
 	"log"
 	"os"
 	"time"
@@ -8,6 +9,7 @@ import (
 	"github.com/ilyakaznacheev/cleanenv"
 	"github.com/jomei/notionapi"
 	telegram_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/telegram"
+	vk_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/vk"
 	appointment_module "github.com/x0k/veterinary-clinic-backend/internal/appointment/module"
 	profiler_module "github.com/x0k/veterinary-clinic-backend/internal/profiler"
 )
@@ -27,10 +29,60 @@ type TelegramConfig struct {
 	InitDataExpiry time.Duration           `yaml:"init_data_expiry" env:"TELEGRAM_INIT_DATA_EXPIRY" env-default:"24h"`
 }
 
+// VK Mini App is not authenticated when the secret is empty
+// and the community bot is disabled when the access token is empty
+type VkConfig struct {
+	AppSecret          vk_adapters.AppSecret        `yaml:"app_secret" env:"VK_APP_SECRET"`
+	LaunchParamsExpiry time.Duration                `yaml:"launch_params_expiry" env:"VK_LAUNCH_PARAMS_EXPIRY" env-default:"24h"`
+	AccessToken        vk_adapters.AccessToken      `yaml:"access_token" env:"VK_ACCESS_TOKEN"`
+	ApiUrl             string                       `yaml:"api_url" env:"VK_API_URL" env-default:"https://api.vk.com/method"`
+	ApiTimeout         time.Duration                `yaml:"api_timeout" env:"VK_API_TIMEOUT" env-default:"10s"`
+	CallbackAddress    string                       `yaml:"callback_address" env:"VK_CALLBACK_ADDRESS" env-default:"0.0.0.0:6014"`
+	CallbackSecret     vk_adapters.CallbackSecret   `yaml:"callback_secret" env:"VK_CALLBACK_SECRET"`
+	ConfirmationCode   vk_adapters.ConfirmationCode `yaml:"confirmation_code" env:"VK_CONFIRMATION_CODE"`
+}
+
+var ErrVkCallbackSecretRequired = errors.New("vk callback secret is required")
+
+// Email notifications are disabled when the address is empty
+type SmtpConfig struct {
+	Address  string `yaml:"address" env:"SMTP_ADDRESS"`
+	Username string `yaml:"username" env:"SMTP_USERNAME"`
+	Password string `yaml:"password" env:"SMTP_PASSWORD"`
+	From     string `yaml:"from" env:"SMTP_FROM"`
+}
+
+// SMS notifications are disabled when the gateway url is empty
+type SmsConfig struct {
+	GatewayUrl    string `yaml:"gateway_url" env:"SMS_GATEWAY_URL"`
+	Token         string `yaml:"token" env:"SMS_GATEWAY_TOKEN"`
+	From          string `yaml:"from" env:"SMS_FROM"`
+	Transliterate bool   `yaml:"transliterate" env:"SMS_TRANSLITERATE" env-default:"true"`
+	MaxSegments   int    `yaml:"max_segments" env:"SMS_MAX_SEGMENTS" env-default:"2"`
+}
+
+// Web push is disabled when the private key is empty,
+// run `go run ./cmd/vapid` to generate the keys
+type WebPushConfig struct {
+	VapidPrivateKey string `yaml:"vapid_private_key" env:"WEB_PUSH_VAPID_PRIVATE_KEY"`
+	// Contact of the push service operator, a mailto: or https: url
+	Subject string        `yaml:"subject" env:"WEB_PUSH_SUBJECT"`
+	Ttl     time.Duration `yaml:"ttl" env:"WEB_PUSH_TTL" env-default:"24h"`
+}
+
+type StorageConfig struct {
+	Path string `yaml:"path" env:"STORAGE_PATH" env-default:"./storage/storage.db"`
+}
+
 type Config struct {
 	Logger   LoggerConfig   `yaml:"logger"`
 	Notion   NotionConfig   `yaml:"notion"`
 	Telegram TelegramConfig `yaml:"telegram"`
+	Vk       VkConfig       `yaml:"vk"`
+	Smtp     SmtpConfig     `yaml:"smtp"`
+	Sms      SmsConfig      `yaml:"sms"`
+	WebPush  WebPushConfig  `yaml:"web_push"`
+	Storage  StorageConfig  `yaml:"storage"`
 
 	Profiler    profiler_module.Config    `yaml:"profiler"`
 	Appointment appointment_module.Config `yaml:"appointment"`
@@ -1,12 +1,21 @@
 package app
 
 import (
+	"context"
 	"log/slog"
+	"net/http"
 
 	"github.com/jomei/notionapi"
+	http_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/http"
+	sms_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/sms"
+	smtp_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/smtp"
+	sqlite_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/sqlite"
 	telegram_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/telegram"
+	vk_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/vk"
+	webpush_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/webpush"
 	appointment_module "github.com/x0k/veterinary-clinic-backend/internal/appointment/module"
 	"github.com/x0k/veterinary-clinic-backend/internal/lib/logger"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/logger/sl"
 	"github.com/x0k/veterinary-clinic-backend/internal/lib/module"
 	profiler_module "github.com/x0k/veterinary-clinic-backend/internal/profiler"
 	"gopkg.in/telebot.v3"
@@ -36,6 +45,12 @@ func NewRoot(cfg *Config, log *logger.Logger) (*module.Root, error) {
 
 	notion := notionapi.NewClient(cfg.Notion.Token)
 
+	db, err := sqlite_adapters.Open(cfg.Storage.Path)
+	if err != nil {
+		return nil, err
+	}
+	m.Append(sqlite_adapters.NewService("storage", db))
+
 	// Modules
 
 	profilerModule := profiler_module.New(&cfg.Profiler, log)
@@ -46,12 +61,97 @@ func NewRoot(cfg *Config, log *logger.Logger) (*module.Root, error) {
 		cfg.Telegram.InitDataExpiry,
 	)
 
+	var vkLaunchParamsParser vk_adapters.LaunchParamsParser
+	if cfg.Vk.AppSecret != "" {
+		vkLaunchParamsParser = vk_adapters.NewLaunchParamsParser(
+			cfg.Vk.AppSecret,
+			cfg.Vk.LaunchParamsExpiry,
+		)
+	}
+
+	var vkBot *vk_adapters.Bot
+	if cfg.Vk.AccessToken != "" {
+		// The Callback API server is public, so updates are trusted only by the secret
+		if cfg.Vk.CallbackSecret == "" {
+			return nil, ErrVkCallbackSecretRequired
+		}
+		vkLog := log.With(sl.Component("vk_bot"))
+		vkBot = vk_adapters.NewBot(
+			vk_adapters.NewClient(
+				&http.Client{Timeout: cfg.Vk.ApiTimeout},
+				cfg.Vk.ApiUrl,
+				cfg.Vk.AccessToken,
+			),
+			cfg.Vk.CallbackSecret,
+			cfg.Vk.ConfirmationCode,
+			func(err error, _ *vk_adapters.Context) {
+				vkLog.Error(context.Background(), "failed to handle update", sl.Err(err))
+			},
+		)
+		m.Append(
+			module.NewService("vk_bot", vkBot.Start),
+			http_adapters.NewService("vk_bot_callback_server", &http.Server{
+				Addr:    cfg.Vk.CallbackAddress,
+				Handler: http_adapters.Logging(log, vkBot),
+			}, m),
+		)
+	}
+
+	var emailSender *smtp_adapters.Sender
+	if cfg.Smtp.Address != "" {
+		emailSender, err = smtp_adapters.NewSender(
+			cfg.Smtp.Address,
+			cfg.Smtp.Username,
+			cfg.Smtp.Password,
+			cfg.Smtp.From,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var smsSender *sms_adapters.Sender
+	if cfg.Sms.GatewayUrl != "" {
+		smsSender = sms_adapters.NewSender(
+			sms_adapters.NewHttpGateway(
+				http.DefaultClient,
+				cfg.Sms.GatewayUrl,
+				cfg.Sms.Token,
+				cfg.Sms.From,
+			),
+			cfg.Sms.Transliterate,
+			cfg.Sms.MaxSegments,
+		)
+	}
+
+	var webPushPusher *webpush_adapters.Pusher
+	if cfg.WebPush.VapidPrivateKey != "" {
+		vapid, err := webpush_adapters.NewVapid(
+			cfg.WebPush.VapidPrivateKey,
+			cfg.WebPush.Subject,
+		)
+		if err != nil {
+			return nil, err
+		}
+		webPushPusher = webpush_adapters.NewPusher(
+			http.DefaultClient,
+			vapid,
+			cfg.WebPush.Ttl,
+		)
+	}
+
 	appointmentModule, err := appointment_module.New(
 		&cfg.Appointment,
 		log,
 		bot,
 		notion,
+		db,
 		telegramInitDataParser,
+		vkLaunchParamsParser,
+		vkBot,
+		emailSender,
+		smsSender,
+		webPushPusher,
 	)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,63 @@
+package appointment_http_adapters
+
+import (
+	"time"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	shared_http_adapters "github.com/x0k/veterinary-clinic-backend/internal/shared/adapters/http"
+)
+
+type ScheduleEntryDTO struct {
+	Type           int                                    `json:"type"`
+	Title          string                                 `json:"title"`
+	DateTimePeriod shared_http_adapters.DateTimePeriodDTO `json:"dateTimePeriod"`
+}
+
+func ScheduleEntryToDTO(entry appointment.ScheduleEntry) ScheduleEntryDTO {
+	return ScheduleEntryDTO{
+		Type:           entry.Type.Int(),
+		Title:          entry.Title,
+		DateTimePeriod: shared_http_adapters.DateTimePeriodToDTO(entry.DateTimePeriod),
+	}
+}
+
+type ScheduleDTO struct {
+	Date     string             `json:"date"`
+	Entries  []ScheduleEntryDTO `json:"entries"`
+	NextDate string             `json:"nextDate"`
+	PrevDate string             `json:"prevDate"`
+}
+
+func ScheduleToDTO(schedule appointment.Schedule) ScheduleDTO {
+	entries := make([]ScheduleEntryDTO, len(schedule.Entries))
+	for i, entry := range schedule.Entries {
+		entries[i] = ScheduleEntryToDTO(entry)
+	}
+	return ScheduleDTO{
+		Date:     schedule.Date.Format(time.RFC3339),
+		Entries:  entries,
+		NextDate: schedule.NextDate.Format(time.RFC3339),
+		PrevDate: schedule.PrevDate.Format(time.RFC3339),
+	}
+}
+
+type AppointmentInfoDTO struct {
+	Record  RecordDTO  `json:"record"`
+	Service ServiceDTO `json:"service"`
+}
+
+func AppointmentInfoToDTO(
+	record appointment.RecordEntity,
+	service appointment.ServiceEntity,
+) AppointmentInfoDTO {
+	return AppointmentInfoDTO{
+		Record:  RecordToDTO(record),
+		Service: ServiceToDTO(service),
+	}
+}
+
+type CreateAppointmentDTO struct {
+	Date      string `json:"date"`
+	Identity  string `json:"identity"`
+	ServiceId string `json:"serviceId"`
+}
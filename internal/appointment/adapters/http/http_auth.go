@@ -0,0 +1,16 @@
+package appointment_http_adapters
+
+import (
+	initdata "github.com/telegram-mini-apps/init-data-golang"
+	vk_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/vk"
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	"github.com/x0k/veterinary-clinic-backend/internal/shared"
+)
+
+func TelegramCustomerIdentity(data initdata.InitData) (appointment.CustomerIdentity, error) {
+	return appointment.NewTelegramCustomerIdentity(shared.NewTelegramUserId(data.User.ID))
+}
+
+func VkCustomerIdentity(params vk_adapters.LaunchParams) (appointment.CustomerIdentity, error) {
+	return appointment.NewVkCustomerIdentity(shared.NewVkUserId(params.UserId))
+}
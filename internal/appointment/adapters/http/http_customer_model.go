@@ -0,0 +1,39 @@
+package appointment_http_adapters
+
+type UpsertCustomerDTO struct {
+	Name     string `json:"name"`
+	Identity string `json:"identity"`
+	Phone    string `json:"phone"`
+	Email    string `json:"email"`
+}
+
+type CustomerIdDTO struct {
+	Id string `json:"id"`
+}
+
+type QuietHoursDTO struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+type NotificationPreferencesDTO struct {
+	Channels    []string       `json:"channels"`
+	MutedEvents []string       `json:"mutedEvents"`
+	QuietHours  *QuietHoursDTO `json:"quietHours"`
+}
+
+type WebPushSubscriptionKeysDTO struct {
+	P256dh string `json:"p256dh"`
+	Auth   string `json:"auth"`
+}
+
+// JSON of the browser `PushSubscription`
+type WebPushSubscriptionDTO struct {
+	Endpoint       string                     `json:"endpoint"`
+	ExpirationTime *int64                     `json:"expirationTime"`
+	Keys           WebPushSubscriptionKeysDTO `json:"keys"`
+}
+
+type WebPushPublicKeyDTO struct {
+	PublicKey string `json:"publicKey"`
+}
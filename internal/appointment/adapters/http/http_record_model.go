@@ -0,0 +1,32 @@
+package appointment_http_adapters
+
+import (
+	"time"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	shared_http_adapters "github.com/x0k/veterinary-clinic-backend/internal/shared/adapters/http"
+)
+
+type RecordDTO struct {
+	Id             string                                 `json:"id"`
+	Title          string                                 `json:"title"`
+	Status         string                                 `json:"status"`
+	IsArchived     bool                                   `json:"isArchived"`
+	DateTimePeriod shared_http_adapters.DateTimePeriodDTO `json:"dateTimePeriod"`
+	CustomerId     string                                 `json:"customerId"`
+	ServiceId      string                                 `json:"serviceId"`
+	CreatedAt      string                                 `json:"createdAt"`
+}
+
+func RecordToDTO(record appointment.RecordEntity) RecordDTO {
+	return RecordDTO{
+		Id:             record.Id.String(),
+		Title:          record.Title,
+		Status:         record.Status.String(),
+		IsArchived:     record.IsArchived,
+		DateTimePeriod: shared_http_adapters.DateTimePeriodToDTO(record.DateTimePeriod),
+		CustomerId:     record.CustomerId.String(),
+		ServiceId:      record.ServiceId.String(),
+		CreatedAt:      record.CreatedAt.Format(time.RFC3339),
+	}
+}
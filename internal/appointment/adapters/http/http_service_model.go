@@ -0,0 +1,23 @@
+package appointment_http_adapters
+
+import "github.com/x0k/veterinary-clinic-backend/internal/appointment"
+
+type ServiceDTO struct {
+	Id                string `json:"id"`
+	Title             string `json:"title"`
+	DurationInMinutes int    `json:"durationInMinutes"`
+	Description       string `json:"description"`
+	CostDescription   string `json:"costDescription"`
+	RequiresApproval  bool   `json:"requiresApproval"`
+}
+
+func ServiceToDTO(service appointment.ServiceEntity) ServiceDTO {
+	return ServiceDTO{
+		Id:                service.Id.String(),
+		Title:             service.Title,
+		DurationInMinutes: service.DurationInMinutes.Int(),
+		Description:       service.Description,
+		CostDescription:   service.CostDescription,
+		RequiresApproval:  service.RequiresApproval,
+	}
+}
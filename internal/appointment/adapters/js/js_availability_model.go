@@ -0,0 +1,19 @@
+package appointment_js_adapters
+
+import (
+	"time"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+)
+
+type DayAvailabilityDTO struct {
+	Date   string `js:"date"`
+	Status string `js:"status"`
+}
+
+func DayAvailabilityToDTO(day appointment.DayAvailability) DayAvailabilityDTO {
+	return DayAvailabilityDTO{
+		Date:   day.Date.Format(time.DateOnly),
+		Status: day.Status.String(),
+	}
+}
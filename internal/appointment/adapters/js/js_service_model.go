@@ -11,6 +11,7 @@ type ServiceDTO struct {
 	DurationInMinutes int    `js:"durationInMinutes"`
 	Description       string `js:"description"`
 	CostDescription   string `js:"costDescription"`
+	RequiresApproval  bool   `js:"requiresApproval"`
 }
 
 func ServiceIdToDTO(id appointment.ServiceId) (string, error) {
@@ -24,6 +25,7 @@ func ServiceToDTO(service appointment.ServiceEntity) (ServiceDTO, error) {
 		DurationInMinutes: service.DurationInMinutes.Int(),
 		Description:       service.Description,
 		CostDescription:   service.CostDescription,
+		RequiresApproval:  service.RequiresApproval,
 	}, nil
 }
 
@@ -34,5 +36,6 @@ func ServiceFromDTO(dto ServiceDTO) (appointment.ServiceEntity, error) {
 		shared.NewDurationInMinutes(dto.DurationInMinutes),
 		dto.Description,
 		dto.CostDescription,
+		dto.RequiresApproval,
 	), nil
 }
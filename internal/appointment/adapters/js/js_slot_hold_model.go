@@ -0,0 +1,38 @@
+package appointment_js_adapters
+
+import (
+	"time"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	shared_js_adapters "github.com/x0k/veterinary-clinic-backend/internal/shared/adapters/js"
+)
+
+type SlotHoldDTO struct {
+	Owner          string                               `js:"owner"`
+	DateTimePeriod shared_js_adapters.DateTimePeriodDTO `js:"dateTimePeriod"`
+	ExpiresAt      string                               `js:"expiresAt"`
+}
+
+func SlotHoldToDTO(hold appointment.SlotHold) SlotHoldDTO {
+	return SlotHoldDTO{
+		Owner:          hold.Owner.String(),
+		DateTimePeriod: shared_js_adapters.DateTimePeriodToDTO(hold.DateTimePeriod),
+		ExpiresAt:      hold.ExpiresAt.Format(time.RFC3339),
+	}
+}
+
+func SlotHoldFromDTO(dto SlotHoldDTO) (appointment.SlotHold, error) {
+	owner, err := appointment.NewCustomerIdentity(dto.Owner)
+	if err != nil {
+		return appointment.SlotHold{}, err
+	}
+	expiresAt, err := time.Parse(time.RFC3339, dto.ExpiresAt)
+	if err != nil {
+		return appointment.SlotHold{}, err
+	}
+	return appointment.NewSlotHold(
+		owner,
+		shared_js_adapters.DateTimePeriodFromDTO(dto.DateTimePeriod),
+		expiresAt,
+	), nil
+}
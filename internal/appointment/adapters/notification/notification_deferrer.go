@@ -0,0 +1,121 @@
+package appointment_notification_adapters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	sms_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/sms"
+	vk_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/vk"
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/logger"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/logger/sl"
+)
+
+const deferrerName = "appointment_notification_adapters.Deferrer"
+
+var ErrUnsupportedDeferredMessage = errors.New("unsupported deferred message")
+
+// Only the channels which can not be silent are deferred
+type deferredMessageDTO struct {
+	Vk  *vk_adapters.TextMessages `json:"vk,omitempty"`
+	Sms *sms_adapters.Message     `json:"sms,omitempty"`
+}
+
+func encodeDeferredMessage(msg Message, delivery appointment.NotificationDelivery) ([]byte, error) {
+	for _, c := range delivery.Channels {
+		if c != appointment.VkNotificationChannel && c != appointment.SmsNotificationChannel {
+			return nil, fmt.Errorf("%w: %s", ErrUnsupportedDeferredMessage, c)
+		}
+	}
+	var dto deferredMessageDTO
+	if msg.Vk != nil && delivery.Includes(appointment.VkNotificationChannel) {
+		vkMsg, ok := msg.Vk.(vk_adapters.TextMessages)
+		if !ok {
+			return nil, fmt.Errorf("%w: %T", ErrUnsupportedDeferredMessage, msg.Vk)
+		}
+		dto.Vk = &vkMsg
+	}
+	if delivery.Includes(appointment.SmsNotificationChannel) {
+		dto.Sms = msg.Sms
+	}
+	return json.Marshal(dto)
+}
+
+func decodeDeferredMessage(payload []byte) (Message, error) {
+	var dto deferredMessageDTO
+	if err := json.Unmarshal(payload, &dto); err != nil {
+		return Message{}, err
+	}
+	msg := Message{Sms: dto.Sms}
+	if dto.Vk != nil {
+		msg.Vk = *dto.Vk
+	}
+	return msg, nil
+}
+
+// Stores messages postponed by quiet hours, so they survive restarts,
+// and sends them with `SendDue`
+type Deferrer struct {
+	log                    *logger.Logger
+	sender                 *Sender
+	notificationSaver      appointment.DeferredNotificationSaver
+	dueNotificationsLoader appointment.DueDeferredNotificationsLoader
+	notificationRemover    appointment.DeferredNotificationRemover
+}
+
+func NewDeferrer(
+	log *logger.Logger,
+	sender *Sender,
+	notificationSaver appointment.DeferredNotificationSaver,
+	dueNotificationsLoader appointment.DueDeferredNotificationsLoader,
+	notificationRemover appointment.DeferredNotificationRemover,
+) *Deferrer {
+	return &Deferrer{
+		log:                    log.With(sl.Component(deferrerName)),
+		sender:                 sender,
+		notificationSaver:      notificationSaver,
+		dueNotificationsLoader: dueNotificationsLoader,
+		notificationRemover:    notificationRemover,
+	}
+}
+
+func (d *Deferrer) Defer(
+	ctx context.Context,
+	msg Message,
+	delivery appointment.NotificationDelivery,
+	at time.Time,
+) error {
+	payload, err := encodeDeferredMessage(msg, delivery)
+	if err != nil {
+		return err
+	}
+	return d.notificationSaver(ctx, appointment.DeferredNotification{
+		Payload:  payload,
+		Channels: delivery.Channels,
+		SendAt:   at,
+	})
+}
+
+// Failed messages are not retried, like the ones sent right away
+func (d *Deferrer) SendDue(ctx context.Context, now time.Time) {
+	notifications, err := d.dueNotificationsLoader(ctx, now)
+	if err != nil {
+		d.log.Error(ctx, "failed to load deferred notifications", sl.Err(err))
+		return
+	}
+	for _, n := range notifications {
+		log := d.log.With(slog.Int64("notification_id", int64(n.Id)))
+		if msg, err := decodeDeferredMessage(n.Payload); err != nil {
+			log.Error(ctx, "deferred notification is corrupted", sl.Err(err))
+		} else if err := d.sender.Send(ctx, msg, appointment.NotificationDelivery{Channels: n.Channels}); err != nil {
+			log.Error(ctx, "failed to send deferred notification", sl.Err(err))
+		}
+		if err := d.notificationRemover(ctx, n.Id); err != nil {
+			log.Error(ctx, "failed to remove deferred notification", sl.Err(err))
+		}
+	}
+}
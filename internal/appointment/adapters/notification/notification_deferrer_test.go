@@ -0,0 +1,105 @@
+package appointment_notification_adapters
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	sms_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/sms"
+	vk_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/vk"
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/logger"
+)
+
+func TestDeferrerSendsStoredMessages(t *testing.T) {
+	ctx := context.Background()
+	var stored []appointment.DeferredNotification
+	var sent []sms_adapters.Message
+	sender := NewSender(nil, nil, nil, func(_ context.Context, msg *sms_adapters.Message) error {
+		sent = append(sent, *msg)
+		return nil
+	}, nil)
+	newDeferrer := func() *Deferrer {
+		return NewDeferrer(
+			logger.New(slog.New(slog.NewTextHandler(io.Discard, nil))),
+			sender,
+			func(_ context.Context, n appointment.DeferredNotification) error {
+				n.Id = appointment.DeferredNotificationId(len(stored) + 1)
+				stored = append(stored, n)
+				return nil
+			},
+			func(_ context.Context, now time.Time) ([]appointment.DeferredNotification, error) {
+				due := make([]appointment.DeferredNotification, 0)
+				for _, n := range stored {
+					if !n.SendAt.After(now) {
+						due = append(due, n)
+					}
+				}
+				return due, nil
+			},
+			func(_ context.Context, id appointment.DeferredNotificationId) error {
+				for i, n := range stored {
+					if n.Id == id {
+						stored = append(stored[:i], stored[i+1:]...)
+						break
+					}
+				}
+				return nil
+			},
+		)
+	}
+	morning := time.Date(2024, 5, 6, 8, 0, 0, 0, time.UTC)
+	msg := Message{
+		Vk:  vk_adapters.NewTextMessages(1, vk_adapters.NewText("Appointment changed")),
+		Sms: &sms_adapters.Message{Phone: "+79991234567", Text: "Appointment changed"},
+	}
+	delivery := appointment.NotificationDelivery{
+		Channels: []appointment.NotificationChannel{appointment.SmsNotificationChannel},
+	}
+	if err := newDeferrer().Defer(ctx, msg, delivery, morning); err != nil {
+		t.Fatal(err)
+	}
+
+	// Restarted process
+	deferrer := newDeferrer()
+	deferrer.SendDue(ctx, morning.Add(-time.Minute))
+	if len(sent) != 0 {
+		t.Fatalf("sent = %+v before the end of quiet hours", sent)
+	}
+	deferrer.SendDue(ctx, morning)
+	if len(sent) != 1 || sent[0] != *msg.Sms {
+		t.Errorf("sent = %+v, want %+v", sent, *msg.Sms)
+	}
+	if len(stored) != 0 {
+		t.Errorf("stored = %+v, want sent notifications to be removed", stored)
+	}
+}
+
+func TestEncodeDeferredMessage(t *testing.T) {
+	msg := Message{Vk: vk_adapters.NewTextMessages(
+		1,
+		vk_adapters.NewText("Offer", vk_adapters.NewInlineKeyboard()),
+	)}
+	delivery := appointment.NotificationDelivery{
+		Channels: []appointment.NotificationChannel{appointment.VkNotificationChannel},
+	}
+	payload, err := encodeDeferredMessage(msg, delivery)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := decodeDeferredMessage(payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again, err := encodeDeferredMessage(decoded, delivery); err != nil || string(again) != string(payload) {
+		t.Errorf("encoded again = %s, %v, want %s", again, err, payload)
+	}
+
+	if _, err := encodeDeferredMessage(msg, appointment.NotificationDelivery{
+		Channels: []appointment.NotificationChannel{appointment.TelegramNotificationChannel},
+	}); err == nil {
+		t.Error("telegram messages are expected to be sent right away")
+	}
+}
@@ -0,0 +1,131 @@
+package appointment_notification_adapters
+
+import (
+	"context"
+	"errors"
+
+	sms_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/sms"
+	smtp_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/smtp"
+	telegram_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/telegram"
+	vk_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/vk"
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	appointment_webpush_adapters "github.com/x0k/veterinary-clinic-backend/internal/appointment/adapters/webpush"
+	"github.com/x0k/veterinary-clinic-backend/internal/shared"
+)
+
+// Customer notification for the channels chosen by the customer
+type Message struct {
+	Telegram telegram_adapters.Message
+	Vk       vk_adapters.Message
+	Email    *smtp_adapters.Message
+	Sms      *sms_adapters.Message
+	WebPush  *appointment_webpush_adapters.Message
+}
+
+type Sender struct {
+	telegramSender shared.Sender[telegram_adapters.Message]
+	vkSender       shared.Sender[vk_adapters.Message]
+	emailSender    shared.Sender[*smtp_adapters.Message]
+	smsSender      shared.Sender[*sms_adapters.Message]
+	webPushSender  shared.Sender[*appointment_webpush_adapters.Message]
+}
+
+// All senders except `telegramSender` are optional,
+// messages for the missing channels are dropped
+func NewSender(
+	telegramSender shared.Sender[telegram_adapters.Message],
+	vkSender shared.Sender[vk_adapters.Message],
+	emailSender shared.Sender[*smtp_adapters.Message],
+	smsSender shared.Sender[*sms_adapters.Message],
+	webPushSender shared.Sender[*appointment_webpush_adapters.Message],
+) *Sender {
+	return &Sender{
+		telegramSender: telegramSender,
+		vkSender:       vkSender,
+		emailSender:    emailSender,
+		smsSender:      smsSender,
+		webPushSender:  webPushSender,
+	}
+}
+
+func (s *Sender) Send(ctx context.Context, msg Message, delivery appointment.NotificationDelivery) error {
+	var err error
+	if msg.Telegram != nil && delivery.Includes(appointment.TelegramNotificationChannel) {
+		telegramMsg := msg.Telegram
+		if delivery.Silent {
+			telegramMsg = telegram_adapters.Silent(telegramMsg)
+		}
+		err = s.telegramSender(ctx, telegramMsg)
+	}
+	if msg.Vk != nil && s.vkSender != nil && delivery.Includes(appointment.VkNotificationChannel) {
+		err = errors.Join(err, s.vkSender(ctx, msg.Vk))
+	}
+	if msg.Email != nil && s.emailSender != nil && delivery.Includes(appointment.EmailNotificationChannel) {
+		err = errors.Join(err, s.emailSender(ctx, msg.Email))
+	}
+	if msg.Sms != nil && s.smsSender != nil && delivery.Includes(appointment.SmsNotificationChannel) {
+		err = errors.Join(err, s.smsSender(ctx, msg.Sms))
+	}
+	if msg.WebPush != nil && s.webPushSender != nil && delivery.Includes(appointment.WebPushNotificationChannel) {
+		webPushMsg := *msg.WebPush
+		webPushMsg.Notification.Silent = delivery.Silent
+		err = errors.Join(err, s.webPushSender(ctx, &webPushMsg))
+	}
+	return err
+}
+
+func EmailSender(sender shared.Sender[smtp_adapters.Message]) shared.Sender[*smtp_adapters.Message] {
+	return func(ctx context.Context, msg *smtp_adapters.Message) error {
+		return sender(ctx, *msg)
+	}
+}
+
+func SmsSender(sender shared.Sender[sms_adapters.Message]) shared.Sender[*sms_adapters.Message] {
+	return func(ctx context.Context, msg *sms_adapters.Message) error {
+		return sender(ctx, *msg)
+	}
+}
+
+// Renderers of the channels which can not deliver the message are nil
+type channels struct {
+	telegram func() (telegram_adapters.Message, error)
+	vk       func() (vk_adapters.Message, error)
+	email    func() (*smtp_adapters.Message, error)
+	sms      func() (*sms_adapters.Message, error)
+	webPush  func() (*appointment_webpush_adapters.Message, error)
+}
+
+func route(customer appointment.CustomerEntity, c channels) (Message, error) {
+	var msg Message
+	var err error
+	for _, channel := range customer.NotificationChannels() {
+		switch channel {
+		case appointment.TelegramNotificationChannel:
+			if c.telegram != nil {
+				msg.Telegram, err = c.telegram()
+			}
+		case appointment.VkNotificationChannel:
+			if c.vk != nil {
+				msg.Vk, err = c.vk()
+			}
+		case appointment.EmailNotificationChannel:
+			if c.email != nil {
+				msg.Email, err = c.email()
+			}
+		case appointment.SmsNotificationChannel:
+			if c.sms != nil {
+				msg.Sms, err = c.sms()
+			}
+		}
+		if err != nil {
+			return Message{}, err
+		}
+	}
+	if c.webPush != nil && customer.AcceptsWebPush() {
+		msg.WebPush, err = c.webPush()
+		if err != nil {
+			return Message{}, err
+		}
+	}
+	return msg, nil
+}
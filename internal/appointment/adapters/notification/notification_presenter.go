@@ -0,0 +1,130 @@
+package appointment_notification_adapters
+
+import (
+	sms_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/sms"
+	smtp_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/smtp"
+	telegram_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/telegram"
+	vk_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/vk"
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	appointment_webpush_adapters "github.com/x0k/veterinary-clinic-backend/internal/appointment/adapters/webpush"
+)
+
+// Presenters of a channel which is not configured should be nil
+
+func ChangedEventPresenter(
+	telegram appointment.ChangedEventPresenter[telegram_adapters.Message],
+	vk appointment.ChangedEventPresenter[vk_adapters.Message],
+	email appointment.ChangedEventPresenter[*smtp_adapters.Message],
+	sms appointment.ChangedEventPresenter[*sms_adapters.Message],
+	webPush appointment.ChangedEventPresenter[*appointment_webpush_adapters.Message],
+) appointment.ChangedEventPresenter[Message] {
+	return func(event appointment.ChangedEvent, customer appointment.CustomerEntity, service appointment.ServiceEntity) (Message, error) {
+		return route(customer, channels{
+			telegram: bind(telegram, event, customer, service),
+			vk:       bind(vk, event, customer, service),
+			email:    bind(email, event, customer, service),
+			sms:      bind(sms, event, customer, service),
+			webPush:  bind(webPush, event, customer, service),
+		})
+	}
+}
+
+func StatusTransitionPresenter(
+	telegram appointment.StatusTransitionPresenter[telegram_adapters.Message],
+	vk appointment.StatusTransitionPresenter[vk_adapters.Message],
+	email appointment.StatusTransitionPresenter[*smtp_adapters.Message],
+	sms appointment.StatusTransitionPresenter[*sms_adapters.Message],
+	webPush appointment.StatusTransitionPresenter[*appointment_webpush_adapters.Message],
+) appointment.StatusTransitionPresenter[Message] {
+	return func(transition appointment.StatusTransition, customer appointment.CustomerEntity, service appointment.ServiceEntity) (Message, error) {
+		return route(customer, channels{
+			telegram: bind(telegram, transition, customer, service),
+			vk:       bind(vk, transition, customer, service),
+			email:    bind(email, transition, customer, service),
+			sms:      bind(sms, transition, customer, service),
+			webPush:  bind(webPush, transition, customer, service),
+		})
+	}
+}
+
+// Slots of the offer are picked in the chat
+func RescheduleOfferPresenter(
+	telegram appointment.RescheduleOfferPresenter[telegram_adapters.Message],
+	vk appointment.RescheduleOfferPresenter[vk_adapters.Message],
+) appointment.RescheduleOfferPresenter[Message] {
+	return func(offer appointment.RescheduleOffer, customer appointment.CustomerEntity, service appointment.ServiceEntity) (Message, error) {
+		return route(customer, channels{
+			telegram: bind(telegram, offer, customer, service),
+			vk:       bind(vk, offer, customer, service),
+		})
+	}
+}
+
+// Customers see the result of their own actions in the chat,
+// so messengers are not notified
+func CreatedEventPresenter(
+	email appointment.CreatedEventPresenter[*smtp_adapters.Message],
+	sms appointment.CreatedEventPresenter[*sms_adapters.Message],
+	webPush appointment.CreatedEventPresenter[*appointment_webpush_adapters.Message],
+) appointment.CreatedEventPresenter[Message] {
+	return func(event appointment.CreatedEvent) (Message, error) {
+		return route(event.Customer, channels{
+			email:   bindEvent(email, event),
+			sms:     bindEvent(sms, event),
+			webPush: bindEvent(webPush, event),
+		})
+	}
+}
+
+func CanceledEventPresenter(
+	email appointment.CanceledEventPresenter[*smtp_adapters.Message],
+	sms appointment.CanceledEventPresenter[*sms_adapters.Message],
+	webPush appointment.CanceledEventPresenter[*appointment_webpush_adapters.Message],
+) appointment.CanceledEventPresenter[Message] {
+	return func(event appointment.CanceledEvent) (Message, error) {
+		return route(event.Customer, channels{
+			email:   bindEvent(email, event),
+			sms:     bindEvent(sms, event),
+			webPush: bindEvent(webPush, event),
+		})
+	}
+}
+
+func ReminderPresenter(
+	vk appointment.ReminderPresenter[vk_adapters.Message],
+	email appointment.ReminderPresenter[*smtp_adapters.Message],
+	sms appointment.ReminderPresenter[*sms_adapters.Message],
+	webPush appointment.ReminderPresenter[*appointment_webpush_adapters.Message],
+) appointment.ReminderPresenter[Message] {
+	return func(record appointment.RecordEntity, customer appointment.CustomerEntity, service appointment.ServiceEntity) (Message, error) {
+		return route(customer, channels{
+			vk:      bind(vk, record, customer, service),
+			email:   bind(email, record, customer, service),
+			sms:     bind(sms, record, customer, service),
+			webPush: bind(webPush, record, customer, service),
+		})
+	}
+}
+
+func bind[E any, R any](
+	present func(E, appointment.CustomerEntity, appointment.ServiceEntity) (R, error),
+	event E,
+	customer appointment.CustomerEntity,
+	service appointment.ServiceEntity,
+) func() (R, error) {
+	if present == nil {
+		return nil
+	}
+	return func() (R, error) {
+		return present(event, customer, service)
+	}
+}
+
+func bindEvent[E any, R any](present func(E) (R, error), event E) func() (R, error) {
+	if present == nil {
+		return nil
+	}
+	return func() (R, error) {
+		return present(event)
+	}
+}
@@ -62,4 +62,35 @@ var (
 		Text:   "Отменить запись",
 		Unique: "cncl-app",
 	}
+	ApproveAppointmentBtn = &telebot.InlineButton{
+		Text:   "Одобрить",
+		Unique: "apr-app",
+	}
+	DeclineAppointmentBtn = &telebot.InlineButton{
+		Text:   "Отклонить",
+		Unique: "dcl-app",
+	}
+	CompleteAppointmentBtn = &telebot.InlineButton{
+		Text:   "Выполнено",
+		Unique: "dn-app",
+	}
+	NotAppearAppointmentBtn = &telebot.InlineButton{
+		Text:   "Не пришел",
+		Unique: "na-app",
+	}
+	CancelByClinicAppointmentBtn = &telebot.InlineButton{
+		Text:   "Отменить",
+		Unique: "cbc-app",
+	}
+	HistoryAppointmentBtn = &telebot.InlineButton{
+		Text:   "История",
+		Unique: "hst-app",
+	}
+	RescheduleSlotBtn = &telebot.InlineButton{
+		Unique: "rsch-slt",
+	}
+	DeclineRescheduleBtn = &telebot.InlineButton{
+		Text:   "Не подходит",
+		Unique: "rsch-dcl",
+	}
 )
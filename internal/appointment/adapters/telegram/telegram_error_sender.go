@@ -11,7 +11,7 @@ type ErrorSender interface {
 }
 
 type errorSender struct {
-	errorPresenter appointment.ErrorPresenter[telegram_adapters.TextResponses]
+	errorPresenter appointment.ErrorPresenter[telegram_adapters.LocalizedTextResponses]
 }
 
 func (s *errorSender) Send(c telebot.Context, err error) error {
@@ -22,6 +22,6 @@ func (s *errorSender) Send(c telebot.Context, err error) error {
 	return res.Send(c)
 }
 
-func NewErrorSender(errorPresenter appointment.ErrorPresenter[telegram_adapters.TextResponses]) ErrorSender {
+func NewErrorSender(errorPresenter appointment.ErrorPresenter[telegram_adapters.LocalizedTextResponses]) ErrorSender {
 	return &errorSender{errorPresenter: errorPresenter}
 }
@@ -0,0 +1,51 @@
+package appointment_telegram_adapters
+
+import (
+	"context"
+
+	telegram_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/telegram"
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/i18n"
+	"github.com/x0k/veterinary-clinic-backend/internal/shared"
+	"gopkg.in/telebot.v3"
+)
+
+type Localizer struct {
+	localeDetector func(ctx context.Context, identity appointment.CustomerIdentity, detected string) string
+	printer        func(locale string) i18n.Printer
+}
+
+func NewLocalizer(
+	localeDetector func(ctx context.Context, identity appointment.CustomerIdentity, detected string) string,
+	printer func(locale string) i18n.Printer,
+) *Localizer {
+	return &Localizer{
+		localeDetector: localeDetector,
+		printer:        printer,
+	}
+}
+
+// Returns the printer in the locale of the Telegram user,
+// `languageCode` is the language of the user's client
+func (l *Localizer) Printer(ctx context.Context, userId shared.TelegramUserId, languageCode string) i18n.Printer {
+	detected := i18n.NewLocale(languageCode).String()
+	identity, err := appointment.NewTelegramCustomerIdentity(userId)
+	if err != nil {
+		return l.printer(detected)
+	}
+	return l.printer(l.localeDetector(ctx, identity, detected))
+}
+
+// Should be used before registration of handlers
+func (l *Localizer) Middleware(ctx context.Context) telebot.MiddlewareFunc {
+	return func(next telebot.HandlerFunc) telebot.HandlerFunc {
+		return func(c telebot.Context) error {
+			p := l.printer("")
+			if sender := c.Sender(); sender != nil {
+				p = l.Printer(ctx, shared.NewTelegramUserId(sender.ID), sender.LanguageCode)
+			}
+			telegram_adapters.SetPrinter(c, p)
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package appointment_vk_adapters
+
+import vk_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/vk"
+
+var (
+	NextScheduleBtn = vk_adapters.CallbackButton{
+		Label:   "➡",
+		Command: "next-schedule",
+	}
+	PreviousScheduleBtn = vk_adapters.CallbackButton{
+		Label:   "⬅",
+		Command: "next-schedule",
+	}
+	ServicesBtn = vk_adapters.CallbackButton{
+		Label:   "Услуги",
+		Command: "services",
+	}
+	ScheduleBtn = vk_adapters.CallbackButton{
+		Label:   "График работы",
+		Command: "schedule",
+	}
+	StartMakeAppointmentDialogBtn = vk_adapters.CallbackButton{
+		Label:   "Запись на прием",
+		Command: "appointment",
+		Color:   vk_adapters.PrimaryButtonColor,
+	}
+	RegisterVkCustomerBtn = vk_adapters.CallbackButton{
+		Label:   "Зарегистрироваться",
+		Command: "reg-vk-cst",
+		Color:   vk_adapters.PositiveButtonColor,
+	}
+	MakeAppointmentServiceBtn = vk_adapters.CallbackButton{
+		Command: "mk-app-srv",
+	}
+	NextMakeAppointmentDateBtn = vk_adapters.CallbackButton{
+		Label:   "➡",
+		Command: "nx-mk-app-dt",
+	}
+	PrevMakeAppointmentDateBtn = vk_adapters.CallbackButton{
+		Label:   "⬅",
+		Command: "nx-mk-app-dt",
+	}
+	CancelMakeAppointmentDateBtn = vk_adapters.CallbackButton{
+		Label:   "Назад",
+		Command: "cncl-mk-app-dt",
+	}
+	SelectMakeAppointmentDateBtn = vk_adapters.CallbackButton{
+		Label:   "Продолжить",
+		Command: "slc-mk-app-dt",
+		Color:   vk_adapters.PrimaryButtonColor,
+	}
+	MakeAppointmentTimeBtn = vk_adapters.CallbackButton{
+		Command: "mk-app-tm",
+	}
+	MoreMakeAppointmentTimeBtn = vk_adapters.CallbackButton{
+		Label:   "➡",
+		Command: "mr-mk-app-tm",
+	}
+	CancelMakeAppointmentTimeBtn = vk_adapters.CallbackButton{
+		Label:   "Назад",
+		Command: "cncl-mk-app-tm",
+	}
+	ConfirmMakeAppointmentBtn = vk_adapters.CallbackButton{
+		Label:   "Подтвердить запись",
+		Command: "cnf-mk-app",
+		Color:   vk_adapters.PositiveButtonColor,
+	}
+	CancelConfirmationAppointmentBtn = vk_adapters.CallbackButton{
+		Label:   "Назад",
+		Command: "cncl-mk-app",
+	}
+	CancelAppointmentBtn = vk_adapters.CallbackButton{
+		Label:   "Отменить запись",
+		Command: "cncl-app",
+		Color:   vk_adapters.NegativeButtonColor,
+	}
+	RescheduleSlotBtn = vk_adapters.CallbackButton{
+		Command: "rsch-slt",
+	}
+	DeclineRescheduleBtn = vk_adapters.CallbackButton{
+		Label:   "Не подходит",
+		Command: "rsch-dcl",
+	}
+)
@@ -0,0 +1,5 @@
+package appointment_vk_adapters
+
+import "errors"
+
+var ErrUnknownState = errors.New("unknown state")
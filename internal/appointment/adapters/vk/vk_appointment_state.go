@@ -0,0 +1,14 @@
+package appointment_vk_adapters
+
+import (
+	"time"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+)
+
+// Payload of VK buttons is limited to 255 characters,
+// so dialog state is stored on the server
+type AppointmentState struct {
+	ServiceId appointment.ServiceId
+	Date      time.Time
+}
@@ -0,0 +1,26 @@
+package appointment_vk_adapters
+
+import (
+	vk_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/vk"
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+)
+
+type ErrorSender interface {
+	Send(c *vk_adapters.Context, err error) error
+}
+
+type errorSender struct {
+	errorPresenter appointment.ErrorPresenter[vk_adapters.LocalizedTextResponses]
+}
+
+func (s *errorSender) Send(c *vk_adapters.Context, err error) error {
+	res, err := s.errorPresenter(err)
+	if err != nil {
+		return err
+	}
+	return res.Send(c)
+}
+
+func NewErrorSender(errorPresenter appointment.ErrorPresenter[vk_adapters.LocalizedTextResponses]) ErrorSender {
+	return &errorSender{errorPresenter: errorPresenter}
+}
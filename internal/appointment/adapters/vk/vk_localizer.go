@@ -0,0 +1,48 @@
+package appointment_vk_adapters
+
+import (
+	"context"
+	"strconv"
+
+	vk_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/vk"
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/i18n"
+	"github.com/x0k/veterinary-clinic-backend/internal/shared"
+)
+
+type Localizer struct {
+	localeDetector func(ctx context.Context, identity appointment.CustomerIdentity, detected string) string
+	printer        func(locale string) i18n.Printer
+}
+
+func NewLocalizer(
+	localeDetector func(ctx context.Context, identity appointment.CustomerIdentity, detected string) string,
+	printer func(locale string) i18n.Printer,
+) *Localizer {
+	return &Localizer{
+		localeDetector: localeDetector,
+		printer:        printer,
+	}
+}
+
+// Returns the printer in the locale of the VK user,
+// `languageCode` is the language of the user's client
+func (l *Localizer) Printer(ctx context.Context, userId shared.VkUserId, languageCode string) i18n.Printer {
+	detected := i18n.NewLocale(languageCode).String()
+	identity, err := appointment.NewVkCustomerIdentity(userId)
+	if err != nil {
+		return l.printer(detected)
+	}
+	return l.printer(l.localeDetector(ctx, identity, detected))
+}
+
+func (l *Localizer) Middleware(next vk_adapters.HandlerFunc) vk_adapters.HandlerFunc {
+	return func(c *vk_adapters.Context) error {
+		vk_adapters.SetPrinter(c, l.Printer(
+			c.Context(),
+			shared.NewVkUserId(strconv.FormatInt(c.Sender(), 10)),
+			c.LanguageCode(),
+		))
+		return next(c)
+	}
+}
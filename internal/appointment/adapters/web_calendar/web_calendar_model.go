@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/url"
 	"strings"
+	"time"
 )
 
 const HandlerPath = "/web-calendar"
@@ -14,6 +15,16 @@ const AppInputValidationSchema = `{"type":"object","properties":{"selectedDates"
 
 const AppOptionsTemplate = `{"date":{"min":"%s"},"settings":{"selected":{"dates":["%s"]}}}`
 
+const AppDatePickerOptionsTemplate = `{"date":{"min":"%s"},"settings":{"range":{"disabled":[%s]},"selected":{"dates":["%s"]}}}`
+
+func DisabledDates(dates []time.Time) string {
+	quoted := make([]string, len(dates))
+	for i, d := range dates {
+		quoted[i] = fmt.Sprintf(`"%s"`, d.Format(time.DateOnly))
+	}
+	return strings.Join(quoted, ",")
+}
+
 type AppUrl string
 
 func (u AppUrl) String() string {
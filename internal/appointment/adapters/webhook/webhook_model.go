@@ -0,0 +1,136 @@
+package appointment_webhook_adapters
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	"github.com/x0k/veterinary-clinic-backend/internal/shared"
+)
+
+const (
+	CreatedEventName  = "appointment.created"
+	CanceledEventName = "appointment.canceled"
+	ChangedEventName  = "appointment.changed"
+)
+
+var changeTypeNames = map[appointment.ChangeType]string{
+	appointment.CreatedChangeType:  "created",
+	appointment.DateTimeChangeType: "date_time",
+	appointment.RemovedChangeType:  "removed",
+	appointment.StatusChangeType:   "status",
+}
+
+type RecordDTO struct {
+	Id         string `json:"id"`
+	Status     string `json:"status"`
+	Start      string `json:"start"`
+	End        string `json:"end"`
+	CustomerId string `json:"customerId"`
+	ServiceId  string `json:"serviceId"`
+	CreatedAt  string `json:"createdAt"`
+}
+
+func RecordToDTO(record appointment.RecordEntity) RecordDTO {
+	return RecordDTO{
+		Id:         record.Id.String(),
+		Status:     record.Status.String(),
+		Start:      shared.DateTimeToGoTime(record.DateTimePeriod.Start).Format(time.RFC3339),
+		End:        shared.DateTimeToGoTime(record.DateTimePeriod.End).Format(time.RFC3339),
+		CustomerId: record.CustomerId.String(),
+		ServiceId:  record.ServiceId.String(),
+		CreatedAt:  record.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+type CustomerDTO struct {
+	Id          string `json:"id"`
+	Name        string `json:"name"`
+	PhoneNumber string `json:"phoneNumber"`
+	Email       string `json:"email"`
+}
+
+func CustomerToDTO(customer appointment.CustomerEntity) *CustomerDTO {
+	return &CustomerDTO{
+		Id:          customer.Id.String(),
+		Name:        customer.Name,
+		PhoneNumber: customer.PhoneNumber,
+		Email:       customer.Email,
+	}
+}
+
+type ServiceDTO struct {
+	Id                string `json:"id"`
+	Title             string `json:"title"`
+	DurationInMinutes int    `json:"durationInMinutes"`
+}
+
+func ServiceToDTO(service appointment.ServiceEntity) *ServiceDTO {
+	return &ServiceDTO{
+		Id:                service.Id.String(),
+		Title:             service.Title,
+		DurationInMinutes: int(service.DurationInMinutes),
+	}
+}
+
+type EventDataDTO struct {
+	Record     RecordDTO    `json:"record"`
+	Customer   *CustomerDTO `json:"customer,omitempty"`
+	Service    *ServiceDTO  `json:"service,omitempty"`
+	ChangeType string       `json:"changeType,omitempty"`
+}
+
+type EventDTO struct {
+	Id   string       `json:"id"`
+	Type string       `json:"type"`
+	Data EventDataDTO `json:"data"`
+}
+
+func eventToDTO(event appointment.Event) (string, EventDataDTO, error) {
+	switch e := event.(type) {
+	case appointment.CreatedEvent:
+		return CreatedEventName, EventDataDTO{
+			Record:   RecordToDTO(e.Record),
+			Customer: CustomerToDTO(e.Customer),
+			Service:  ServiceToDTO(e.Service),
+		}, nil
+	case appointment.CanceledEvent:
+		return CanceledEventName, EventDataDTO{
+			Record:   RecordToDTO(e.Record),
+			Customer: CustomerToDTO(e.Customer),
+			Service:  ServiceToDTO(e.Service),
+		}, nil
+	case appointment.ChangedEvent:
+		return ChangedEventName, EventDataDTO{
+			Record:     RecordToDTO(e.Record),
+			ChangeType: changeTypeNames[e.ChangeType],
+		}, nil
+	default:
+		return "", EventDataDTO{}, fmt.Errorf("unsupported webhook event: %v", event.Type())
+	}
+}
+
+// Event id is the id of the outbox entry, so redeliveries of the entry
+// keep the id while repeated changes get new ones
+func EncodeEvent(id appointment.OutboxEntryId, event appointment.Event) (appointment.WebhookEvent, error) {
+	name, data, err := eventToDTO(event)
+	if err != nil {
+		return appointment.WebhookEvent{}, err
+	}
+	dto := EventDTO{
+		Id:   strconv.FormatInt(int64(id), 10),
+		Type: name,
+		Data: data,
+	}
+	payload, err := json.Marshal(dto)
+	if err != nil {
+		return appointment.WebhookEvent{}, err
+	}
+	return appointment.WebhookEvent{
+		Id:      dto.Id,
+		Type:    event.Type(),
+		Payload: payload,
+	}, nil
+}
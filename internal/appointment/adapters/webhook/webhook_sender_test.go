@@ -0,0 +1,85 @@
+package appointment_webhook_adapters
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+)
+
+const (
+	testSecret    = "secret"
+	testTimestamp = "1700000000"
+	testPayload   = `{"id":"event-id"}`
+	// HMAC-SHA256 of `1700000000.{"id":"event-id"}` with the `secret` key
+	testSignature = "sha256=6a77acf87e21ba72d87292529f0d554e1f04c3ec0353a39820c2d784c2ad6f94"
+)
+
+func TestSign(t *testing.T) {
+	if got := Sign([]byte(testSecret), testTimestamp, []byte(testPayload)); got != testSignature {
+		t.Errorf("Sign() = %q, want %q", got, testSignature)
+	}
+}
+
+func TestSenderSend(t *testing.T) {
+	type request struct {
+		header http.Header
+		body   string
+		err    error
+	}
+	requests := make(chan request, 2)
+	status := make(chan int, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		requests <- request{header: r.Header, body: string(body), err: err}
+		w.WriteHeader(<-status)
+	}))
+	defer server.Close()
+
+	sender := NewSender(server.Client(), testSecret)
+	sender.now = func() time.Time {
+		return time.Unix(1700000000, 0)
+	}
+	event := appointment.WebhookEvent{
+		Id:      "event-id",
+		Type:    appointment.CreatedEventType,
+		Payload: []byte(testPayload),
+	}
+	tests := []struct {
+		name    string
+		status  int
+		wantErr error
+	}{
+		{name: "unexpected status", status: http.StatusInternalServerError, wantErr: ErrUnexpectedStatus},
+		{name: "delivered", status: http.StatusNoContent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status <- tt.status
+			if err := sender.Send(context.Background(), server.URL, event); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Send() error = %v, want %v", err, tt.wantErr)
+			}
+			r := <-requests
+			if r.err != nil {
+				t.Fatal(r.err)
+			}
+			if r.body != testPayload {
+				t.Errorf("body = %q, want %q", r.body, testPayload)
+			}
+			if got := r.header.Get(TimestampHeader); got != testTimestamp {
+				t.Errorf("timestamp = %q, want %q", got, testTimestamp)
+			}
+			if got := r.header.Get(SignatureHeader); got != testSignature {
+				t.Errorf("signature = %q, want %q", got, testSignature)
+			}
+			if got := r.header.Get(IdHeader); got != "event-id" {
+				t.Errorf("id = %q", got)
+			}
+		})
+	}
+}
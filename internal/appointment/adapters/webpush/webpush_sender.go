@@ -0,0 +1,53 @@
+package appointment_webpush_adapters
+
+import (
+	"context"
+	"errors"
+
+	webpush_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/webpush"
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+)
+
+type Message struct {
+	Identity     appointment.CustomerIdentity
+	Notification webpush_adapters.Notification
+}
+
+// Pushes the notification to every device of the customer
+type Sender struct {
+	subscriptionsLoader appointment.WebPushSubscriptionsLoader
+	subscriptionRemover appointment.WebPushSubscriptionRemover
+	pusher              *webpush_adapters.Pusher
+}
+
+func NewSender(
+	subscriptionsLoader appointment.WebPushSubscriptionsLoader,
+	subscriptionRemover appointment.WebPushSubscriptionRemover,
+	pusher *webpush_adapters.Pusher,
+) *Sender {
+	return &Sender{
+		subscriptionsLoader: subscriptionsLoader,
+		subscriptionRemover: subscriptionRemover,
+		pusher:              pusher,
+	}
+}
+
+func (s *Sender) Send(ctx context.Context, msg *Message) error {
+	subscriptions, err := s.subscriptionsLoader(ctx, msg.Identity)
+	if err != nil {
+		return err
+	}
+	for _, subscription := range subscriptions {
+		pushErr := s.pusher.Push(ctx, webpush_adapters.Subscription{
+			Endpoint: subscription.Endpoint,
+			P256dh:   subscription.P256dh,
+			Auth:     subscription.Auth,
+		}, msg.Notification)
+		// Expired and unsubscribed endpoints are forgotten
+		if errors.Is(pushErr, webpush_adapters.ErrSubscriptionGone) {
+			pushErr = s.subscriptionRemover(ctx, msg.Identity, subscription.Endpoint)
+		}
+		err = errors.Join(err, pushErr)
+	}
+	return err
+}
@@ -1,6 +1,10 @@
 package appointment
 
-import "github.com/x0k/veterinary-clinic-backend/internal/lib/pubsub"
+import (
+	"fmt"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/pubsub"
+)
 
 type EventType int
 
@@ -8,6 +12,16 @@ const (
 	CreatedEventType EventType = iota
 	CanceledEventType
 	ChangedEventType
+	ConfirmedEventType
+	CheckedInEventType
+	InProgressEventType
+	CompletedEventType
+	NotAppearedEventType
+	CanceledByClinicEventType
+	RescheduledEventType
+	ApprovedEventType
+	DeclinedEventType
+	RescheduleOfferedEventType
 )
 
 type Event pubsub.Event[EventType]
@@ -61,14 +75,17 @@ type ChangeType int
 
 const (
 	CreatedChangeType ChangeType = iota
-	StatusChangeType
 	DateTimeChangeType
 	RemovedChangeType
+	// Status is changed outside of the known transitions
+	StatusChangeType
 )
 
 type ChangedEvent struct {
 	ChangeType ChangeType
 	Record     RecordEntity
+	// Set for date time and status changes
+	Previous RecordEntity
 }
 
 func NewChanged(
@@ -81,6 +98,140 @@ func NewChanged(
 	}
 }
 
+func NewDateTimeChanged(previous RecordEntity, appointment RecordEntity) ChangedEvent {
+	return ChangedEvent{
+		ChangeType: DateTimeChangeType,
+		Record:     appointment,
+		Previous:   previous,
+	}
+}
+
+func NewStatusChanged(previous RecordEntity, appointment RecordEntity) ChangedEvent {
+	return ChangedEvent{
+		ChangeType: StatusChangeType,
+		Record:     appointment,
+		Previous:   previous,
+	}
+}
+
 func (e ChangedEvent) Type() EventType {
 	return ChangedEventType
 }
+
+type StatusTransition struct {
+	From   RecordStatus
+	Record RecordEntity
+	Reason string
+}
+
+type StatusTransitionEvent interface {
+	Event
+	Transition() StatusTransition
+}
+
+type ConfirmedEvent struct{ StatusTransition }
+
+func (e ConfirmedEvent) Type() EventType { return ConfirmedEventType }
+
+func (e ConfirmedEvent) Transition() StatusTransition { return e.StatusTransition }
+
+type CheckedInEvent struct{ StatusTransition }
+
+func (e CheckedInEvent) Type() EventType { return CheckedInEventType }
+
+func (e CheckedInEvent) Transition() StatusTransition { return e.StatusTransition }
+
+type InProgressEvent struct{ StatusTransition }
+
+func (e InProgressEvent) Type() EventType { return InProgressEventType }
+
+func (e InProgressEvent) Transition() StatusTransition { return e.StatusTransition }
+
+type CompletedEvent struct{ StatusTransition }
+
+func (e CompletedEvent) Type() EventType { return CompletedEventType }
+
+func (e CompletedEvent) Transition() StatusTransition { return e.StatusTransition }
+
+type NotAppearedEvent struct{ StatusTransition }
+
+func (e NotAppearedEvent) Type() EventType { return NotAppearedEventType }
+
+func (e NotAppearedEvent) Transition() StatusTransition { return e.StatusTransition }
+
+type CanceledByClinicEvent struct{ StatusTransition }
+
+func (e CanceledByClinicEvent) Type() EventType { return CanceledByClinicEventType }
+
+func (e CanceledByClinicEvent) Transition() StatusTransition { return e.StatusTransition }
+
+type RescheduledEvent struct{ StatusTransition }
+
+func (e RescheduledEvent) Type() EventType { return RescheduledEventType }
+
+func (e RescheduledEvent) Transition() StatusTransition { return e.StatusTransition }
+
+type ApprovedEvent struct{ StatusTransition }
+
+func (e ApprovedEvent) Type() EventType { return ApprovedEventType }
+
+func (e ApprovedEvent) Transition() StatusTransition { return e.StatusTransition }
+
+type DeclinedEvent struct{ StatusTransition }
+
+func (e DeclinedEvent) Type() EventType { return DeclinedEventType }
+
+func (e DeclinedEvent) Transition() StatusTransition { return e.StatusTransition }
+
+func NewStatusTransitionEvent(
+	from RecordStatus,
+	appointment RecordEntity,
+	reason string,
+) (StatusTransitionEvent, error) {
+	t := StatusTransition{
+		From:   from,
+		Record: appointment,
+		Reason: reason,
+	}
+	switch appointment.Status {
+	// Records are approved only once, other returns to awaiting
+	// are made by hand in the storage
+	case RecordAwaits:
+		if from != RecordPendingApproval {
+			return nil, fmt.Errorf("%w: %s -> %s", ErrInvalidStatusTransition, from, appointment.Status)
+		}
+		return ApprovedEvent{t}, nil
+	case RecordDeclined:
+		return DeclinedEvent{t}, nil
+	case RecordConfirmed:
+		return ConfirmedEvent{t}, nil
+	case RecordCheckedIn:
+		return CheckedInEvent{t}, nil
+	case RecordInProgress:
+		return InProgressEvent{t}, nil
+	case RecordDone:
+		return CompletedEvent{t}, nil
+	case RecordNotAppear:
+		return NotAppearedEvent{t}, nil
+	case RecordCanceledByClinic:
+		return CanceledByClinicEvent{t}, nil
+	case RecordRescheduled:
+		return RescheduledEvent{t}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s -> %s", ErrInvalidStatusTransition, from, appointment.Status)
+	}
+}
+
+type RescheduleOfferedEvent struct {
+	Offer RescheduleOffer
+}
+
+func NewRescheduleOffered(offer RescheduleOffer) RescheduleOfferedEvent {
+	return RescheduleOfferedEvent{
+		Offer: offer,
+	}
+}
+
+func (e RescheduleOfferedEvent) Type() EventType {
+	return RescheduleOfferedEventType
+}
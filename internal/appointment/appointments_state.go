@@ -1,14 +1,32 @@
 package appointment
 
-import "maps"
+import (
+	"errors"
+	"maps"
+	"time"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/shared"
+)
+
+var ErrAppointmentsStateCorrupted = errors.New("appointments state is corrupted")
 
 type AppointmentsState struct {
 	appointments map[RecordId]RecordEntity
+	// Start of the last successful sync with the records storage
+	cursor time.Time
+	// Start of the last full reconciliation
+	reconciledAt time.Time
 }
 
-func NewAppointmentsState(appointments map[RecordId]RecordEntity) AppointmentsState {
+func NewAppointmentsState(
+	appointments map[RecordId]RecordEntity,
+	cursor time.Time,
+	reconciledAt time.Time,
+) AppointmentsState {
 	return AppointmentsState{
 		appointments: appointments,
+		cursor:       cursor,
+		reconciledAt: reconciledAt,
 	}
 }
 
@@ -16,31 +34,76 @@ func (s *AppointmentsState) Appointments() map[RecordId]RecordEntity {
 	return s.appointments
 }
 
-func (s *AppointmentsState) Reconcile(actualAppointments []RecordEntity) []ChangedEvent {
+func (s *AppointmentsState) Cursor() time.Time {
+	return s.cursor
+}
+
+func (s *AppointmentsState) ReconciledAt() time.Time {
+	return s.reconciledAt
+}
+
+func NewEmptyAppointmentsState() AppointmentsState {
+	return NewAppointmentsState(map[RecordId]RecordEntity{}, time.Time{}, time.Time{})
+}
+
+// State was never synced or was lost, so it can not be used
+// to detect changes
+func (s *AppointmentsState) IsEmpty() bool {
+	return s.cursor.IsZero()
+}
+
+// Replaces the state with actual appointments without reporting changes
+func (s *AppointmentsState) Rebuild(syncedAt time.Time, actualAppointments []RecordEntity) {
+	s.appointments = make(map[RecordId]RecordEntity, len(actualAppointments))
+	for _, app := range actualAppointments {
+		s.appointments[app.Id] = app
+	}
+	s.cursor = syncedAt
+	s.reconciledAt = syncedAt
+}
+
+func (s *AppointmentsState) NeedsReconciliation(now time.Time, interval time.Duration) bool {
+	return s.cursor.IsZero() || now.Sub(s.reconciledAt) >= interval
+}
+
+func (s *AppointmentsState) update(
+	oldApp RecordEntity,
+	actualApp RecordEntity,
+	changes []Event,
+) []Event {
+	s.appointments[actualApp.Id] = actualApp
+	if oldApp.Status != actualApp.Status {
+		// Statuses are changed outside of the app, so the transition
+		// is reported even if it is not allowed, and a change without
+		// a known transition is reported as a plain status change
+		if event, err := NewStatusTransitionEvent(oldApp.Status, actualApp, ""); err == nil {
+			changes = append(changes, event)
+		} else {
+			changes = append(changes, NewStatusChanged(oldApp, actualApp))
+		}
+	} else if oldApp.DateTimePeriod != actualApp.DateTimePeriod {
+		changes = append(changes, NewDateTimeChanged(oldApp, actualApp))
+	}
+	return changes
+}
+
+// Replaces the whole state with actual appointments,
+// `syncedAt` should be taken before the appointments are loaded
+func (s *AppointmentsState) Reconcile(syncedAt time.Time, actualAppointments []RecordEntity) []Event {
 	appsCopy := maps.Clone(s.appointments)
-	changes := make([]ChangedEvent, 0, len(actualAppointments))
+	changes := make([]Event, 0, len(actualAppointments))
 	for _, actualApp := range actualAppointments {
-		s.appointments[actualApp.Id] = actualApp
 		oldApp, ok := appsCopy[actualApp.Id]
 		// created
 		if !ok {
+			s.appointments[actualApp.Id] = actualApp
 			changes = append(changes, NewChanged(
 				CreatedChangeType,
 				actualApp,
 			))
 			continue
 		}
-		if oldApp.Status != actualApp.Status {
-			changes = append(changes, NewChanged(
-				StatusChangeType,
-				actualApp,
-			))
-		} else if oldApp.DateTimePeriod != actualApp.DateTimePeriod {
-			changes = append(changes, NewChanged(
-				DateTimeChangeType,
-				actualApp,
-			))
-		}
+		changes = s.update(oldApp, actualApp, changes)
 		delete(appsCopy, actualApp.Id)
 	}
 	for _, app := range appsCopy {
@@ -50,9 +113,48 @@ func (s *AppointmentsState) Reconcile(actualAppointments []RecordEntity) []Chang
 			app,
 		))
 	}
+	s.cursor = syncedAt
+	s.reconciledAt = syncedAt
+	return changes
+}
+
+// Applies appointments edited since the cursor.
+// Deleted records are not reported by the storage, they are
+// detected by the next reconciliation.
+func (s *AppointmentsState) Merge(syncedAt time.Time, editedAppointments []RecordEntity) []Event {
+	changes := make([]Event, 0, len(editedAppointments))
+	for _, editedApp := range editedAppointments {
+		oldApp, ok := s.appointments[editedApp.Id]
+		if !isActualAppointment(syncedAt, editedApp) {
+			if ok {
+				delete(s.appointments, oldApp.Id)
+				changes = append(changes, NewChanged(
+					RemovedChangeType,
+					oldApp,
+				))
+			}
+			continue
+		}
+		if !ok {
+			s.appointments[editedApp.Id] = editedApp
+			changes = append(changes, NewChanged(
+				CreatedChangeType,
+				editedApp,
+			))
+			continue
+		}
+		changes = s.update(oldApp, editedApp, changes)
+	}
+	s.cursor = syncedAt
 	return changes
 }
 
+// Mirrors the set of appointments returned by `ActualAppointmentsLoader`
+func isActualAppointment(now time.Time, app RecordEntity) bool {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	return !app.IsArchived && shared.DateTimeToGoTime(app.DateTimePeriod.Start).After(today)
+}
+
 func (s *AppointmentsState) AddAppointment(appointment RecordEntity) {
 	s.appointments[appointment.Id] = appointment
 }
@@ -0,0 +1,111 @@
+package appointment
+
+import (
+	"testing"
+	"time"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/shared"
+)
+
+func TestAppointmentsStateMerge(t *testing.T) {
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.Local)
+	period := func(day, hour int) shared.DateTimePeriod {
+		start := time.Date(2024, 5, day, hour, 0, 0, 0, time.Local)
+		return shared.DateTimePeriod{
+			Start: shared.GoTimeToDateTime(start),
+			End:   shared.GoTimeToDateTime(start.Add(time.Hour)),
+		}
+	}
+	state := NewAppointmentsState(map[RecordId]RecordEntity{
+		"moved":    {Id: "moved", Status: RecordAwaits, DateTimePeriod: period(11, 10)},
+		"archived": {Id: "archived", Status: RecordAwaits, DateTimePeriod: period(11, 12)},
+		"same":     {Id: "same", Status: RecordAwaits, DateTimePeriod: period(11, 14)},
+	}, now.Add(-time.Minute), now.Add(-time.Hour))
+
+	changes := state.Merge(now, []RecordEntity{
+		{Id: "moved", Status: RecordAwaits, DateTimePeriod: period(12, 10)},
+		{Id: "archived", Status: RecordAwaits, IsArchived: true, DateTimePeriod: period(11, 12)},
+		{Id: "same", Status: RecordAwaits, DateTimePeriod: period(11, 14)},
+		{Id: "new", Status: RecordAwaits, DateTimePeriod: period(13, 10)},
+		{Id: "past", Status: RecordDone, DateTimePeriod: period(1, 10)},
+	})
+	expected := map[RecordId]ChangeType{
+		"moved":    DateTimeChangeType,
+		"archived": RemovedChangeType,
+		"new":      CreatedChangeType,
+	}
+	if len(changes) != len(expected) {
+		t.Fatalf("Merge() = %v", changes)
+	}
+	for _, c := range changes {
+		changed, ok := c.(ChangedEvent)
+		if !ok || expected[changed.Record.Id] != changed.ChangeType {
+			t.Errorf("unexpected change %v", c)
+		}
+	}
+	if _, ok := state.Appointments()["archived"]; ok {
+		t.Error("archived record is not removed")
+	}
+	if !state.Cursor().Equal(now) {
+		t.Errorf("Cursor() = %v, want %v", state.Cursor(), now)
+	}
+	if !state.NeedsReconciliation(now, time.Hour) {
+		t.Error("NeedsReconciliation() = false")
+	}
+}
+
+func TestAppointmentsStateStatusChanges(t *testing.T) {
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.Local)
+	period := shared.DateTimePeriod{
+		Start: shared.GoTimeToDateTime(now.Add(24 * time.Hour)),
+		End:   shared.GoTimeToDateTime(now.Add(25 * time.Hour)),
+	}
+	tests := []struct {
+		name string
+		from RecordStatus
+		to   RecordStatus
+		want func(Event) bool
+	}{
+		{
+			name: "approved",
+			from: RecordPendingApproval,
+			to:   RecordAwaits,
+			want: func(e Event) bool { _, ok := e.(ApprovedEvent); return ok },
+		},
+		{
+			name: "returned to awaiting",
+			from: RecordConfirmed,
+			to:   RecordAwaits,
+			want: func(e Event) bool {
+				changed, ok := e.(ChangedEvent)
+				return ok && changed.ChangeType == StatusChangeType && changed.Previous.Status == RecordConfirmed
+			},
+		},
+		{
+			name: "returned to pending approval",
+			from: RecordAwaits,
+			to:   RecordPendingApproval,
+			want: func(e Event) bool {
+				changed, ok := e.(ChangedEvent)
+				return ok && changed.ChangeType == StatusChangeType && changed.Previous.Status == RecordAwaits
+			},
+		},
+		{
+			name: "completed",
+			from: RecordConfirmed,
+			to:   RecordDone,
+			want: func(e Event) bool { _, ok := e.(CompletedEvent); return ok },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := NewAppointmentsState(map[RecordId]RecordEntity{
+				"record": {Id: "record", Status: tt.from, DateTimePeriod: period},
+			}, now.Add(-time.Minute), now)
+			changes := state.Merge(now, []RecordEntity{{Id: "record", Status: tt.to, DateTimePeriod: period}})
+			if len(changes) != 1 || !tt.want(changes[0]) {
+				t.Errorf("Merge() = %+v", changes)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package appointment
+
+import "time"
+
+type AuditActorType string
+
+const (
+	CustomerAuditActorType AuditActorType = "customer"
+	StaffAuditActorType    AuditActorType = "staff"
+	// Edits made directly in the records storage
+	ExternalAuditActorType AuditActorType = "external"
+)
+
+type AuditActor struct {
+	Type     AuditActorType
+	Identity CustomerIdentity
+}
+
+func NewCustomerAuditActor(identity CustomerIdentity) AuditActor {
+	return AuditActor{Type: CustomerAuditActorType, Identity: identity}
+}
+
+func NewStaffAuditActor(identity CustomerIdentity) AuditActor {
+	return AuditActor{Type: StaffAuditActorType, Identity: identity}
+}
+
+var ExternalAuditActor = AuditActor{Type: ExternalAuditActorType}
+
+type AuditAction string
+
+const (
+	CreatedAuditAction         AuditAction = "created"
+	CanceledAuditAction        AuditAction = "canceled"
+	RescheduledAuditAction     AuditAction = "rescheduled"
+	StatusChangedAuditAction   AuditAction = "status_changed"
+	DateTimeChangedAuditAction AuditAction = "date_time_changed"
+	RemovedAuditAction         AuditAction = "removed"
+)
+
+type AuditEntry struct {
+	RecordId RecordId
+	Action   AuditAction
+	Actor    AuditActor
+	// Record before and after the action, nil when it did not exist
+	Before     *RecordEntity
+	After      *RecordEntity
+	Reason     string
+	OccurredAt time.Time
+}
+
+func NewCreatedAuditEntry(actor AuditActor, record RecordEntity, occurredAt time.Time) AuditEntry {
+	return AuditEntry{
+		RecordId:   record.Id,
+		Action:     CreatedAuditAction,
+		Actor:      actor,
+		After:      &record,
+		OccurredAt: occurredAt,
+	}
+}
+
+func NewCanceledAuditEntry(actor AuditActor, record RecordEntity, occurredAt time.Time) AuditEntry {
+	return AuditEntry{
+		RecordId:   record.Id,
+		Action:     CanceledAuditAction,
+		Actor:      actor,
+		Before:     &record,
+		OccurredAt: occurredAt,
+	}
+}
+
+func NewStatusChangedAuditEntry(actor AuditActor, transition StatusTransition, occurredAt time.Time) AuditEntry {
+	before := transition.Record
+	before.Status = transition.From
+	return AuditEntry{
+		RecordId:   transition.Record.Id,
+		Action:     StatusChangedAuditAction,
+		Actor:      actor,
+		Before:     &before,
+		After:      &transition.Record,
+		Reason:     transition.Reason,
+		OccurredAt: occurredAt,
+	}
+}
+
+// Entry of the canceled record, the new one gets its own created entry
+func NewRescheduledAuditEntry(actor AuditActor, offer RescheduleOffer, record RecordEntity, occurredAt time.Time) AuditEntry {
+	return AuditEntry{
+		RecordId:   offer.CanceledRecordId,
+		Action:     RescheduledAuditAction,
+		Actor:      actor,
+		After:      &record,
+		Reason:     offer.Reason,
+		OccurredAt: occurredAt,
+	}
+}
+
+// Entries for changes detected in the records storage
+func NewExternalAuditEntries(changes []Event, occurredAt time.Time) []AuditEntry {
+	entries := make([]AuditEntry, 0, len(changes))
+	for _, change := range changes {
+		switch e := change.(type) {
+		case StatusTransitionEvent:
+			entries = append(entries, NewStatusChangedAuditEntry(ExternalAuditActor, e.Transition(), occurredAt))
+		case ChangedEvent:
+			record := e.Record
+			entry := AuditEntry{
+				RecordId:   record.Id,
+				Actor:      ExternalAuditActor,
+				OccurredAt: occurredAt,
+			}
+			switch e.ChangeType {
+			case CreatedChangeType:
+				entry.Action = CreatedAuditAction
+				entry.After = &record
+			case DateTimeChangeType:
+				previous := e.Previous
+				entry.Action = DateTimeChangedAuditAction
+				entry.Before = &previous
+				entry.After = &record
+			case RemovedChangeType:
+				entry.Action = RemovedAuditAction
+				entry.Before = &record
+			case StatusChangeType:
+				previous := e.Previous
+				entry.Action = StatusChangedAuditAction
+				entry.Before = &previous
+				entry.After = &record
+			}
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
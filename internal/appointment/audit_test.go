@@ -0,0 +1,56 @@
+package appointment
+
+import (
+	"testing"
+	"time"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/shared"
+)
+
+func TestNewExternalAuditEntries(t *testing.T) {
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.Local)
+	period := func(day int) shared.DateTimePeriod {
+		start := time.Date(2024, 5, day, 10, 0, 0, 0, time.Local)
+		return shared.DateTimePeriod{
+			Start: shared.GoTimeToDateTime(start),
+			End:   shared.GoTimeToDateTime(start.Add(time.Hour)),
+		}
+	}
+	state := NewAppointmentsState(map[RecordId]RecordEntity{
+		"moved":    {Id: "moved", Status: RecordAwaits, DateTimePeriod: period(11)},
+		"done":     {Id: "done", Status: RecordAwaits, DateTimePeriod: period(12)},
+		"archived": {Id: "archived", Status: RecordAwaits, DateTimePeriod: period(13)},
+	}, now.Add(-time.Minute), now.Add(-time.Hour))
+	changes := state.Merge(now, []RecordEntity{
+		{Id: "moved", Status: RecordAwaits, DateTimePeriod: period(14)},
+		{Id: "done", Status: RecordDone, DateTimePeriod: period(12)},
+		{Id: "archived", Status: RecordAwaits, IsArchived: true, DateTimePeriod: period(13)},
+	})
+	entries := NewExternalAuditEntries(changes, now)
+	if len(entries) != 3 {
+		t.Fatalf("NewExternalAuditEntries() = %v", entries)
+	}
+	for _, e := range entries {
+		if e.Actor != ExternalAuditActor || !e.OccurredAt.Equal(now) {
+			t.Errorf("unexpected entry %v", e)
+		}
+		switch e.RecordId {
+		case "moved":
+			if e.Action != DateTimeChangedAuditAction ||
+				e.Before.DateTimePeriod != period(11) ||
+				e.After.DateTimePeriod != period(14) {
+				t.Errorf("unexpected date time change %v", e)
+			}
+		case "done":
+			if e.Action != StatusChangedAuditAction ||
+				e.Before.Status != RecordAwaits ||
+				e.After.Status != RecordDone {
+				t.Errorf("unexpected status change %v", e)
+			}
+		case "archived":
+			if e.Action != RemovedAuditAction || e.Before == nil || e.After != nil {
+				t.Errorf("unexpected removal %v", e)
+			}
+		}
+	}
+}
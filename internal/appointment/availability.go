@@ -0,0 +1,74 @@
+package appointment
+
+import (
+	"time"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/shared"
+)
+
+type DayStatus int
+
+const (
+	ClosedDay DayStatus = iota
+	FullDay
+	HasSlotsDay
+	// The day or its working hours are over
+	PastDay
+)
+
+func (s DayStatus) String() string {
+	switch s {
+	case ClosedDay:
+		return "closed"
+	case FullDay:
+		return "full"
+	case HasSlotsDay:
+		return "has_slots"
+	case PastDay:
+		return "past"
+	default:
+		return "unknown"
+	}
+}
+
+type DayAvailability struct {
+	Date   time.Time
+	Status DayStatus
+}
+
+// `dayTimePeriods` are the working hours of the whole day,
+// `slots` are sampled from the rest of the day after `now`
+func NewDayAvailability(
+	now shared.DateTime,
+	date time.Time,
+	dayTimePeriods DayTimePeriods,
+	slots SampledFreeTimeSlots,
+	durationInMinutes shared.DurationInMinutes,
+) DayAvailability {
+	status := HasSlotsDay
+	if shared.CompareDate(dayTimePeriods.Date, now.Date) < 0 {
+		status = PastDay
+	} else if len(dayTimePeriods.Periods) == 0 {
+		status = ClosedDay
+	} else if len(dayTimePeriods.OmitPast(now).Periods) == 0 {
+		status = PastDay
+	} else if !slots.HasSlotFor(durationInMinutes) {
+		status = FullDay
+	}
+	return DayAvailability{
+		Date:   date,
+		Status: status,
+	}
+}
+
+type Availability []DayAvailability
+
+func (a Availability) NotAvailableDays() []time.Time {
+	days := make([]time.Time, 0, len(a))
+	for _, d := range a {
+		if d.Status != HasSlotsDay {
+			days = append(days, d.Date)
+		}
+	}
+	return days
+}
@@ -0,0 +1,118 @@
+package appointment
+
+import (
+	"testing"
+	"time"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/shared"
+)
+
+func TestNewDayAvailability(t *testing.T) {
+	now := shared.DateTime{
+		Date: shared.Date{Year: 2024, Month: 5, Day: 6},
+		Time: shared.Time{Hours: 12},
+	}
+	workingDay := DayTimePeriods{
+		Date: now.Date,
+		Periods: []shared.TimePeriod{
+			{
+				Start: shared.Time{Hours: 9},
+				End:   shared.Time{Hours: 17},
+			},
+		},
+	}
+	tomorrow := DayTimePeriods{
+		Date:    shared.Date{Year: 2024, Month: 5, Day: 7},
+		Periods: workingDay.Periods,
+	}
+	type args struct {
+		dayTimePeriods    DayTimePeriods
+		slots             SampledFreeTimeSlots
+		durationInMinutes shared.DurationInMinutes
+	}
+	tests := []struct {
+		name string
+		args args
+		want DayStatus
+	}{
+		{
+			name: "Closed",
+			args: args{
+				dayTimePeriods:    DayTimePeriods{Date: tomorrow.Date},
+				durationInMinutes: 30,
+			},
+			want: ClosedDay,
+		},
+		{
+			name: "Full",
+			args: args{
+				dayTimePeriods: workingDay,
+				slots: SampledFreeTimeSlots{
+					{
+						Start: shared.Time{Hours: 16, Minutes: 45},
+						End:   shared.Time{Hours: 17},
+					},
+				},
+				durationInMinutes: 30,
+			},
+			want: FullDay,
+		},
+		{
+			name: "Past",
+			args: args{
+				dayTimePeriods: DayTimePeriods{
+					Date:    shared.Date{Year: 2024, Month: 5, Day: 5},
+					Periods: workingDay.Periods,
+				},
+				durationInMinutes: 30,
+			},
+			want: PastDay,
+		},
+		{
+			name: "Past closed",
+			args: args{
+				dayTimePeriods:    DayTimePeriods{Date: shared.Date{Year: 2024, Month: 5, Day: 5}},
+				durationInMinutes: 30,
+			},
+			want: PastDay,
+		},
+		{
+			name: "Working hours are over",
+			args: args{
+				dayTimePeriods: DayTimePeriods{
+					Date: now.Date,
+					Periods: []shared.TimePeriod{
+						{
+							Start: shared.Time{Hours: 9},
+							End:   shared.Time{Hours: 11},
+						},
+					},
+				},
+				durationInMinutes: 30,
+			},
+			want: PastDay,
+		},
+		{
+			name: "Has slots",
+			args: args{
+				dayTimePeriods: workingDay,
+				slots: SampledFreeTimeSlots{
+					{
+						Start: shared.Time{Hours: 16, Minutes: 30},
+						End:   shared.Time{Hours: 17},
+					},
+				},
+				durationInMinutes: 30,
+			},
+			want: HasSlotsDay,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewDayAvailability(now, time.Time{}, tt.args.dayTimePeriods, tt.args.slots, tt.args.durationInMinutes)
+			if got.Status != tt.want {
+				t.Errorf("NewDayAvailability() = %v, want %v", got.Status, tt.want)
+			}
+		})
+	}
+}
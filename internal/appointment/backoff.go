@@ -0,0 +1,14 @@
+package appointment
+
+import "time"
+
+const maxRetryBackoff = time.Hour
+
+// Exponential backoff capped by an hour, `attempts` includes the failed one
+func NextAttemptAt(attempts int, now time.Time, baseBackoff time.Duration) time.Time {
+	backoff := baseBackoff
+	for i := 1; i < attempts && backoff < maxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	return now.Add(min(backoff, maxRetryBackoff))
+}
@@ -1,5 +1,18 @@
 package appointment
 
-import "github.com/x0k/veterinary-clinic-backend/internal/shared"
+import (
+	"time"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/shared"
+)
 
 type BusyPeriods []shared.TimePeriod
+
+type DatesBusyPeriods map[shared.JsonDate]BusyPeriods
+
+func (d DatesBusyPeriods) ForDay(day time.Time) BusyPeriods {
+	if periods, ok := d[shared.GoTimeToJsonDate(day)]; ok {
+		return periods
+	}
+	return BusyPeriods{}
+}
@@ -0,0 +1,408 @@
+// Code generated by cmd/apiclient from the OpenAPI document. DO NOT EDIT.
+
+package appointment_http_client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Error response of the API
+type ResponseError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *ResponseError) Error() string {
+	if e.Message == "" {
+		return http.StatusText(e.StatusCode)
+	}
+	return fmt.Sprintf("%s: %s", http.StatusText(e.StatusCode), e.Message)
+}
+
+type Client struct {
+	baseUrl       string
+	httpClient    *http.Client
+	authorization string
+}
+
+// `baseUrl` is the origin of the server without the trailing slash
+func NewClient(baseUrl string, httpClient *http.Client) *Client {
+	return &Client{
+		baseUrl:    baseUrl,
+		httpClient: httpClient,
+	}
+}
+
+// Returns a copy of the client which sends the `Authorization` header,
+// e.g. `tma <initData>` or `vk <launch params>`
+func (c *Client) WithAuthorization(authorization string) *Client {
+	clone := *c
+	clone.authorization = authorization
+	return &clone
+}
+
+func (c *Client) newRequest(ctx context.Context, method string, path string, query url.Values, body any) (*http.Request, error) {
+	u := c.baseUrl + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, reader)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.authorization != "" {
+		req.Header.Set("Authorization", c.authorization)
+	}
+	return req, nil
+}
+
+func (c *Client) do(req *http.Request, result any) error {
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		responseErr := &ResponseError{StatusCode: res.StatusCode}
+		var body struct {
+			Error string `json:"error"`
+		}
+		if err := json.NewDecoder(res.Body).Decode(&body); err == nil {
+			responseErr.Message = body.Error
+		}
+		return responseErr
+	}
+	if result == nil {
+		return nil
+	}
+	return json.NewDecoder(res.Body).Decode(result)
+}
+
+type AppointmentInfo struct {
+	Record  Record  `json:"record"`
+	Service Service `json:"service"`
+}
+
+type CreateAppointment struct {
+	Date      time.Time `json:"date"`
+	Identity  *string   `json:"identity,omitempty"`
+	ServiceId string    `json:"serviceId"`
+}
+
+type CustomerId struct {
+	Id string `json:"id"`
+}
+
+type Date struct {
+	Day   int `json:"day"`
+	Month int `json:"month"`
+	Year  int `json:"year"`
+}
+
+type DateTime struct {
+	Date Date `json:"date"`
+	Time Time `json:"time"`
+}
+
+type DateTimePeriod struct {
+	End   DateTime `json:"end"`
+	Start DateTime `json:"start"`
+}
+
+type Error struct {
+	Error string `json:"error"`
+}
+
+type NotificationPreferencesQuietHours struct {
+	End   string `json:"end"`
+	Start string `json:"start"`
+}
+
+type NotificationPreferences struct {
+	// Empty list means the messenger of the customer with an email copy. Conflict is returned when the customer has no contact for a channel
+	Channels    []string `json:"channels"`
+	MutedEvents []string `json:"mutedEvents"`
+	// Notifications are silent within the period, VK messages and SMS are not sent. The period may cross midnight
+	QuietHours *NotificationPreferencesQuietHours `json:"quietHours,omitempty"`
+}
+
+type Record struct {
+	CreatedAt      time.Time      `json:"createdAt"`
+	CustomerId     string         `json:"customerId"`
+	DateTimePeriod DateTimePeriod `json:"dateTimePeriod"`
+	Id             string         `json:"id"`
+	IsArchived     bool           `json:"isArchived"`
+	ServiceId      string         `json:"serviceId"`
+	Status         string         `json:"status"`
+	Title          string         `json:"title"`
+}
+
+type Schedule struct {
+	Date     time.Time       `json:"date"`
+	Entries  []ScheduleEntry `json:"entries"`
+	NextDate time.Time       `json:"nextDate"`
+	PrevDate time.Time       `json:"prevDate"`
+}
+
+type ScheduleEntry struct {
+	DateTimePeriod DateTimePeriod `json:"dateTimePeriod"`
+	Title          string         `json:"title"`
+	Type           int            `json:"type"`
+}
+
+type Service struct {
+	CostDescription   string `json:"costDescription"`
+	Description       string `json:"description"`
+	DurationInMinutes int    `json:"durationInMinutes"`
+	Id                string `json:"id"`
+	RequiresApproval  bool   `json:"requiresApproval"`
+	Title             string `json:"title"`
+}
+
+type Time struct {
+	Hours   int `json:"hours"`
+	Minutes int `json:"minutes"`
+}
+
+type TimePeriod struct {
+	End   Time `json:"end"`
+	Start Time `json:"start"`
+}
+
+type UpsertCustomer struct {
+	Email    *string `json:"email,omitempty"`
+	Identity *string `json:"identity,omitempty"`
+	Name     *string `json:"name,omitempty"`
+	Phone    *string `json:"phone,omitempty"`
+}
+
+type WebPushPublicKey struct {
+	// Base64url encoded P-256 public key
+	PublicKey string `json:"publicKey"`
+}
+
+type WebPushSubscriptionKeys struct {
+	Auth   string `json:"auth"`
+	P256dh string `json:"p256dh"`
+}
+
+// JSON of the browser `PushSubscription`
+type WebPushSubscription struct {
+	// HTTPS url of the push service
+	Endpoint       string                  `json:"endpoint"`
+	ExpirationTime *int                    `json:"expirationTime,omitempty"`
+	Keys           WebPushSubscriptionKeys `json:"keys"`
+}
+
+// Make an appointment
+//
+//   - idempotencyKey: Repeated requests with the same key return the same appointment
+func (c *Client) MakeAppointment(ctx context.Context, idempotencyKey string, body CreateAppointment) (AppointmentInfo, error) {
+	var result AppointmentInfo
+	query := url.Values{}
+	req, err := c.newRequest(ctx, "POST", "/api/v1/appointments", query, body)
+	if err != nil {
+		return result, err
+	}
+	if idempotencyKey != "" {
+		req.Header.Set("Idempotency-Key", idempotencyKey)
+	}
+	err = c.do(req, &result)
+	return result, err
+}
+
+// Create or update the customer
+func (c *Client) UpsertCustomer(ctx context.Context, body UpsertCustomer) (CustomerId, error) {
+	var result CustomerId
+	query := url.Values{}
+	req, err := c.newRequest(ctx, "PUT", "/api/v1/customers", query, body)
+	if err != nil {
+		return result, err
+	}
+	err = c.do(req, &result)
+	return result, err
+}
+
+// Active appointment of the customer
+//
+//   - identity: Customer identity, e.g. `tg-123456`
+func (c *Client) ActiveAppointment(ctx context.Context, identity string) (AppointmentInfo, error) {
+	var result AppointmentInfo
+	query := url.Values{}
+	req, err := c.newRequest(ctx, "GET", "/api/v1/customers/"+url.PathEscape(identity)+"/appointment", query, nil)
+	if err != nil {
+		return result, err
+	}
+	err = c.do(req, &result)
+	return result, err
+}
+
+// Cancel the active appointment of the customer
+//
+//   - identity: Customer identity, e.g. `tg-123456`
+func (c *Client) CancelAppointment(ctx context.Context, identity string) error {
+	query := url.Values{}
+	req, err := c.newRequest(ctx, "DELETE", "/api/v1/customers/"+url.PathEscape(identity)+"/appointment", query, nil)
+	if err != nil {
+		return err
+	}
+	return c.do(req, nil)
+}
+
+// Notification preferences of the customer
+//
+//   - identity: Customer identity, e.g. `tg-123456`
+func (c *Client) NotificationPreferences(ctx context.Context, identity string) (NotificationPreferences, error) {
+	var result NotificationPreferences
+	query := url.Values{}
+	req, err := c.newRequest(ctx, "GET", "/api/v1/customers/"+url.PathEscape(identity)+"/notification-preferences", query, nil)
+	if err != nil {
+		return result, err
+	}
+	err = c.do(req, &result)
+	return result, err
+}
+
+// Replace notification preferences of the customer
+//
+//   - identity: Customer identity, e.g. `tg-123456`
+func (c *Client) SetNotificationPreferences(ctx context.Context, identity string, body NotificationPreferences) (NotificationPreferences, error) {
+	var result NotificationPreferences
+	query := url.Values{}
+	req, err := c.newRequest(ctx, "PUT", "/api/v1/customers/"+url.PathEscape(identity)+"/notification-preferences", query, body)
+	if err != nil {
+		return result, err
+	}
+	err = c.do(req, &result)
+	return result, err
+}
+
+// Register a push subscription of the customer device
+//
+//   - identity: Customer identity, e.g. `tg-123456`
+func (c *Client) SubscribeWebPush(ctx context.Context, identity string, body WebPushSubscription) error {
+	query := url.Values{}
+	req, err := c.newRequest(ctx, "PUT", "/api/v1/customers/"+url.PathEscape(identity)+"/web-push-subscriptions", query, body)
+	if err != nil {
+		return err
+	}
+	return c.do(req, nil)
+}
+
+// Remove a push subscription of the customer device
+//
+//   - identity: Customer identity, e.g. `tg-123456`
+//   - endpoint: Endpoint of the subscription
+func (c *Client) UnsubscribeWebPush(ctx context.Context, identity string, endpoint string) error {
+	query := url.Values{}
+	query.Set("endpoint", endpoint)
+	req, err := c.newRequest(ctx, "DELETE", "/api/v1/customers/"+url.PathEscape(identity)+"/web-push-subscriptions", query, nil)
+	if err != nil {
+		return err
+	}
+	return c.do(req, nil)
+}
+
+// This document
+func (c *Client) OpenApi(ctx context.Context) (json.RawMessage, error) {
+	var result json.RawMessage
+	query := url.Values{}
+	req, err := c.newRequest(ctx, "GET", "/api/v1/openapi.json", query, nil)
+	if err != nil {
+		return result, err
+	}
+	err = c.do(req, &result)
+	return result, err
+}
+
+// Schedule of the day
+//
+//   - date: RFC 3339 date time
+func (c *Client) Schedule(ctx context.Context, date time.Time) (Schedule, error) {
+	var result Schedule
+	query := url.Values{}
+	query.Set("date", date.Format(time.RFC3339))
+	req, err := c.newRequest(ctx, "GET", "/api/v1/schedule", query, nil)
+	if err != nil {
+		return result, err
+	}
+	err = c.do(req, &result)
+	return result, err
+}
+
+// Clinic services
+func (c *Client) Services(ctx context.Context) ([]Service, error) {
+	var result []Service
+	query := url.Values{}
+	req, err := c.newRequest(ctx, "GET", "/api/v1/services", query, nil)
+	if err != nil {
+		return result, err
+	}
+	err = c.do(req, &result)
+	return result, err
+}
+
+// Free time slots of the day for the service
+//
+//   - date: RFC 3339 date time
+//   - identity: Slots held by this customer are treated as free, requires authentication
+func (c *Client) FreeTimeSlots(ctx context.Context, serviceId string, date time.Time, identity string) ([]TimePeriod, error) {
+	var result []TimePeriod
+	query := url.Values{}
+	query.Set("date", date.Format(time.RFC3339))
+	if identity != "" {
+		query.Set("identity", identity)
+	}
+	req, err := c.newRequest(ctx, "GET", "/api/v1/services/"+url.PathEscape(serviceId)+"/free-time-slots", query, nil)
+	if err != nil {
+		return result, err
+	}
+	err = c.do(req, &result)
+	return result, err
+}
+
+// VAPID public key for the `applicationServerKey` of the push subscription
+func (c *Client) WebPushPublicKey(ctx context.Context) (WebPushPublicKey, error) {
+	var result WebPushPublicKey
+	query := url.Values{}
+	req, err := c.newRequest(ctx, "GET", "/api/v1/web-push/public-key", query, nil)
+	if err != nil {
+		return result, err
+	}
+	err = c.do(req, &result)
+	return result, err
+}
+
+// The given day or the next working day
+//
+//   - date: RFC 3339 date time
+func (c *Client) DayOrNextWorkingDay(ctx context.Context, date time.Time) (time.Time, error) {
+	var result time.Time
+	query := url.Values{}
+	query.Set("date", date.Format(time.RFC3339))
+	req, err := c.newRequest(ctx, "GET", "/api/v1/working-day", query, nil)
+	if err != nil {
+		return result, err
+	}
+	err = c.do(req, &result)
+	return result, err
+}
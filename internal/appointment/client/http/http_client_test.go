@@ -0,0 +1,103 @@
+package appointment_http_client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	appointment_http_controller "github.com/x0k/veterinary-clinic-backend/internal/appointment/controller/http"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/openapi"
+)
+
+func TestClientIsUpToDate(t *testing.T) {
+	generated, err := openapi.GenerateClient(appointment_http_controller.OpenApiSpec, "appointment_http_client")
+	if err != nil {
+		t.Fatal(err)
+	}
+	current, err := os.ReadFile("http_client.gen.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(generated, current) {
+		t.Fatal("the client is outdated, run `go generate ./internal/appointment/controller/http`")
+	}
+}
+
+func TestClient(t *testing.T) {
+	type request struct {
+		method        string
+		uri           string
+		authorization string
+		idempotency   string
+		body          string
+	}
+	requests := make(chan request, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body bytes.Buffer
+		body.ReadFrom(r.Body)
+		requests <- request{
+			method:        r.Method,
+			uri:           r.URL.RequestURI(),
+			authorization: r.Header.Get("Authorization"),
+			idempotency:   r.Header.Get("Idempotency-Key"),
+			body:          body.String(),
+		}
+		switch r.URL.Path {
+		case "/api/v1/services/1/free-time-slots":
+			w.Write([]byte(`[{"start":{"hours":9,"minutes":0},"end":{"hours":9,"minutes":30}}]`))
+		case "/api/v1/appointments":
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"error":"conflict"}`))
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+	client := NewClient(server.URL, server.Client()).WithAuthorization("tma init-data")
+	ctx := context.Background()
+	date := time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC)
+
+	slots, err := client.FreeTimeSlots(ctx, "1", date, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(slots) != 1 || slots[0].Start.Hours != 9 || slots[0].End.Minutes != 30 {
+		t.Fatalf("unexpected slots: %+v", slots)
+	}
+	if r := <-requests; r.method != http.MethodGet ||
+		r.uri != "/api/v1/services/1/free-time-slots?date=2024-05-06T00%3A00%3A00Z" ||
+		r.authorization != "tma init-data" {
+		t.Fatalf("unexpected request: %+v", r)
+	}
+
+	_, err = client.MakeAppointment(ctx, "key", CreateAppointment{Date: date, ServiceId: "1"})
+	var responseErr *ResponseError
+	if !errors.As(err, &responseErr) || responseErr.StatusCode != http.StatusConflict || responseErr.Message != "conflict" {
+		t.Fatalf("expected a conflict error, got %v", err)
+	}
+	r := <-requests
+	var body map[string]any
+	if err := json.Unmarshal([]byte(r.body), &body); err != nil {
+		t.Fatal(err)
+	}
+	if r.method != http.MethodPost || r.idempotency != "key" || body["serviceId"] != "1" || body["date"] != "2024-05-06T00:00:00Z" {
+		t.Fatalf("unexpected request: %+v", r)
+	}
+	if _, ok := body["identity"]; ok {
+		t.Fatalf("optional identity should be omitted: %s", r.body)
+	}
+
+	if err := client.UnsubscribeWebPush(ctx, "tg-1", "https://push.example/a b"); err != nil {
+		t.Fatal(err)
+	}
+	if r := <-requests; r.method != http.MethodDelete ||
+		r.uri != "/api/v1/customers/tg-1/web-push-subscriptions?endpoint=https%3A%2F%2Fpush.example%2Fa+b" {
+		t.Fatalf("unexpected request: %+v", r)
+	}
+}
@@ -0,0 +1,296 @@
+package appointment_http_controller
+
+import (
+	_ "embed"
+	"net/http"
+	"time"
+
+	http_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/http"
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	appointment_http_adapters "github.com/x0k/veterinary-clinic-backend/internal/appointment/adapters/http"
+	appointment_use_case "github.com/x0k/veterinary-clinic-backend/internal/appointment/use_case"
+	appointment_js_use_case "github.com/x0k/veterinary-clinic-backend/internal/appointment/use_case/js"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/httpx"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/logger"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/logger/sl"
+)
+
+const ApiPrefix = "/api/v1"
+
+const idempotencyKeyHeader = "Idempotency-Key"
+
+//go:generate go run ../../../../cmd/apiclient -spec openapi.json -package appointment_http_client -out ../../client/http/http_client.gen.go
+
+// Describes every endpoint registered by `UseApiRouter`
+//
+//go:embed openapi.json
+var OpenApiSpec []byte
+
+type Router interface {
+	HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request))
+}
+
+func UseApiRouter(
+	mux Router,
+	log *logger.Logger,
+	scheduleUseCase *appointment_use_case.ScheduleUseCase[http_adapters.JSONResponse],
+	dayOrNextWorkingDayUseCase *appointment_js_use_case.DayOrNextWorkingDayUseCase[http_adapters.JSONResponse],
+	upsertCustomerUseCase *appointment_js_use_case.UpsertCustomerUseCase[http_adapters.JSONResponse],
+	freeTimeSlotsUseCase *appointment_js_use_case.FreeTimeSlotsUseCase[http_adapters.JSONResponse],
+	activeAppointmentUseCase *appointment_js_use_case.ActiveAppointmentUseCase[http_adapters.JSONResponse],
+	makeAppointmentUseCase *appointment_use_case.MakeAppointmentUseCase[http_adapters.JSONResponse],
+	cancelAppointmentUseCase *appointment_use_case.CancelAppointmentUseCase[http_adapters.JSONResponse],
+	servicesUseCase *appointment_use_case.ServicesUseCase[http_adapters.JSONResponse],
+	notificationPreferencesUseCase *appointment_use_case.NotificationPreferencesUseCase[http_adapters.JSONResponse],
+	webPushSubscriptionUseCase *appointment_use_case.WebPushSubscriptionUseCase[http_adapters.JSONResponse],
+) {
+	jsonBodyDecoder := &httpx.JsonBodyDecoder{
+		MaxBytes:              1 * 1024 * 1024,
+		DisallowUnknownFields: true,
+	}
+
+	toHandlerFunc := func(
+		handler func(w http.ResponseWriter, r *http.Request) (http_adapters.JSONResponse, error),
+	) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			res, err := handler(w, r)
+			if err != nil {
+				log.Error(r.Context(), "failed to handle request", sl.Err(err))
+				res = http_adapters.NewJSONError(http.StatusInternalServerError)
+			}
+			if err := res.Write(w); err != nil {
+				log.Error(r.Context(), "failed to write response", sl.Err(err))
+			}
+		}
+	}
+
+	handle := func(
+		pattern string,
+		handler func(w http.ResponseWriter, r *http.Request) (http_adapters.JSONResponse, error),
+	) {
+		mux.HandleFunc(pattern, toHandlerFunc(handler))
+	}
+
+	handleCustomer := func(
+		pattern string,
+		handler func(w http.ResponseWriter, r *http.Request) (http_adapters.JSONResponse, error),
+	) {
+		mux.HandleFunc(pattern, http_adapters.RequireIdentity[appointment.CustomerIdentity](toHandlerFunc(handler)).ServeHTTP)
+	}
+
+	mux.HandleFunc("GET "+ApiPrefix+"/openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if _, err := w.Write(OpenApiSpec); err != nil {
+			log.Error(r.Context(), "failed to write response", sl.Err(err))
+		}
+	})
+
+	handle("GET "+ApiPrefix+"/schedule", func(w http.ResponseWriter, r *http.Request) (http_adapters.JSONResponse, error) {
+		date, ok := queryTime(r, "date")
+		if !ok {
+			return http_adapters.NewJSONError(http.StatusBadRequest), nil
+		}
+		return scheduleUseCase.Schedule(r.Context(), time.Now(), date)
+	})
+
+	handle("GET "+ApiPrefix+"/working-day", func(w http.ResponseWriter, r *http.Request) (http_adapters.JSONResponse, error) {
+		date, ok := queryTime(r, "date")
+		if !ok {
+			return http_adapters.NewJSONError(http.StatusBadRequest), nil
+		}
+		return dayOrNextWorkingDayUseCase.DayOrNextWorkingDay(r.Context(), date)
+	})
+
+	handle("GET "+ApiPrefix+"/services", func(w http.ResponseWriter, r *http.Request) (http_adapters.JSONResponse, error) {
+		return servicesUseCase.Services(r.Context())
+	})
+
+	handle("GET "+ApiPrefix+"/services/{serviceId}/free-time-slots", func(w http.ResponseWriter, r *http.Request) (http_adapters.JSONResponse, error) {
+		date, ok := queryTime(r, "date")
+		if !ok {
+			return http_adapters.NewJSONError(http.StatusBadRequest), nil
+		}
+		identity, httpErr := customerIdentity(r, r.URL.Query().Get("identity"))
+		if httpErr != nil {
+			return *httpErr, nil
+		}
+		return freeTimeSlotsUseCase.FreeTimeSlots(
+			r.Context(),
+			identity,
+			appointment.NewServiceId(r.PathValue("serviceId")),
+			time.Now(),
+			date,
+		)
+	})
+
+	handle("GET "+ApiPrefix+"/web-push/public-key", func(w http.ResponseWriter, r *http.Request) (http_adapters.JSONResponse, error) {
+		return webPushSubscriptionUseCase.PublicKey(r.Context())
+	})
+
+	handleCustomer("PUT "+ApiPrefix+"/customers", func(w http.ResponseWriter, r *http.Request) (http_adapters.JSONResponse, error) {
+		dto, httpErr := decodeBody[appointment_http_adapters.UpsertCustomerDTO](log, jsonBodyDecoder, w, r)
+		if httpErr != nil {
+			return *httpErr, nil
+		}
+		identity, httpErr := requiredCustomerIdentity(r, dto.Identity)
+		if httpErr != nil {
+			return *httpErr, nil
+		}
+		return upsertCustomerUseCase.Upsert(r.Context(), identity, dto.Name, dto.Phone, dto.Email)
+	})
+
+	handleCustomer("GET "+ApiPrefix+"/customers/{identity}/appointment", func(w http.ResponseWriter, r *http.Request) (http_adapters.JSONResponse, error) {
+		identity, httpErr := requiredCustomerIdentity(r, r.PathValue("identity"))
+		if httpErr != nil {
+			return *httpErr, nil
+		}
+		return activeAppointmentUseCase.ActiveAppointment(r.Context(), identity)
+	})
+
+	handleCustomer("DELETE "+ApiPrefix+"/customers/{identity}/appointment", func(w http.ResponseWriter, r *http.Request) (http_adapters.JSONResponse, error) {
+		identity, httpErr := requiredCustomerIdentity(r, r.PathValue("identity"))
+		if httpErr != nil {
+			return *httpErr, nil
+		}
+		_, res, err := cancelAppointmentUseCase.CancelAppointment(r.Context(), identity)
+		return res, err
+	})
+
+	handleCustomer("GET "+ApiPrefix+"/customers/{identity}/notification-preferences", func(w http.ResponseWriter, r *http.Request) (http_adapters.JSONResponse, error) {
+		identity, httpErr := requiredCustomerIdentity(r, r.PathValue("identity"))
+		if httpErr != nil {
+			return *httpErr, nil
+		}
+		return notificationPreferencesUseCase.NotificationPreferences(r.Context(), identity)
+	})
+
+	handleCustomer("PUT "+ApiPrefix+"/customers/{identity}/notification-preferences", func(w http.ResponseWriter, r *http.Request) (http_adapters.JSONResponse, error) {
+		dto, httpErr := decodeBody[appointment_http_adapters.NotificationPreferencesDTO](log, jsonBodyDecoder, w, r)
+		if httpErr != nil {
+			return *httpErr, nil
+		}
+		identity, httpErr := requiredCustomerIdentity(r, r.PathValue("identity"))
+		if httpErr != nil {
+			return *httpErr, nil
+		}
+		quietHours := appointment_http_adapters.QuietHoursDTO{}
+		if dto.QuietHours != nil {
+			quietHours = *dto.QuietHours
+		}
+		return notificationPreferencesUseCase.SetNotificationPreferences(
+			r.Context(),
+			identity,
+			dto.Channels,
+			dto.MutedEvents,
+			quietHours.Start,
+			quietHours.End,
+		)
+	})
+
+	handleCustomer("PUT "+ApiPrefix+"/customers/{identity}/web-push-subscriptions", func(w http.ResponseWriter, r *http.Request) (http_adapters.JSONResponse, error) {
+		dto, httpErr := decodeBody[appointment_http_adapters.WebPushSubscriptionDTO](log, jsonBodyDecoder, w, r)
+		if httpErr != nil {
+			return *httpErr, nil
+		}
+		identity, httpErr := requiredCustomerIdentity(r, r.PathValue("identity"))
+		if httpErr != nil {
+			return *httpErr, nil
+		}
+		return webPushSubscriptionUseCase.Subscribe(
+			r.Context(),
+			identity,
+			dto.Endpoint,
+			dto.Keys.P256dh,
+			dto.Keys.Auth,
+		)
+	})
+
+	handleCustomer("DELETE "+ApiPrefix+"/customers/{identity}/web-push-subscriptions", func(w http.ResponseWriter, r *http.Request) (http_adapters.JSONResponse, error) {
+		endpoint := r.URL.Query().Get("endpoint")
+		if endpoint == "" {
+			return http_adapters.NewJSONError(http.StatusBadRequest), nil
+		}
+		identity, httpErr := requiredCustomerIdentity(r, r.PathValue("identity"))
+		if httpErr != nil {
+			return *httpErr, nil
+		}
+		return webPushSubscriptionUseCase.Unsubscribe(r.Context(), identity, endpoint)
+	})
+
+	handleCustomer("POST "+ApiPrefix+"/appointments", func(w http.ResponseWriter, r *http.Request) (http_adapters.JSONResponse, error) {
+		dto, httpErr := decodeBody[appointment_http_adapters.CreateAppointmentDTO](log, jsonBodyDecoder, w, r)
+		if httpErr != nil {
+			return *httpErr, nil
+		}
+		date, err := time.Parse(time.RFC3339, dto.Date)
+		if err != nil {
+			return http_adapters.NewJSONError(http.StatusBadRequest), nil
+		}
+		identity, httpErr := requiredCustomerIdentity(r, dto.Identity)
+		if httpErr != nil {
+			return *httpErr, nil
+		}
+		return makeAppointmentUseCase.CreateAppointment(
+			r.Context(),
+			time.Now(),
+			date,
+			identity,
+			appointment.NewServiceId(dto.ServiceId),
+			appointment.NewIdempotencyKey(r.Header.Get(idempotencyKeyHeader)),
+		)
+	})
+}
+
+// Resolves the identity of a customer, an authenticated customer
+// can omit it or act only on its own behalf.
+// The claimed identity of an unauthenticated request is never trusted.
+func customerIdentity(r *http.Request, claimed string) (appointment.CustomerIdentity, *http_adapters.JSONResponse) {
+	authenticated, isAuthenticated := http_adapters.IdentityFromContext[appointment.CustomerIdentity](r.Context())
+	if claimed == "" {
+		return authenticated, nil
+	}
+	if !isAuthenticated {
+		res := http_adapters.NewJSONError(http.StatusUnauthorized)
+		return "", &res
+	}
+	identity, err := appointment.NewCustomerIdentity(claimed)
+	if err != nil {
+		res := http_adapters.NewJSONError(http.StatusBadRequest)
+		return identity, &res
+	}
+	if identity != authenticated {
+		res := http_adapters.NewJSONError(http.StatusForbidden)
+		return identity, &res
+	}
+	return identity, nil
+}
+
+func requiredCustomerIdentity(r *http.Request, claimed string) (appointment.CustomerIdentity, *http_adapters.JSONResponse) {
+	identity, httpErr := customerIdentity(r, claimed)
+	if httpErr == nil && identity == "" {
+		res := http_adapters.NewJSONError(http.StatusBadRequest)
+		return identity, &res
+	}
+	return identity, httpErr
+}
+
+func queryTime(r *http.Request, key string) (time.Time, bool) {
+	t, err := time.Parse(time.RFC3339, r.URL.Query().Get(key))
+	return t, err == nil
+}
+
+func decodeBody[T any](
+	log *logger.Logger,
+	decoder *httpx.JsonBodyDecoder,
+	w http.ResponseWriter,
+	r *http.Request,
+) (T, *http_adapters.JSONResponse) {
+	dto, httpErr := httpx.JSONBody[T](log.Logger, decoder, w, r)
+	if httpErr != nil {
+		res := http_adapters.NewJSONResponse(httpErr.Status, http_adapters.ErrorDTO{
+			Error: httpErr.Text,
+		})
+		return dto, &res
+	}
+	return dto, nil
+}
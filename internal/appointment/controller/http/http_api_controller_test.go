@@ -0,0 +1,274 @@
+package appointment_http_controller
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	http_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/http"
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	appointment_http_adapters "github.com/x0k/veterinary-clinic-backend/internal/appointment/adapters/http"
+	appointment_js_adapters "github.com/x0k/veterinary-clinic-backend/internal/appointment/adapters/js"
+	appointment_http_presenter "github.com/x0k/veterinary-clinic-backend/internal/appointment/presenter/http"
+	appointment_use_case "github.com/x0k/veterinary-clinic-backend/internal/appointment/use_case"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/logger"
+	shared_http_adapters "github.com/x0k/veterinary-clinic-backend/internal/shared/adapters/http"
+	shared_js_adapters "github.com/x0k/veterinary-clinic-backend/internal/shared/adapters/js"
+)
+
+type openApiSchema struct {
+	Properties map[string]json.RawMessage `json:"properties"`
+}
+
+type openApiDocument struct {
+	Paths      map[string]map[string]json.RawMessage `json:"paths"`
+	Components struct {
+		Schemas map[string]openApiSchema `json:"schemas"`
+	} `json:"components"`
+}
+
+func loadOpenApiDocument(t *testing.T) openApiDocument {
+	t.Helper()
+	var doc openApiDocument
+	if err := json.Unmarshal(OpenApiSpec, &doc); err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+type recordingRouter struct {
+	*http.ServeMux
+	patterns []string
+}
+
+func (r *recordingRouter) HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request)) {
+	r.patterns = append(r.patterns, pattern)
+	r.ServeMux.HandleFunc(pattern, handler)
+}
+
+func newTestRouter(t *testing.T) *recordingRouter {
+	t.Helper()
+	log := logger.New(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	router := &recordingRouter{ServeMux: http.NewServeMux()}
+	UseApiRouter(
+		router, log,
+		nil, nil, nil, nil, nil, nil, nil,
+		appointment_use_case.NewServicesUseCase(
+			log,
+			func(context.Context) ([]appointment.ServiceEntity, error) {
+				return []appointment.ServiceEntity{{Id: "vaccination", Title: "Vaccination"}}, nil
+			},
+			appointment_http_presenter.ServicesPresenter,
+			appointment_http_presenter.ErrorPresenter,
+		),
+		nil, nil,
+	)
+	return router
+}
+
+func TestOpenApiPathsMatchHandlers(t *testing.T) {
+	doc := loadOpenApiDocument(t)
+	documented := make([]string, 0)
+	for path, operations := range doc.Paths {
+		for method := range operations {
+			documented = append(documented, strings.ToUpper(method)+" "+path)
+		}
+	}
+	registered := newTestRouter(t).patterns
+	slices.Sort(documented)
+	slices.Sort(registered)
+	if !slices.Equal(documented, registered) {
+		t.Errorf("documented operations %v, registered handlers %v", documented, registered)
+	}
+}
+
+func tagNames(t reflect.Type, tag string) []string {
+	names := make([]string, 0, t.NumField())
+	for i := range t.NumField() {
+		names = append(names, t.Field(i).Tag.Get(tag))
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Inline objects are addressed by the property name, e.g. `Schema.property`
+func documentedSchema(t *testing.T, doc openApiDocument, name string) (openApiSchema, bool) {
+	t.Helper()
+	schemaName, property, isInline := strings.Cut(name, ".")
+	schema, ok := doc.Components.Schemas[schemaName]
+	if !ok || !isInline {
+		return schema, ok
+	}
+	raw, ok := schema.Properties[property]
+	if !ok {
+		return openApiSchema{}, false
+	}
+	var inline openApiSchema
+	if err := json.Unmarshal(raw, &inline); err != nil {
+		t.Fatal(err)
+	}
+	return inline, true
+}
+
+func TestOpenApiSchemasMatchDTOs(t *testing.T) {
+	doc := loadOpenApiDocument(t)
+	cases := map[string]struct {
+		http reflect.Type
+		js   reflect.Type
+	}{
+		"Time":                               {reflect.TypeFor[shared_http_adapters.TimeDTO](), reflect.TypeFor[shared_js_adapters.TimeDTO]()},
+		"TimePeriod":                         {reflect.TypeFor[shared_http_adapters.TimePeriodDTO](), reflect.TypeFor[shared_js_adapters.TimePeriodDTO]()},
+		"Date":                               {reflect.TypeFor[shared_http_adapters.DateDTO](), reflect.TypeFor[shared_js_adapters.DateDTO]()},
+		"DateTime":                           {reflect.TypeFor[shared_http_adapters.DateTimeDTO](), reflect.TypeFor[shared_js_adapters.DateTimeDTO]()},
+		"DateTimePeriod":                     {reflect.TypeFor[shared_http_adapters.DateTimePeriodDTO](), reflect.TypeFor[shared_js_adapters.DateTimePeriodDTO]()},
+		"ScheduleEntry":                      {reflect.TypeFor[appointment_http_adapters.ScheduleEntryDTO](), reflect.TypeFor[appointment_js_adapters.ScheduleEntryDTO]()},
+		"Schedule":                           {reflect.TypeFor[appointment_http_adapters.ScheduleDTO](), reflect.TypeFor[appointment_js_adapters.ScheduleDTO]()},
+		"Record":                             {reflect.TypeFor[appointment_http_adapters.RecordDTO](), reflect.TypeFor[appointment_js_adapters.RecordDTO]()},
+		"Service":                            {reflect.TypeFor[appointment_http_adapters.ServiceDTO](), reflect.TypeFor[appointment_js_adapters.ServiceDTO]()},
+		"AppointmentInfo":                    {reflect.TypeFor[appointment_http_adapters.AppointmentInfoDTO](), reflect.TypeFor[appointment_js_adapters.AppointmentInfoDTO]()},
+		"UpsertCustomer":                     {reflect.TypeFor[appointment_http_adapters.UpsertCustomerDTO](), reflect.TypeFor[appointment_js_adapters.CreateCustomerDTO]()},
+		"CustomerId":                         {reflect.TypeFor[appointment_http_adapters.CustomerIdDTO](), nil},
+		"CreateAppointment":                  {reflect.TypeFor[appointment_http_adapters.CreateAppointmentDTO](), nil},
+		"NotificationPreferences":            {reflect.TypeFor[appointment_http_adapters.NotificationPreferencesDTO](), nil},
+		"NotificationPreferences.quietHours": {reflect.TypeFor[appointment_http_adapters.QuietHoursDTO](), nil},
+		"WebPushPublicKey":                   {reflect.TypeFor[appointment_http_adapters.WebPushPublicKeyDTO](), nil},
+		"WebPushSubscription":                {reflect.TypeFor[appointment_http_adapters.WebPushSubscriptionDTO](), nil},
+		"WebPushSubscription.keys":           {reflect.TypeFor[appointment_http_adapters.WebPushSubscriptionKeysDTO](), nil},
+	}
+	for name, c := range cases {
+		schema, ok := documentedSchema(t, doc, name)
+		if !ok {
+			t.Errorf("schema %s is not documented", name)
+			continue
+		}
+		properties := make([]string, 0, len(schema.Properties))
+		for p := range schema.Properties {
+			properties = append(properties, p)
+		}
+		slices.Sort(properties)
+		if fields := tagNames(c.http, "json"); !slices.Equal(properties, fields) {
+			t.Errorf("schema %s properties %v, json fields %v", name, properties, fields)
+		}
+		if c.js == nil {
+			continue
+		}
+		if fields := tagNames(c.js, "js"); !slices.Equal(properties, fields) {
+			t.Errorf("schema %s properties %v, js fields %v", name, properties, fields)
+		}
+	}
+}
+
+func TestApiResponsesMatchOpenApiSchemas(t *testing.T) {
+	doc := loadOpenApiDocument(t)
+	router := newTestRouter(t)
+	cases := []struct {
+		target string
+		status int
+		schema string
+		isList bool
+	}{
+		{ApiPrefix + "/services", http.StatusOK, "Service", true},
+		{ApiPrefix + "/schedule?date=invalid", http.StatusBadRequest, "Error", false},
+	}
+	for _, c := range cases {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, c.target, nil))
+		if w.Code != c.status {
+			t.Fatalf("GET %s status = %d, want %d", c.target, w.Code, c.status)
+		}
+		var objects []map[string]json.RawMessage
+		if c.isList {
+			if err := json.Unmarshal(w.Body.Bytes(), &objects); err != nil {
+				t.Fatal(err)
+			}
+		} else {
+			var object map[string]json.RawMessage
+			if err := json.Unmarshal(w.Body.Bytes(), &object); err != nil {
+				t.Fatal(err)
+			}
+			objects = append(objects, object)
+		}
+		properties := doc.Components.Schemas[c.schema].Properties
+		for _, object := range objects {
+			for key := range object {
+				if _, ok := properties[key]; !ok {
+					t.Errorf("GET %s returned undocumented field %q", c.target, key)
+				}
+			}
+			for key := range properties {
+				if _, ok := object[key]; !ok {
+					t.Errorf("GET %s did not return documented field %q", c.target, key)
+				}
+			}
+		}
+	}
+}
+
+func TestCustomerEndpointsRequireAuthentication(t *testing.T) {
+	router := newTestRouter(t)
+	cases := []struct {
+		method string
+		target string
+	}{
+		{http.MethodGet, ApiPrefix + "/customers/tg-1/appointment"},
+		{http.MethodDelete, ApiPrefix + "/customers/tg-1/appointment"},
+		{http.MethodGet, ApiPrefix + "/customers/tg-1/notification-preferences"},
+		{http.MethodPut, ApiPrefix + "/customers/tg-1/notification-preferences"},
+		{http.MethodPut, ApiPrefix + "/customers/tg-1/web-push-subscriptions"},
+		{http.MethodDelete, ApiPrefix + "/customers/tg-1/web-push-subscriptions?endpoint=https://push.example.com"},
+		{http.MethodPut, ApiPrefix + "/customers"},
+		{http.MethodPost, ApiPrefix + "/appointments"},
+		{http.MethodGet, ApiPrefix + "/services/vaccination/free-time-slots?date=2024-01-01T00:00:00Z&identity=tg-1"},
+	}
+	for _, c := range cases {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(c.method, c.target, strings.NewReader("{}")))
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("%s %s status = %d, want %d", c.method, c.target, w.Code, http.StatusUnauthorized)
+		}
+	}
+}
+
+func TestCustomerIdentity(t *testing.T) {
+	cases := []struct {
+		name          string
+		authenticated appointment.CustomerIdentity
+		claimed       string
+		want          appointment.CustomerIdentity
+		wantStatus    int
+	}{
+		{"anonymous without claim", "", "", "", 0},
+		{"anonymous with claim", "", "tg-1", "", http.StatusUnauthorized},
+		{"authenticated without claim", "tg-1", "", "tg-1", 0},
+		{"authenticated with own claim", "tg-1", "tg-1", "tg-1", 0},
+		{"authenticated with foreign claim", "tg-1", "tg-2", "", http.StatusForbidden},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			if c.authenticated != "" {
+				r = r.WithContext(http_adapters.WithIdentity(r.Context(), c.authenticated))
+			}
+			identity, httpErr := customerIdentity(r, c.claimed)
+			if c.wantStatus != 0 {
+				if httpErr == nil || httpErr.Status != c.wantStatus {
+					t.Fatalf("customerIdentity() error = %v, want status %d", httpErr, c.wantStatus)
+				}
+				return
+			}
+			if httpErr != nil {
+				t.Fatalf("customerIdentity() unexpected error %v", httpErr)
+			}
+			if identity != c.want {
+				t.Errorf("customerIdentity() = %q, want %q", identity, c.want)
+			}
+		})
+	}
+}
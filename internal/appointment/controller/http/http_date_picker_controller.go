@@ -7,6 +7,7 @@ import (
 
 	telegram_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/telegram"
 	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	appointment_telegram_adapters "github.com/x0k/veterinary-clinic-backend/internal/appointment/adapters/telegram"
 	web_calendar_adapters "github.com/x0k/veterinary-clinic-backend/internal/appointment/adapters/web_calendar"
 	appointment_telegram_use_case "github.com/x0k/veterinary-clinic-backend/internal/appointment/use_case/telegram"
 	"github.com/x0k/veterinary-clinic-backend/internal/lib/logger"
@@ -20,14 +21,16 @@ func UseDatePickerRouter(
 	bot *telebot.Bot,
 	webCalendarAppOrigin web_calendar_adapters.AppOrigin,
 	telegramIniDataParser telegram_adapters.InitDataParser,
-	appointmentDatePickerUseCase *appointment_telegram_use_case.AppointmentDatePickerUseCase[telegram_adapters.QueryResponse],
+	localizer *appointment_telegram_adapters.Localizer,
+	appointmentDatePickerUseCase *appointment_telegram_use_case.AppointmentDatePickerUseCase[telegram_adapters.LocalizedQueryResponse],
 ) error {
 	return useWebCalendarEndpoints(
 		mux, log, bot,
 		web_calendar_adapters.DatePickerPath,
 		webCalendarAppOrigin,
 		telegramIniDataParser,
-		func(ctx context.Context, res web_calendar_adapters.AppResultResponse) (telegram_adapters.QueryResponse, error) {
+		localizer,
+		func(ctx context.Context, res web_calendar_adapters.AppResultResponse) (telegram_adapters.LocalizedQueryResponse, error) {
 			selectedDate, err := time.Parse(time.DateOnly, res.Data.SelectedDates[0])
 			if err != nil {
 				log.Error(
@@ -35,7 +38,7 @@ func UseDatePickerRouter(
 					"failed to parse selected date",
 					sl.Err(err),
 				)
-				return telegram_adapters.QueryResponse{}, err
+				return nil, err
 			}
 			datePicker, err := appointmentDatePickerUseCase.DatePicker(
 				ctx,
@@ -49,7 +52,7 @@ func UseDatePickerRouter(
 					"failed to get date picker",
 					sl.Err(err),
 				)
-				return telegram_adapters.QueryResponse{}, err
+				return nil, err
 			}
 			return datePicker, nil
 		},
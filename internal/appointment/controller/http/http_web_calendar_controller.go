@@ -6,6 +6,7 @@ import (
 	"time"
 
 	telegram_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/telegram"
+	appointment_telegram_adapters "github.com/x0k/veterinary-clinic-backend/internal/appointment/adapters/telegram"
 	web_calendar_adapters "github.com/x0k/veterinary-clinic-backend/internal/appointment/adapters/web_calendar"
 	appointment_use_case "github.com/x0k/veterinary-clinic-backend/internal/appointment/use_case"
 	"github.com/x0k/veterinary-clinic-backend/internal/lib/logger"
@@ -19,7 +20,8 @@ func UseWebCalendarRouter(
 	bot *telebot.Bot,
 	webCalendarAppOrigin web_calendar_adapters.AppOrigin,
 	telegramIniDataParser telegram_adapters.InitDataParser,
-	scheduleUseCase *appointment_use_case.ScheduleUseCase[telegram_adapters.QueryResponse],
+	localizer *appointment_telegram_adapters.Localizer,
+	scheduleUseCase *appointment_use_case.ScheduleUseCase[telegram_adapters.LocalizedQueryResponse],
 ) error {
 
 	return useWebCalendarEndpoints(
@@ -27,7 +29,8 @@ func UseWebCalendarRouter(
 		web_calendar_adapters.HandlerPath,
 		webCalendarAppOrigin,
 		telegramIniDataParser,
-		func(ctx context.Context, res web_calendar_adapters.AppResultResponse) (telegram_adapters.QueryResponse, error) {
+		localizer,
+		func(ctx context.Context, res web_calendar_adapters.AppResultResponse) (telegram_adapters.LocalizedQueryResponse, error) {
 			selectedDate, err := time.Parse(time.DateOnly, res.Data.SelectedDates[0])
 			if err != nil {
 				log.Error(
@@ -35,7 +38,7 @@ func UseWebCalendarRouter(
 					"failed to parse selected date",
 					sl.Err(err),
 				)
-				return telegram_adapters.QueryResponse{}, err
+				return nil, err
 			}
 			schedule, err := scheduleUseCase.Schedule(ctx, time.Now(), selectedDate)
 			if err != nil {
@@ -44,7 +47,7 @@ func UseWebCalendarRouter(
 					"failed to schedule",
 					sl.Err(err),
 				)
-				return telegram_adapters.QueryResponse{}, err
+				return nil, err
 			}
 			return schedule, nil
 		},
@@ -6,10 +6,12 @@ import (
 	"net/http"
 
 	telegram_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/telegram"
+	appointment_telegram_adapters "github.com/x0k/veterinary-clinic-backend/internal/appointment/adapters/telegram"
 	web_calendar_adapters "github.com/x0k/veterinary-clinic-backend/internal/appointment/adapters/web_calendar"
 	"github.com/x0k/veterinary-clinic-backend/internal/lib/httpx"
 	"github.com/x0k/veterinary-clinic-backend/internal/lib/logger"
 	"github.com/x0k/veterinary-clinic-backend/internal/lib/logger/sl"
+	"github.com/x0k/veterinary-clinic-backend/internal/shared"
 	"gopkg.in/telebot.v3"
 )
 
@@ -20,7 +22,8 @@ func useWebCalendarEndpoints(
 	endpointPath string,
 	webCalendarAppOrigin web_calendar_adapters.AppOrigin,
 	telegramInitDataParser telegram_adapters.InitDataParser,
-	useCase func(context.Context, web_calendar_adapters.AppResultResponse) (telegram_adapters.QueryResponse, error),
+	localizer *appointment_telegram_adapters.Localizer,
+	useCase func(context.Context, web_calendar_adapters.AppResultResponse) (telegram_adapters.LocalizedQueryResponse, error),
 ) error {
 	jsonBodyDecoder := &httpx.JsonBodyDecoder{
 		MaxBytes: 1 * 1024 * 1024,
@@ -79,7 +82,11 @@ func useWebCalendarEndpoints(
 				&telebot.Query{
 					ID: data.QueryID,
 				},
-				result.Result,
+				result(localizer.Printer(
+					r.Context(),
+					shared.NewTelegramUserId(data.User.ID),
+					data.User.LanguageCode,
+				)).Result,
 			)
 			if err != nil {
 				log.Error(
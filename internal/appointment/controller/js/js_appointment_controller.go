@@ -26,6 +26,8 @@ func NewAppointment(
 	createAppointmentUseCase *appointment_use_case.MakeAppointmentUseCase[js_adapters.Result],
 	cancelAppointmentUseCase *appointment_use_case.CancelAppointmentUseCase[js_adapters.Result],
 	servicesUseCase *appointment_use_case.ServicesUseCase[js_adapters.Result],
+	availabilityUseCase *appointment_use_case.AvailabilityUseCase[js_adapters.Result],
+	holdSlotUseCase *appointment_js_use_case.HoldSlotUseCase[js_adapters.Result],
 ) {
 	module.Set("schedule", js_adapters.Async(func(args []js.Value) js_adapters.Promise {
 		if len(args) < 1 {
@@ -82,9 +84,16 @@ func NewAppointment(
 		if err != nil {
 			return js_adapters.ResolveError(err)
 		}
+		var identity appointment.CustomerIdentity
+		if len(args) > 2 {
+			if identity, err = appointment.NewCustomerIdentity(args[2].String()); err != nil {
+				return js_adapters.ResolveError(err)
+			}
+		}
 		return js_adapters.NewPromise(func() (js_adapters.Result, error) {
 			return freeTimeSlotsUseCase.FreeTimeSlots(
 				ctx,
+				identity,
 				serviceId,
 				time.Now(),
 				appointmentDate,
@@ -119,6 +128,10 @@ func NewAppointment(
 			return js_adapters.ResolveError(err)
 		}
 		serviceId := appointment.NewServiceId(args[2].String())
+		var idempotencyKey appointment.IdempotencyKey
+		if len(args) > 3 && args[3].Type() == js.TypeString {
+			idempotencyKey = appointment.NewIdempotencyKey(args[3].String())
+		}
 		return js_adapters.NewPromise(func() (js_adapters.Result, error) {
 			return createAppointmentUseCase.CreateAppointment(
 				ctx,
@@ -126,6 +139,7 @@ func NewAppointment(
 				appointmentDate,
 				customerIdentity,
 				serviceId,
+				idempotencyKey,
 			)
 		})
 	}))
@@ -150,4 +164,40 @@ func NewAppointment(
 			return servicesUseCase.Services(ctx)
 		})
 	}))
+	module.Set("availability", js_adapters.Async(func(args []js.Value) js_adapters.Promise {
+		if len(args) < 2 {
+			return js_adapters.ResolveError(js_adapters.ErrTooFewArguments)
+		}
+		month, err := time.Parse(time.RFC3339, args[0].String())
+		if err != nil {
+			return js_adapters.ResolveError(err)
+		}
+		serviceId := appointment.NewServiceId(args[1].String())
+		return js_adapters.NewPromise(func() (js_adapters.Result, error) {
+			return availabilityUseCase.Availability(ctx, time.Now(), month, serviceId)
+		})
+	}))
+	module.Set("holdSlot", js_adapters.Async(func(args []js.Value) js_adapters.Promise {
+		if len(args) < 3 {
+			return js_adapters.ResolveError(js_adapters.ErrTooFewArguments)
+		}
+		appointmentDate, err := time.Parse(time.RFC3339, args[0].String())
+		if err != nil {
+			return js_adapters.ResolveError(err)
+		}
+		customerIdentity, err := appointment.NewCustomerIdentity(args[1].String())
+		if err != nil {
+			return js_adapters.ResolveError(err)
+		}
+		serviceId := appointment.NewServiceId(args[2].String())
+		return js_adapters.NewPromise(func() (js_adapters.Result, error) {
+			return holdSlotUseCase.HoldSlot(
+				ctx,
+				time.Now(),
+				appointmentDate,
+				customerIdentity,
+				serviceId,
+			)
+		})
+	}))
 }
@@ -0,0 +1,41 @@
+package appointment_pubsub_controller
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	appointment_use_case "github.com/x0k/veterinary-clinic-backend/internal/appointment/use_case"
+)
+
+func NewOutboxEventHandler[S any, C any](
+	sendStaffNotificationUseCase *appointment_use_case.SendStaffNotificationUseCase[S],
+	sendCustomerNotificationUseCase *appointment_use_case.SendCustomerNotificationUseCase[C],
+	updateAppointmentsUseCase *appointment_use_case.UpdateAppointmentsStateUseCase,
+	enqueueWebhooksUseCase *appointment_use_case.EnqueueWebhooksUseCase,
+) appointment.OutboxEventHandler {
+	return func(ctx context.Context, id appointment.OutboxEntryId, event appointment.Event) error {
+		// Enqueueing is idempotent, so it is safe to repeat on retries
+		if err := enqueueWebhooksUseCase.Enqueue(ctx, id, event); err != nil {
+			return err
+		}
+		switch e := event.(type) {
+		case appointment.CreatedEvent:
+			updateAppointmentsUseCase.AddAppointment(ctx, e.Record)
+			return errors.Join(
+				sendStaffNotificationUseCase.SendStaffNotification(ctx, e),
+				sendCustomerNotificationUseCase.SendCreatedNotification(ctx, e, time.Now()),
+			)
+		case appointment.CanceledEvent:
+			updateAppointmentsUseCase.RemoveAppointment(ctx, e.Record)
+			return errors.Join(
+				sendStaffNotificationUseCase.SendStaffNotification(ctx, e),
+				sendCustomerNotificationUseCase.SendCanceledNotification(ctx, e, time.Now()),
+			)
+		case appointment.ChangedEvent:
+			return sendCustomerNotificationUseCase.SendCustomerNotification(ctx, e, time.Now())
+		}
+		return appointment.ErrUnsupportedOutboxEvent
+	}
+}
@@ -1,6 +1,8 @@
 package appointment_pubsub_controller
 
 import (
+	"sync"
+
 	pubsub_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/pubsub"
 	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
 	"github.com/x0k/veterinary-clinic-backend/internal/lib/module"
@@ -13,3 +15,41 @@ func Subscribe[E appointment.Event](
 ) <-chan E {
 	return pubsub_adapters.Subscribe[appointment.EventType, E](subs, preStopper)
 }
+
+func subscribeTransition[E appointment.StatusTransitionEvent](
+	subs pubsub.SubscriptionsManager[appointment.EventType],
+	preStopper module.PreStopper,
+	wg *sync.WaitGroup,
+	out chan<- appointment.StatusTransitionEvent,
+) {
+	events := Subscribe[E](subs, preStopper)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for e := range events {
+			out <- e
+		}
+	}()
+}
+
+func SubscribeStatusTransitions(
+	subs pubsub.SubscriptionsManager[appointment.EventType],
+	preStopper module.PreStopper,
+) <-chan appointment.StatusTransitionEvent {
+	out := make(chan appointment.StatusTransitionEvent)
+	wg := &sync.WaitGroup{}
+	subscribeTransition[appointment.ConfirmedEvent](subs, preStopper, wg, out)
+	subscribeTransition[appointment.CheckedInEvent](subs, preStopper, wg, out)
+	subscribeTransition[appointment.InProgressEvent](subs, preStopper, wg, out)
+	subscribeTransition[appointment.CompletedEvent](subs, preStopper, wg, out)
+	subscribeTransition[appointment.NotAppearedEvent](subs, preStopper, wg, out)
+	subscribeTransition[appointment.CanceledByClinicEvent](subs, preStopper, wg, out)
+	subscribeTransition[appointment.RescheduledEvent](subs, preStopper, wg, out)
+	subscribeTransition[appointment.ApprovedEvent](subs, preStopper, wg, out)
+	subscribeTransition[appointment.DeclinedEvent](subs, preStopper, wg, out)
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
@@ -0,0 +1,176 @@
+package appointment_telegram_controller
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/adapters"
+	telegram_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/telegram"
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	appointment_telegram_adapters "github.com/x0k/veterinary-clinic-backend/internal/appointment/adapters/telegram"
+	appointment_use_case "github.com/x0k/veterinary-clinic-backend/internal/appointment/use_case"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/module"
+	"github.com/x0k/veterinary-clinic-backend/internal/shared"
+	"gopkg.in/telebot.v3"
+)
+
+func NewAdmin(
+	bot *telebot.Bot,
+	dayAppointmentsUseCase *appointment_use_case.DayAppointmentsUseCase[telegram_adapters.LocalizedTextResponses],
+	findCustomersUseCase *appointment_use_case.FindCustomersUseCase[telegram_adapters.LocalizedTextResponses],
+	blockPeriodUseCase *appointment_use_case.BlockPeriodUseCase[telegram_adapters.LocalizedTextResponses],
+	changeRecordStatusUseCase *appointment_use_case.ChangeRecordStatusUseCase[telegram_adapters.LocalizedCallbackResponse],
+	cancelRecordUseCase *appointment_use_case.ChangeRecordStatusUseCase[telegram_adapters.LocalizedTextResponses],
+	staff *appointment.Staff,
+	cancelRecordIdSaver adapters.StateByKeySaver[appointment.RecordId],
+	cancelRecordIdPopper adapters.StatePopper[appointment.RecordId],
+	textInputs *telegram_adapters.TextInputs,
+) module.Hook {
+	return module.NewHook(
+		"appointment_telegram_controller.NewAdmin",
+		func(ctx context.Context) error {
+			dayAppointmentsHandler := func(offset int) telebot.HandlerFunc {
+				return func(c telebot.Context) error {
+					identity, err := appointment.NewTelegramCustomerIdentity(
+						shared.NewTelegramUserId(c.Sender().ID),
+					)
+					if err != nil {
+						return err
+					}
+					res, err := dayAppointmentsUseCase.DayAppointments(
+						ctx,
+						identity,
+						time.Now().AddDate(0, 0, offset),
+					)
+					if err != nil {
+						return err
+					}
+					return res.Send(c)
+				}
+			}
+			bot.Handle("/today", dayAppointmentsHandler(0))
+			bot.Handle("/tomorrow", dayAppointmentsHandler(1))
+
+			bot.Handle("/customer", func(c telebot.Context) error {
+				identity, err := appointment.NewTelegramCustomerIdentity(
+					shared.NewTelegramUserId(c.Sender().ID),
+				)
+				if err != nil {
+					return err
+				}
+				phone := strings.TrimSpace(c.Message().Payload)
+				if phone == "" {
+					return c.Send(telegram_adapters.ChatPrinter(c).Text("usage.customer"))
+				}
+				res, err := findCustomersUseCase.CustomersByPhone(ctx, identity, phone)
+				if err != nil {
+					return err
+				}
+				return res.Send(c)
+			})
+
+			bot.Handle("/block", func(c telebot.Context) error {
+				identity, err := appointment.NewTelegramCustomerIdentity(
+					shared.NewTelegramUserId(c.Sender().ID),
+				)
+				if err != nil {
+					return err
+				}
+				title, period, ok := parsePeriodPayload(c.Message().Payload)
+				if !ok {
+					return c.Send(telegram_adapters.ChatPrinter(c).Text("usage.block"))
+				}
+				if title == "" {
+					title = telegram_adapters.ChatPrinter(c).Text("admin.period_blocked.title")
+				}
+				res, err := blockPeriodUseCase.BlockPeriod(ctx, identity, title, period)
+				if err != nil {
+					return err
+				}
+				return res.Send(c)
+			})
+
+			bot.Handle(
+				appointment_telegram_adapters.CompleteAppointmentBtn,
+				changeRecordStatusHandler(ctx, changeRecordStatusUseCase, appointment.RecordDone),
+			)
+			bot.Handle(
+				appointment_telegram_adapters.NotAppearAppointmentBtn,
+				changeRecordStatusHandler(ctx, changeRecordStatusUseCase, appointment.RecordNotAppear),
+			)
+
+			bot.Handle(appointment_telegram_adapters.CancelByClinicAppointmentBtn, func(c telebot.Context) error {
+				identity, err := appointment.NewTelegramCustomerIdentity(
+					shared.NewTelegramUserId(c.Sender().ID),
+				)
+				if err != nil {
+					return err
+				}
+				if err := staff.Check(identity, appointment.CancelAppointmentsPermission); err != nil {
+					return c.Respond(&telebot.CallbackResponse{
+						Text: telegram_adapters.ChatPrinter(c).Text("error.forbidden"),
+					})
+				}
+				cancelRecordIdSaver(
+					adapters.NewStateId(identity.String()),
+					appointment.NewRecordId(c.Callback().Data),
+				)
+				if err := c.Respond(); err != nil {
+					return err
+				}
+				return c.Send(telegram_adapters.ChatPrinter(c).Text("admin.cancel_reason"), &telebot.SendOptions{
+					ReplyMarkup: &telebot.ReplyMarkup{
+						ForceReply: true,
+					},
+				})
+			})
+
+			// The reason is awaited only after the cancel button was pressed
+			textInputs.Add(func(c telebot.Context) (bool, error) {
+				identity, err := appointment.NewTelegramCustomerIdentity(
+					shared.NewTelegramUserId(c.Sender().ID),
+				)
+				if err != nil {
+					return false, nil
+				}
+				recordId, ok := cancelRecordIdPopper(adapters.NewStateId(identity.String()))
+				if !ok {
+					return false, nil
+				}
+				_, res, err := cancelRecordUseCase.ChangeStatus(
+					ctx,
+					identity,
+					recordId,
+					appointment.RecordCanceledByClinic,
+					strings.TrimSpace(c.Text()),
+				)
+				if err != nil {
+					return true, err
+				}
+				return true, res.Send(c)
+			})
+			return nil
+		},
+	)
+}
+
+// Expects `DD.MM.YYYY HH:MM HH:MM [text]`
+func parsePeriodPayload(payload string) (string, shared.DateTimePeriod, bool) {
+	fields := strings.Fields(payload)
+	if len(fields) < 3 {
+		return "", shared.DateTimePeriod{}, false
+	}
+	start, err := time.ParseInLocation("02.01.2006 15:04", fields[0]+" "+fields[1], time.Local)
+	if err != nil {
+		return "", shared.DateTimePeriod{}, false
+	}
+	end, err := time.ParseInLocation("02.01.2006 15:04", fields[0]+" "+fields[2], time.Local)
+	if err != nil {
+		return "", shared.DateTimePeriod{}, false
+	}
+	return strings.Join(fields[3:], " "), shared.DateTimePeriod{
+		Start: shared.GoTimeToDateTime(start),
+		End:   shared.GoTimeToDateTime(end),
+	}, true
+}
@@ -0,0 +1,64 @@
+package appointment_telegram_controller
+
+import (
+	"context"
+
+	telegram_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/telegram"
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	appointment_telegram_adapters "github.com/x0k/veterinary-clinic-backend/internal/appointment/adapters/telegram"
+	appointment_use_case "github.com/x0k/veterinary-clinic-backend/internal/appointment/use_case"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/module"
+	"github.com/x0k/veterinary-clinic-backend/internal/shared"
+	"gopkg.in/telebot.v3"
+)
+
+func NewAppointmentApproval(
+	bot *telebot.Bot,
+	changeRecordStatusUseCase *appointment_use_case.ChangeRecordStatusUseCase[telegram_adapters.LocalizedCallbackResponse],
+) module.Hook {
+	return module.NewHook(
+		"appointment_telegram_controller.NewAppointmentApproval",
+		func(ctx context.Context) error {
+			bot.Handle(
+				appointment_telegram_adapters.ApproveAppointmentBtn,
+				changeRecordStatusHandler(ctx, changeRecordStatusUseCase, appointment.RecordAwaits),
+			)
+			bot.Handle(
+				appointment_telegram_adapters.DeclineAppointmentBtn,
+				changeRecordStatusHandler(ctx, changeRecordStatusUseCase, appointment.RecordDeclined),
+			)
+			return nil
+		},
+	)
+}
+
+func changeRecordStatusHandler(
+	ctx context.Context,
+	changeRecordStatusUseCase *appointment_use_case.ChangeRecordStatusUseCase[telegram_adapters.LocalizedCallbackResponse],
+	status appointment.RecordStatus,
+) telebot.HandlerFunc {
+	return func(c telebot.Context) error {
+		identity, err := appointment.NewTelegramCustomerIdentity(
+			shared.NewTelegramUserId(c.Sender().ID),
+		)
+		if err != nil {
+			return err
+		}
+		isChanged, res, err := changeRecordStatusUseCase.ChangeStatus(
+			ctx,
+			identity,
+			appointment.NewRecordId(c.Callback().Data),
+			status,
+			"",
+		)
+		if err != nil {
+			return err
+		}
+		if isChanged {
+			if err := c.Edit(&telebot.ReplyMarkup{}); err != nil {
+				return err
+			}
+		}
+		return res.Respond(c)
+	}
+}
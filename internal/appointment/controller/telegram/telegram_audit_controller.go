@@ -0,0 +1,107 @@
+package appointment_telegram_controller
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	telegram_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/telegram"
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	appointment_telegram_adapters "github.com/x0k/veterinary-clinic-backend/internal/appointment/adapters/telegram"
+	appointment_use_case "github.com/x0k/veterinary-clinic-backend/internal/appointment/use_case"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/module"
+	"github.com/x0k/veterinary-clinic-backend/internal/shared"
+	"gopkg.in/telebot.v3"
+)
+
+const defaultHistoryExportDays = 30
+
+func NewAudit(
+	bot *telebot.Bot,
+	auditLogUseCase *appointment_use_case.AuditLogUseCase[telegram_adapters.LocalizedTextResponses],
+	exportAuditLogUseCase *appointment_use_case.ExportAuditLogUseCase[telegram_adapters.LocalizedResponse],
+) module.Hook {
+	return module.NewHook(
+		"appointment_telegram_controller.NewAudit",
+		func(ctx context.Context) error {
+			bot.Handle("/history", func(c telebot.Context) error {
+				identity, err := appointment.NewTelegramCustomerIdentity(
+					shared.NewTelegramUserId(c.Sender().ID),
+				)
+				if err != nil {
+					return err
+				}
+				res, err := auditLogUseCase.RecentEntries(ctx, identity)
+				if err != nil {
+					return err
+				}
+				return res.Send(c)
+			})
+
+			bot.Handle(appointment_telegram_adapters.HistoryAppointmentBtn, func(c telebot.Context) error {
+				identity, err := appointment.NewTelegramCustomerIdentity(
+					shared.NewTelegramUserId(c.Sender().ID),
+				)
+				if err != nil {
+					return err
+				}
+				res, err := auditLogUseCase.RecordHistory(
+					ctx,
+					identity,
+					appointment.NewRecordId(c.Callback().Data),
+				)
+				if err != nil {
+					return err
+				}
+				if err := c.Respond(); err != nil {
+					return err
+				}
+				return res.Send(c)
+			})
+
+			bot.Handle("/history_export", func(c telebot.Context) error {
+				identity, err := appointment.NewTelegramCustomerIdentity(
+					shared.NewTelegramUserId(c.Sender().ID),
+				)
+				if err != nil {
+					return err
+				}
+				from, to, ok := parseExportPeriodPayload(c.Message().Payload, time.Now())
+				if !ok {
+					return c.Send(telegram_adapters.ChatPrinter(c).Text("usage.history_export"))
+				}
+				res, err := exportAuditLogUseCase.Export(ctx, identity, from, to)
+				if err != nil {
+					return err
+				}
+				return res.Send(c)
+			})
+			return nil
+		},
+	)
+}
+
+// Expects `[DD.MM.YYYY [DD.MM.YYYY]]`, both days are included.
+// By default the last 30 days are exported
+func parseExportPeriodPayload(payload string, now time.Time) (time.Time, time.Time, bool) {
+	fields := strings.Fields(payload)
+	if len(fields) > 2 {
+		return time.Time{}, time.Time{}, false
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	from := today.AddDate(0, 0, -defaultHistoryExportDays)
+	last := today
+	if len(fields) > 0 {
+		var err error
+		if from, err = time.ParseInLocation("02.01.2006", fields[0], time.Local); err != nil {
+			return time.Time{}, time.Time{}, false
+		}
+	}
+	if len(fields) > 1 {
+		var err error
+		if last, err = time.ParseInLocation("02.01.2006", fields[1], time.Local); err != nil {
+			return time.Time{}, time.Time{}, false
+		}
+	}
+	return from, last.AddDate(0, 0, 1), true
+}
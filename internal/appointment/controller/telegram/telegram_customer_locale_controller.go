@@ -0,0 +1,53 @@
+package appointment_telegram_controller
+
+import (
+	"context"
+	"strings"
+
+	telegram_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/telegram"
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	appointment_telegram_adapters "github.com/x0k/veterinary-clinic-backend/internal/appointment/adapters/telegram"
+	appointment_use_case "github.com/x0k/veterinary-clinic-backend/internal/appointment/use_case"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/module"
+	"github.com/x0k/veterinary-clinic-backend/internal/shared"
+	"gopkg.in/telebot.v3"
+)
+
+const autoLocale = "auto"
+
+// Should be started before other controllers, since
+// the middleware applies only to subsequently registered handlers
+func NewCustomerLocale(
+	bot *telebot.Bot,
+	localizer *appointment_telegram_adapters.Localizer,
+	customerLocaleUseCase *appointment_use_case.CustomerLocaleUseCase[telegram_adapters.LocalizedTextResponses],
+) module.Hook {
+	return module.NewHook(
+		"appointment_telegram_controller.NewCustomerLocale",
+		func(ctx context.Context) error {
+			bot.Use(localizer.Middleware(ctx))
+			bot.Handle("/language", func(c telebot.Context) error {
+				identity, err := appointment.NewTelegramCustomerIdentity(
+					shared.NewTelegramUserId(c.Sender().ID),
+				)
+				if err != nil {
+					return err
+				}
+				var res telegram_adapters.LocalizedTextResponses
+				switch locale := strings.TrimSpace(c.Message().Payload); locale {
+				case "":
+					res, err = customerLocaleUseCase.CustomerLocale(ctx, identity)
+				case autoLocale:
+					res, err = customerLocaleUseCase.SetPreferredLocale(ctx, identity, "")
+				default:
+					res, err = customerLocaleUseCase.SetPreferredLocale(ctx, identity, locale)
+				}
+				if err != nil {
+					return err
+				}
+				return res.Send(c)
+			})
+			return nil
+		},
+	)
+}
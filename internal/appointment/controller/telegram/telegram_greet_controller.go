@@ -11,8 +11,9 @@ import (
 
 func NewGreet(
 	bot *telebot.Bot,
-	greetUseCase *appointment_telegram_use_case.GreetUseCase[telegram_adapters.TextResponses],
-	createAppointment bool,
+	greetUseCase *appointment_telegram_use_case.GreetUseCase[telegram_adapters.LocalizedTextResponses],
+	// By the language code, empty code is for the default list
+	commands map[string][]telebot.Command,
 ) module.Hook {
 	return module.NewHook(
 		"appointment_telegram_controller.NewGreet",
@@ -24,27 +25,12 @@ func NewGreet(
 				}
 				return res.Send(c)
 			})
-			commands := []telebot.Command{
-				{
-					Text:        "/start",
-					Description: "Приветствие",
-				},
-				{
-					Text:        "/services",
-					Description: "Список услуг",
-				},
-				{
-					Text:        "/schedule",
-					Description: "График работы",
-				},
-			}
-			if createAppointment {
-				commands = append(commands, telebot.Command{
-					Text:        "/appointment",
-					Description: "Запись на прием",
-				})
+			for languageCode, cmds := range commands {
+				if err := bot.SetCommands(cmds, languageCode); err != nil {
+					return err
+				}
 			}
-			return bot.SetCommands(commands)
+			return nil
 		},
 	)
 }
@@ -17,12 +17,12 @@ import (
 
 func NewMakeAppointment(
 	bot *telebot.Bot,
-	startMakeAppointmentDialogUseCase *appointment_telegram_use_case.StartMakeAppointmentDialogUseCase[telegram_adapters.TextResponses],
-	appointmentDatePickerUseCase *appointment_telegram_use_case.AppointmentDatePickerUseCase[telegram_adapters.TextResponses],
-	appointmentTimePickerUseCase *appointment_telegram_use_case.AppointmentTimePickerUseCase[telegram_adapters.TextResponses],
-	appointmentConfirmationUseCase *appointment_telegram_use_case.AppointmentConfirmationUseCase[telegram_adapters.TextResponses],
-	makeAppointmentUseCase *appointment_use_case.MakeAppointmentUseCase[telegram_adapters.TextResponses],
-	cancelAppointmentUseCase *appointment_use_case.CancelAppointmentUseCase[telegram_adapters.CallbackResponse],
+	startMakeAppointmentDialogUseCase *appointment_telegram_use_case.StartMakeAppointmentDialogUseCase[telegram_adapters.LocalizedTextResponses],
+	appointmentDatePickerUseCase *appointment_telegram_use_case.AppointmentDatePickerUseCase[telegram_adapters.LocalizedTextResponses],
+	appointmentTimePickerUseCase *appointment_telegram_use_case.AppointmentTimePickerUseCase[telegram_adapters.LocalizedTextResponses],
+	appointmentConfirmationUseCase *appointment_telegram_use_case.AppointmentConfirmationUseCase[telegram_adapters.LocalizedTextResponses],
+	makeAppointmentUseCase *appointment_use_case.MakeAppointmentUseCase[telegram_adapters.LocalizedTextResponses],
+	cancelAppointmentUseCase *appointment_use_case.CancelAppointmentUseCase[telegram_adapters.LocalizedCallbackResponse],
 	errorSender appointment_telegram_adapters.ErrorSender,
 	serviceIdLoader adapters.StateLoader[appointment.ServiceId],
 	appointmentStateLoader adapters.StateLoader[appointment_telegram_adapters.AppointmentSate],
@@ -81,8 +81,15 @@ func NewMakeAppointment(
 				if !ok {
 					return errorSender.Send(c, appointment_telegram_adapters.ErrUnknownState)
 				}
+				identity, err := appointment.NewTelegramCustomerIdentity(
+					shared.NewTelegramUserId(c.Sender().ID),
+				)
+				if err != nil {
+					return err
+				}
 				timePicker, err := appointmentTimePickerUseCase.TimePicker(
 					ctx,
+					identity,
 					state.ServiceId,
 					time.Now(),
 					state.Date,
@@ -101,6 +108,25 @@ func NewMakeAppointment(
 				if !ok {
 					return errorSender.Send(c, appointment_telegram_adapters.ErrUnknownState)
 				}
+				identity, err := appointment.NewTelegramCustomerIdentity(
+					shared.NewTelegramUserId(c.Sender().ID),
+				)
+				if err != nil {
+					return err
+				}
+				isHeld, res, err := appointmentTimePickerUseCase.HoldTime(
+					ctx,
+					identity,
+					state.ServiceId,
+					time.Now(),
+					state.Date,
+				)
+				if err != nil {
+					return err
+				}
+				if !isHeld {
+					return res.Send(c)
+				}
 				confirmation, err := appointmentConfirmationUseCase.Confirmation(ctx, state.ServiceId, state.Date)
 				if err != nil {
 					return err
@@ -111,9 +137,8 @@ func NewMakeAppointment(
 			bot.Handle(appointment_telegram_adapters.CancelMakeAppointmentTimeBtn, appointmentNextDatePickerHandler)
 
 			bot.Handle(appointment_telegram_adapters.ConfirmMakeAppointmentBtn, func(c telebot.Context) error {
-				state, ok := appointmentStateLoader(
-					adapters.NewStateId(c.Callback().Data),
-				)
+				stateId := adapters.NewStateId(c.Callback().Data)
+				state, ok := appointmentStateLoader(stateId)
 				if !ok {
 					return errorSender.Send(c, appointment_telegram_adapters.ErrUnknownState)
 				}
@@ -129,6 +154,7 @@ func NewMakeAppointment(
 					state.Date,
 					identity,
 					state.ServiceId,
+					appointment.NewIdempotencyKey(stateId.String()),
 				)
 				if err != nil {
 					return err
@@ -154,7 +180,7 @@ func NewMakeAppointment(
 						return err
 					}
 				}
-				if err := c.Respond(res.Response); err != nil {
+				if err := res.Respond(c); err != nil {
 					return err
 				}
 				return nil
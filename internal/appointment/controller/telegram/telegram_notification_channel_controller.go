@@ -0,0 +1,43 @@
+package appointment_telegram_controller
+
+import (
+	"context"
+	"strings"
+
+	telegram_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/telegram"
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	appointment_use_case "github.com/x0k/veterinary-clinic-backend/internal/appointment/use_case"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/module"
+	"github.com/x0k/veterinary-clinic-backend/internal/shared"
+	"gopkg.in/telebot.v3"
+)
+
+func NewNotificationChannel(
+	bot *telebot.Bot,
+	notificationChannelUseCase *appointment_use_case.NotificationChannelUseCase[telegram_adapters.LocalizedTextResponses],
+) module.Hook {
+	return module.NewHook(
+		"appointment_telegram_controller.NewNotificationChannel",
+		func(ctx context.Context) error {
+			// Without arguments the default channels are restored
+			bot.Handle("/notifications_channels", func(c telebot.Context) error {
+				identity, err := appointment.NewTelegramCustomerIdentity(
+					shared.NewTelegramUserId(c.Sender().ID),
+				)
+				if err != nil {
+					return err
+				}
+				res, err := notificationChannelUseCase.SetNotificationChannels(
+					ctx,
+					identity,
+					strings.Fields(c.Message().Payload),
+				)
+				if err != nil {
+					return err
+				}
+				return res.Send(c)
+			})
+			return nil
+		},
+	)
+}
@@ -0,0 +1,60 @@
+package appointment_telegram_controller
+
+import (
+	"context"
+	"strings"
+
+	telegram_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/telegram"
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	appointment_use_case "github.com/x0k/veterinary-clinic-backend/internal/appointment/use_case"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/module"
+	"github.com/x0k/veterinary-clinic-backend/internal/shared"
+	"gopkg.in/telebot.v3"
+)
+
+func NewNotificationPreferences(
+	bot *telebot.Bot,
+	notificationPreferencesUseCase *appointment_use_case.NotificationPreferencesUseCase[telegram_adapters.LocalizedTextResponses],
+) module.Hook {
+	return module.NewHook(
+		"appointment_telegram_controller.NewNotificationPreferences",
+		func(ctx context.Context) error {
+			handle := func(
+				command string,
+				update func(identity appointment.CustomerIdentity, args []string) (telegram_adapters.LocalizedTextResponses, error),
+			) {
+				bot.Handle(command, func(c telebot.Context) error {
+					identity, err := appointment.NewTelegramCustomerIdentity(
+						shared.NewTelegramUserId(c.Sender().ID),
+					)
+					if err != nil {
+						return err
+					}
+					res, err := update(identity, strings.Fields(c.Message().Payload))
+					if err != nil {
+						return err
+					}
+					return res.Send(c)
+				})
+			}
+			handle("/notifications", func(identity appointment.CustomerIdentity, _ []string) (telegram_adapters.LocalizedTextResponses, error) {
+				return notificationPreferencesUseCase.NotificationPreferences(ctx, identity)
+			})
+			handle("/notifications_mute", func(identity appointment.CustomerIdentity, args []string) (telegram_adapters.LocalizedTextResponses, error) {
+				return notificationPreferencesUseCase.SetMutedNotificationEvents(ctx, identity, args)
+			})
+			// Without arguments quiet hours are disabled
+			handle("/notifications_quiet", func(identity appointment.CustomerIdentity, args []string) (telegram_adapters.LocalizedTextResponses, error) {
+				var start, end string
+				if len(args) > 0 {
+					start = args[0]
+				}
+				if len(args) > 1 {
+					end = args[1]
+				}
+				return notificationPreferencesUseCase.SetQuietHours(ctx, identity, start, end)
+			})
+			return nil
+		},
+	)
+}
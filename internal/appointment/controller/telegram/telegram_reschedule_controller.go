@@ -0,0 +1,129 @@
+package appointment_telegram_controller
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	telegram_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/telegram"
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	appointment_telegram_adapters "github.com/x0k/veterinary-clinic-backend/internal/appointment/adapters/telegram"
+	appointment_use_case "github.com/x0k/veterinary-clinic-backend/internal/appointment/use_case"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/module"
+	"github.com/x0k/veterinary-clinic-backend/internal/shared"
+	"gopkg.in/telebot.v3"
+)
+
+const rescheduleOffersDays = 14
+
+func NewReschedule(
+	bot *telebot.Bot,
+	cancelPeriodUseCase *appointment_use_case.CancelPeriodUseCase[telegram_adapters.LocalizedTextResponses],
+	rescheduleOffersUseCase *appointment_use_case.RescheduleOffersUseCase[telegram_adapters.LocalizedTextResponses],
+	rescheduleOfferUseCase *appointment_use_case.RescheduleOfferUseCase[telegram_adapters.LocalizedTextResponses],
+) module.Hook {
+	return module.NewHook(
+		"appointment_telegram_controller.NewReschedule",
+		func(ctx context.Context) error {
+			cancelPeriodHandler := func(usageKey string, offerAlternatives bool) telebot.HandlerFunc {
+				return func(c telebot.Context) error {
+					identity, err := appointment.NewTelegramCustomerIdentity(
+						shared.NewTelegramUserId(c.Sender().ID),
+					)
+					if err != nil {
+						return err
+					}
+					reason, period, ok := parsePeriodPayload(c.Message().Payload)
+					if !ok || reason == "" {
+						return c.Send(telegram_adapters.ChatPrinter(c).Text(usageKey))
+					}
+					res, err := cancelPeriodUseCase.CancelPeriod(
+						ctx,
+						identity,
+						time.Now(),
+						period,
+						reason,
+						offerAlternatives,
+					)
+					if err != nil {
+						return err
+					}
+					return res.Send(c)
+				}
+			}
+			bot.Handle("/cancel_period", cancelPeriodHandler("usage.cancel_period", false))
+			bot.Handle("/reschedule_period", cancelPeriodHandler("usage.reschedule_period", true))
+
+			bot.Handle("/reschedules", func(c telebot.Context) error {
+				identity, err := appointment.NewTelegramCustomerIdentity(
+					shared.NewTelegramUserId(c.Sender().ID),
+				)
+				if err != nil {
+					return err
+				}
+				res, err := rescheduleOffersUseCase.RescheduleOffers(
+					ctx,
+					identity,
+					time.Now().AddDate(0, 0, -rescheduleOffersDays),
+				)
+				if err != nil {
+					return err
+				}
+				return res.Send(c)
+			})
+
+			bot.Handle(appointment_telegram_adapters.RescheduleSlotBtn, func(c telebot.Context) error {
+				args := c.Args()
+				if len(args) != 2 {
+					return c.Respond()
+				}
+				slotIndex, err := strconv.Atoi(args[1])
+				if err != nil {
+					return c.Respond()
+				}
+				identity, err := appointment.NewTelegramCustomerIdentity(
+					shared.NewTelegramUserId(c.Sender().ID),
+				)
+				if err != nil {
+					return err
+				}
+				isAccepted, res, err := rescheduleOfferUseCase.Accept(
+					ctx,
+					time.Now(),
+					identity,
+					appointment.NewRescheduleOfferId(args[0]),
+					slotIndex,
+				)
+				if err != nil {
+					return err
+				}
+				if isAccepted {
+					return res.Edit(c)
+				}
+				return res.Send(c)
+			})
+
+			bot.Handle(appointment_telegram_adapters.DeclineRescheduleBtn, func(c telebot.Context) error {
+				identity, err := appointment.NewTelegramCustomerIdentity(
+					shared.NewTelegramUserId(c.Sender().ID),
+				)
+				if err != nil {
+					return err
+				}
+				isDeclined, res, err := rescheduleOfferUseCase.Decline(
+					ctx,
+					identity,
+					appointment.NewRescheduleOfferId(c.Callback().Data),
+				)
+				if err != nil {
+					return err
+				}
+				if isDeclined {
+					return res.Edit(c)
+				}
+				return res.Send(c)
+			})
+			return nil
+		},
+	)
+}
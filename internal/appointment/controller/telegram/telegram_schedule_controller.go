@@ -13,7 +13,7 @@ import (
 
 func NewSchedule(
 	bot *telebot.Bot,
-	scheduleUseCase *appointment_use_case.ScheduleUseCase[telegram_adapters.TextResponses],
+	scheduleUseCase *appointment_use_case.ScheduleUseCase[telegram_adapters.LocalizedTextResponses],
 ) module.Hook {
 	return module.NewHook(
 		"appointment_telegram_controller.NewSchedule",
@@ -12,7 +12,7 @@ import (
 
 func NewServices(
 	bot *telebot.Bot,
-	servicesUseCase *appointment_use_case.ServicesUseCase[telegram_adapters.TextResponses],
+	servicesUseCase *appointment_use_case.ServicesUseCase[telegram_adapters.LocalizedTextResponses],
 ) module.Hook {
 	return module.NewHook(
 		"appointment_telegram_controller.NewServices", func(ctx context.Context) error {
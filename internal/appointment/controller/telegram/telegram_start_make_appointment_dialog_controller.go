@@ -16,9 +16,12 @@ import (
 func NewStartMakeAppointmentDialog(
 	bot *telebot.Bot,
 	tgUserIdLoader adapters.StatePopper[shared.TelegramUserId],
-	startMakeAppointmentDialogUseCase *appointment_telegram_use_case.StartMakeAppointmentDialogUseCase[telegram_adapters.TextResponses],
-	registerCustomerUseCase *appointment_telegram_use_case.RegisterCustomerUseCase[telegram_adapters.TextResponses],
+	startMakeAppointmentDialogUseCase *appointment_telegram_use_case.StartMakeAppointmentDialogUseCase[telegram_adapters.LocalizedTextResponses],
+	registerCustomerUseCase *appointment_telegram_use_case.RegisterCustomerUseCase[telegram_adapters.LocalizedTextResponses],
 	errorSender appointment_telegram_adapters.ErrorSender,
+	// Reply buttons are matched by text
+	cancelRegistrationTexts []string,
+	registrationCanceled telegram_adapters.LocalizedTextResponses,
 ) module.Hook {
 	return module.NewHook(
 		"appointment_telegram_controller.NewStartMakeAppointmentDialog",
@@ -57,11 +60,9 @@ func NewStartMakeAppointmentDialog(
 				}
 				return res.Send(c)
 			})
-			bot.Handle(appointment_telegram_adapters.CancelRegisterTelegramCustomerBtn, func(c telebot.Context) error {
-				return c.Send("Регистрация отменена", &telebot.SendOptions{
-					ReplyMarkup: &telebot.ReplyMarkup{RemoveKeyboard: true},
-				})
-			})
+			for _, text := range cancelRegistrationTexts {
+				bot.Handle(text, registrationCanceled.Send)
+			}
 			return nil
 		},
 	)
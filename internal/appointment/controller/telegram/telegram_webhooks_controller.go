@@ -0,0 +1,63 @@
+package appointment_telegram_controller
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	telegram_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/telegram"
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	appointment_use_case "github.com/x0k/veterinary-clinic-backend/internal/appointment/use_case"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/module"
+	"github.com/x0k/veterinary-clinic-backend/internal/shared"
+	"gopkg.in/telebot.v3"
+)
+
+func NewWebhooks(
+	bot *telebot.Bot,
+	webhookDeliveriesUseCase *appointment_use_case.WebhookDeliveriesUseCase[telegram_adapters.LocalizedTextResponses],
+) module.Hook {
+	return module.NewHook(
+		"appointment_telegram_controller.NewWebhooks",
+		func(ctx context.Context) error {
+			bot.Handle("/webhooks", func(c telebot.Context) error {
+				identity, err := appointment.NewTelegramCustomerIdentity(
+					shared.NewTelegramUserId(c.Sender().ID),
+				)
+				if err != nil {
+					return err
+				}
+				res, err := webhookDeliveriesUseCase.FailedDeliveries(ctx, identity)
+				if err != nil {
+					return err
+				}
+				return res.Send(c)
+			})
+
+			bot.Handle("/webhooks_replay", func(c telebot.Context) error {
+				identity, err := appointment.NewTelegramCustomerIdentity(
+					shared.NewTelegramUserId(c.Sender().ID),
+				)
+				if err != nil {
+					return err
+				}
+				fields := strings.Fields(c.Message().Payload)
+				ids := make([]appointment.WebhookDeliveryId, 0, len(fields))
+				for _, f := range fields {
+					id, err := strconv.ParseInt(f, 10, 64)
+					if err != nil {
+						return c.Send(telegram_adapters.ChatPrinter(c).Text("usage.webhooks_replay"))
+					}
+					ids = append(ids, appointment.WebhookDeliveryId(id))
+				}
+				res, err := webhookDeliveriesUseCase.Replay(ctx, identity, time.Now(), ids)
+				if err != nil {
+					return err
+				}
+				return res.Send(c)
+			})
+			return nil
+		},
+	)
+}
@@ -0,0 +1,32 @@
+package appointment_vk_controller
+
+import (
+	"context"
+
+	vk_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/vk"
+	appointment_telegram_use_case "github.com/x0k/veterinary-clinic-backend/internal/appointment/use_case/telegram"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/module"
+)
+
+func NewGreet(
+	bot *vk_adapters.Bot,
+	greetUseCase *appointment_telegram_use_case.GreetUseCase[vk_adapters.LocalizedTextResponses],
+) module.Hook {
+	return module.NewHook(
+		"appointment_vk_controller.NewGreet",
+		func(ctx context.Context) error {
+			greetHandler := func(c *vk_adapters.Context) error {
+				res, err := greetUseCase.Greet(ctx)
+				if err != nil {
+					return err
+				}
+				return res.Send(c)
+			}
+			bot.Handle("/start", greetHandler)
+			bot.Handle("начать", greetHandler)
+			bot.Handle(vk_adapters.StartCommand, greetHandler)
+			bot.Handle(vk_adapters.OnText, greetHandler)
+			return nil
+		},
+	)
+}
@@ -0,0 +1,17 @@
+package appointment_vk_controller
+
+import (
+	"strconv"
+
+	vk_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/vk"
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	"github.com/x0k/veterinary-clinic-backend/internal/shared"
+)
+
+func senderVkUserId(c *vk_adapters.Context) shared.VkUserId {
+	return shared.NewVkUserId(strconv.FormatInt(c.Sender(), 10))
+}
+
+func senderIdentity(c *vk_adapters.Context) (appointment.CustomerIdentity, error) {
+	return appointment.NewVkCustomerIdentity(senderVkUserId(c))
+}
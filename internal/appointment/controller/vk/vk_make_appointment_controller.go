@@ -0,0 +1,175 @@
+package appointment_vk_controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/adapters"
+	vk_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/vk"
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	appointment_vk_adapters "github.com/x0k/veterinary-clinic-backend/internal/appointment/adapters/vk"
+	appointment_use_case "github.com/x0k/veterinary-clinic-backend/internal/appointment/use_case"
+	appointment_telegram_use_case "github.com/x0k/veterinary-clinic-backend/internal/appointment/use_case/telegram"
+	appointment_vk_use_case "github.com/x0k/veterinary-clinic-backend/internal/appointment/use_case/vk"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/module"
+)
+
+func NewMakeAppointment(
+	bot *vk_adapters.Bot,
+	startMakeAppointmentDialogUseCase *appointment_vk_use_case.StartMakeAppointmentDialogUseCase[vk_adapters.LocalizedTextResponses],
+	appointmentDatePickerUseCase *appointment_telegram_use_case.AppointmentDatePickerUseCase[vk_adapters.LocalizedTextResponses],
+	appointmentTimePickerUseCase *appointment_telegram_use_case.AppointmentTimePickerUseCase[vk_adapters.LocalizedTextResponses],
+	appointmentConfirmationUseCase *appointment_telegram_use_case.AppointmentConfirmationUseCase[vk_adapters.LocalizedTextResponses],
+	makeAppointmentUseCase *appointment_use_case.MakeAppointmentUseCase[vk_adapters.LocalizedTextResponses],
+	cancelAppointmentUseCase *appointment_use_case.CancelAppointmentUseCase[vk_adapters.LocalizedEventResponse],
+	errorSender appointment_vk_adapters.ErrorSender,
+	serviceIdLoader adapters.StateLoader[appointment.ServiceId],
+	appointmentStateLoader adapters.StateLoader[appointment_vk_adapters.AppointmentState],
+) module.Hook {
+	return module.NewHook(
+		"appointment_vk_controller.NewMakeAppointment",
+		func(ctx context.Context) error {
+			bot.Handle(appointment_vk_adapters.MakeAppointmentServiceBtn.Command, func(c *vk_adapters.Context) error {
+				serviceId, ok := serviceIdLoader(adapters.NewStateId(c.Data()))
+				if !ok {
+					return errorSender.Send(c, appointment_vk_adapters.ErrUnknownState)
+				}
+				now := time.Now()
+				datePicker, err := appointmentDatePickerUseCase.DatePicker(ctx, serviceId, now, now)
+				if err != nil {
+					return err
+				}
+				return datePicker.Edit(c)
+			})
+
+			appointmentNextDatePickerHandler := func(c *vk_adapters.Context) error {
+				state, ok := appointmentStateLoader(adapters.NewStateId(c.Data()))
+				if !ok {
+					return errorSender.Send(c, appointment_vk_adapters.ErrUnknownState)
+				}
+				datePicker, err := appointmentDatePickerUseCase.DatePicker(
+					ctx,
+					state.ServiceId,
+					time.Now(),
+					state.Date,
+				)
+				if err != nil {
+					return err
+				}
+				return datePicker.Edit(c)
+			}
+			bot.Handle(appointment_vk_adapters.NextMakeAppointmentDateBtn.Command, appointmentNextDatePickerHandler)
+
+			bot.Handle(appointment_vk_adapters.CancelMakeAppointmentDateBtn.Command, func(c *vk_adapters.Context) error {
+				res, err := startMakeAppointmentDialogUseCase.StartMakeAppointmentDialog(
+					ctx,
+					senderVkUserId(c),
+				)
+				if err != nil {
+					return err
+				}
+				return res.Edit(c)
+			})
+
+			appointmentTimePickerHandler := func(c *vk_adapters.Context) error {
+				state, ok := appointmentStateLoader(adapters.NewStateId(c.Data()))
+				if !ok {
+					return errorSender.Send(c, appointment_vk_adapters.ErrUnknownState)
+				}
+				identity, err := senderIdentity(c)
+				if err != nil {
+					return err
+				}
+				timePicker, err := appointmentTimePickerUseCase.TimePicker(
+					ctx,
+					identity,
+					state.ServiceId,
+					time.Now(),
+					state.Date,
+				)
+				if err != nil {
+					return err
+				}
+				return timePicker.Edit(c)
+			}
+			bot.Handle(appointment_vk_adapters.SelectMakeAppointmentDateBtn.Command, appointmentTimePickerHandler)
+			bot.Handle(appointment_vk_adapters.MoreMakeAppointmentTimeBtn.Command, appointmentTimePickerHandler)
+
+			bot.Handle(appointment_vk_adapters.MakeAppointmentTimeBtn.Command, func(c *vk_adapters.Context) error {
+				state, ok := appointmentStateLoader(adapters.NewStateId(c.Data()))
+				if !ok {
+					return errorSender.Send(c, appointment_vk_adapters.ErrUnknownState)
+				}
+				identity, err := senderIdentity(c)
+				if err != nil {
+					return err
+				}
+				isHeld, res, err := appointmentTimePickerUseCase.HoldTime(
+					ctx,
+					identity,
+					state.ServiceId,
+					time.Now(),
+					state.Date,
+				)
+				if err != nil {
+					return err
+				}
+				if !isHeld {
+					return res.Send(c)
+				}
+				confirmation, err := appointmentConfirmationUseCase.Confirmation(ctx, state.ServiceId, state.Date)
+				if err != nil {
+					return err
+				}
+				return confirmation.Edit(c)
+			})
+
+			bot.Handle(appointment_vk_adapters.CancelMakeAppointmentTimeBtn.Command, appointmentNextDatePickerHandler)
+
+			bot.Handle(appointment_vk_adapters.ConfirmMakeAppointmentBtn.Command, func(c *vk_adapters.Context) error {
+				stateId := adapters.NewStateId(c.Data())
+				state, ok := appointmentStateLoader(stateId)
+				if !ok {
+					return errorSender.Send(c, appointment_vk_adapters.ErrUnknownState)
+				}
+				identity, err := senderIdentity(c)
+				if err != nil {
+					return err
+				}
+				app, err := makeAppointmentUseCase.CreateAppointment(
+					ctx,
+					time.Now(),
+					state.Date,
+					identity,
+					state.ServiceId,
+					appointment.NewIdempotencyKey(stateId.String()),
+				)
+				if err != nil {
+					return err
+				}
+				return app.Edit(c)
+			})
+
+			bot.Handle(appointment_vk_adapters.CancelConfirmationAppointmentBtn.Command, appointmentTimePickerHandler)
+
+			bot.Handle(appointment_vk_adapters.CancelAppointmentBtn.Command, func(c *vk_adapters.Context) error {
+				identity, err := senderIdentity(c)
+				if err != nil {
+					return err
+				}
+				isCanceled, res, err := cancelAppointmentUseCase.CancelAppointment(ctx, identity)
+				if err != nil {
+					return err
+				}
+				if isCanceled {
+					if err := c.Delete(); err != nil {
+						return err
+					}
+				}
+				return res.Send(c)
+			})
+
+			return nil
+		},
+	)
+}
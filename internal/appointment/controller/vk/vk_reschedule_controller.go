@@ -0,0 +1,73 @@
+package appointment_vk_controller
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	vk_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/vk"
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	appointment_vk_adapters "github.com/x0k/veterinary-clinic-backend/internal/appointment/adapters/vk"
+	appointment_use_case "github.com/x0k/veterinary-clinic-backend/internal/appointment/use_case"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/module"
+)
+
+func NewReschedule(
+	bot *vk_adapters.Bot,
+	rescheduleOfferUseCase *appointment_use_case.RescheduleOfferUseCase[vk_adapters.LocalizedTextResponses],
+) module.Hook {
+	return module.NewHook(
+		"appointment_vk_controller.NewReschedule",
+		func(ctx context.Context) error {
+			bot.Handle(appointment_vk_adapters.RescheduleSlotBtn.Command, func(c *vk_adapters.Context) error {
+				offerId, slot, ok := strings.Cut(c.Data(), "|")
+				if !ok {
+					return nil
+				}
+				slotIndex, err := strconv.Atoi(slot)
+				if err != nil {
+					return nil
+				}
+				identity, err := senderIdentity(c)
+				if err != nil {
+					return err
+				}
+				isAccepted, res, err := rescheduleOfferUseCase.Accept(
+					ctx,
+					time.Now(),
+					identity,
+					appointment.NewRescheduleOfferId(offerId),
+					slotIndex,
+				)
+				if err != nil {
+					return err
+				}
+				if isAccepted {
+					return res.Edit(c)
+				}
+				return res.Send(c)
+			})
+
+			bot.Handle(appointment_vk_adapters.DeclineRescheduleBtn.Command, func(c *vk_adapters.Context) error {
+				identity, err := senderIdentity(c)
+				if err != nil {
+					return err
+				}
+				isDeclined, res, err := rescheduleOfferUseCase.Decline(
+					ctx,
+					identity,
+					appointment.NewRescheduleOfferId(c.Data()),
+				)
+				if err != nil {
+					return err
+				}
+				if isDeclined {
+					return res.Edit(c)
+				}
+				return res.Send(c)
+			})
+			return nil
+		},
+	)
+}
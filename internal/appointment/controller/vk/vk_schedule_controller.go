@@ -0,0 +1,46 @@
+package appointment_vk_controller
+
+import (
+	"context"
+	"time"
+
+	vk_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/vk"
+	appointment_vk_adapters "github.com/x0k/veterinary-clinic-backend/internal/appointment/adapters/vk"
+	appointment_use_case "github.com/x0k/veterinary-clinic-backend/internal/appointment/use_case"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/module"
+)
+
+func NewSchedule(
+	bot *vk_adapters.Bot,
+	scheduleUseCase *appointment_use_case.ScheduleUseCase[vk_adapters.LocalizedTextResponses],
+) module.Hook {
+	return module.NewHook(
+		"appointment_vk_controller.NewSchedule",
+		func(ctx context.Context) error {
+			scheduleHandler := func(c *vk_adapters.Context) error {
+				now := time.Now()
+				res, err := scheduleUseCase.Schedule(ctx, now, now)
+				if err != nil {
+					return err
+				}
+				return res.Send(c)
+			}
+
+			bot.Handle("/schedule", scheduleHandler)
+			bot.Handle(appointment_vk_adapters.ScheduleBtn.Command, scheduleHandler)
+
+			bot.Handle(appointment_vk_adapters.NextScheduleBtn.Command, func(c *vk_adapters.Context) error {
+				date, err := time.Parse(time.DateOnly, c.Data())
+				if err != nil {
+					return err
+				}
+				res, err := scheduleUseCase.Schedule(ctx, time.Now(), date)
+				if err != nil {
+					return err
+				}
+				return res.Edit(c)
+			})
+			return nil
+		},
+	)
+}
@@ -0,0 +1,31 @@
+package appointment_vk_controller
+
+import (
+	"context"
+
+	vk_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/vk"
+	appointment_vk_adapters "github.com/x0k/veterinary-clinic-backend/internal/appointment/adapters/vk"
+	appointment_use_case "github.com/x0k/veterinary-clinic-backend/internal/appointment/use_case"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/module"
+)
+
+func NewServices(
+	bot *vk_adapters.Bot,
+	servicesUseCase *appointment_use_case.ServicesUseCase[vk_adapters.LocalizedTextResponses],
+) module.Hook {
+	return module.NewHook(
+		"appointment_vk_controller.NewServices",
+		func(ctx context.Context) error {
+			servicesHandler := func(c *vk_adapters.Context) error {
+				res, err := servicesUseCase.Services(ctx)
+				if err != nil {
+					return err
+				}
+				return res.Send(c)
+			}
+			bot.Handle("/services", servicesHandler)
+			bot.Handle(appointment_vk_adapters.ServicesBtn.Command, servicesHandler)
+			return nil
+		},
+	)
+}
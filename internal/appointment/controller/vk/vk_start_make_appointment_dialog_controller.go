@@ -0,0 +1,51 @@
+package appointment_vk_controller
+
+import (
+	"context"
+
+	vk_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/vk"
+	appointment_vk_adapters "github.com/x0k/veterinary-clinic-backend/internal/appointment/adapters/vk"
+	appointment_vk_use_case "github.com/x0k/veterinary-clinic-backend/internal/appointment/use_case/vk"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/module"
+)
+
+func NewStartMakeAppointmentDialog(
+	bot *vk_adapters.Bot,
+	startMakeAppointmentDialogUseCase *appointment_vk_use_case.StartMakeAppointmentDialogUseCase[vk_adapters.LocalizedTextResponses],
+	registerCustomerUseCase *appointment_vk_use_case.RegisterCustomerUseCase[vk_adapters.LocalizedTextResponses],
+) module.Hook {
+	return module.NewHook(
+		"appointment_vk_controller.NewStartMakeAppointmentDialog",
+		func(ctx context.Context) error {
+			startMakeAppointmentHandler := func(c *vk_adapters.Context) error {
+				res, err := startMakeAppointmentDialogUseCase.StartMakeAppointmentDialog(
+					ctx,
+					senderVkUserId(c),
+				)
+				if err != nil {
+					return err
+				}
+				return res.Send(c)
+			}
+			bot.Handle("/appointment", startMakeAppointmentHandler)
+			bot.Handle(appointment_vk_adapters.StartMakeAppointmentDialogBtn.Command, startMakeAppointmentHandler)
+			bot.Handle(appointment_vk_adapters.RegisterVkCustomerBtn.Command, func(c *vk_adapters.Context) error {
+				profile, err := c.Profile()
+				if err != nil {
+					return err
+				}
+				res, err := registerCustomerUseCase.RegisterCustomer(
+					ctx,
+					senderVkUserId(c),
+					profile.FirstName,
+					profile.LastName,
+				)
+				if err != nil {
+					return err
+				}
+				return res.Edit(c)
+			})
+			return nil
+		},
+	)
+}
@@ -3,6 +3,8 @@ package appointment
 import (
 	"errors"
 	"fmt"
+	"net/mail"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -62,6 +64,17 @@ func (identity CustomerIdentity) ToTelegramUserId() (shared.TelegramUserId, erro
 	return shared.NewTelegramUserId(id), nil
 }
 
+func (identity CustomerIdentity) ToVkUserId() (shared.VkUserId, error) {
+	tp, err := identity.Type()
+	if err != nil {
+		return "", err
+	}
+	if tp != VkIdentityType {
+		return "", ErrWrongCustomerIdentityType
+	}
+	return shared.NewVkUserId(strings.TrimPrefix(string(identity), VkIdentityType.String()+"-")), nil
+}
+
 func (identity CustomerIdentity) String() string {
 	return string(identity)
 }
@@ -94,6 +107,10 @@ type CustomerEntity struct {
 	Name        string
 	PhoneNumber string
 	Email       string
+	// Stored apart from the customer, see `CustomerNotificationPreferencesLoader`
+	NotificationPreferences NotificationPreferences
+	// Stored apart from the customer, see `CustomerLocaleLoader`
+	Locale CustomerLocale
 }
 
 func NewCustomer(
@@ -124,6 +141,118 @@ func (c *CustomerEntity) IdentityType() (CustomerIdentityType, error) {
 	return c.Identity.Type()
 }
 
+// Email may hold a profile link for customers registered by messengers
+func (c *CustomerEntity) EmailAddress() (mail.Address, bool) {
+	addr, err := mail.ParseAddress(c.Email)
+	if err != nil || addr.Address != c.Email {
+		return mail.Address{}, false
+	}
+	return mail.Address{Name: c.Name, Address: addr.Address}, true
+}
+
+// Phone number in the international format, e.g. +79991234567.
+// Numbers starting with 8 are treated as Russian ones.
+func (c *CustomerEntity) SmsPhoneNumber() (string, bool) {
+	digits := make([]byte, 0, len(c.PhoneNumber))
+	for i := 0; i < len(c.PhoneNumber); i++ {
+		ch := c.PhoneNumber[i]
+		switch {
+		case ch >= '0' && ch <= '9':
+			digits = append(digits, ch)
+		case ch == '+' && len(digits) == 0, ch == ' ', ch == '-', ch == '(', ch == ')':
+		default:
+			return "", false
+		}
+	}
+	if len(digits) == 11 && digits[0] == '8' {
+		digits[0] = '7'
+	}
+	if len(digits) < 10 || len(digits) > 15 {
+		return "", false
+	}
+	return "+" + string(digits), true
+}
+
+// Channels to notify the customer by, the chosen channels are used
+// when some of them can reach the customer. Web push is not included,
+// see `AcceptsWebPush`.
+func (c *CustomerEntity) NotificationChannels() []NotificationChannel {
+	preferred := make([]NotificationChannel, 0, len(c.NotificationPreferences.Channels))
+	for _, channel := range c.NotificationPreferences.Channels {
+		if channel != WebPushNotificationChannel && c.isReachableBy(channel) {
+			preferred = append(preferred, channel)
+		}
+	}
+	if len(preferred) > 0 || c.onlyWebPush() {
+		return preferred
+	}
+	channels := make([]NotificationChannel, 0, 2)
+	identityType, _ := c.IdentityType()
+	if messenger, ok := identityNotificationChannel(identityType); ok {
+		channels = append(channels, messenger)
+	}
+	if _, ok := c.EmailAddress(); ok {
+		channels = append(channels, EmailNotificationChannel)
+	}
+	// Older customers may be reachable only by phone
+	if len(channels) == 0 {
+		if _, ok := c.SmsPhoneNumber(); ok {
+			channels = append(channels, SmsNotificationChannel)
+		}
+	}
+	return channels
+}
+
+// Subscriptions of the devices are not known to the customer,
+// so web push is delivered unless the customer chose other channels
+func (c *CustomerEntity) AcceptsWebPush() bool {
+	return len(c.NotificationPreferences.Channels) == 0 ||
+		slices.Contains(c.NotificationPreferences.Channels, WebPushNotificationChannel)
+}
+
+func (c *CustomerEntity) onlyWebPush() bool {
+	return len(c.NotificationPreferences.Channels) == 1 &&
+		c.NotificationPreferences.Channels[0] == WebPushNotificationChannel
+}
+
+func (c *CustomerEntity) isReachableBy(channel NotificationChannel) bool {
+	switch channel {
+	case TelegramNotificationChannel, VkNotificationChannel:
+		identityType, _ := c.IdentityType()
+		messenger, ok := identityNotificationChannel(identityType)
+		return ok && messenger == channel
+	case EmailNotificationChannel:
+		_, ok := c.EmailAddress()
+		return ok
+	case SmsNotificationChannel:
+		_, ok := c.SmsPhoneNumber()
+		return ok
+	case WebPushNotificationChannel:
+		return true
+	default:
+		return false
+	}
+}
+
+// Empty `channels` restore the default ones
+func (c *CustomerEntity) SetNotificationChannels(channels []NotificationChannel) error {
+	for _, channel := range channels {
+		if !c.isReachableBy(channel) {
+			return fmt.Errorf("%w: %s", ErrUnreachableNotificationChannel, channel)
+		}
+	}
+	c.NotificationPreferences.Channels = channels
+	return nil
+}
+
+func (c *CustomerEntity) SetNotificationPreferences(preferences NotificationPreferences) error {
+	if err := c.SetNotificationChannels(preferences.Channels); err != nil {
+		return err
+	}
+	c.NotificationPreferences = preferences
+	return nil
+}
+
 func (c *CustomerEntity) Update(
 	name string,
 	phoneNumber string,
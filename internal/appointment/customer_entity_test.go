@@ -0,0 +1,88 @@
+package appointment
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCustomerEntityNotificationChannels(t *testing.T) {
+	tests := []struct {
+		name     string
+		customer CustomerEntity
+		want     []NotificationChannel
+	}{
+		{
+			name:     "Messenger profile link is not an email",
+			customer: CustomerEntity{Identity: "tg-1", Email: "https://t.me/user"},
+			want:     []NotificationChannel{TelegramNotificationChannel},
+		},
+		{
+			name:     "Email copy by default",
+			customer: CustomerEntity{Identity: "vk-1", Email: "user@example.com"},
+			want:     []NotificationChannel{VkNotificationChannel, EmailNotificationChannel},
+		},
+		{
+			name: "Preferred sms",
+			customer: CustomerEntity{
+				Identity:    "tg-1",
+				PhoneNumber: "8 (999) 123-45-67",
+				NotificationPreferences: NotificationPreferences{
+					Channels: []NotificationChannel{SmsNotificationChannel},
+				},
+			},
+			want: []NotificationChannel{SmsNotificationChannel},
+		},
+		{
+			name: "Unreachable preferred channel",
+			customer: CustomerEntity{
+				Identity: "tg-1",
+				NotificationPreferences: NotificationPreferences{
+					Channels: []NotificationChannel{VkNotificationChannel},
+				},
+			},
+			want: []NotificationChannel{TelegramNotificationChannel},
+		},
+		{
+			name: "Web push only",
+			customer: CustomerEntity{
+				Identity: "tg-1",
+				NotificationPreferences: NotificationPreferences{
+					Channels: []NotificationChannel{WebPushNotificationChannel},
+				},
+			},
+			want: []NotificationChannel{},
+		},
+		{
+			name:     "Phone only customer",
+			customer: CustomerEntity{Identity: "unknown", PhoneNumber: "+7 999 123 45 67"},
+			want:     []NotificationChannel{SmsNotificationChannel},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.customer.NotificationChannels(); !slices.Equal(got, tt.want) {
+				t.Errorf("NotificationChannels() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCustomerEntitySmsPhoneNumber(t *testing.T) {
+	tests := []struct {
+		phone  string
+		want   string
+		wantOk bool
+	}{
+		{"+7 (999) 123-45-67", "+79991234567", true},
+		{"89991234567", "+79991234567", true},
+		{"123", "", false},
+		{"https://t.me/user", "", false},
+	}
+	for _, tt := range tests {
+		c := CustomerEntity{PhoneNumber: tt.phone}
+		got, ok := c.SmsPhoneNumber()
+		if got != tt.want || ok != tt.wantOk {
+			t.Errorf("SmsPhoneNumber(%q) = %q, %v, want %q, %v", tt.phone, got, ok, tt.want, tt.wantOk)
+		}
+	}
+}
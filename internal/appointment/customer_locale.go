@@ -0,0 +1,35 @@
+package appointment
+
+import (
+	"errors"
+	"slices"
+)
+
+var ErrUnsupportedLocale = errors.New("unsupported locale")
+
+// Locales are primary subtags of IETF language tags
+type CustomerLocale struct {
+	// Chosen by the customer, takes precedence over the detected one
+	Preferred string
+	// Reported by the client of the messenger
+	Detected string
+}
+
+// Empty preferred locale restores the detection
+func NewPreferredLocale(locale string, supported []string) (string, error) {
+	if locale != "" && !slices.Contains(supported, locale) {
+		return "", ErrUnsupportedLocale
+	}
+	return locale, nil
+}
+
+func (l CustomerLocale) String() string {
+	if l.Preferred != "" {
+		return l.Preferred
+	}
+	return l.Detected
+}
+
+func (l CustomerLocale) IsZero() bool {
+	return l.Preferred == "" && l.Detected == ""
+}
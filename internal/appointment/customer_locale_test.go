@@ -0,0 +1,40 @@
+package appointment
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewPreferredLocale(t *testing.T) {
+	supported := []string{"ru", "en"}
+	tests := []struct {
+		name   string
+		locale string
+		want   string
+		err    error
+	}{
+		{"Supported", "en", "en", nil},
+		{"Restores detection", "", "", nil},
+		{"Unsupported", "de", "", ErrUnsupportedLocale},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewPreferredLocale(tt.locale, supported)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("NewPreferredLocale(%q) error = %v, want %v", tt.locale, err, tt.err)
+			}
+			if got != tt.want {
+				t.Errorf("NewPreferredLocale(%q) = %q, want %q", tt.locale, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCustomerLocaleString(t *testing.T) {
+	if got := (CustomerLocale{Preferred: "en", Detected: "ru"}).String(); got != "en" {
+		t.Errorf("preferred locale: got %q, want %q", got, "en")
+	}
+	if got := (CustomerLocale{Detected: "ru"}).String(); got != "ru" {
+		t.Errorf("detected locale: got %q, want %q", got, "ru")
+	}
+}
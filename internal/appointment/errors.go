@@ -3,3 +3,4 @@ package appointment
 import "errors"
 
 var ErrPeriodIsLocked = errors.New("periods is locked")
+var ErrForbidden = errors.New("forbidden")
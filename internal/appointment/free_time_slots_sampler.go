@@ -73,3 +73,12 @@ func NewSampleFreeTimeSlots(
 	}
 	return sampledPeriods
 }
+
+func (slots SampledFreeTimeSlots) HasSlotFor(durationInMinutes shared.DurationInMinutes) bool {
+	for _, s := range slots {
+		if shared.TimePeriodDurationInMinutes(s) >= durationInMinutes {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,39 @@
+package appointment
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrIdempotencyKeyIsReserved = errors.New("idempotency key is reserved")
+
+type IdempotencyKey string
+
+func NewIdempotencyKey(str string) IdempotencyKey {
+	return IdempotencyKey(str)
+}
+
+func (k IdempotencyKey) String() string {
+	return string(k)
+}
+
+func (k IdempotencyKey) IsEmpty() bool {
+	return k == ""
+}
+
+// Client keys are not globally unique (e.g. dialog state ids are reset
+// on restart), so the key is bound to the request it was issued for.
+func (k IdempotencyKey) Scoped(
+	customerIdentity CustomerIdentity,
+	serviceId ServiceId,
+	appointmentDate time.Time,
+) IdempotencyKey {
+	return IdempotencyKey(fmt.Sprintf(
+		"%s:%s:%s:%s",
+		customerIdentity,
+		serviceId,
+		appointmentDate.Format(time.RFC3339),
+		k,
+	))
+}
@@ -1,12 +1,16 @@
 package appointment_module
 
 import (
+	"errors"
+	"fmt"
+	"os"
 	"time"
 
 	"github.com/jomei/notionapi"
 	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
 	production_calendar_adapters "github.com/x0k/veterinary-clinic-backend/internal/appointment/adapters/production_calendar"
 	web_calendar_adapters "github.com/x0k/veterinary-clinic-backend/internal/appointment/adapters/web_calendar"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/pubsub"
 )
 
 type NotionConfig struct {
@@ -27,17 +31,113 @@ type WebCalendarConfig struct {
 	HandlerUrlRoot web_calendar_adapters.HandlerUrlRoot `yaml:"handler_url_root" env:"APPOINTMENT_WEB_CALENDAR_HANDLER_URL_ROOT" env-required:"true"`
 }
 
+// The API is not served when the handler address is empty.
+// Customer endpoints always require Telegram init data or VK launch params.
+type ApiConfig struct {
+	HandlerAddress string   `yaml:"handler_address" env:"APPOINTMENT_API_HANDLER_ADDRESS"`
+	AllowedOrigins []string `yaml:"allowed_origins" env:"APPOINTMENT_API_ALLOWED_ORIGINS" env-separator:","`
+}
+
 type SchedulingServiceConfig struct {
 	SampleRateInMinutes appointment.SampleRateInMinutes `yaml:"sample_rate_in_minutes" env:"APPOINTMENT_SCHEDULING_SERVICE_SAMPLE_RATE_IN_MINUTES" env-default:"30"`
+	SlotHoldTTL         time.Duration                   `yaml:"slot_hold_ttl" env:"APPOINTMENT_SCHEDULING_SERVICE_SLOT_HOLD_TTL" env-default:"5m"`
+	IdempotencyKeyTTL   time.Duration                   `yaml:"idempotency_key_ttl" env:"APPOINTMENT_SCHEDULING_SERVICE_IDEMPOTENCY_KEY_TTL" env-default:"24h"`
+}
+
+type DateTimePeriodLocksBackend string
+
+const (
+	MemoryDateTimePeriodLocksBackend DateTimePeriodLocksBackend = "memory"
+	SqliteDateTimePeriodLocksBackend DateTimePeriodLocksBackend = "sqlite"
+	FileDateTimePeriodLocksBackend   DateTimePeriodLocksBackend = "file"
+)
+
+type DateTimePeriodLocksConfig struct {
+	Backend  DateTimePeriodLocksBackend `yaml:"backend" env:"APPOINTMENT_DATE_TIME_PERIOD_LOCKS_BACKEND" env-default:"memory"`
+	Owner    string                     `yaml:"owner" env:"APPOINTMENT_DATE_TIME_PERIOD_LOCKS_OWNER"`
+	LeaseTTL time.Duration              `yaml:"lease_ttl" env:"APPOINTMENT_DATE_TIME_PERIOD_LOCKS_LEASE_TTL" env-default:"1m"`
+	FilePath string                     `yaml:"file_path" env:"APPOINTMENT_DATE_TIME_PERIOD_LOCKS_FILE_PATH" env-default:"./storage/locks.json"`
+}
+
+var ErrUnknownDateTimePeriodLocksBackend = errors.New("unknown date time period locks backend")
+
+func (c DateTimePeriodLocksConfig) LockOwner() string {
+	if c.Owner != "" {
+		return c.Owner
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	return fmt.Sprintf("%s-%d", hostname, os.Getpid())
+}
+
+type StaffSubscriptionConfig struct {
+	Topics              []appointment.StaffNotificationTopic `yaml:"topics"`
+	OwnAppointmentsOnly bool                                 `yaml:"own_appointments_only"`
 }
 
 type NotificationsConfig struct {
-	AdminIdentity appointment.CustomerIdentity `yaml:"admin_identity" env:"APPOINTMENT_NOTIFICATIONS_ADMIN_IDENTITY" env-required:"true"`
+	// Deprecated: use staff members with the owner role
+	AdminIdentity appointment.CustomerIdentity                      `yaml:"admin_identity" env:"APPOINTMENT_NOTIFICATIONS_ADMIN_IDENTITY"`
+	Subscriptions map[appointment.StaffRole]StaffSubscriptionConfig `yaml:"subscriptions"`
+}
+
+type StaffMemberConfig struct {
+	Identity   appointment.CustomerIdentity `yaml:"identity"`
+	Role       appointment.StaffRole        `yaml:"role"`
+	ServiceIds []appointment.ServiceId      `yaml:"service_ids"`
+}
+
+func (c *Config) StaffMembers() ([]appointment.StaffMember, error) {
+	members := make([]appointment.StaffMember, 0, len(c.Staff.Members)+1)
+	for _, m := range c.Staff.Members {
+		identity, err := appointment.NewCustomerIdentity(string(m.Identity))
+		if err != nil {
+			return nil, err
+		}
+		member, err := appointment.NewStaffMember(identity, m.Role, m.ServiceIds)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+	if c.Notifications.AdminIdentity != "" {
+		members = append(members, appointment.StaffMember{
+			Identity: c.Notifications.AdminIdentity,
+			Role:     appointment.OwnerStaffRole,
+		})
+	}
+	return members, nil
+}
+
+func (c *Config) StaffSubscriptions() (map[appointment.StaffRole]appointment.StaffSubscription, error) {
+	subscriptions := make(map[appointment.StaffRole]appointment.StaffSubscription, len(c.Notifications.Subscriptions))
+	for role, sub := range c.Notifications.Subscriptions {
+		if _, err := appointment.NewStaffRole(string(role)); err != nil {
+			return nil, err
+		}
+		for _, topic := range sub.Topics {
+			if _, err := appointment.NewStaffNotificationTopic(string(topic)); err != nil {
+				return nil, err
+			}
+		}
+		subscriptions[role] = appointment.StaffSubscription{
+			Topics:              sub.Topics,
+			OwnAppointmentsOnly: sub.OwnAppointmentsOnly,
+		}
+	}
+	return subscriptions, nil
+}
+
+type StaffConfig struct {
+	Members []StaffMemberConfig `yaml:"members"`
 }
 
 type TrackingServiceConfig struct {
-	StatePath        string        `yaml:"state_path" env:"APPOINTMENT_TRACKING_STATE_PATH" env-required:"true"`
 	TrackingInterval time.Duration `yaml:"tracking_interval" env:"APPOINTMENT_TRACKING_TRACKING_INTERVAL" env-default:"1m"`
+	// Between ticks only records edited since the previous one are loaded
+	ReconciliationInterval time.Duration `yaml:"reconciliation_interval" env:"APPOINTMENT_TRACKING_RECONCILIATION_INTERVAL" env-default:"30m"`
 }
 
 type ArchivingServiceConfig struct {
@@ -46,17 +146,82 @@ type ArchivingServiceConfig struct {
 	ArchivingMinute   int           `yaml:"archiving_minute" env:"APPOINTMENT_ARCHIVING_SERVICE_ARCHIVING_MINUTE" env-default:"0"`
 }
 
+type EventBusConfig struct {
+	QueueSize      int                   `yaml:"queue_size" env:"APPOINTMENT_EVENT_BUS_QUEUE_SIZE" env-default:"64"`
+	OverflowPolicy pubsub.OverflowPolicy `yaml:"overflow_policy" env:"APPOINTMENT_EVENT_BUS_OVERFLOW_POLICY" env-default:"block"`
+	SpillDir       string                `yaml:"spill_dir" env:"APPOINTMENT_EVENT_BUS_SPILL_DIR" env-default:"./storage/events_spill"`
+	DrainTimeout   time.Duration         `yaml:"drain_timeout" env:"APPOINTMENT_EVENT_BUS_DRAIN_TIMEOUT" env-default:"10s"`
+}
+
+type OutboxConfig struct {
+	PollInterval  time.Duration `yaml:"poll_interval" env:"APPOINTMENT_OUTBOX_POLL_INTERVAL" env-default:"5s"`
+	BatchSize     int           `yaml:"batch_size" env:"APPOINTMENT_OUTBOX_BATCH_SIZE" env-default:"50"`
+	MaxAttempts   int           `yaml:"max_attempts" env:"APPOINTMENT_OUTBOX_MAX_ATTEMPTS" env-default:"10"`
+	RetryBackoff  time.Duration `yaml:"retry_backoff" env:"APPOINTMENT_OUTBOX_RETRY_BACKOFF" env-default:"10s"`
+	PurgeInterval time.Duration `yaml:"purge_interval" env:"APPOINTMENT_OUTBOX_PURGE_INTERVAL" env-default:"1h"`
+	Retention     time.Duration `yaml:"retention" env:"APPOINTMENT_OUTBOX_RETENTION" env-default:"168h"`
+}
+
+type WebhooksConfig struct {
+	Urls         []string      `yaml:"urls" env:"APPOINTMENT_WEBHOOKS_URLS" env-separator:","`
+	Secret       string        `yaml:"secret" env:"APPOINTMENT_WEBHOOKS_SECRET"`
+	Timeout      time.Duration `yaml:"timeout" env:"APPOINTMENT_WEBHOOKS_TIMEOUT" env-default:"10s"`
+	PollInterval time.Duration `yaml:"poll_interval" env:"APPOINTMENT_WEBHOOKS_POLL_INTERVAL" env-default:"10s"`
+	BatchSize    int           `yaml:"batch_size" env:"APPOINTMENT_WEBHOOKS_BATCH_SIZE" env-default:"20"`
+	MaxAttempts  int           `yaml:"max_attempts" env:"APPOINTMENT_WEBHOOKS_MAX_ATTEMPTS" env-default:"8"`
+	RetryBackoff time.Duration `yaml:"retry_backoff" env:"APPOINTMENT_WEBHOOKS_RETRY_BACKOFF" env-default:"30s"`
+}
+
+var ErrWebhooksSecretRequired = errors.New("webhooks secret is required")
+
+type AuditLogConfig struct {
+	RecentLimit int `yaml:"recent_limit" env:"APPOINTMENT_AUDIT_LOG_RECENT_LIMIT" env-default:"20"`
+}
+
 type TelegramBotConfig struct {
 	CreateAppointment bool `yaml:"create_appointment" env:"APPOINTMENT_TELEGRAM_BOT_CREATE_APPOINTMENT"`
 }
 
+type EmailConfig struct {
+	ClinicName string `yaml:"clinic_name" env:"APPOINTMENT_EMAIL_CLINIC_NAME"`
+	Location   string `yaml:"location" env:"APPOINTMENT_EMAIL_LOCATION"`
+	// Reminders are sent this long before the appointment,
+	// the same schedule is used for the SMS, web push and VK reminders
+	ReminderLeadTime time.Duration `yaml:"reminder_lead_time" env:"APPOINTMENT_EMAIL_REMINDER_LEAD_TIME" env-default:"24h"`
+	ReminderInterval time.Duration `yaml:"reminder_interval" env:"APPOINTMENT_EMAIL_REMINDER_INTERVAL" env-default:"10m"`
+}
+
+type SmsConfig struct {
+	ClinicName string `yaml:"clinic_name" env:"APPOINTMENT_SMS_CLINIC_NAME"`
+}
+
+type WebPushConfig struct {
+	// Page of the web app opened by the notification click
+	Url string `yaml:"url" env:"APPOINTMENT_WEB_PUSH_URL"`
+}
+
+type VkBotConfig struct {
+	CreateAppointment bool `yaml:"create_appointment" env:"APPOINTMENT_VK_BOT_CREATE_APPOINTMENT"`
+}
+
 type Config struct {
-	Notion             NotionConfig             `yaml:"notion"`
-	ProductionCalendar ProductionCalendarConfig `yaml:"production_calendar"`
-	WebCalendar        WebCalendarConfig        `yaml:"web_calendar"`
-	SchedulingService  SchedulingServiceConfig  `yaml:"scheduling_service"`
-	Notifications      NotificationsConfig      `yaml:"notifications"`
-	TrackingService    TrackingServiceConfig    `yaml:"tracking_service"`
-	ArchivingService   ArchivingServiceConfig   `yaml:"archiving_service"`
-	TelegramBot        TelegramBotConfig        `yaml:"telegram_bot"`
+	Notion              NotionConfig              `yaml:"notion"`
+	ProductionCalendar  ProductionCalendarConfig  `yaml:"production_calendar"`
+	WebCalendar         WebCalendarConfig         `yaml:"web_calendar"`
+	Api                 ApiConfig                 `yaml:"api"`
+	SchedulingService   SchedulingServiceConfig   `yaml:"scheduling_service"`
+	DateTimePeriodLocks DateTimePeriodLocksConfig `yaml:"date_time_period_locks"`
+	Notifications       NotificationsConfig       `yaml:"notifications"`
+	TrackingService     TrackingServiceConfig     `yaml:"tracking_service"`
+	ArchivingService    ArchivingServiceConfig    `yaml:"archiving_service"`
+	EventBus            EventBusConfig            `yaml:"event_bus"`
+	Outbox              OutboxConfig              `yaml:"outbox"`
+	Webhooks            WebhooksConfig            `yaml:"webhooks"`
+	AuditLog            AuditLogConfig            `yaml:"audit_log"`
+	TelegramBot         TelegramBotConfig         `yaml:"telegram_bot"`
+	VkBot               VkBotConfig               `yaml:"vk_bot"`
+	Email               EmailConfig               `yaml:"email"`
+	Sms                 SmsConfig                 `yaml:"sms"`
+	WebPush             WebPushConfig             `yaml:"web_push"`
+	Staff               StaffConfig               `yaml:"staff"`
 }
@@ -1,8 +1,15 @@
 package appointment_module
 
 import (
+	"context"
 	"crypto/tls"
+	"database/sql"
+	"encoding/gob"
+	"expvar"
+	"fmt"
 	"net/http"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/jomei/notionapi"
@@ -10,39 +17,105 @@ import (
 	cache_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/cache"
 	adapters_cron "github.com/x0k/veterinary-clinic-backend/internal/adapters/cron"
 	http_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/http"
+	pubsub_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/pubsub"
+	sms_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/sms"
+	smtp_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/smtp"
 	telegram_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/telegram"
+	vk_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/vk"
+	webpush_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/webpush"
 	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	appointment_http_adapters "github.com/x0k/veterinary-clinic-backend/internal/appointment/adapters/http"
+	appointment_notification_adapters "github.com/x0k/veterinary-clinic-backend/internal/appointment/adapters/notification"
 	appointment_telegram_adapters "github.com/x0k/veterinary-clinic-backend/internal/appointment/adapters/telegram"
+	appointment_vk_adapters "github.com/x0k/veterinary-clinic-backend/internal/appointment/adapters/vk"
 	web_calendar_adapters "github.com/x0k/veterinary-clinic-backend/internal/appointment/adapters/web_calendar"
+	appointment_webhook_adapters "github.com/x0k/veterinary-clinic-backend/internal/appointment/adapters/webhook"
+	appointment_webpush_adapters "github.com/x0k/veterinary-clinic-backend/internal/appointment/adapters/webpush"
 	appointment_http_controller "github.com/x0k/veterinary-clinic-backend/internal/appointment/controller/http"
 	appointment_pubsub_controller "github.com/x0k/veterinary-clinic-backend/internal/appointment/controller/pubsub"
 	appointment_telegram_controller "github.com/x0k/veterinary-clinic-backend/internal/appointment/controller/telegram"
+	appointment_vk_controller "github.com/x0k/veterinary-clinic-backend/internal/appointment/controller/vk"
+	appointment_presenter "github.com/x0k/veterinary-clinic-backend/internal/appointment/presenter"
+	appointment_email_presenter "github.com/x0k/veterinary-clinic-backend/internal/appointment/presenter/email"
+	appointment_http_presenter "github.com/x0k/veterinary-clinic-backend/internal/appointment/presenter/http"
+	appointment_sms_presenter "github.com/x0k/veterinary-clinic-backend/internal/appointment/presenter/sms"
 	appointment_telegram_presenter "github.com/x0k/veterinary-clinic-backend/internal/appointment/presenter/telegram"
+	appointment_vk_presenter "github.com/x0k/veterinary-clinic-backend/internal/appointment/presenter/vk"
+	appointment_webpush_presenter "github.com/x0k/veterinary-clinic-backend/internal/appointment/presenter/webpush"
 	appointment_fs_repository "github.com/x0k/veterinary-clinic-backend/internal/appointment/repository/fs"
 	appointment_http_repository "github.com/x0k/veterinary-clinic-backend/internal/appointment/repository/http"
 	appointment_in_memory_repository "github.com/x0k/veterinary-clinic-backend/internal/appointment/repository/memory"
 	appointment_notion_repository "github.com/x0k/veterinary-clinic-backend/internal/appointment/repository/notion"
+	appointment_sqlite_repository "github.com/x0k/veterinary-clinic-backend/internal/appointment/repository/sqlite"
 	appointment_static_repository "github.com/x0k/veterinary-clinic-backend/internal/appointment/repository/static"
 	appointment_use_case "github.com/x0k/veterinary-clinic-backend/internal/appointment/use_case"
+	appointment_js_use_case "github.com/x0k/veterinary-clinic-backend/internal/appointment/use_case/js"
 	appointment_telegram_use_case "github.com/x0k/veterinary-clinic-backend/internal/appointment/use_case/telegram"
+	appointment_vk_use_case "github.com/x0k/veterinary-clinic-backend/internal/appointment/use_case/vk"
 	"github.com/x0k/veterinary-clinic-backend/internal/lib/cache/memory"
 	"github.com/x0k/veterinary-clinic-backend/internal/lib/loader"
 	"github.com/x0k/veterinary-clinic-backend/internal/lib/logger"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/logger/sl"
 	"github.com/x0k/veterinary-clinic-backend/internal/lib/module"
 	"github.com/x0k/veterinary-clinic-backend/internal/lib/pubsub"
 	"github.com/x0k/veterinary-clinic-backend/internal/shared"
 	"gopkg.in/telebot.v3"
 )
 
+// Quiet hours end at a whole minute
+const deferredNotificationsInterval = time.Minute
+
+var (
+	eventBusMetrics            atomic.Pointer[func() any]
+	publishEventBusMetricsOnce sync.Once
+)
+
+// Reports metrics of the latest module,
+// since expvar panics when a name is published twice
+func publishEventBusMetrics(metrics func() any) {
+	eventBusMetrics.Store(&metrics)
+	publishEventBusMetricsOnce.Do(func() {
+		expvar.Publish("appointment_event_bus", expvar.Func(func() any {
+			return (*eventBusMetrics.Load())()
+		}))
+	})
+}
+
 func New(
 	cfg *Config,
 	log *logger.Logger,
 	bot *telebot.Bot,
 	notion *notionapi.Client,
+	db *sql.DB,
 	telegramInitDataParser telegram_adapters.InitDataParser,
+	vkLaunchParamsParser vk_adapters.LaunchParamsParser,
+	vkBot *vk_adapters.Bot,
+	emailSender *smtp_adapters.Sender,
+	smsSender *sms_adapters.Sender,
+	webPushPusher *webpush_adapters.Pusher,
 ) (*module.Module, error) {
 	m := module.New(log.Logger, "appointment")
 
+	customerLocaleRepository := appointment_sqlite_repository.NewCustomerLocaleRepository(db)
+	customerLocaleUseCase := appointment_use_case.NewCustomerLocaleUseCase(
+		log,
+		appointment_presenter.SupportedLocales(),
+		customerLocaleRepository.CustomerLocale,
+		customerLocaleRepository.SaveCustomerLocale,
+		appointment_telegram_presenter.RenderCustomerLocale,
+		appointment_telegram_presenter.TextErrorPresenter,
+	)
+	telegramLocalizer := appointment_telegram_adapters.NewLocalizer(
+		customerLocaleUseCase.DetectLocale,
+		appointment_presenter.Printer,
+	)
+	customerLocaleController := appointment_telegram_controller.NewCustomerLocale(
+		bot,
+		telegramLocalizer,
+		customerLocaleUseCase,
+	)
+	m.PostStart(customerLocaleController)
+
 	greetPresenter := appointment_telegram_presenter.NewGreetingPresenter(
 		cfg.TelegramBot.CreateAppointment,
 	)
@@ -52,11 +125,47 @@ func New(
 		appointment_telegram_use_case.NewGreetUseCase(
 			greetPresenter.RenderGreeting,
 		),
-		cfg.TelegramBot.CreateAppointment,
+		appointment_telegram_presenter.BotCommands(cfg.TelegramBot.CreateAppointment),
 	)
 	m.PostStart(greetController)
 
-	publisher := pubsub.New[appointment.EventType]()
+	registerGobEvents()
+	bus, err := pubsub.NewAsync(pubsub.AsyncOptions[appointment.EventType]{
+		QueueSize:      cfg.EventBus.QueueSize,
+		OverflowPolicy: cfg.EventBus.OverflowPolicy,
+		SpillFactory: func() (pubsub.Spill[appointment.EventType], error) {
+			return pubsub.NewFileSpill(cfg.EventBus.SpillDir, pubsub.GobCodec[appointment.EventType]{})
+		},
+		OnError: func(err error) {
+			log.Error(context.Background(), "event bus error", sl.Err(err))
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	// Registered before subscriptions so queued events reach still active handlers
+	m.PreStop(module.NewHook(
+		"appointment_module.event_bus_drain",
+		func(ctx context.Context) error {
+			ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), cfg.EventBus.DrainTimeout)
+			defer cancel()
+			if err := bus.Drain(ctx); err != nil {
+				log.Error(ctx, "failed to drain event bus", sl.Err(err))
+			}
+			return nil
+		},
+	))
+	publishEventBusMetrics(func() any {
+		return bus.Metrics()
+	})
+	outboxRepository := appointment_sqlite_repository.NewOutboxRepository(db)
+	publisher := pubsub_adapters.NewOutboxPublisher(
+		bus,
+		func(event pubsub.Event[appointment.EventType]) error {
+			return outboxRepository.SaveEvent(context.Background(), event)
+		},
+		appointment.OutboxEventTypes...,
+	)
 
 	appointmentRepository := appointment_notion_repository.NewAppointment(
 		log,
@@ -129,27 +238,79 @@ func New(
 		cfg.Notion.BreaksDatabaseId,
 	)
 
+	workBreaksCache := cache_adapters.StartSimpleExpirableCache(
+		m, "appointment_module.work_breaks_cache",
+		memory.NewSimpleExpirable[appointment.WorkBreaks](time.Hour),
+	)
+
 	cachedWorkBreaks := appointment.WorkBreaksLoader(
 		loader.WithCache(
 			log, workBreaksRepository.WorkBreaks,
-			cache_adapters.StartSimpleExpirableCache(
-				m, "appointment_module.work_breaks_cache",
-				memory.NewSimpleExpirable[appointment.WorkBreaks](time.Hour),
-			),
+			workBreaksCache,
 		),
 	)
 
-	dateTimerPeriodLockRepository := appointment_in_memory_repository.NewDateTimePeriodLocksRepository()
+	inMemoryDateTimePeriodLocksRepository := appointment_in_memory_repository.NewDateTimePeriodLocksRepository()
+	var (
+		periodLocker     appointment.DateTimePeriodLocker   = inMemoryDateTimePeriodLocksRepository.Lock
+		periodUnLocker   appointment.DateTimePeriodUnLocker = inMemoryDateTimePeriodLocksRepository.UnLock
+		slotHolder       appointment.SlotHolder             = inMemoryDateTimePeriodLocksRepository.Hold
+		slotHoldReleaser appointment.SlotHoldReleaser       = inMemoryDateTimePeriodLocksRepository.Release
+		slotHoldsLoader  appointment.SlotHoldsLoader        = inMemoryDateTimePeriodLocksRepository.Holds
+	)
+	switch cfg.DateTimePeriodLocks.Backend {
+	case MemoryDateTimePeriodLocksBackend:
+	case SqliteDateTimePeriodLocksBackend:
+		sqliteDateTimePeriodLocksRepository := appointment_sqlite_repository.NewDateTimePeriodLocksRepository(
+			db,
+			cfg.DateTimePeriodLocks.LockOwner(),
+			cfg.DateTimePeriodLocks.LeaseTTL,
+		)
+		periodLocker = sqliteDateTimePeriodLocksRepository.Lock
+		periodUnLocker = sqliteDateTimePeriodLocksRepository.UnLock
+		slotHolder = sqliteDateTimePeriodLocksRepository.Hold
+		slotHoldReleaser = sqliteDateTimePeriodLocksRepository.Release
+		slotHoldsLoader = sqliteDateTimePeriodLocksRepository.Holds
+	case FileDateTimePeriodLocksBackend:
+		fsDateTimePeriodLocksRepository := appointment_fs_repository.NewDateTimePeriodLocksRepository(
+			cfg.DateTimePeriodLocks.FilePath,
+			cfg.DateTimePeriodLocks.LockOwner(),
+			cfg.DateTimePeriodLocks.LeaseTTL,
+		)
+		periodLocker = fsDateTimePeriodLocksRepository.Lock
+		periodUnLocker = fsDateTimePeriodLocksRepository.UnLock
+		slotHolder = fsDateTimePeriodLocksRepository.Hold
+		slotHoldReleaser = fsDateTimePeriodLocksRepository.Release
+		slotHoldsLoader = fsDateTimePeriodLocksRepository.Holds
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownDateTimePeriodLocksBackend, cfg.DateTimePeriodLocks.Backend)
+	}
+
+	idempotentRecordsRepository := appointment_sqlite_repository.NewIdempotentRecordsRepository(
+		db,
+		cfg.SchedulingService.IdempotencyKeyTTL,
+	)
+
+	auditLogRepository := appointment_sqlite_repository.NewAuditLogRepository(db)
+	notificationChannelsRepository := appointment_sqlite_repository.NewNotificationChannelsRepository(db)
+	notificationPreferencesRepository := appointment_sqlite_repository.NewNotificationPreferencesRepository(db)
+	webPushSubscriptionsRepository := appointment_sqlite_repository.NewWebPushSubscriptionsRepository(db)
 
 	schedulingService := appointment.NewSchedulingService(
 		log,
 		cfg.SchedulingService.SampleRateInMinutes,
-		dateTimerPeriodLockRepository.Lock,
-		dateTimerPeriodLockRepository.UnLock,
+		periodLocker,
+		periodUnLocker,
+		cfg.SchedulingService.SlotHoldTTL,
+		slotHolder,
+		slotHoldReleaser,
+		slotHoldsLoader,
 		appointmentRepository.CreateAppointment,
 		cachedProductionCalendar,
 		workingHoursRepository.WorkingHours,
 		appointmentRepository.BusyPeriods,
+		appointmentRepository.BusyPeriodsInRange,
+		cachedService,
 		cachedWorkBreaks,
 		appointmentRepository.CustomerActiveAppointment,
 		appointmentRepository.RemoveAppointment,
@@ -201,6 +362,7 @@ func New(
 		bot,
 		webCalendarAppOrigin,
 		telegramInitDataParser,
+		telegramLocalizer,
 		appointment_use_case.NewScheduleUseCase(
 			log,
 			schedulingService,
@@ -221,6 +383,7 @@ func New(
 		bot,
 		webCalendarAppOrigin,
 		telegramInitDataParser,
+		telegramLocalizer,
 		appointment_telegram_use_case.NewAppointmentDatePickerUseCase(
 			log,
 			schedulingService,
@@ -249,6 +412,32 @@ func New(
 		cfg.Notion.CustomersDatabaseId,
 	)
 
+	notificationChannelController := appointment_telegram_controller.NewNotificationChannel(
+		bot,
+		appointment_use_case.NewNotificationChannelUseCase(
+			log,
+			customerRepository.CustomerByIdentity,
+			notificationChannelsRepository.NotificationChannels,
+			notificationChannelsRepository.SaveNotificationChannels,
+			appointment_telegram_presenter.RenderNotificationChannels,
+			appointment_telegram_presenter.TextErrorPresenter,
+		),
+	)
+	m.PostStart(notificationChannelController)
+
+	notificationPreferencesController := appointment_telegram_controller.NewNotificationPreferences(
+		bot,
+		appointment_use_case.NewNotificationPreferencesUseCase(
+			log,
+			customerRepository.CustomerByIdentity,
+			notificationPreferencesRepository.NotificationPreferences,
+			notificationPreferencesRepository.SaveNotificationPreferences,
+			appointment_telegram_presenter.RenderNotificationPreferences,
+			appointment_telegram_presenter.TextErrorPresenter,
+		),
+	)
+	m.PostStart(notificationPreferencesController)
+
 	expirableServiceIdContainer := adapters.NewExpirableStateContainer[appointment.ServiceId](
 		"appointment_module.expirable_service_id_container",
 		uint64(time.Now().UnixNano()),
@@ -290,6 +479,10 @@ func New(
 		successRegistrationPresenter := appointment_telegram_presenter.NewSuccessRegistrationPresenter(
 			servicesPickerPresenter,
 		)
+		registrationCanceled, err := appointment_telegram_presenter.RenderRegistrationCanceled()
+		if err != nil {
+			return nil, err
+		}
 		startMakeAppointmentDialogController := appointment_telegram_controller.NewStartMakeAppointmentDialog(
 			bot,
 			expirableTelegramUserIdContainer.Pop,
@@ -302,6 +495,8 @@ func New(
 				appointment_telegram_presenter.TextErrorPresenter,
 			),
 			errorSender,
+			appointment_telegram_presenter.CancelRegistrationTexts(),
+			registrationCanceled,
 		)
 		m.PostStart(startMakeAppointmentDialogController)
 
@@ -343,6 +538,10 @@ func New(
 				schedulingService,
 				customerRepository.CustomerByIdentity,
 				cachedService,
+				idempotentRecordsRepository.Reserve,
+				idempotentRecordsRepository.Release,
+				idempotentRecordsRepository.SaveRecord,
+				auditLogRepository.SaveEntries,
 				appointment_telegram_presenter.RenderAppointmentInfo,
 				appointment_telegram_presenter.TextErrorPresenter,
 				publisher,
@@ -352,6 +551,7 @@ func New(
 				schedulingService,
 				customerRepository.CustomerByIdentity,
 				cachedService,
+				auditLogRepository.SaveEntries,
 				appointment_telegram_presenter.RenderAppointmentCancel,
 				appointment_telegram_presenter.CallbackErrorPresenter,
 				publisher,
@@ -363,56 +563,691 @@ func New(
 		m.PostStart(makeAppointmentController)
 	}
 
-	adminTgId, err := cfg.Notifications.AdminIdentity.ToTelegramUserId()
+	webPushPublicKey := ""
+	if webPushPusher != nil {
+		webPushPublicKey = webPushPusher.PublicKey()
+	}
+	if cfg.Api.HandlerAddress != "" {
+		apiServerMux := http.NewServeMux()
+		var apiHandler http.Handler = apiServerMux
+		if vkLaunchParamsParser != nil {
+			apiHandler = http_adapters.VkLaunchParamsAuth(
+				vkLaunchParamsParser,
+				appointment_http_adapters.VkCustomerIdentity,
+				apiHandler,
+			)
+		}
+		appointment_http_controller.UseApiRouter(
+			apiServerMux,
+			log,
+			appointment_use_case.NewScheduleUseCase(
+				log,
+				schedulingService,
+				appointment_http_presenter.SchedulePresenter,
+				appointment_http_presenter.ErrorPresenter,
+			),
+			appointment_js_use_case.NewDayOrNextWorkingDayUseCase(
+				log,
+				cachedProductionCalendar,
+				appointment_http_presenter.DayPresenter,
+				appointment_http_presenter.ErrorPresenter,
+			),
+			appointment_js_use_case.NewUpsertCustomerUseCase(
+				log,
+				customerRepository.CustomerByIdentity,
+				customerRepository.CreateCustomer,
+				customerRepository.UpdateCustomer,
+				appointment_http_presenter.CustomerPresenter,
+				appointment_http_presenter.ErrorPresenter,
+			),
+			appointment_js_use_case.NewFreeTimeSlotsUseCase(
+				log,
+				schedulingService,
+				cachedService,
+				appointment_http_presenter.FreeTimeSlotsPresenter,
+				appointment_http_presenter.ErrorPresenter,
+			),
+			appointment_js_use_case.NewActiveAppointmentUseCase(
+				log,
+				customerRepository.CustomerByIdentity,
+				appointmentRepository.CustomerActiveAppointment,
+				cachedService,
+				appointment_http_presenter.AppointmentInfoPresenter,
+				appointment_http_presenter.NotFoundPresenter,
+				appointment_http_presenter.ErrorPresenter,
+			),
+			appointment_use_case.NewMakeAppointmentUseCase(
+				log,
+				schedulingService,
+				customerRepository.CustomerByIdentity,
+				cachedService,
+				idempotentRecordsRepository.Reserve,
+				idempotentRecordsRepository.Release,
+				idempotentRecordsRepository.SaveRecord,
+				auditLogRepository.SaveEntries,
+				appointment_http_presenter.AppointmentInfoPresenter,
+				appointment_http_presenter.ErrorPresenter,
+				publisher,
+			),
+			appointment_use_case.NewCancelAppointmentUseCase(
+				log,
+				schedulingService,
+				customerRepository.CustomerByIdentity,
+				cachedService,
+				auditLogRepository.SaveEntries,
+				appointment_http_presenter.NoContentPresenter,
+				appointment_http_presenter.ErrorPresenter,
+				publisher,
+			),
+			appointment_use_case.NewServicesUseCase(
+				log,
+				cachedServices,
+				appointment_http_presenter.ServicesPresenter,
+				appointment_http_presenter.ErrorPresenter,
+			),
+			appointment_use_case.NewNotificationPreferencesUseCase(
+				log,
+				customerRepository.CustomerByIdentity,
+				notificationPreferencesRepository.NotificationPreferences,
+				notificationPreferencesRepository.SaveNotificationPreferences,
+				appointment_http_presenter.NotificationPreferencesPresenter,
+				appointment_http_presenter.ErrorPresenter,
+			),
+			appointment_use_case.NewWebPushSubscriptionUseCase(
+				log,
+				webPushPublicKey,
+				webPushSubscriptionsRepository.SaveSubscription,
+				webPushSubscriptionsRepository.RemoveSubscription,
+				appointment_http_presenter.WebPushPublicKeyPresenter,
+				appointment_http_presenter.NoContentPresenter,
+				appointment_http_presenter.ErrorPresenter,
+			),
+		)
+		m.Append(http_adapters.NewService(
+			"appointment_module.api_server",
+			&http.Server{
+				Addr: cfg.Api.HandlerAddress,
+				Handler: http_adapters.Logging(
+					log,
+					http_adapters.CORS(
+						cfg.Api.AllowedOrigins,
+						http_adapters.TelegramInitDataAuth(
+							telegramInitDataParser,
+							appointment_http_adapters.TelegramCustomerIdentity,
+							apiHandler,
+						),
+					),
+				),
+			},
+			m,
+		))
+	}
+
+	staffMembers, err := cfg.StaffMembers()
+	if err != nil {
+		return nil, err
+	}
+	staffSubscriptions, err := cfg.StaffSubscriptions()
 	if err != nil {
 		return nil, err
 	}
-	admin := &telebot.User{
-		ID: adminTgId.Int(),
+	staff, err := appointment.NewStaff(staffMembers, staffSubscriptions)
+	if err != nil {
+		return nil, err
 	}
-	telegramSender := telegram_adapters.NewSender(bot)
-	appointmentCreatedEventPresenter := appointment_telegram_presenter.NewAppointmentCreatedEventPresenter(
-		admin,
-	)
-	appointmentCanceledEventPresenter := appointment_telegram_presenter.NewAppointmentCanceledEventPresenter(
-		admin,
-	)
-	appointmentsStateRepository := appointment_fs_repository.NewAppointmentsStateRepository(
-		"appointment_module.appointments_state_repository",
-		cfg.TrackingService.StatePath,
+	workBreakCreator := func(ctx context.Context, title string, period shared.DateTimePeriod) error {
+		if err := workBreaksRepository.CreateWorkBreak(ctx, title, period); err != nil {
+			return err
+		}
+		workBreaks, err := workBreaksRepository.WorkBreaks(ctx)
+		if err != nil {
+			return err
+		}
+		return workBreaksCache.Add(ctx, workBreaks)
+	}
+	recordsService := appointment.NewRecordsService(
+		appointmentRepository.Record,
+		appointmentRepository.UpdateRecordStatus,
+		appointmentRepository.DayAppointments,
+		appointmentRepository.AppointmentsInPeriod,
+		customerRepository.CustomerById,
+		customerRepository.CustomersByPhone,
+		appointmentRepository.CustomerActiveAppointment,
+		cachedService,
 	)
-	m.Append(appointmentsStateRepository)
+	appointmentsStateRepository := appointment_sqlite_repository.NewAppointmentsStateRepository(db)
 	trackingService := appointment.NewTracking(
 		appointmentRepository.ActualAppointments,
+		appointmentRepository.EditedAppointments,
+		cfg.TrackingService.ReconciliationInterval,
 		appointmentsStateRepository.AppointmentsState,
 		appointmentsStateRepository.SaveAppointmentsState,
 	)
-	appointmentEventsController := appointment_pubsub_controller.NewAppointmentEvents(
+	changeRecordStatusUseCase := appointment_use_case.NewChangeRecordStatusUseCase(
+		log,
+		staff,
+		recordsService,
+		trackingService,
+		auditLogRepository.SaveEntries,
+		appointment_telegram_presenter.RenderRecordStatus,
+		appointment_telegram_presenter.CallbackErrorPresenter,
 		publisher,
-		appointment_use_case.NewSendAdminNotificationUseCase(
+	)
+	appointmentApprovalController := appointment_telegram_controller.NewAppointmentApproval(
+		bot,
+		changeRecordStatusUseCase,
+	)
+	m.PostStart(appointmentApprovalController)
+
+	textInputs := telegram_adapters.NewTextInputs()
+	bot.Handle(telebot.OnText, textInputs.Handle)
+
+	expirableCancelRecordIdContainer := adapters.NewExpirableStateContainer[appointment.RecordId](
+		"appointment_module.expirable_cancel_record_id_container",
+		uint64(time.Now().UnixNano()),
+		10*time.Minute,
+	)
+	m.Append(expirableCancelRecordIdContainer)
+
+	adminController := appointment_telegram_controller.NewAdmin(
+		bot,
+		appointment_use_case.NewDayAppointmentsUseCase(
 			log,
-			telegramSender.Send,
-			appointmentCreatedEventPresenter.Present,
-			appointmentCanceledEventPresenter.Present,
+			staff,
+			recordsService,
+			appointment_telegram_presenter.RenderDayAppointments,
+			appointment_telegram_presenter.TextErrorPresenter,
 		),
-		appointment_use_case.NewSendCustomerNotificationUseCase(
+		appointment_use_case.NewFindCustomersUseCase(
 			log,
-			customerRepository.CustomerById,
-			cachedService,
-			telegramSender.Send,
-			appointment_telegram_presenter.AppointmentChangedEventPresenter,
+			staff,
+			recordsService,
+			appointment_telegram_presenter.RenderCustomers,
+			appointment_telegram_presenter.TextErrorPresenter,
+		),
+		appointment_use_case.NewBlockPeriodUseCase(
+			log,
+			staff,
+			workBreakCreator,
+			appointment_telegram_presenter.RenderPeriodBlocked,
+			appointment_telegram_presenter.TextErrorPresenter,
 		),
-		appointment_use_case.NewUpdateAppointmentsStateUseCase(
+		changeRecordStatusUseCase,
+		appointment_use_case.NewChangeRecordStatusUseCase(
+			log,
+			staff,
+			recordsService,
+			trackingService,
+			auditLogRepository.SaveEntries,
+			appointment_telegram_presenter.RenderTextRecordStatus,
+			appointment_telegram_presenter.TextErrorPresenter,
+			publisher,
+		),
+		staff,
+		expirableCancelRecordIdContainer.SaveByKey,
+		expirableCancelRecordIdContainer.Pop,
+		textInputs,
+	)
+	m.PostStart(adminController)
+
+	rescheduleOffersRepository := appointment_sqlite_repository.NewRescheduleOffersRepository(db)
+	rescheduleController := appointment_telegram_controller.NewReschedule(
+		bot,
+		appointment_use_case.NewCancelPeriodUseCase(
 			log,
+			staff,
+			recordsService,
 			trackingService,
+			schedulingService,
+			workBreakCreator,
+			rescheduleOffersRepository.SaveRescheduleOffer,
+			auditLogRepository.SaveEntries,
+			appointment_telegram_presenter.RenderPeriodCanceled,
+			appointment_telegram_presenter.TextErrorPresenter,
+			publisher,
+		),
+		appointment_use_case.NewRescheduleOffersUseCase(
+			log,
+			staff,
+			recordsService,
+			rescheduleOffersRepository.RescheduleOffers,
+			appointment_telegram_presenter.RenderRescheduleOffers,
+			appointment_telegram_presenter.TextErrorPresenter,
+		),
+		appointment_use_case.NewRescheduleOfferUseCase(
+			log,
+			schedulingService,
+			customerRepository.CustomerByIdentity,
+			cachedService,
+			rescheduleOffersRepository.RescheduleOffer,
+			rescheduleOffersRepository.SaveRescheduleOffer,
+			rescheduleOffersRepository.SwapRescheduleOfferStatus,
+			auditLogRepository.SaveEntries,
+			appointment_telegram_presenter.RenderAppointmentInfo,
+			appointment_telegram_presenter.RenderRescheduleDeclined,
+			appointment_telegram_presenter.TextErrorPresenter,
+			publisher,
+		),
+	)
+	m.PostStart(rescheduleController)
+
+	auditController := appointment_telegram_controller.NewAudit(
+		bot,
+		appointment_use_case.NewAuditLogUseCase(
+			log,
+			staff,
+			cfg.AuditLog.RecentLimit,
+			auditLogRepository.RecentEntries,
+			auditLogRepository.RecordEntries,
+			appointment_telegram_presenter.RenderAuditEntries,
+			appointment_telegram_presenter.TextErrorPresenter,
+		),
+		appointment_use_case.NewExportAuditLogUseCase(
+			log,
+			staff,
+			auditLogRepository.EntriesInPeriod,
+			appointment_telegram_presenter.RenderAuditExport,
+			appointment_telegram_presenter.ResponseErrorPresenter,
+		),
+	)
+	m.PostStart(auditController)
+
+	if len(cfg.Webhooks.Urls) > 0 && cfg.Webhooks.Secret == "" {
+		return nil, ErrWebhooksSecretRequired
+	}
+	webhookDeliveriesRepository := appointment_sqlite_repository.NewWebhookDeliveriesRepository(db)
+	webhooksController := appointment_telegram_controller.NewWebhooks(
+		bot,
+		appointment_use_case.NewWebhookDeliveriesUseCase(
+			log,
+			staff,
+			webhookDeliveriesRepository.FailedDeliveries,
+			webhookDeliveriesRepository.Replay,
+			appointment_telegram_presenter.RenderFailedWebhookDeliveries,
+			appointment_telegram_presenter.RenderWebhookDeliveriesReplayed,
+			appointment_telegram_presenter.TextErrorPresenter,
 		),
+	)
+	m.PostStart(webhooksController)
+	webhookSender := appointment_webhook_adapters.NewSender(
+		&http.Client{Timeout: cfg.Webhooks.Timeout},
+		cfg.Webhooks.Secret,
+	)
+	deliverWebhooksUseCase := appointment_use_case.NewDeliverWebhooksUseCase(
+		log,
+		cfg.Webhooks.BatchSize,
+		cfg.Webhooks.MaxAttempts,
+		cfg.Webhooks.RetryBackoff,
+		webhookDeliveriesRepository.PendingDeliveries,
+		webhookSender.Send,
+		webhookDeliveriesRepository.Complete,
+		webhookDeliveriesRepository.Retry,
+		webhookDeliveriesRepository.Fail,
+	)
+	m.Append(adapters_cron.NewTask(
+		"appointment_module.deliver_webhooks_cron_task",
+		cfg.Webhooks.PollInterval,
+		deliverWebhooksUseCase.Deliver,
+	))
+
+	var vkSender shared.Sender[vk_adapters.Message]
+	var vkChangedEventPresenter appointment.ChangedEventPresenter[vk_adapters.Message]
+	var vkStatusTransitionPresenter appointment.StatusTransitionPresenter[vk_adapters.Message]
+	var vkRescheduleOfferPresenter appointment.RescheduleOfferPresenter[vk_adapters.Message]
+	var vkReminderPresenter appointment.ReminderPresenter[vk_adapters.Message]
+	if vkBot != nil {
+		vkSender = vk_adapters.NewSender(vkBot.Client()).Send
+		vkChangedEventPresenter = appointment_vk_presenter.AppointmentChangedEventPresenter
+		vkStatusTransitionPresenter = appointment_vk_presenter.AppointmentStatusTransitionPresenter
+		vkRescheduleOfferPresenter = appointment_vk_presenter.RescheduleOfferPresenter
+		vkReminderPresenter = appointment_vk_presenter.AppointmentReminderPresenter
+
+		vkBot.Use(appointment_vk_adapters.NewLocalizer(
+			customerLocaleUseCase.DetectLocale,
+			appointment_presenter.Printer,
+		).Middleware)
+
+		vkGreetPresenter := appointment_vk_presenter.NewGreetingPresenter(
+			cfg.VkBot.CreateAppointment,
+		)
+		m.PostStart(appointment_vk_controller.NewGreet(
+			vkBot,
+			appointment_telegram_use_case.NewGreetUseCase(
+				vkGreetPresenter.RenderGreeting,
+			),
+		))
+		m.PostStart(appointment_vk_controller.NewServices(
+			vkBot,
+			appointment_use_case.NewServicesUseCase(
+				log,
+				cachedServices,
+				appointment_vk_presenter.ServicesPresenter,
+				appointment_vk_presenter.TextErrorPresenter,
+			),
+		))
+		m.PostStart(appointment_vk_controller.NewSchedule(
+			vkBot,
+			appointment_use_case.NewScheduleUseCase(
+				log,
+				schedulingService,
+				appointment_vk_presenter.RenderSchedule,
+				appointment_vk_presenter.TextErrorPresenter,
+			),
+		))
+
+		rescheduleOfferUseCase := appointment_use_case.NewRescheduleOfferUseCase(
+			log,
+			schedulingService,
+			customerRepository.CustomerByIdentity,
+			cachedService,
+			rescheduleOffersRepository.RescheduleOffer,
+			rescheduleOffersRepository.SaveRescheduleOffer,
+			rescheduleOffersRepository.SwapRescheduleOfferStatus,
+			auditLogRepository.SaveEntries,
+			appointment_vk_presenter.RenderAppointmentInfo,
+			appointment_vk_presenter.RenderRescheduleDeclined,
+			appointment_vk_presenter.TextErrorPresenter,
+			publisher,
+		)
+		m.PostStart(appointment_vk_controller.NewReschedule(
+			vkBot,
+			rescheduleOfferUseCase,
+		))
+
+		if cfg.VkBot.CreateAppointment {
+			vkAppointmentStateContainer := adapters.NewExpirableStateContainer[appointment_vk_adapters.AppointmentState](
+				"appointment_module.vk_appointment_state_container",
+				uint64(time.Now().UnixNano()),
+				10*time.Minute,
+			)
+			m.Append(vkAppointmentStateContainer)
+
+			vkServicesPickerPresenter := appointment_vk_presenter.NewServicesPickerPresenter(
+				expirableServiceIdContainer.Save,
+			)
+			vkStartMakeAppointmentDialogUseCase := appointment_vk_use_case.NewStartMakeAppointmentDialogUseCase(
+				log,
+				customerRepository.CustomerByIdentity,
+				appointmentRepository.CustomerActiveAppointment,
+				cachedServices,
+				cachedService,
+				appointment_vk_presenter.RenderAppointmentInfo,
+				vkServicesPickerPresenter.RenderServicesList,
+				appointment_vk_presenter.RenderRegistration,
+				appointment_vk_presenter.TextErrorPresenter,
+			)
+			vkSuccessRegistrationPresenter := appointment_vk_presenter.NewSuccessRegistrationPresenter(
+				vkServicesPickerPresenter,
+			)
+			m.PostStart(appointment_vk_controller.NewStartMakeAppointmentDialog(
+				vkBot,
+				vkStartMakeAppointmentDialogUseCase,
+				appointment_vk_use_case.NewRegisterCustomerUseCase(
+					log,
+					customerRepository.CreateCustomer,
+					cachedServices,
+					vkSuccessRegistrationPresenter.RenderSuccessRegistration,
+					appointment_vk_presenter.TextErrorPresenter,
+				),
+			))
+
+			vkDatePickerPresenter := appointment_vk_presenter.NewDatePickerPresenter(
+				vkAppointmentStateContainer.Save,
+			)
+			vkTimePickerPresenter := appointment_vk_presenter.NewTimePickerPresenter(
+				vkAppointmentStateContainer.Save,
+			)
+			vkConfirmationPresenter := appointment_vk_presenter.NewConfirmationPresenter(
+				vkAppointmentStateContainer.Save,
+			)
+			m.PostStart(appointment_vk_controller.NewMakeAppointment(
+				vkBot,
+				vkStartMakeAppointmentDialogUseCase,
+				appointment_telegram_use_case.NewAppointmentDatePickerUseCase(
+					log,
+					schedulingService,
+					vkDatePickerPresenter.RenderDatePicker,
+					appointment_vk_presenter.TextErrorPresenter,
+				),
+				appointment_telegram_use_case.NewAppointmentTimePickerUseCase(
+					log,
+					schedulingService,
+					cachedService,
+					vkTimePickerPresenter.RenderTimePicker,
+					appointment_vk_presenter.TextErrorPresenter,
+				),
+				appointment_telegram_use_case.NewAppointmentConfirmationUseCase(
+					log,
+					cachedService,
+					vkConfirmationPresenter.RenderConfirmation,
+					appointment_vk_presenter.TextErrorPresenter,
+				),
+				appointment_use_case.NewMakeAppointmentUseCase(
+					log,
+					schedulingService,
+					customerRepository.CustomerByIdentity,
+					cachedService,
+					idempotentRecordsRepository.Reserve,
+					idempotentRecordsRepository.Release,
+					idempotentRecordsRepository.SaveRecord,
+					auditLogRepository.SaveEntries,
+					appointment_vk_presenter.RenderAppointmentInfo,
+					appointment_vk_presenter.TextErrorPresenter,
+					publisher,
+				),
+				appointment_use_case.NewCancelAppointmentUseCase(
+					log,
+					schedulingService,
+					customerRepository.CustomerByIdentity,
+					cachedService,
+					auditLogRepository.SaveEntries,
+					appointment_vk_presenter.RenderAppointmentCancel,
+					appointment_vk_presenter.EventErrorPresenter,
+					publisher,
+				),
+				appointment_vk_adapters.NewErrorSender(
+					appointment_vk_presenter.TextErrorPresenter,
+				),
+				expirableServiceIdContainer.Load,
+				vkAppointmentStateContainer.Load,
+			))
+		}
+	}
+
+	var notificationEmailSender shared.Sender[*smtp_adapters.Message]
+	var emailChangedEventPresenter appointment.ChangedEventPresenter[*smtp_adapters.Message]
+	var emailStatusTransitionPresenter appointment.StatusTransitionPresenter[*smtp_adapters.Message]
+	var emailCreatedEventPresenter appointment.CreatedEventPresenter[*smtp_adapters.Message]
+	var emailCanceledEventPresenter appointment.CanceledEventPresenter[*smtp_adapters.Message]
+	var emailReminderPresenter appointment.ReminderPresenter[*smtp_adapters.Message]
+	if emailSender != nil {
+		notificationEmailSender = appointment_notification_adapters.EmailSender(emailSender.Send)
+		emailPresenter := appointment_email_presenter.NewNotificationPresenter(
+			cfg.Email.ClinicName,
+			cfg.Email.Location,
+		)
+		emailChangedEventPresenter = emailPresenter.RenderChanged
+		emailStatusTransitionPresenter = emailPresenter.RenderStatusTransition
+		emailCreatedEventPresenter = emailPresenter.RenderCreated
+		emailCanceledEventPresenter = emailPresenter.RenderCanceled
+		emailReminderPresenter = emailPresenter.RenderReminder
+	}
+
+	var notificationSmsSender shared.Sender[*sms_adapters.Message]
+	var smsChangedEventPresenter appointment.ChangedEventPresenter[*sms_adapters.Message]
+	var smsStatusTransitionPresenter appointment.StatusTransitionPresenter[*sms_adapters.Message]
+	var smsCreatedEventPresenter appointment.CreatedEventPresenter[*sms_adapters.Message]
+	var smsCanceledEventPresenter appointment.CanceledEventPresenter[*sms_adapters.Message]
+	var smsReminderPresenter appointment.ReminderPresenter[*sms_adapters.Message]
+	if smsSender != nil {
+		notificationSmsSender = appointment_notification_adapters.SmsSender(smsSender.Send)
+		smsPresenter := appointment_sms_presenter.NewNotificationPresenter(
+			cfg.Sms.ClinicName,
+		)
+		smsChangedEventPresenter = smsPresenter.RenderChanged
+		smsStatusTransitionPresenter = smsPresenter.RenderStatusTransition
+		smsCreatedEventPresenter = smsPresenter.RenderCreated
+		smsCanceledEventPresenter = smsPresenter.RenderCanceled
+		smsReminderPresenter = smsPresenter.RenderReminder
+	}
+
+	var notificationWebPushSender shared.Sender[*appointment_webpush_adapters.Message]
+	var webPushChangedEventPresenter appointment.ChangedEventPresenter[*appointment_webpush_adapters.Message]
+	var webPushStatusTransitionPresenter appointment.StatusTransitionPresenter[*appointment_webpush_adapters.Message]
+	var webPushCreatedEventPresenter appointment.CreatedEventPresenter[*appointment_webpush_adapters.Message]
+	var webPushCanceledEventPresenter appointment.CanceledEventPresenter[*appointment_webpush_adapters.Message]
+	var webPushReminderPresenter appointment.ReminderPresenter[*appointment_webpush_adapters.Message]
+	if webPushPusher != nil {
+		notificationWebPushSender = appointment_webpush_adapters.NewSender(
+			webPushSubscriptionsRepository.Subscriptions,
+			webPushSubscriptionsRepository.RemoveSubscription,
+			webPushPusher,
+		).Send
+		webPushPresenter := appointment_webpush_presenter.NewNotificationPresenter(
+			cfg.WebPush.Url,
+		)
+		webPushChangedEventPresenter = webPushPresenter.RenderChanged
+		webPushStatusTransitionPresenter = webPushPresenter.RenderStatusTransition
+		webPushCreatedEventPresenter = webPushPresenter.RenderCreated
+		webPushCanceledEventPresenter = webPushPresenter.RenderCanceled
+		webPushReminderPresenter = webPushPresenter.RenderReminder
+	}
+
+	telegramSender := telegram_adapters.NewSender(bot)
+	notificationSender := appointment_notification_adapters.NewSender(
+		telegramSender.Send,
+		vkSender,
+		notificationEmailSender,
+		notificationSmsSender,
+		notificationWebPushSender,
+	)
+	deferredNotificationsRepository := appointment_sqlite_repository.NewDeferredNotificationsRepository(db)
+	notificationDeferrer := appointment_notification_adapters.NewDeferrer(
+		log,
+		notificationSender,
+		deferredNotificationsRepository.SaveNotification,
+		deferredNotificationsRepository.DueNotifications,
+		deferredNotificationsRepository.RemoveNotification,
+	)
+	m.Append(adapters_cron.NewTask(
+		"appointment_module.send_deferred_notifications_cron_task",
+		deferredNotificationsInterval,
+		notificationDeferrer.SendDue,
+	))
+	sendCustomerNotificationUseCase := appointment_use_case.NewSendCustomerNotificationUseCase(
+		log,
+		customerRepository.CustomerById,
+		appointment.NewNotificationDispatcher(
+			notificationPreferencesRepository.NotificationPreferences,
+			customerLocaleRepository.CustomerLocale,
+		),
+		cachedService,
+		notificationSender.Send,
+		notificationDeferrer.Defer,
+		appointment_notification_adapters.ChangedEventPresenter(
+			appointment_telegram_presenter.AppointmentChangedEventPresenter,
+			vkChangedEventPresenter,
+			emailChangedEventPresenter,
+			smsChangedEventPresenter,
+			webPushChangedEventPresenter,
+		),
+		appointment_notification_adapters.StatusTransitionPresenter(
+			appointment_telegram_presenter.AppointmentStatusTransitionPresenter,
+			vkStatusTransitionPresenter,
+			emailStatusTransitionPresenter,
+			smsStatusTransitionPresenter,
+			webPushStatusTransitionPresenter,
+		),
+		appointment_notification_adapters.RescheduleOfferPresenter(
+			appointment_telegram_presenter.RescheduleOfferPresenter,
+			vkRescheduleOfferPresenter,
+		),
+		appointment_notification_adapters.CreatedEventPresenter(
+			emailCreatedEventPresenter,
+			smsCreatedEventPresenter,
+			webPushCreatedEventPresenter,
+		),
+		appointment_notification_adapters.CanceledEventPresenter(
+			emailCanceledEventPresenter,
+			smsCanceledEventPresenter,
+			webPushCanceledEventPresenter,
+		),
+		appointment_notification_adapters.ReminderPresenter(
+			vkReminderPresenter,
+			emailReminderPresenter,
+			smsReminderPresenter,
+			webPushReminderPresenter,
+		),
+	)
+	if vkBot != nil || emailSender != nil || smsSender != nil || webPushPusher != nil {
+		sendRemindersUseCase := appointment_use_case.NewSendRemindersUseCase(
+			log,
+			cfg.Email.ReminderLeadTime,
+			cfg.Email.ReminderInterval,
+			appointmentRepository.ActualAppointments,
+			sendCustomerNotificationUseCase,
+		)
+		m.Append(adapters_cron.NewTask(
+			"appointment_module.send_reminders_cron_task",
+			cfg.Email.ReminderInterval,
+			sendRemindersUseCase.SendReminders,
+		))
+	}
+	updateAppointmentsStateUseCase := appointment_use_case.NewUpdateAppointmentsStateUseCase(
+		log,
+		trackingService,
+	)
+	appointmentEventsController := appointment_pubsub_controller.NewAppointmentEvents(
+		bus,
+		sendCustomerNotificationUseCase,
 		m,
 	)
 	m.Append(appointmentEventsController)
 
+	deliverOutboxEventsUseCase := appointment_use_case.NewDeliverOutboxEventsUseCase(
+		log,
+		cfg.Outbox.BatchSize,
+		cfg.Outbox.MaxAttempts,
+		cfg.Outbox.RetryBackoff,
+		cfg.Outbox.Retention,
+		outboxRepository.Entries,
+		outboxRepository.Complete,
+		outboxRepository.Retry,
+		outboxRepository.Fail,
+		outboxRepository.Purge,
+		appointment_pubsub_controller.NewOutboxEventHandler(
+			appointment_use_case.NewSendStaffNotificationUseCase(
+				log,
+				staff,
+				telegramSender.Send,
+				appointment_telegram_presenter.AppointmentCreatedEventPresenter,
+				appointment_telegram_presenter.AppointmentCanceledEventPresenter,
+			),
+			sendCustomerNotificationUseCase,
+			updateAppointmentsStateUseCase,
+			appointment_use_case.NewEnqueueWebhooksUseCase(
+				log,
+				cfg.Webhooks.Urls,
+				appointment_webhook_adapters.EncodeEvent,
+				webhookDeliveriesRepository.SaveDeliveries,
+			),
+		),
+	)
+	m.Append(adapters_cron.NewTask(
+		"appointment_module.deliver_outbox_events_cron_task",
+		cfg.Outbox.PollInterval,
+		deliverOutboxEventsUseCase.Deliver,
+	))
+	m.Append(adapters_cron.NewTask(
+		"appointment_module.purge_outbox_cron_task",
+		cfg.Outbox.PurgeInterval,
+		deliverOutboxEventsUseCase.Purge,
+	))
+
 	detectChangesUseCase := appointment_use_case.NewDetectChangesUseCase(
 		log,
 		trackingService,
+		auditLogRepository.SaveEntries,
 		publisher,
 	)
 	detectChangesCronTask := adapters_cron.NewTask(
@@ -437,3 +1272,19 @@ func New(
 
 	return m, nil
 }
+
+func registerGobEvents() {
+	gob.Register(appointment.CreatedEvent{})
+	gob.Register(appointment.CanceledEvent{})
+	gob.Register(appointment.ChangedEvent{})
+	gob.Register(appointment.ConfirmedEvent{})
+	gob.Register(appointment.CheckedInEvent{})
+	gob.Register(appointment.InProgressEvent{})
+	gob.Register(appointment.CompletedEvent{})
+	gob.Register(appointment.NotAppearedEvent{})
+	gob.Register(appointment.CanceledByClinicEvent{})
+	gob.Register(appointment.RescheduledEvent{})
+	gob.Register(appointment.ApprovedEvent{})
+	gob.Register(appointment.DeclinedEvent{})
+	gob.Register(appointment.RescheduleOfferedEvent{})
+}
@@ -11,7 +11,9 @@ import (
 )
 
 type SchedulingServiceConfig struct {
-	SampleRateInMinutes appointment.SampleRateInMinutes `js:"sampleRateInMinutes"`
+	SampleRateInMinutes        appointment.SampleRateInMinutes `js:"sampleRateInMinutes"`
+	SlotHoldTTLInMinutes       int                             `js:"slotHoldTtlInMinutes"`
+	IdempotencyKeyTTLInMinutes int                             `js:"idempotencyKeyTtlInMinutes"`
 }
 
 type ProductionCalendarRepositoryConfig struct {
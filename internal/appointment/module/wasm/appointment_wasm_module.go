@@ -6,6 +6,7 @@ import (
 	"context"
 	"net/http"
 	"syscall/js"
+	"time"
 
 	"github.com/jomei/notionapi"
 	js_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/js"
@@ -16,6 +17,7 @@ import (
 	appointment_js_presenter "github.com/x0k/veterinary-clinic-backend/internal/appointment/presenter/js"
 	appointment_http_repository "github.com/x0k/veterinary-clinic-backend/internal/appointment/repository/http"
 	appointment_js_repository "github.com/x0k/veterinary-clinic-backend/internal/appointment/repository/js"
+	appointment_in_memory_repository "github.com/x0k/veterinary-clinic-backend/internal/appointment/repository/memory"
 	appointment_notion_repository "github.com/x0k/veterinary-clinic-backend/internal/appointment/repository/notion"
 	appointment_static_repository "github.com/x0k/veterinary-clinic-backend/internal/appointment/repository/static"
 	appointment_use_case "github.com/x0k/veterinary-clinic-backend/internal/appointment/use_case"
@@ -35,6 +37,10 @@ func New(
 	m := js_adapters.ObjectConstructor.New()
 
 	publisher := pubsub_adapters.NewNullPublisher[appointment.EventType]()
+	// The server records these changes when it detects them in the records storage
+	auditEntriesSaver := func(context.Context, []appointment.AuditEntry) error {
+		return nil
+	}
 
 	// Schedule controller
 	appointmentRepository := appointment_notion_repository.NewAppointment(
@@ -125,15 +131,29 @@ func New(
 		cfg.DateTimeLocksRepository,
 	)
 
+	idempotencyKeyTTL := time.Duration(cfg.SchedulingService.IdempotencyKeyTTLInMinutes) * time.Minute
+	if idempotencyKeyTTL <= 0 {
+		idempotencyKeyTTL = 24 * time.Hour
+	}
+	idempotentRecordsRepository := appointment_in_memory_repository.NewIdempotentRecordsRepository(
+		idempotencyKeyTTL,
+	)
+
 	schedulingService := appointment.NewSchedulingService(
 		log,
 		cfg.SchedulingService.SampleRateInMinutes,
 		dateTimerPeriodLockRepository.Lock,
 		dateTimerPeriodLockRepository.UnLock,
+		time.Duration(cfg.SchedulingService.SlotHoldTTLInMinutes)*time.Minute,
+		dateTimerPeriodLockRepository.Hold,
+		dateTimerPeriodLockRepository.Release,
+		dateTimerPeriodLockRepository.Holds,
 		appointmentRepository.CreateAppointment,
 		cachedProductionCalendar,
 		workingHoursRepository.WorkingHours,
 		appointmentRepository.BusyPeriods,
+		appointmentRepository.BusyPeriodsInRange,
+		cachedService,
 		cachedWorkBreaks,
 		appointmentRepository.CustomerActiveAppointment,
 		appointmentRepository.RemoveAppointment,
@@ -187,6 +207,10 @@ func New(
 			schedulingService,
 			customerRepository.CustomerByIdentity,
 			cachedService,
+			idempotentRecordsRepository.Reserve,
+			idempotentRecordsRepository.Release,
+			idempotentRecordsRepository.SaveRecord,
+			auditEntriesSaver,
 			appointment_js_presenter.AppointmentInfoPresenter,
 			appointment_js_presenter.ErrorPresenter,
 			publisher,
@@ -196,6 +220,7 @@ func New(
 			schedulingService,
 			customerRepository.CustomerByIdentity,
 			cachedService,
+			auditEntriesSaver,
 			appointment_js_presenter.OkPresenter,
 			appointment_js_presenter.ErrorPresenter,
 			publisher,
@@ -206,6 +231,19 @@ func New(
 			appointment_js_presenter.ServicesPresenter,
 			appointment_js_presenter.ErrorPresenter,
 		),
+		appointment_use_case.NewAvailabilityUseCase(
+			log,
+			schedulingService,
+			appointment_js_presenter.AvailabilityPresenter,
+			appointment_js_presenter.ErrorPresenter,
+		),
+		appointment_js_use_case.NewHoldSlotUseCase(
+			log,
+			schedulingService,
+			cachedService,
+			appointment_js_presenter.OkPresenter,
+			appointment_js_presenter.ErrorPresenter,
+		),
 	)
 	return m
 }
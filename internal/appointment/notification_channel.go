@@ -0,0 +1,72 @@
+package appointment
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+var ErrUnknownNotificationChannel = errors.New("unknown notification channel")
+var ErrUnreachableNotificationChannel = errors.New("customer is unreachable by the notification channel")
+
+type NotificationChannel string
+
+const (
+	TelegramNotificationChannel NotificationChannel = "telegram"
+	VkNotificationChannel       NotificationChannel = "vk"
+	EmailNotificationChannel    NotificationChannel = "email"
+	SmsNotificationChannel      NotificationChannel = "sms"
+	// Devices subscribed in the web app
+	WebPushNotificationChannel NotificationChannel = "web_push"
+)
+
+var NotificationChannels = []NotificationChannel{
+	TelegramNotificationChannel,
+	VkNotificationChannel,
+	EmailNotificationChannel,
+	SmsNotificationChannel,
+	WebPushNotificationChannel,
+}
+
+func NewNotificationChannel(str string) (NotificationChannel, error) {
+	channel := NotificationChannel(str)
+	if slices.Contains(NotificationChannels, channel) {
+		return channel, nil
+	}
+	return "", fmt.Errorf("%w: %s", ErrUnknownNotificationChannel, str)
+}
+
+// Duplicates are dropped
+func NewNotificationChannels(strs []string) ([]NotificationChannel, error) {
+	channels := make([]NotificationChannel, 0, len(strs))
+	for _, str := range strs {
+		channel, err := NewNotificationChannel(str)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(channels, channel) {
+			channels = append(channels, channel)
+		}
+	}
+	return channels, nil
+}
+
+func (c NotificationChannel) String() string {
+	return string(c)
+}
+
+// VK messages and SMS can not be delivered without a sound
+func (c NotificationChannel) CanBeSilent() bool {
+	return c != VkNotificationChannel && c != SmsNotificationChannel
+}
+
+func identityNotificationChannel(identityType CustomerIdentityType) (NotificationChannel, bool) {
+	switch identityType {
+	case TelegramIdentityType:
+		return TelegramNotificationChannel, true
+	case VkIdentityType:
+		return VkNotificationChannel, true
+	default:
+		return "", false
+	}
+}
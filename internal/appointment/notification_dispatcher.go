@@ -0,0 +1,109 @@
+package appointment
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/shared"
+)
+
+type NotificationDelivery struct {
+	Channels []NotificationChannel
+	// Notification should not make a sound
+	Silent bool
+	// Channels which should be used after the quiet hours
+	Deferred      []NotificationChannel
+	DeferredUntil time.Time
+}
+
+func (d NotificationDelivery) Includes(channel NotificationChannel) bool {
+	return slices.Contains(d.Channels, channel)
+}
+
+// Sends the rendered notification by the channels of the delivery only
+type CustomerNotificationSender[R any] func(ctx context.Context, notification R, delivery NotificationDelivery) error
+
+// Sends the rendered notification by the channels of the delivery at the given time
+type CustomerNotificationDeferrer[R any] func(ctx context.Context, notification R, delivery NotificationDelivery, at time.Time) error
+
+type DeferredNotificationId int64
+
+// Serialized notification which waits for the end of the quiet hours
+type DeferredNotification struct {
+	Id       DeferredNotificationId
+	Payload  []byte
+	Channels []NotificationChannel
+	SendAt   time.Time
+}
+
+// Applies preferences of the customer to notifications
+type NotificationDispatcher struct {
+	preferencesLoader CustomerNotificationPreferencesLoader
+	localeLoader      CustomerLocaleLoader
+}
+
+func NewNotificationDispatcher(
+	preferencesLoader CustomerNotificationPreferencesLoader,
+	localeLoader CustomerLocaleLoader,
+) *NotificationDispatcher {
+	return &NotificationDispatcher{
+		preferencesLoader: preferencesLoader,
+		localeLoader:      localeLoader,
+	}
+}
+
+// Loads preferences and the locale into the customer and plans the delivery.
+// Returns false when the customer muted the event or no channel is left.
+func (d *NotificationDispatcher) Dispatch(
+	ctx context.Context,
+	customer *CustomerEntity,
+	event NotificationEvent,
+	now time.Time,
+) (NotificationDelivery, bool, error) {
+	preferences, err := d.preferencesLoader(ctx, customer.Id)
+	if err != nil {
+		return NotificationDelivery{}, false, err
+	}
+	customer.NotificationPreferences = preferences
+	if customer.Locale, err = d.localeLoader(ctx, customer.Identity); err != nil {
+		return NotificationDelivery{}, false, err
+	}
+	delivery := PlanNotificationDelivery(*customer, event, now)
+	return delivery, len(delivery.Channels) > 0 || len(delivery.Deferred) > 0, nil
+}
+
+// During quiet hours channels which can not be silent are deferred
+// until the end of them
+func PlanNotificationDelivery(
+	customer CustomerEntity,
+	event NotificationEvent,
+	now time.Time,
+) NotificationDelivery {
+	preferences := customer.NotificationPreferences
+	if preferences.IsMuted(event) {
+		return NotificationDelivery{}
+	}
+	channels := customer.NotificationChannels()
+	if customer.AcceptsWebPush() {
+		channels = append(channels, WebPushNotificationChannel)
+	}
+	if !preferences.QuietHours.Contains(shared.GoTimeToTime(now)) {
+		return NotificationDelivery{Channels: channels}
+	}
+	delivery := NotificationDelivery{
+		Channels: make([]NotificationChannel, 0, len(channels)),
+		Silent:   true,
+	}
+	for _, c := range channels {
+		if c.CanBeSilent() {
+			delivery.Channels = append(delivery.Channels, c)
+		} else {
+			delivery.Deferred = append(delivery.Deferred, c)
+		}
+	}
+	if len(delivery.Deferred) > 0 {
+		delivery.DeferredUntil = preferences.QuietHours.EndAfter(now)
+	}
+	return delivery
+}
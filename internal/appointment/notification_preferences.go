@@ -0,0 +1,127 @@
+package appointment
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/shared"
+)
+
+var ErrUnknownNotificationEvent = errors.New("unknown notification event")
+var ErrInvalidQuietHours = errors.New("invalid quiet hours")
+
+type NotificationEvent string
+
+const (
+	CreatedNotificationEvent  NotificationEvent = "created"
+	CanceledNotificationEvent NotificationEvent = "canceled"
+	// Date, time or removal of the record changed in the calendar
+	ChangedNotificationEvent         NotificationEvent = "changed"
+	StatusNotificationEvent          NotificationEvent = "status"
+	RescheduleOfferNotificationEvent NotificationEvent = "reschedule_offer"
+	ReminderNotificationEvent        NotificationEvent = "reminder"
+)
+
+var NotificationEvents = []NotificationEvent{
+	CreatedNotificationEvent,
+	CanceledNotificationEvent,
+	ChangedNotificationEvent,
+	StatusNotificationEvent,
+	RescheduleOfferNotificationEvent,
+	ReminderNotificationEvent,
+}
+
+func NewNotificationEvent(str string) (NotificationEvent, error) {
+	event := NotificationEvent(str)
+	if slices.Contains(NotificationEvents, event) {
+		return event, nil
+	}
+	return "", fmt.Errorf("%w: %s", ErrUnknownNotificationEvent, str)
+}
+
+// Duplicates are dropped
+func NewNotificationEvents(strs []string) ([]NotificationEvent, error) {
+	events := make([]NotificationEvent, 0, len(strs))
+	for _, str := range strs {
+		event, err := NewNotificationEvent(str)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(events, event) {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+func (e NotificationEvent) String() string {
+	return string(e)
+}
+
+// Period of the day without sound notifications, it may cross midnight.
+// The zero value disables quiet hours.
+type QuietHours struct {
+	Start shared.Time
+	End   shared.Time
+}
+
+// Times are in the `15:04` format, both empty disable quiet hours
+func NewQuietHours(start string, end string) (QuietHours, error) {
+	if start == "" && end == "" {
+		return QuietHours{}, nil
+	}
+	startTime, err := time.Parse("15:04", start)
+	if err != nil {
+		return QuietHours{}, fmt.Errorf("%w: %w", ErrInvalidQuietHours, err)
+	}
+	endTime, err := time.Parse("15:04", end)
+	if err != nil {
+		return QuietHours{}, fmt.Errorf("%w: %w", ErrInvalidQuietHours, err)
+	}
+	q := QuietHours{
+		Start: shared.GoTimeToTime(startTime),
+		End:   shared.GoTimeToTime(endTime),
+	}
+	if q.Start == q.End {
+		return QuietHours{}, fmt.Errorf("%w: empty period", ErrInvalidQuietHours)
+	}
+	return q, nil
+}
+
+func (q QuietHours) IsZero() bool {
+	return q.Start == q.End
+}
+
+func (q QuietHours) Contains(t shared.Time) bool {
+	if q.IsZero() {
+		return false
+	}
+	afterStart := shared.CompareTime(t, q.Start) >= 0
+	beforeEnd := shared.CompareTime(t, q.End) < 0
+	if shared.CompareTime(q.Start, q.End) < 0 {
+		return afterStart && beforeEnd
+	}
+	return afterStart || beforeEnd
+}
+
+// Returns the nearest end of quiet hours after `now`
+func (q QuietHours) EndAfter(now time.Time) time.Time {
+	end := time.Date(now.Year(), now.Month(), now.Day(), q.End.Hours, q.End.Minutes, 0, 0, now.Location())
+	if !end.After(now) {
+		end = end.AddDate(0, 0, 1)
+	}
+	return end
+}
+
+type NotificationPreferences struct {
+	// Channels chosen by the customer, empty means the default ones
+	Channels    []NotificationChannel
+	MutedEvents []NotificationEvent
+	QuietHours  QuietHours
+}
+
+func (p NotificationPreferences) IsMuted(event NotificationEvent) bool {
+	return slices.Contains(p.MutedEvents, event)
+}
@@ -0,0 +1,156 @@
+package appointment
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/shared"
+)
+
+func TestQuietHoursContains(t *testing.T) {
+	night, err := NewQuietHours("22:00", "08:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	day, err := NewQuietHours("13:00", "14:30")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name       string
+		quietHours QuietHours
+		time       shared.Time
+		want       bool
+	}{
+		{"Before midnight", night, shared.Time{Hours: 23}, true},
+		{"After midnight", night, shared.Time{Hours: 7, Minutes: 59}, true},
+		{"End is excluded", night, shared.Time{Hours: 8}, false},
+		{"Day time", night, shared.Time{Hours: 12}, false},
+		{"Within a day period", day, shared.Time{Hours: 14}, true},
+		{"Outside of a day period", day, shared.Time{Hours: 15}, false},
+		{"Disabled", QuietHours{}, shared.Time{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.quietHours.Contains(tt.time); got != tt.want {
+				t.Errorf("Contains(%s) = %v, want %v", tt.time, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlanNotificationDelivery(t *testing.T) {
+	quietHours, err := NewQuietHours("22:00", "08:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	customer := CustomerEntity{
+		Identity:    "vk-1",
+		Email:       "user@example.com",
+		PhoneNumber: "+79991234567",
+		NotificationPreferences: NotificationPreferences{
+			Channels: []NotificationChannel{
+				VkNotificationChannel,
+				SmsNotificationChannel,
+				EmailNotificationChannel,
+			},
+			MutedEvents: []NotificationEvent{ReminderNotificationEvent},
+			QuietHours:  quietHours,
+		},
+	}
+	day := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	delivery := PlanNotificationDelivery(customer, StatusNotificationEvent, day)
+	if delivery.Silent || !slices.Equal(delivery.Channels, customer.NotificationPreferences.Channels) {
+		t.Errorf("day delivery = %v", delivery)
+	}
+	if delivery := PlanNotificationDelivery(customer, ReminderNotificationEvent, day); len(delivery.Channels) != 0 {
+		t.Errorf("muted event delivery = %v", delivery)
+	}
+}
+
+func TestPlanNotificationDeliveryDuringQuietHours(t *testing.T) {
+	quietHours, err := NewQuietHours("22:00", "08:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	customer := func(channels ...NotificationChannel) CustomerEntity {
+		return CustomerEntity{
+			Identity:    "vk-1",
+			Email:       "user@example.com",
+			PhoneNumber: "+79991234567",
+			NotificationPreferences: NotificationPreferences{
+				Channels:   channels,
+				QuietHours: quietHours,
+			},
+		}
+	}
+	morning := time.Date(2024, 5, 2, 8, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name         string
+		customer     CustomerEntity
+		now          time.Time
+		wantChannels []NotificationChannel
+		wantDeferred []NotificationChannel
+	}{
+		{
+			"Before midnight",
+			customer(VkNotificationChannel, SmsNotificationChannel, EmailNotificationChannel),
+			time.Date(2024, 5, 1, 23, 0, 0, 0, time.UTC),
+			[]NotificationChannel{EmailNotificationChannel},
+			[]NotificationChannel{VkNotificationChannel, SmsNotificationChannel},
+		},
+		{
+			"After midnight",
+			customer(SmsNotificationChannel),
+			time.Date(2024, 5, 2, 7, 59, 0, 0, time.UTC),
+			[]NotificationChannel{},
+			[]NotificationChannel{SmsNotificationChannel},
+		},
+		{
+			"Only silent channels",
+			customer(EmailNotificationChannel),
+			time.Date(2024, 5, 1, 23, 0, 0, 0, time.UTC),
+			[]NotificationChannel{EmailNotificationChannel},
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delivery := PlanNotificationDelivery(tt.customer, StatusNotificationEvent, tt.now)
+			if !delivery.Silent {
+				t.Errorf("delivery is not silent")
+			}
+			if !slices.Equal(delivery.Channels, tt.wantChannels) {
+				t.Errorf("channels = %v, want %v", delivery.Channels, tt.wantChannels)
+			}
+			if !slices.Equal(delivery.Deferred, tt.wantDeferred) {
+				t.Errorf("deferred = %v, want %v", delivery.Deferred, tt.wantDeferred)
+			}
+			if len(tt.wantDeferred) > 0 && !delivery.DeferredUntil.Equal(morning) {
+				t.Errorf("deferred until %s, want %s", delivery.DeferredUntil, morning)
+			}
+		})
+	}
+}
+
+func TestQuietHoursEndAfter(t *testing.T) {
+	quietHours, err := NewQuietHours("22:00", "08:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		now  time.Time
+		want time.Time
+	}{
+		{time.Date(2024, 5, 1, 23, 0, 0, 0, time.UTC), time.Date(2024, 5, 2, 8, 0, 0, 0, time.UTC)},
+		{time.Date(2024, 5, 2, 1, 0, 0, 0, time.UTC), time.Date(2024, 5, 2, 8, 0, 0, 0, time.UTC)},
+		{time.Date(2024, 5, 2, 8, 0, 0, 0, time.UTC), time.Date(2024, 5, 3, 8, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		if got := quietHours.EndAfter(tt.now); !got.Equal(tt.want) {
+			t.Errorf("EndAfter(%s) = %s, want %s", tt.now, got, tt.want)
+		}
+	}
+}
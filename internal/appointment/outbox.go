@@ -0,0 +1,34 @@
+package appointment
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrUnsupportedOutboxEvent = errors.New("unsupported outbox event")
+
+// Events that must survive process restarts
+var OutboxEventTypes = []EventType{
+	CreatedEventType,
+	CanceledEventType,
+	ChangedEventType,
+}
+
+type OutboxEntryId int64
+
+type OutboxEntry struct {
+	Id       OutboxEntryId
+	Event    Event
+	Attempts int
+	// Set instead of the event when the stored one can not be restored
+	Err error
+}
+
+// Exponential backoff for the next delivery attempt
+func (e OutboxEntry) NextAttemptAt(now time.Time, baseBackoff time.Duration) time.Time {
+	return NextAttemptAt(e.Attempts, now, baseBackoff)
+}
+
+// The entry id is passed to identify the event on redeliveries
+type OutboxEventHandler func(context.Context, OutboxEntryId, Event) error
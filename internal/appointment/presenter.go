@@ -18,11 +18,20 @@ type ErrorPresenter[R any] func(err error) (R, error)
 
 type RegistrationPresenter[R any] func(telegramUserId shared.TelegramUserId) (R, error)
 
+type VkRegistrationPresenter[R any] func(vkUserId shared.VkUserId) (R, error)
+
 type SuccessRegistrationPresenter[R any] func(services []ServiceEntity) (R, error)
 
 type ServicesPickerPresenter[R any] func(services []ServiceEntity) (R, error)
 
-type DatePickerPresenter[R any] func(now time.Time, serviceId ServiceId, schedule Schedule) (R, error)
+type DatePickerPresenter[R any] func(
+	now time.Time,
+	serviceId ServiceId,
+	schedule Schedule,
+	availability Availability,
+) (R, error)
+
+type AvailabilityPresenter[R any] func(availability Availability) (R, error)
 
 type GreetPresenter[R any] func() (R, error)
 
@@ -50,6 +59,55 @@ type NotFoundPresenter[R any] func() (R, error)
 
 type AppointmentCancelPresenter[R any] func() (R, error)
 
-type EventPresenter[E Event, R any] func(E) (R, error)
+type SlotHeldPresenter[R any] func() (R, error)
+
+type RecordStatusPresenter[R any] func(RecordEntity) (R, error)
+
+type StaffEventPresenter[E Event, R any] func(StaffMember, E) (R, error)
 
 type ChangedEventPresenter[R any] func(ChangedEvent, CustomerEntity, ServiceEntity) (R, error)
+
+type StatusTransitionPresenter[R any] func(StatusTransition, CustomerEntity, ServiceEntity) (R, error)
+
+type DayAppointmentsPresenter[R any] func(day time.Time, appointments []AppointmentDetails) (R, error)
+
+type PeriodBlockedPresenter[R any] func(title string, period shared.DateTimePeriod) (R, error)
+
+type CustomersPresenter[R any] func([]CustomerDetails) (R, error)
+
+type NotificationChannelsPresenter[R any] func([]NotificationChannel) (R, error)
+
+type NotificationPreferencesPresenter[R any] func(NotificationPreferences) (R, error)
+
+type CustomerLocalePresenter[R any] func(CustomerLocale) (R, error)
+
+type WebPushPublicKeyPresenter[R any] func(publicKey string) (R, error)
+
+type WebPushSubscriptionPresenter[R any] func() (R, error)
+
+type CreatedEventPresenter[R any] func(CreatedEvent) (R, error)
+
+type CanceledEventPresenter[R any] func(CanceledEvent) (R, error)
+
+type ReminderPresenter[R any] func(RecordEntity, CustomerEntity, ServiceEntity) (R, error)
+
+type RescheduleOfferPresenter[R any] func(RescheduleOffer, CustomerEntity, ServiceEntity) (R, error)
+
+type RescheduleOffersPresenter[R any] func([]RescheduleOfferDetails) (R, error)
+
+type RescheduleDeclinedPresenter[R any] func() (R, error)
+
+type PeriodCanceledPresenter[R any] func(
+	period shared.DateTimePeriod,
+	reason string,
+	canceled []AppointmentDetails,
+	offers []RescheduleOffer,
+) (R, error)
+
+type FailedWebhookDeliveriesPresenter[R any] func([]WebhookDelivery) (R, error)
+
+type WebhookDeliveriesReplayedPresenter[R any] func(count int) (R, error)
+
+type AuditEntriesPresenter[R any] func([]AuditEntry) (R, error)
+
+type AuditExportPresenter[R any] func(from time.Time, to time.Time, entries []AuditEntry) (R, error)
@@ -0,0 +1,55 @@
+package appointment_presenter
+
+import (
+	"encoding/csv"
+	"io"
+	"time"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	"github.com/x0k/veterinary-clinic-backend/internal/shared"
+)
+
+const auditCSVTimeLayout = "2006-01-02 15:04"
+
+var auditCSVHeader = []string{
+	"occurred_at", "record_id", "action", "actor_type", "actor_identity",
+	"before_status", "before_start", "before_end",
+	"after_status", "after_start", "after_end",
+	"reason",
+}
+
+func auditCSVRecord(record *appointment.RecordEntity) []string {
+	if record == nil {
+		return []string{"", "", ""}
+	}
+	return []string{
+		record.Status.String(),
+		shared.DateTimeToGoTime(record.DateTimePeriod.Start).Format(auditCSVTimeLayout),
+		shared.DateTimeToGoTime(record.DateTimePeriod.End).Format(auditCSVTimeLayout),
+	}
+}
+
+func WriteAuditEntriesCSV(w io.Writer, entries []appointment.AuditEntry) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(auditCSVHeader); err != nil {
+		return err
+	}
+	for _, e := range entries {
+		row := make([]string, 0, len(auditCSVHeader))
+		row = append(row,
+			e.OccurredAt.Format(time.RFC3339),
+			e.RecordId.String(),
+			string(e.Action),
+			string(e.Actor.Type),
+			e.Actor.Identity.String(),
+		)
+		row = append(row, auditCSVRecord(e.Before)...)
+		row = append(row, auditCSVRecord(e.After)...)
+		row = append(row, e.Reason)
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,174 @@
+package appointment_email_presenter
+
+import (
+	"net/mail"
+	"strings"
+	"time"
+
+	smtp_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/smtp"
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	appointment_presenter "github.com/x0k/veterinary-clinic-backend/internal/appointment/presenter"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/ics"
+	"github.com/x0k/veterinary-clinic-backend/internal/shared"
+)
+
+const (
+	calendarProdId     = "-//x0k//veterinary-clinic-backend//RU"
+	calendarUidSuffix  = "@veterinary-clinic"
+	calendarAttachment = "appointment.ics"
+)
+
+// Presenters return nil when the event is not worth an email
+type NotificationPresenter struct {
+	clinicName string
+	location   string
+}
+
+func NewNotificationPresenter(clinicName string, location string) *NotificationPresenter {
+	return &NotificationPresenter{
+		clinicName: clinicName,
+		location:   location,
+	}
+}
+
+func (p *NotificationPresenter) RenderCreated(event appointment.CreatedEvent) (*smtp_adapters.Message, error) {
+	subject := "notification.created"
+	status := ics.ConfirmedStatus
+	if event.Record.Status == appointment.RecordPendingApproval {
+		subject = "notification.created.pending"
+		status = ics.TentativeStatus
+	}
+	return p.message(subject, event.Record, event.Customer, event.Service, "", ics.PublishMethod, status)
+}
+
+func (p *NotificationPresenter) RenderCanceled(event appointment.CanceledEvent) (*smtp_adapters.Message, error) {
+	return p.message("notification.canceled", event.Record, event.Customer, event.Service, "", ics.CancelMethod, ics.CancelledStatus)
+}
+
+func (p *NotificationPresenter) RenderChanged(
+	event appointment.ChangedEvent,
+	customer appointment.CustomerEntity,
+	service appointment.ServiceEntity,
+) (*smtp_adapters.Message, error) {
+	switch event.ChangeType {
+	case appointment.CreatedChangeType:
+		return p.message("change.created", event.Record, customer, service, "", ics.PublishMethod, ics.ConfirmedStatus)
+	case appointment.DateTimeChangeType:
+		return p.message("change.date_time", event.Record, customer, service, "", ics.PublishMethod, ics.ConfirmedStatus)
+	case appointment.RemovedChangeType:
+		return p.message("change.removed", event.Record, customer, service, "", ics.CancelMethod, ics.CancelledStatus)
+	case appointment.StatusChangeType:
+		return p.message("change.status", event.Record, customer, service, "", ics.PublishMethod, ics.ConfirmedStatus)
+	default:
+		return nil, nil
+	}
+}
+
+func (p *NotificationPresenter) RenderStatusTransition(
+	transition appointment.StatusTransition,
+	customer appointment.CustomerEntity,
+	service appointment.ServiceEntity,
+) (*smtp_adapters.Message, error) {
+	record := transition.Record
+	switch record.Status {
+	case appointment.RecordAwaits:
+		return p.message("transition.awaits", record, customer, service, transition.Reason, ics.PublishMethod, ics.ConfirmedStatus)
+	case appointment.RecordConfirmed:
+		return p.message("transition.confirmed", record, customer, service, transition.Reason, ics.PublishMethod, ics.ConfirmedStatus)
+	case appointment.RecordRescheduled:
+		return p.message("transition.rescheduled", record, customer, service, transition.Reason, ics.PublishMethod, ics.ConfirmedStatus)
+	case appointment.RecordDeclined:
+		return p.message("transition.declined", record, customer, service, transition.Reason, ics.CancelMethod, ics.CancelledStatus)
+	case appointment.RecordCanceledByClinic:
+		return p.message("transition.canceled_by_clinic", record, customer, service, transition.Reason, ics.CancelMethod, ics.CancelledStatus)
+	default:
+		return nil, nil
+	}
+}
+
+func (p *NotificationPresenter) RenderReminder(
+	record appointment.RecordEntity,
+	customer appointment.CustomerEntity,
+	service appointment.ServiceEntity,
+) (*smtp_adapters.Message, error) {
+	return p.message("notification.reminder.email", record, customer, service, "", ics.PublishMethod, ics.ConfirmedStatus)
+}
+
+// `subject` is a message key
+func (p *NotificationPresenter) message(
+	subject string,
+	record appointment.RecordEntity,
+	customer appointment.CustomerEntity,
+	service appointment.ServiceEntity,
+	reason string,
+	method ics.Method,
+	status ics.Status,
+) (*smtp_adapters.Message, error) {
+	to, ok := customer.EmailAddress()
+	if !ok {
+		return nil, nil
+	}
+	start := shared.DateTimeToGoTime(record.DateTimePeriod.Start)
+	end := shared.DateTimeToGoTime(record.DateTimePeriod.End)
+	pr := appointment_presenter.CustomerPrinter(customer)
+	subject = pr.Text(subject)
+
+	sb := strings.Builder{}
+	sb.WriteString(customer.Name)
+	sb.WriteString(",\n\n")
+	sb.WriteString(subject)
+	sb.WriteString(":\n\n")
+	sb.WriteString(service.Title)
+	sb.WriteString("\n")
+	sb.WriteString(pr.DateTime(start))
+	sb.WriteString(" - ")
+	sb.WriteString(end.Format("15:04"))
+	sb.WriteString("\n")
+	if p.location != "" {
+		sb.WriteString(p.location)
+		sb.WriteString("\n")
+	}
+	if reason != "" {
+		sb.WriteString("\n")
+		sb.WriteString(pr.Text("label.reason"))
+		sb.WriteString(": ")
+		sb.WriteString(reason)
+		sb.WriteString("\n")
+	}
+	if p.clinicName != "" {
+		sb.WriteString("\n")
+		sb.WriteString(p.clinicName)
+		sb.WriteString("\n")
+	}
+
+	now := time.Now()
+	calendar := ics.Calendar{
+		ProdId: calendarProdId,
+		Method: method,
+		Events: []ics.Event{{
+			Uid: record.Id.String() + calendarUidSuffix,
+			// Calendar clients apply only updates with a greater sequence
+			Sequence:    now.Unix(),
+			Stamp:       now,
+			Start:       start,
+			End:         end,
+			Summary:     service.Title,
+			Description: service.Description,
+			Location:    p.location,
+			Status:      status,
+		}},
+	}
+	if p.clinicName != "" {
+		subject = p.clinicName + ": " + subject
+	}
+	return &smtp_adapters.Message{
+		To:      []mail.Address{to},
+		Subject: subject,
+		Text:    sb.String(),
+		Attachments: []smtp_adapters.Attachment{{
+			Name:        calendarAttachment,
+			ContentType: ics.ContentType + "; method=" + string(method),
+			Data:        []byte(calendar.String()),
+		}},
+	}, nil
+}
@@ -0,0 +1,44 @@
+package appointment_http_presenter
+
+import (
+	"errors"
+	"net/http"
+
+	http_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/http"
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	"github.com/x0k/veterinary-clinic-backend/internal/shared"
+)
+
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, appointment.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, shared.ErrNotFound),
+		errors.Is(err, appointment.ErrRescheduleOfferNotFound),
+		errors.Is(err, appointment.ErrWebPushIsDisabled):
+		return http.StatusNotFound
+	case errors.Is(err, appointment.ErrDateTimePeriodIsOccupied),
+		errors.Is(err, appointment.ErrPeriodIsLocked),
+		errors.Is(err, appointment.ErrSlotIsHeld),
+		errors.Is(err, appointment.ErrAnotherAppointmentIsAlreadyScheduled),
+		errors.Is(err, appointment.ErrInvalidAppointmentStatusForCancel),
+		errors.Is(err, appointment.ErrUnreachableNotificationChannel),
+		errors.Is(err, shared.ErrAlreadyExists):
+		return http.StatusConflict
+	case errors.Is(err, appointment.ErrInvalidDateTimePeriod),
+		errors.Is(err, appointment.ErrUnknownCustomerIdentityType),
+		errors.Is(err, appointment.ErrWrongCustomerIdentityType),
+		errors.Is(err, appointment.ErrUnknownNotificationChannel),
+		errors.Is(err, appointment.ErrUnknownNotificationEvent),
+		errors.Is(err, appointment.ErrInvalidQuietHours),
+		errors.Is(err, appointment.ErrInvalidWebPushSubscription):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// Only the status text is exposed to clients
+func ErrorPresenter(err error) (http_adapters.JSONResponse, error) {
+	return http_adapters.NewJSONError(errorStatus(err)), nil
+}
@@ -0,0 +1,91 @@
+package appointment_http_presenter
+
+import (
+	"net/http"
+	"time"
+
+	http_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/http"
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	appointment_http_adapters "github.com/x0k/veterinary-clinic-backend/internal/appointment/adapters/http"
+	shared_http_adapters "github.com/x0k/veterinary-clinic-backend/internal/shared/adapters/http"
+)
+
+func SchedulePresenter(
+	now time.Time,
+	schedule appointment.Schedule,
+) (http_adapters.JSONResponse, error) {
+	return http_adapters.NewJSONResponse(
+		http.StatusOK,
+		appointment_http_adapters.ScheduleToDTO(schedule),
+	), nil
+}
+
+func DayPresenter(day time.Time) (http_adapters.JSONResponse, error) {
+	return http_adapters.NewJSONResponse(http.StatusOK, day.Format(time.RFC3339)), nil
+}
+
+func CustomerPresenter(customer appointment.CustomerEntity) (http_adapters.JSONResponse, error) {
+	return http_adapters.NewJSONResponse(http.StatusOK, appointment_http_adapters.CustomerIdDTO{
+		Id: customer.Id.String(),
+	}), nil
+}
+
+func NotificationPreferencesPresenter(preferences appointment.NotificationPreferences) (http_adapters.JSONResponse, error) {
+	dto := appointment_http_adapters.NotificationPreferencesDTO{
+		Channels:    make([]string, len(preferences.Channels)),
+		MutedEvents: make([]string, len(preferences.MutedEvents)),
+	}
+	for i, c := range preferences.Channels {
+		dto.Channels[i] = c.String()
+	}
+	for i, e := range preferences.MutedEvents {
+		dto.MutedEvents[i] = e.String()
+	}
+	if !preferences.QuietHours.IsZero() {
+		dto.QuietHours = &appointment_http_adapters.QuietHoursDTO{
+			Start: preferences.QuietHours.Start.String(),
+			End:   preferences.QuietHours.End.String(),
+		}
+	}
+	return http_adapters.NewJSONResponse(http.StatusOK, dto), nil
+}
+
+func WebPushPublicKeyPresenter(publicKey string) (http_adapters.JSONResponse, error) {
+	return http_adapters.NewJSONResponse(http.StatusOK, appointment_http_adapters.WebPushPublicKeyDTO{
+		PublicKey: publicKey,
+	}), nil
+}
+
+func FreeTimeSlotsPresenter(slots appointment.SampledFreeTimeSlots) (http_adapters.JSONResponse, error) {
+	periods := make([]shared_http_adapters.TimePeriodDTO, len(slots))
+	for i, s := range slots {
+		periods[i] = shared_http_adapters.TimePeriodToDTO(s)
+	}
+	return http_adapters.NewJSONResponse(http.StatusOK, periods), nil
+}
+
+func AppointmentInfoPresenter(
+	record appointment.RecordEntity,
+	service appointment.ServiceEntity,
+) (http_adapters.JSONResponse, error) {
+	return http_adapters.NewJSONResponse(
+		http.StatusOK,
+		appointment_http_adapters.AppointmentInfoToDTO(record, service),
+	), nil
+}
+
+func ServicesPresenter(services []appointment.ServiceEntity) (http_adapters.JSONResponse, error) {
+	dtos := make([]appointment_http_adapters.ServiceDTO, len(services))
+	for i, s := range services {
+		dtos[i] = appointment_http_adapters.ServiceToDTO(s)
+	}
+	return http_adapters.NewJSONResponse(http.StatusOK, dtos), nil
+}
+
+func NotFoundPresenter() (http_adapters.JSONResponse, error) {
+	return http_adapters.NewJSONError(http.StatusNotFound), nil
+}
+
+func NoContentPresenter() (http_adapters.JSONResponse, error) {
+	return http_adapters.NewJSONResponse(http.StatusNoContent, nil), nil
+}
@@ -0,0 +1,20 @@
+//go:build js && wasm
+
+package appointment_js_presenter
+
+import (
+	"github.com/x0k/vert"
+	js_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/js"
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	appointment_js_adapters "github.com/x0k/veterinary-clinic-backend/internal/appointment/adapters/js"
+)
+
+func AvailabilityPresenter(
+	availability appointment.Availability,
+) (js_adapters.Result, error) {
+	days := make([]appointment_js_adapters.DayAvailabilityDTO, len(availability))
+	for i, d := range availability {
+		days[i] = appointment_js_adapters.DayAvailabilityToDTO(d)
+	}
+	return js_adapters.Ok(vert.ValueOf(days)), nil
+}
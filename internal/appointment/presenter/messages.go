@@ -0,0 +1,45 @@
+package appointment_presenter
+
+import (
+	"slices"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/i18n"
+)
+
+// Russian is the language of the clinic, missing translations fall back to it
+var Catalog = i18n.NewCatalog(ruDictionary, enDictionary)
+
+func SupportedLocales() []string {
+	locales := Catalog.Locales()
+	tags := make([]string, len(locales))
+	for i, l := range locales {
+		tags[i] = l.String()
+	}
+	return tags
+}
+
+// Returns the distinct translations of the message
+func Texts(key string) []string {
+	texts := make([]string, 0, len(Catalog.Locales()))
+	for _, l := range Catalog.Locales() {
+		if t := Catalog.Printer(l).Text(key); !slices.Contains(texts, t) {
+			texts = append(texts, t)
+		}
+	}
+	return texts
+}
+
+func Printer(locale string) i18n.Printer {
+	return Catalog.Printer(i18n.NewLocale(locale))
+}
+
+func CustomerPrinter(customer appointment.CustomerEntity) i18n.Printer {
+	return Printer(customer.Locale.String())
+}
+
+// The language of the clinic, used for staff and for customers
+// whose locale is unknown
+func ClinicPrinter() i18n.Printer {
+	return Catalog.Printer(ruDictionary.Locale)
+}
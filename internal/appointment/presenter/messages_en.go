@@ -0,0 +1,205 @@
+package appointment_presenter
+
+import "github.com/x0k/veterinary-clinic-backend/internal/lib/i18n"
+
+var enDictionary = &i18n.Dictionary{
+	Locale:     "en",
+	PluralRule: i18n.EnglishPluralRule,
+	Messages: map[string]string{
+		"locale.name":   "English",
+		"locale":        "Language",
+		"locale.auto":   "detected automatically",
+		"locale.change": "Change",
+
+		"record.status.pending":            "pending approval",
+		"record.status.declined":           "declined",
+		"record.status.awaits":             "awaits",
+		"record.status.confirmed":          "confirmed",
+		"record.status.checked_in":         "checked in",
+		"record.status.in_progress":        "in progress",
+		"record.status.done":               "done",
+		"record.status.failed":             "did not appear",
+		"record.status.canceled_by_clinic": "canceled by the clinic",
+		"record.status.rescheduled":        "rescheduled",
+		"record.archived":                  "%s (archived)",
+		"record.state":                     "Appointment status: %s",
+
+		"label.status": "Status",
+		"label.reason": "Reason",
+		"label.record": "Appointment",
+		"label.none":   "none",
+
+		"notification.created":         "You have an appointment",
+		"notification.created.pending": "Appointment request received",
+		"notification.canceled":        "Appointment canceled",
+		"notification.reminder":        "Appointment reminder",
+		"notification.reminder.email":  "Appointment reminder",
+
+		"change.created":         "Appointment created",
+		"change.date_time":       "Appointment date and time changed",
+		"change.date_time.short": "Appointment time changed",
+		"change.removed":         "Appointment removed",
+		"change.status":          "Appointment status changed",
+
+		"transition.awaits":             "Appointment approved",
+		"transition.declined":           "Appointment declined",
+		"transition.confirmed":          "Appointment confirmed",
+		"transition.checked_in":         "Checked in",
+		"transition.in_progress":        "Appointment started",
+		"transition.done":               "Appointment completed",
+		"transition.failed":             "Marked as not appeared",
+		"transition.canceled_by_clinic": "Appointment canceled by the clinic",
+		"transition.rescheduled":        "Appointment rescheduled",
+		"transition.other":              "Status changed",
+
+		"staff.created":         "New appointment",
+		"staff.created.pending": "New appointment (approval required)",
+
+		"greeting":                   "Hello!",
+		"services":                   "Services:",
+		"services.pick":              "Choose a service:",
+		"schedule":                   "Schedule",
+		"schedule.title":             "Schedule for %s:",
+		"schedule.empty":             "No appointments",
+		"schedule.free":              "Free",
+		"schedule.busy":              "Busy",
+		"date.pick":                  "Choose a date:",
+		"time.pick":                  "Choose a time:",
+		"time.empty":                 "No free time, choose another date.",
+		"appointment.confirm":        "Confirm the appointment:",
+		"appointment.canceled":       "Your appointment is canceled",
+		"registration":               "To make an appointment, please share your phone number.",
+		"registration.vk":            "To make an appointment, please register. We will save your name and a link to your VK profile.",
+		"registration.success":       "You are successfully registered!",
+		"registration.canceled":      "Registration canceled",
+		"admin.appointments":         "Appointments for %s",
+		"admin.appointments.empty":   "No appointments.",
+		"admin.active.empty":         "No active appointments.",
+		"admin.period_blocked":       "Time blocked",
+		"admin.period_blocked.title": "Unavailable",
+		"admin.cancel_reason":        "Specify the reason for cancellation",
+
+		"reschedule.offer":          "We offer to reschedule your appointment",
+		"reschedule.offer.reason":   "Cancellation reason",
+		"reschedule.offer.pick":     "Choose a convenient time:",
+		"reschedule.declined":       "You can make an appointment at any convenient time.",
+		"reschedule.offers":         "Reschedule offers",
+		"reschedule.offers.empty":   "No offers.",
+		"reschedule.offer.accepted": "rescheduled to %s",
+		"reschedule.offer.declined": "declined",
+		"reschedule.offer.pending":  "awaiting response",
+
+		"webhooks.failed":       "Undelivered webhooks",
+		"webhooks.failed.empty": "No delivery failures.",
+		"webhooks.replay":       "Replay",
+
+		"audit":                          "Change history",
+		"audit.empty":                    "No entries.",
+		"audit.action.created":           "appointment created",
+		"audit.action.canceled":          "appointment canceled",
+		"audit.action.rescheduled":       "appointment rescheduled",
+		"audit.action.status_changed":    "status changed",
+		"audit.action.date_time_changed": "time changed",
+		"audit.action.removed":           "appointment removed",
+		"audit.actor.customer":           "customer %s",
+		"audit.actor.staff":              "staff %s",
+
+		"notifications":                        "Notifications",
+		"notifications.channels":               "Channels",
+		"notifications.channels.default":       "default",
+		"notifications.muted":                  "Muted",
+		"notifications.mute":                   "Mute",
+		"notifications.quiet_hours":            "Quiet hours",
+		"notifications.channel.telegram":       "Telegram",
+		"notifications.channel.vk":             "VK",
+		"notifications.channel.email":          "email",
+		"notifications.channel.sms":            "SMS",
+		"notifications.channel.web_push":       "push notifications",
+		"notifications.event.created":          "appointment created",
+		"notifications.event.canceled":         "appointment canceled",
+		"notifications.event.changed":          "appointment changed",
+		"notifications.event.status":           "status changed",
+		"notifications.event.reschedule_offer": "reschedule offer",
+		"notifications.event.reminder":         "reminder",
+
+		"button.services":         "Services",
+		"button.schedule":         "Schedule",
+		"button.appointment":      "Make an appointment",
+		"button.share_phone":      "Share phone number",
+		"button.register":         "Register",
+		"button.cancel_register":  "Cancel registration",
+		"button.back":             "Back",
+		"button.continue":         "Continue",
+		"button.confirm":          "Confirm appointment",
+		"button.cancel":           "Cancel appointment",
+		"button.approve":          "Approve",
+		"button.decline":          "Decline",
+		"button.done":             "Done",
+		"button.not_appear":       "Did not appear",
+		"button.cancel_by_clinic": "Cancel",
+		"button.history":          "History",
+		"button.decline_offer":    "Not suitable",
+
+		"command.start":       "Greeting",
+		"command.services":    "List of services",
+		"command.schedule":    "Schedule",
+		"command.appointment": "Make an appointment",
+		"command.language":    "Language",
+
+		"usage.block":             "Usage: /block 02.01.2006 10:00 12:00 [description]",
+		"usage.customer":          "Usage: /customer <phone number>",
+		"usage.history_export":    "Usage: /history_export [02.01.2006 [02.01.2006]]",
+		"usage.cancel_period":     "Usage: /cancel_period 02.01.2006 10:00 18:00 <reason>",
+		"usage.reschedule_period": "Usage: /reschedule_period 02.01.2006 10:00 18:00 <reason>",
+		"usage.webhooks_replay":   "Usage: /webhooks_replay [id...]",
+
+		"error":                              "Error",
+		"error.unknown":                      "Something went wrong.",
+		"error.unknown_state":                "The selected action is outdated.\nPlease start over.",
+		"error.forbidden":                    "Insufficient permissions.",
+		"error.invalid_status_transition":    "The appointment status cannot be changed.",
+		"error.status_already_changed":       "The appointment status has already been changed.",
+		"error.not_cancelable":               "Your appointment cannot be canceled.",
+		"error.not_found":                    "Nothing found.",
+		"error.reschedule_offer_closed":      "The offer is no longer relevant.",
+		"error.period_occupied":              "The selected time is already taken, please choose another one.",
+		"error.invalid_period":               "Invalid period.",
+		"error.unknown_notification_channel": "Unknown notification channel.",
+		"error.unreachable_notification":     "No contact details are provided for this channel.",
+		"error.unknown_notification_event":   "Unknown notification type.",
+		"error.invalid_quiet_hours":          "Quiet hours are set as start and end in HH:MM format, e.g. 22:00 08:00.",
+		"error.unsupported_locale":           "The language is not supported.",
+	},
+	Plurals: map[string]map[i18n.PluralForm]string{
+		"audit.export": {
+			i18n.One:   "%d change",
+			i18n.Other: "%d changes",
+		},
+		"period_canceled.records": {
+			i18n.One:   "%d appointment canceled",
+			i18n.Other: "%d appointments canceled",
+		},
+		"period_canceled.offers": {
+			i18n.One:   "rescheduling offered to %d customer",
+			i18n.Other: "rescheduling offered to %d customers",
+		},
+		"webhooks.attempts": {
+			i18n.One:   "%d attempt",
+			i18n.Other: "%d attempts",
+		},
+		"webhooks.replayed": {
+			i18n.One:   "%d webhook returned to the queue",
+			i18n.Other: "%d webhooks returned to the queue",
+		},
+	},
+	DateLayout:     "Jan 2, 2006",
+	DateTimeLayout: "Jan 2, 2006 15:04",
+	DayMonthFormat: "%[2]s %[1]d",
+	Months: [12]string{
+		"January", "February", "March", "April", "May", "June",
+		"July", "August", "September", "October", "November", "December",
+	},
+	Weekdays: [7]string{
+		"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday",
+	},
+}
@@ -0,0 +1,210 @@
+package appointment_presenter
+
+import "github.com/x0k/veterinary-clinic-backend/internal/lib/i18n"
+
+var ruDictionary = &i18n.Dictionary{
+	Locale:     "ru",
+	PluralRule: i18n.RussianPluralRule,
+	Messages: map[string]string{
+		"locale.name":   "Русский",
+		"locale":        "Язык",
+		"locale.auto":   "определяется автоматически",
+		"locale.change": "Изменить",
+
+		"record.status.pending":            "ожидает подтверждения",
+		"record.status.declined":           "отклонено",
+		"record.status.awaits":             "ожидает",
+		"record.status.confirmed":          "подтверждено",
+		"record.status.checked_in":         "пришел",
+		"record.status.in_progress":        "на приеме",
+		"record.status.done":               "выполнено",
+		"record.status.failed":             "не пришел",
+		"record.status.canceled_by_clinic": "отменено клиникой",
+		"record.status.rescheduled":        "перенесено",
+		"record.archived":                  "%s (архив)",
+		"record.state":                     "Статус записи: %s",
+
+		"label.status": "Статус",
+		"label.reason": "Причина",
+		"label.record": "Запись",
+		"label.none":   "нет",
+
+		"notification.created":         "Вы записаны на прием",
+		"notification.created.pending": "Заявка на прием получена",
+		"notification.canceled":        "Запись отменена",
+		"notification.reminder":        "Напоминаем о записи",
+		"notification.reminder.email":  "Напоминание о записи",
+
+		"change.created":         "Создана запись",
+		"change.date_time":       "Дата и время записи изменены",
+		"change.date_time.short": "Время записи изменено",
+		"change.removed":         "Запись удалена",
+		"change.status":          "Статус записи изменен",
+
+		"transition.awaits":             "Запись одобрена",
+		"transition.declined":           "Запись отклонена",
+		"transition.confirmed":          "Запись подтверждена",
+		"transition.checked_in":         "Отмечен приход",
+		"transition.in_progress":        "Прием начат",
+		"transition.done":               "Прием завершен",
+		"transition.failed":             "Отмечена неявка",
+		"transition.canceled_by_clinic": "Запись отменена клиникой",
+		"transition.rescheduled":        "Запись перенесена",
+		"transition.other":              "Статус изменен",
+
+		"staff.created":         "Новая запись",
+		"staff.created.pending": "Новая запись (требует подтверждения)",
+
+		"greeting":                   "Привет!",
+		"services":                   "Услуги:",
+		"services.pick":              "Выберите услугу:",
+		"schedule":                   "График работы",
+		"schedule.title":             "График работы на %s:",
+		"schedule.empty":             "Нет записей",
+		"schedule.free":              "Свободно",
+		"schedule.busy":              "Занято",
+		"date.pick":                  "Выберите дату:",
+		"time.pick":                  "Выберите время:",
+		"time.empty":                 "Нет свободного времени, выберите другую дату.",
+		"appointment.confirm":        "Подтвердите запись:",
+		"appointment.canceled":       "Ваша запись отменена",
+		"registration":               "Для записи на прием, необходимо уточнить ваш номер телефона.",
+		"registration.vk":            "Для записи на прием, необходимо зарегистрироваться. Мы сохраним ваше имя и ссылку на профиль ВКонтакте.",
+		"registration.success":       "Вы успешно зарегистрированы!",
+		"registration.canceled":      "Регистрация отменена",
+		"admin.appointments":         "Записи на %s",
+		"admin.appointments.empty":   "Записей нет.",
+		"admin.active.empty":         "Активных записей нет.",
+		"admin.period_blocked":       "Время заблокировано",
+		"admin.period_blocked.title": "Недоступно",
+		"admin.cancel_reason":        "Укажите причину отмены",
+
+		"reschedule.offer":          "Предлагаем перенести запись",
+		"reschedule.offer.reason":   "Причина отмены",
+		"reschedule.offer.pick":     "Выберите удобное время:",
+		"reschedule.declined":       "Вы можете записаться на прием в любое удобное время.",
+		"reschedule.offers":         "Предложения переноса",
+		"reschedule.offers.empty":   "Предложений нет.",
+		"reschedule.offer.accepted": "перенесено на %s",
+		"reschedule.offer.declined": "отказ",
+		"reschedule.offer.pending":  "ожидает ответа",
+
+		"webhooks.failed":       "Неотправленные вебхуки",
+		"webhooks.failed.empty": "Ошибок доставки нет.",
+		"webhooks.replay":       "Повторить",
+
+		"audit":                          "История изменений",
+		"audit.empty":                    "Записей нет.",
+		"audit.action.created":           "запись создана",
+		"audit.action.canceled":          "запись отменена",
+		"audit.action.rescheduled":       "запись перенесена",
+		"audit.action.status_changed":    "статус изменен",
+		"audit.action.date_time_changed": "время изменено",
+		"audit.action.removed":           "запись удалена",
+		"audit.actor.customer":           "клиент %s",
+		"audit.actor.staff":              "сотрудник %s",
+
+		"notifications":                        "Уведомления",
+		"notifications.channels":               "Каналы",
+		"notifications.channels.default":       "по умолчанию",
+		"notifications.muted":                  "Отключены",
+		"notifications.mute":                   "Отключить",
+		"notifications.quiet_hours":            "Тихие часы",
+		"notifications.channel.telegram":       "Telegram",
+		"notifications.channel.vk":             "ВКонтакте",
+		"notifications.channel.email":          "электронная почта",
+		"notifications.channel.sms":            "SMS",
+		"notifications.channel.web_push":       "push-уведомления",
+		"notifications.event.created":          "создание записи",
+		"notifications.event.canceled":         "отмена записи",
+		"notifications.event.changed":          "изменение записи",
+		"notifications.event.status":           "смена статуса",
+		"notifications.event.reschedule_offer": "предложение переноса",
+		"notifications.event.reminder":         "напоминание",
+
+		"button.services":         "Услуги",
+		"button.schedule":         "График работы",
+		"button.appointment":      "Запись на прием",
+		"button.share_phone":      "Предоставить номер телефона",
+		"button.register":         "Зарегистрироваться",
+		"button.cancel_register":  "Отменить регистрацию",
+		"button.back":             "Назад",
+		"button.continue":         "Продолжить",
+		"button.confirm":          "Подтвердить запись",
+		"button.cancel":           "Отменить запись",
+		"button.approve":          "Одобрить",
+		"button.decline":          "Отклонить",
+		"button.done":             "Выполнено",
+		"button.not_appear":       "Не пришел",
+		"button.cancel_by_clinic": "Отменить",
+		"button.history":          "История",
+		"button.decline_offer":    "Не подходит",
+
+		"command.start":       "Приветствие",
+		"command.services":    "Список услуг",
+		"command.schedule":    "График работы",
+		"command.appointment": "Запись на прием",
+		"command.language":    "Язык",
+
+		"usage.block":             "Использование: /block 02.01.2006 10:00 12:00 [описание]",
+		"usage.customer":          "Использование: /customer <номер телефона>",
+		"usage.history_export":    "Использование: /history_export [02.01.2006 [02.01.2006]]",
+		"usage.cancel_period":     "Использование: /cancel_period 02.01.2006 10:00 18:00 <причина>",
+		"usage.reschedule_period": "Использование: /reschedule_period 02.01.2006 10:00 18:00 <причина>",
+		"usage.webhooks_replay":   "Использование: /webhooks_replay [id...]",
+
+		"error":                              "Ошибка",
+		"error.unknown":                      "Что-то пошло не так.",
+		"error.unknown_state":                "Выбранное действие устарело.\nНачните весь процесс заново.",
+		"error.forbidden":                    "Недостаточно прав.",
+		"error.invalid_status_transition":    "Статус записи не может быть изменен.",
+		"error.status_already_changed":       "Статус записи уже изменен.",
+		"error.not_cancelable":               "Ваша запись не может быть отменена.",
+		"error.not_found":                    "Ничего не найдено.",
+		"error.reschedule_offer_closed":      "Предложение уже неактуально.",
+		"error.period_occupied":              "Выбранное время уже занято, выберите другое.",
+		"error.invalid_period":               "Некорректный период.",
+		"error.unknown_notification_channel": "Неизвестный канал уведомлений.",
+		"error.unreachable_notification":     "Для этого канала не указаны контактные данные.",
+		"error.unknown_notification_event":   "Неизвестный тип уведомлений.",
+		"error.invalid_quiet_hours":          "Тихие часы указываются как начало и конец в формате ЧЧ:ММ, например 22:00 08:00.",
+		"error.unsupported_locale":           "Язык не поддерживается.",
+	},
+	Plurals: map[string]map[i18n.PluralForm]string{
+		"audit.export": {
+			i18n.One:  "%d изменение",
+			i18n.Few:  "%d изменения",
+			i18n.Many: "%d изменений",
+		},
+		"period_canceled.records": {
+			i18n.One:  "Отменена %d запись",
+			i18n.Few:  "Отменены %d записи",
+			i18n.Many: "Отменено %d записей",
+		},
+		"period_canceled.offers": {
+			i18n.One:  "перенос предложен %d клиенту",
+			i18n.Few:  "перенос предложен %d клиентам",
+			i18n.Many: "перенос предложен %d клиентам",
+		},
+		"webhooks.attempts": {
+			i18n.One:  "%d попытка",
+			i18n.Few:  "%d попытки",
+			i18n.Many: "%d попыток",
+		},
+		"webhooks.replayed": {
+			i18n.One:  "В очередь возвращен %d вебхук",
+			i18n.Few:  "В очередь возвращены %d вебхука",
+			i18n.Many: "В очередь возвращено %d вебхуков",
+		},
+	},
+	DateLayout:     "02.01.2006",
+	DateTimeLayout: "02.01.2006 15:04",
+	DayMonthFormat: "%d %s",
+	Months: [12]string{
+		"января", "февраля", "марта", "апреля", "мая", "июня",
+		"июля", "августа", "сентября", "октября", "ноября", "декабря",
+	},
+	Weekdays: [7]string{
+		"воскресенье", "понедельник", "вторник", "среда", "четверг", "пятница", "суббота",
+	},
+}
@@ -4,28 +4,46 @@ import (
 	"fmt"
 
 	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/i18n"
 )
 
-func recordStatus(status appointment.RecordStatus) (string, error) {
+func recordStatus(p i18n.Printer, status appointment.RecordStatus) (string, error) {
 	switch status {
-	case appointment.RecordAwaits:
-		return "ожидает", nil
-	case appointment.RecordDone:
-		return "выполнено", nil
-	case appointment.RecordNotAppear:
-		return "не пришел", nil
+	case appointment.RecordPendingApproval,
+		appointment.RecordDeclined,
+		appointment.RecordAwaits,
+		appointment.RecordConfirmed,
+		appointment.RecordCheckedIn,
+		appointment.RecordInProgress,
+		appointment.RecordDone,
+		appointment.RecordNotAppear,
+		appointment.RecordCanceledByClinic,
+		appointment.RecordRescheduled:
+		return p.Text("record.status." + status.String()), nil
 	default:
 		return "", fmt.Errorf("unknown status: %s", status)
 	}
 }
 
-func RecordState(status appointment.RecordStatus, isArchived bool) (string, error) {
-	st, err := recordStatus(status)
+func RecordState(p i18n.Printer, status appointment.RecordStatus, isArchived bool) (string, error) {
+	st, err := recordStatus(p, status)
 	if err != nil {
 		return "", err
 	}
 	if isArchived {
-		return fmt.Sprintf("%s (архив)", st), nil
+		return p.Text("record.archived", st), nil
 	}
 	return st, nil
 }
+
+// Translates the titles given by the schedule, work breaks keep their own
+func ScheduleEntryTitle(p i18n.Printer, entry appointment.ScheduleEntry) string {
+	switch entry.Title {
+	case appointment.FreePeriodTitle:
+		return p.Text("schedule.free")
+	case appointment.BusyPeriodTitle:
+		return p.Text("schedule.busy")
+	default:
+		return entry.Title
+	}
+}
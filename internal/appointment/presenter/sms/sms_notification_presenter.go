@@ -0,0 +1,118 @@
+package appointment_sms_presenter
+
+import (
+	"strings"
+
+	sms_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/sms"
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	appointment_presenter "github.com/x0k/veterinary-clinic-backend/internal/appointment/presenter"
+	"github.com/x0k/veterinary-clinic-backend/internal/shared"
+)
+
+// Presenters return nil when the event is not worth an SMS
+type NotificationPresenter struct {
+	clinicName string
+}
+
+func NewNotificationPresenter(clinicName string) *NotificationPresenter {
+	return &NotificationPresenter{
+		clinicName: clinicName,
+	}
+}
+
+func (p *NotificationPresenter) RenderCreated(event appointment.CreatedEvent) (*sms_adapters.Message, error) {
+	title := "notification.created"
+	if event.Record.Status == appointment.RecordPendingApproval {
+		title = "notification.created.pending"
+	}
+	return p.message(title, event.Record, event.Customer, event.Service, "")
+}
+
+func (p *NotificationPresenter) RenderCanceled(event appointment.CanceledEvent) (*sms_adapters.Message, error) {
+	return p.message("notification.canceled", event.Record, event.Customer, event.Service, "")
+}
+
+func (p *NotificationPresenter) RenderChanged(
+	event appointment.ChangedEvent,
+	customer appointment.CustomerEntity,
+	service appointment.ServiceEntity,
+) (*sms_adapters.Message, error) {
+	switch event.ChangeType {
+	case appointment.CreatedChangeType:
+		return p.message("change.created", event.Record, customer, service, "")
+	case appointment.DateTimeChangeType:
+		return p.message("change.date_time.short", event.Record, customer, service, "")
+	case appointment.RemovedChangeType:
+		return p.message("change.removed", event.Record, customer, service, "")
+	case appointment.StatusChangeType:
+		return p.message("change.status", event.Record, customer, service, "")
+	default:
+		return nil, nil
+	}
+}
+
+func (p *NotificationPresenter) RenderStatusTransition(
+	transition appointment.StatusTransition,
+	customer appointment.CustomerEntity,
+	service appointment.ServiceEntity,
+) (*sms_adapters.Message, error) {
+	record := transition.Record
+	switch record.Status {
+	case appointment.RecordAwaits:
+		return p.message("transition.awaits", record, customer, service, transition.Reason)
+	case appointment.RecordConfirmed:
+		return p.message("transition.confirmed", record, customer, service, transition.Reason)
+	case appointment.RecordRescheduled:
+		return p.message("transition.rescheduled", record, customer, service, transition.Reason)
+	case appointment.RecordDeclined:
+		return p.message("transition.declined", record, customer, service, transition.Reason)
+	case appointment.RecordCanceledByClinic:
+		return p.message("transition.canceled_by_clinic", record, customer, service, transition.Reason)
+	default:
+		return nil, nil
+	}
+}
+
+func (p *NotificationPresenter) RenderReminder(
+	record appointment.RecordEntity,
+	customer appointment.CustomerEntity,
+	service appointment.ServiceEntity,
+) (*sms_adapters.Message, error) {
+	return p.message("notification.reminder", record, customer, service, "")
+}
+
+// `title` is a message key
+func (p *NotificationPresenter) message(
+	title string,
+	record appointment.RecordEntity,
+	customer appointment.CustomerEntity,
+	service appointment.ServiceEntity,
+	reason string,
+) (*sms_adapters.Message, error) {
+	phone, ok := customer.SmsPhoneNumber()
+	if !ok {
+		return nil, nil
+	}
+	pr := appointment_presenter.CustomerPrinter(customer)
+	start := shared.DateTimeToGoTime(record.DateTimePeriod.Start)
+	sb := strings.Builder{}
+	if p.clinicName != "" {
+		sb.WriteString(p.clinicName)
+		sb.WriteString(". ")
+	}
+	sb.WriteString(pr.Text(title))
+	sb.WriteString(": ")
+	sb.WriteString(service.Title)
+	sb.WriteString(", ")
+	sb.WriteString(pr.DayMonth(start))
+	sb.WriteString(" ")
+	sb.WriteString(start.Format("15:04"))
+	if reason != "" {
+		sb.WriteString(". ")
+		sb.WriteString(reason)
+	}
+	return &sms_adapters.Message{
+		Phone: phone,
+		Text:  sb.String(),
+	}, nil
+}
@@ -0,0 +1,201 @@
+package appointment_telegram_presenter
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	telegram_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/telegram"
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	appointment_telegram_adapters "github.com/x0k/veterinary-clinic-backend/internal/appointment/adapters/telegram"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/i18n"
+	"github.com/x0k/veterinary-clinic-backend/internal/shared"
+	"gopkg.in/telebot.v3"
+)
+
+func RenderDayAppointments(
+	day time.Time,
+	appointments []appointment.AppointmentDetails,
+) (telegram_adapters.LocalizedTextResponses, error) {
+	details := make([]func(p i18n.Printer) telegram_adapters.SendableText, 0, len(appointments))
+	for _, app := range appointments {
+		res, err := adminAppointment(app)
+		if err != nil {
+			return nil, err
+		}
+		details = append(details, res)
+	}
+	return func(p i18n.Printer) telegram_adapters.TextResponses {
+		sb := strings.Builder{}
+		writeTitle(&sb, p.Text("admin.appointments", p.Date(day)))
+		if len(appointments) == 0 {
+			sb.WriteString("\n\n")
+			sb.WriteString(telegram_adapters.EscapeMarkdownString(p.Text("admin.appointments.empty")))
+		}
+		responses := make(telegram_adapters.TextResponses, 0, len(details)+1)
+		responses = append(responses, telegram_adapters.NewSendableText(
+			sb.String(),
+			&telebot.SendOptions{
+				ParseMode: telebot.ModeMarkdownV2,
+			},
+		))
+		for _, d := range details {
+			responses = append(responses, d(p))
+		}
+		return responses
+	}, nil
+}
+
+func adminAppointment(app appointment.AppointmentDetails) (func(p i18n.Printer) telegram_adapters.SendableText, error) {
+	state, err := recordState(app.Record)
+	if err != nil {
+		return nil, err
+	}
+	return func(p i18n.Printer) telegram_adapters.SendableText {
+		sb := strings.Builder{}
+		sb.WriteString(telegram_adapters.EscapeMarkdownString(state(p)))
+		sb.WriteString("\n\n")
+		writeAppointmentSummary(&sb, p, app.Record, app.Customer, app.Service)
+		writeCustomerContacts(&sb, app.Customer)
+		keyboard := [][]telebot.InlineButton{}
+		if app.Record.Status.IsActive() {
+			keyboard = append(
+				keyboard,
+				[]telebot.InlineButton{
+					withData(p, appointment_telegram_adapters.CompleteAppointmentBtn, app.Record.Id.String()),
+					withData(p, appointment_telegram_adapters.NotAppearAppointmentBtn, app.Record.Id.String()),
+				},
+				[]telebot.InlineButton{
+					withData(p, appointment_telegram_adapters.CancelByClinicAppointmentBtn, app.Record.Id.String()),
+				},
+			)
+		}
+		keyboard = append(keyboard, []telebot.InlineButton{
+			withData(p, appointment_telegram_adapters.HistoryAppointmentBtn, app.Record.Id.String()),
+		})
+		return telegram_adapters.NewSendableText(
+			sb.String(),
+			&telebot.SendOptions{
+				ParseMode: telebot.ModeMarkdownV2,
+				ReplyMarkup: &telebot.ReplyMarkup{
+					InlineKeyboard: keyboard,
+				},
+			},
+		)
+	}, nil
+}
+
+func writeCustomerContacts(sb *strings.Builder, customer appointment.CustomerEntity) {
+	if link, ok := customerProfileLink(customer.Identity); ok {
+		sb.WriteString("\n")
+		sb.WriteString(link)
+	}
+	if customer.PhoneNumber != "" {
+		sb.WriteString("\n")
+		sb.WriteString(telegram_adapters.EscapeMarkdownString(customer.PhoneNumber))
+	}
+	if customer.Email != "" {
+		sb.WriteString("\n")
+		sb.WriteString(telegram_adapters.EscapeMarkdownString(customer.Email))
+	}
+}
+
+// Customers who booked through a mini app can be reached only
+// in the messenger of the app
+func customerProfileLink(identity appointment.CustomerIdentity) (string, bool) {
+	tp, err := identity.Type()
+	if err != nil {
+		return "", false
+	}
+	switch tp {
+	case appointment.TelegramIdentityType:
+		id, err := identity.ToTelegramUserId()
+		if err != nil {
+			return "", false
+		}
+		return fmt.Sprintf("[Telegram](tg://user?id=%d)", id.Int()), true
+	case appointment.VkIdentityType:
+		id, err := identity.ToVkUserId()
+		if err != nil {
+			return "", false
+		}
+		return fmt.Sprintf("[VK](https://vk.com/id%s)", id), true
+	default:
+		return "", false
+	}
+}
+
+func RenderPeriodBlocked(
+	title string,
+	period shared.DateTimePeriod,
+) (telegram_adapters.LocalizedTextResponses, error) {
+	start := shared.DateTimeToGoTime(period.Start)
+	end := shared.DateTimeToGoTime(period.End)
+	return func(p i18n.Printer) telegram_adapters.TextResponses {
+		sb := strings.Builder{}
+		writeTitle(&sb, p.Text("admin.period_blocked"))
+		sb.WriteString("\n\n")
+		sb.WriteString(telegram_adapters.EscapeMarkdownString(p.DateTime(start)))
+		sb.WriteString(" \\- ")
+		sb.WriteString(telegram_adapters.EscapeMarkdownString(end.Format("15:04")))
+		sb.WriteString("\n\n")
+		sb.WriteString(telegram_adapters.EscapeMarkdownString(title))
+		return telegram_adapters.TextResponses{{
+			Text: sb.String(),
+			Options: &telebot.SendOptions{
+				ParseMode: telebot.ModeMarkdownV2,
+			},
+		}}
+	}, nil
+}
+
+func RenderCustomers(customers []appointment.CustomerDetails) (telegram_adapters.LocalizedTextResponses, error) {
+	active := make(map[int]func(p i18n.Printer) telegram_adapters.SendableText, len(customers))
+	for i, customer := range customers {
+		if customer.ActiveAppointment == nil {
+			continue
+		}
+		app, err := adminAppointment(*customer.ActiveAppointment)
+		if err != nil {
+			return nil, err
+		}
+		active[i] = app
+	}
+	return func(p i18n.Printer) telegram_adapters.TextResponses {
+		responses := make(telegram_adapters.TextResponses, 0, len(customers))
+		for i, customer := range customers {
+			sb := strings.Builder{}
+			writeTitle(&sb, customer.Customer.Name)
+			writeCustomerContacts(&sb, customer.Customer)
+			sb.WriteString("\n\n")
+			app, ok := active[i]
+			if !ok {
+				sb.WriteString(telegram_adapters.EscapeMarkdownString(p.Text("admin.active.empty")))
+				responses = append(responses, telegram_adapters.NewSendableText(
+					sb.String(),
+					&telebot.SendOptions{
+						ParseMode: telebot.ModeMarkdownV2,
+					},
+				))
+				continue
+			}
+			res := app(p)
+			sb.WriteString(res.Text)
+			res.Text = sb.String()
+			responses = append(responses, res)
+		}
+		return responses
+	}, nil
+}
+
+func RenderTextRecordStatus(record appointment.RecordEntity) (telegram_adapters.LocalizedTextResponses, error) {
+	state, err := recordState(record)
+	if err != nil {
+		return nil, err
+	}
+	return func(p i18n.Printer) telegram_adapters.TextResponses {
+		return telegram_adapters.TextResponses{
+			telegram_adapters.NewSendableText(p.Text("record.state", state(p))),
+		}
+	}, nil
+}
@@ -2,13 +2,43 @@ package appointment_telegram_presenter
 
 import (
 	telegram_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/telegram"
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	appointment_presenter "github.com/x0k/veterinary-clinic-backend/internal/appointment/presenter"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/i18n"
 	"gopkg.in/telebot.v3"
 )
 
-func RenderAppointmentCancel() (telegram_adapters.CallbackResponse, error) {
-	return telegram_adapters.CallbackResponse{
-		Response: &telebot.CallbackResponse{
-			Text: "Ваша запись отменена",
-		},
+// Unknown statuses are reported before the locale of the chat is known
+func recordState(record appointment.RecordEntity) (func(p i18n.Printer) string, error) {
+	if _, err := appointment_presenter.RecordState(i18n.Printer{}, record.Status, record.IsArchived); err != nil {
+		return nil, err
+	}
+	return func(p i18n.Printer) string {
+		state, _ := appointment_presenter.RecordState(p, record.Status, record.IsArchived)
+		return state
+	}, nil
+}
+
+func RenderAppointmentCancel() (telegram_adapters.LocalizedCallbackResponse, error) {
+	return func(p i18n.Printer) telegram_adapters.CallbackResponse {
+		return telegram_adapters.CallbackResponse{
+			Response: &telebot.CallbackResponse{
+				Text: p.Text("appointment.canceled"),
+			},
+		}
+	}, nil
+}
+
+func RenderRecordStatus(record appointment.RecordEntity) (telegram_adapters.LocalizedCallbackResponse, error) {
+	state, err := recordState(record)
+	if err != nil {
+		return nil, err
+	}
+	return func(p i18n.Printer) telegram_adapters.CallbackResponse {
+		return telegram_adapters.CallbackResponse{
+			Response: &telebot.CallbackResponse{
+				Text: p.Text("record.state", state(p)),
+			},
+		}
 	}, nil
 }
@@ -8,6 +8,7 @@ import (
 	telegram_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/telegram"
 	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
 	appointment_telegram_adapters "github.com/x0k/veterinary-clinic-backend/internal/appointment/adapters/telegram"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/i18n"
 	"gopkg.in/telebot.v3"
 )
 
@@ -26,24 +27,27 @@ func NewConfirmationPresenter(
 func (p *ConfirmationPresenter) RenderConfirmation(
 	service appointment.ServiceEntity,
 	appointmentDateTime time.Time,
-) (telegram_adapters.TextResponses, error) {
-	sb := strings.Builder{}
-	sb.WriteString("Подтвердите запись:\n\n")
-	writeAppointment(&sb, service, appointmentDateTime)
+) (telegram_adapters.LocalizedTextResponses, error) {
 	stateId := string(p.stateSaver(appointment_telegram_adapters.AppointmentSate{
 		ServiceId: service.Id,
 		Date:      appointmentDateTime,
 	}))
-	return telegram_adapters.TextResponses{{
-		Text: sb.String(),
-		Options: &telebot.SendOptions{
-			ParseMode: telebot.ModeMarkdownV2,
-			ReplyMarkup: &telebot.ReplyMarkup{
-				InlineKeyboard: [][]telebot.InlineButton{
-					{*appointment_telegram_adapters.ConfirmMakeAppointmentBtn.With(stateId)},
-					{*appointment_telegram_adapters.CancelConfirmationAppointmentBtn.With(stateId)},
+	return func(p i18n.Printer) telegram_adapters.TextResponses {
+		sb := strings.Builder{}
+		sb.WriteString(telegram_adapters.EscapeMarkdownString(p.Text("appointment.confirm")))
+		sb.WriteString("\n\n")
+		writeAppointment(&sb, p, service, appointmentDateTime)
+		return telegram_adapters.TextResponses{{
+			Text: sb.String(),
+			Options: &telebot.SendOptions{
+				ParseMode: telebot.ModeMarkdownV2,
+				ReplyMarkup: &telebot.ReplyMarkup{
+					InlineKeyboard: [][]telebot.InlineButton{
+						{withData(p, appointment_telegram_adapters.ConfirmMakeAppointmentBtn, stateId)},
+						{withData(p, appointment_telegram_adapters.CancelConfirmationAppointmentBtn, stateId)},
+					},
 				},
 			},
-		},
-	}}, nil
+		}}
+	}, nil
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
 	appointment_telegram_adapters "github.com/x0k/veterinary-clinic-backend/internal/appointment/adapters/telegram"
 	web_calendar_adapters "github.com/x0k/veterinary-clinic-backend/internal/appointment/adapters/web_calendar"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/i18n"
 	"gopkg.in/telebot.v3"
 )
 
@@ -32,7 +33,13 @@ func newDatePickerPresenter(
 	}
 }
 
-func (p *datePickerPresenter) buttons(now time.Time, serviceId appointment.ServiceId, schedule appointment.Schedule) [][]telebot.InlineButton {
+func (p *datePickerPresenter) buttons(
+	pr i18n.Printer,
+	now time.Time,
+	serviceId appointment.ServiceId,
+	schedule appointment.Schedule,
+	availability appointment.Availability,
+) [][]telebot.InlineButton {
 	buttons := make([]telebot.InlineButton, 0, 3)
 	if now.Add(-24 * time.Hour).Before(schedule.PrevDate) {
 		buttons = append(buttons, *appointment_telegram_adapters.PrevMakeAppointmentDateBtn.With(string(
@@ -46,8 +53,9 @@ func (p *datePickerPresenter) buttons(now time.Time, serviceId appointment.Servi
 	webAppParams.Add("r", p.webCalendarInputRequestOptions)
 	webAppParams.Add("v", web_calendar_adapters.AppInputValidationSchema)
 	webAppParams.Add("w", fmt.Sprintf(
-		web_calendar_adapters.AppOptionsTemplate,
+		web_calendar_adapters.AppDatePickerOptionsTemplate,
 		time.Now().Format(time.DateOnly),
+		web_calendar_adapters.DisabledDates(availability.NotAvailableDays()),
 		schedule.Date.Format(time.DateOnly),
 	))
 	webAppParams.Add("s", string(serviceId))
@@ -67,8 +75,8 @@ func (p *datePickerPresenter) buttons(now time.Time, serviceId appointment.Servi
 	return [][]telebot.InlineButton{
 		buttons,
 		{
-			*appointment_telegram_adapters.CancelMakeAppointmentDateBtn,
-			*appointment_telegram_adapters.SelectMakeAppointmentDateBtn.With(string(
+			button(pr, appointment_telegram_adapters.CancelMakeAppointmentDateBtn),
+			withData(pr, appointment_telegram_adapters.SelectMakeAppointmentDateBtn, string(
 				p.stateSaver(appointment_telegram_adapters.AppointmentSate{
 					ServiceId: serviceId,
 					Date:      schedule.Date,
@@ -100,18 +108,21 @@ func (p *DatePickerTextPresenter) RenderDatePicker(
 	now time.Time,
 	serviceId appointment.ServiceId,
 	schedule appointment.Schedule,
-) (telegram_adapters.TextResponses, error) {
-	sb := strings.Builder{}
-	writeSchedule(&sb, schedule)
-	return telegram_adapters.TextResponses{{
-		Text: sb.String(),
-		Options: &telebot.SendOptions{
-			ParseMode: telebot.ModeMarkdownV2,
-			ReplyMarkup: &telebot.ReplyMarkup{
-				InlineKeyboard: p.buttons(now, serviceId, schedule),
+	availability appointment.Availability,
+) (telegram_adapters.LocalizedTextResponses, error) {
+	return func(pr i18n.Printer) telegram_adapters.TextResponses {
+		sb := strings.Builder{}
+		writeSchedule(&sb, pr, schedule)
+		return telegram_adapters.TextResponses{{
+			Text: sb.String(),
+			Options: &telebot.SendOptions{
+				ParseMode: telebot.ModeMarkdownV2,
+				ReplyMarkup: &telebot.ReplyMarkup{
+					InlineKeyboard: p.buttons(pr, now, serviceId, schedule, availability),
+				},
 			},
-		},
-	}}, nil
+		}}
+	}, nil
 }
 
 type DatePickerQueryPresenter struct {
@@ -136,21 +147,24 @@ func (p *DatePickerQueryPresenter) RenderDatePicker(
 	now time.Time,
 	serviceId appointment.ServiceId,
 	schedule appointment.Schedule,
-) (telegram_adapters.QueryResponse, error) {
-	sb := strings.Builder{}
-	writeSchedule(&sb, schedule)
-	return telegram_adapters.QueryResponse{
-		Result: &telebot.ArticleResult{
-			ResultBase: telebot.ResultBase{
-				ID:        fmt.Sprintf("%p", &schedule),
-				Type:      "article",
-				ParseMode: telebot.ModeMarkdownV2,
-				ReplyMarkup: &telebot.ReplyMarkup{
-					InlineKeyboard: p.buttons(now, serviceId, schedule),
+	availability appointment.Availability,
+) (telegram_adapters.LocalizedQueryResponse, error) {
+	return func(pr i18n.Printer) telegram_adapters.QueryResponse {
+		sb := strings.Builder{}
+		writeSchedule(&sb, pr, schedule)
+		return telegram_adapters.QueryResponse{
+			Result: &telebot.ArticleResult{
+				ResultBase: telebot.ResultBase{
+					ID:        fmt.Sprintf("%p", &schedule),
+					Type:      "article",
+					ParseMode: telebot.ModeMarkdownV2,
+					ReplyMarkup: &telebot.ReplyMarkup{
+						InlineKeyboard: p.buttons(pr, now, serviceId, schedule, availability),
+					},
 				},
+				Title: pr.Text("date.pick"),
+				Text:  sb.String(),
 			},
-			Title: "Выберите дату:",
-			Text:  sb.String(),
-		},
+		}
 	}, nil
 }
@@ -6,7 +6,7 @@ import (
 	telegram_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/telegram"
 	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
 	appointment_telegram_adapters "github.com/x0k/veterinary-clinic-backend/internal/appointment/adapters/telegram"
-	appointment_presenter "github.com/x0k/veterinary-clinic-backend/internal/appointment/presenter"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/i18n"
 	"github.com/x0k/veterinary-clinic-backend/internal/shared"
 	"gopkg.in/telebot.v3"
 )
@@ -14,29 +14,32 @@ import (
 func RenderAppointmentInfo(
 	app appointment.RecordEntity,
 	service appointment.ServiceEntity,
-) (telegram_adapters.TextResponses, error) {
-	status, err := appointment_presenter.RecordState(app.Status, app.IsArchived)
+) (telegram_adapters.LocalizedTextResponses, error) {
+	status, err := recordState(app)
 	if err != nil {
-		return telegram_adapters.TextResponses{}, err
+		return nil, err
 	}
-	sb := strings.Builder{}
-	sb.WriteString("Статус: ")
-	sb.WriteString(telegram_adapters.EscapeMarkdownString(status))
-	sb.WriteString("\n\n")
-	writeAppointment(&sb, service, shared.DateTimeToGoTime(app.DateTimePeriod.Start))
-	var markup *telebot.ReplyMarkup
-	if app.Status == appointment.RecordAwaits {
-		markup = &telebot.ReplyMarkup{
-			InlineKeyboard: [][]telebot.InlineButton{
-				{*appointment_telegram_adapters.CancelAppointmentBtn},
-			},
+	return func(p i18n.Printer) telegram_adapters.TextResponses {
+		sb := strings.Builder{}
+		sb.WriteString(telegram_adapters.EscapeMarkdownString(p.Text("label.status")))
+		sb.WriteString(": ")
+		sb.WriteString(telegram_adapters.EscapeMarkdownString(status(p)))
+		sb.WriteString("\n\n")
+		writeAppointment(&sb, p, service, shared.DateTimeToGoTime(app.DateTimePeriod.Start))
+		var markup *telebot.ReplyMarkup
+		if app.Status.IsCancelable() {
+			markup = &telebot.ReplyMarkup{
+				InlineKeyboard: [][]telebot.InlineButton{
+					{button(p, appointment_telegram_adapters.CancelAppointmentBtn)},
+				},
+			}
 		}
-	}
-	return telegram_adapters.TextResponses{{
-		Text: sb.String(),
-		Options: &telebot.SendOptions{
-			ParseMode:   telebot.ModeMarkdownV2,
-			ReplyMarkup: markup,
-		},
-	}}, nil
+		return telegram_adapters.TextResponses{{
+			Text: sb.String(),
+			Options: &telebot.SendOptions{
+				ParseMode:   telebot.ModeMarkdownV2,
+				ReplyMarkup: markup,
+			},
+		}}
+	}, nil
 }
@@ -2,12 +2,14 @@ package appointment_telegram_presenter
 
 import (
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/x0k/veterinary-clinic-backend/internal/adapters"
 	telegram_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/telegram"
 	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
 	appointment_telegram_adapters "github.com/x0k/veterinary-clinic-backend/internal/appointment/adapters/telegram"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/i18n"
 	"gopkg.in/telebot.v3"
 )
 
@@ -25,7 +27,7 @@ func (p *TimePickerPresenter) RenderTimePicker(
 	serviceId appointment.ServiceId,
 	appointmentDate time.Time,
 	slots appointment.SampledFreeTimeSlots,
-) (telegram_adapters.TextResponses, error) {
+) (telegram_adapters.LocalizedTextResponses, error) {
 	buttons := make([][]telebot.InlineButton, 0, len(slots)+1)
 	for _, slot := range slots {
 		buttons = append(buttons, []telebot.InlineButton{{
@@ -46,20 +48,21 @@ func (p *TimePickerPresenter) RenderTimePicker(
 			})),
 		}})
 	}
-	buttons = append(buttons, []telebot.InlineButton{
-		*appointment_telegram_adapters.CancelMakeAppointmentTimeBtn.With(string(
-			p.stateSaver(appointment_telegram_adapters.AppointmentSate{
-				ServiceId: serviceId,
-				Date:      appointmentDate,
-			}),
-		)),
-	})
-	return telegram_adapters.TextResponses{{
-		Text: "Выберите время:",
-		Options: &telebot.SendOptions{
-			ReplyMarkup: &telebot.ReplyMarkup{
-				InlineKeyboard: buttons,
+	backStateId := string(p.stateSaver(appointment_telegram_adapters.AppointmentSate{
+		ServiceId: serviceId,
+		Date:      appointmentDate,
+	}))
+	return func(pr i18n.Printer) telegram_adapters.TextResponses {
+		keyboard := append(slices.Clip(buttons), []telebot.InlineButton{
+			withData(pr, appointment_telegram_adapters.CancelMakeAppointmentTimeBtn, backStateId),
+		})
+		return telegram_adapters.TextResponses{{
+			Text: pr.Text("time.pick"),
+			Options: &telebot.SendOptions{
+				ReplyMarkup: &telebot.ReplyMarkup{
+					InlineKeyboard: keyboard,
+				},
 			},
-		},
-	}}, nil
+		}}
+	}, nil
 }
@@ -0,0 +1,143 @@
+package appointment_telegram_presenter
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	telegram_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/telegram"
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	appointment_presenter "github.com/x0k/veterinary-clinic-backend/internal/appointment/presenter"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/i18n"
+	"github.com/x0k/veterinary-clinic-backend/internal/shared"
+	"gopkg.in/telebot.v3"
+)
+
+func auditAction(p i18n.Printer, action appointment.AuditAction) string {
+	switch action {
+	case appointment.CreatedAuditAction,
+		appointment.CanceledAuditAction,
+		appointment.RescheduledAuditAction,
+		appointment.StatusChangedAuditAction,
+		appointment.DateTimeChangedAuditAction,
+		appointment.RemovedAuditAction:
+		return p.Text("audit.action." + string(action))
+	default:
+		return string(action)
+	}
+}
+
+func auditActor(p i18n.Printer, actor appointment.AuditActor) string {
+	switch actor.Type {
+	case appointment.CustomerAuditActorType:
+		return p.Text("audit.actor.customer", actor.Identity.String())
+	case appointment.StaffAuditActorType:
+		return p.Text("audit.actor.staff", actor.Identity.String())
+	case appointment.ExternalAuditActorType:
+		return "Notion"
+	default:
+		return string(actor.Type)
+	}
+}
+
+func auditRecordTime(p i18n.Printer, record *appointment.RecordEntity) string {
+	return p.DateTime(shared.DateTimeToGoTime(record.DateTimePeriod.Start))
+}
+
+func auditRecordStatus(p i18n.Printer, record *appointment.RecordEntity) string {
+	state, err := appointment_presenter.RecordState(p, record.Status, record.IsArchived)
+	if err != nil {
+		return record.Status.String()
+	}
+	return state
+}
+
+func auditChange(p i18n.Printer, entry appointment.AuditEntry) string {
+	switch {
+	case entry.Action == appointment.StatusChangedAuditAction && entry.Before != nil && entry.After != nil:
+		return auditRecordStatus(p, entry.Before) + " → " + auditRecordStatus(p, entry.After)
+	case entry.Before != nil && entry.After != nil:
+		return auditRecordTime(p, entry.Before) + " → " + auditRecordTime(p, entry.After)
+	case entry.After != nil:
+		return "→ " + auditRecordTime(p, entry.After)
+	case entry.Before != nil:
+		return auditRecordTime(p, entry.Before)
+	default:
+		return ""
+	}
+}
+
+func RenderAuditEntries(entries []appointment.AuditEntry) (telegram_adapters.LocalizedTextResponses, error) {
+	return func(p i18n.Printer) telegram_adapters.TextResponses {
+		sb := strings.Builder{}
+		sb.WriteString(p.Text("audit"))
+		if len(entries) == 0 {
+			sb.WriteString("\n\n")
+			sb.WriteString(p.Text("audit.empty"))
+		}
+		for _, e := range entries {
+			sb.WriteString("\n\n")
+			sb.WriteString(p.DateTime(e.OccurredAt))
+			sb.WriteString(", ")
+			sb.WriteString(auditActor(p, e.Actor))
+			sb.WriteString("\n")
+			sb.WriteString(auditAction(p, e.Action))
+			if change := auditChange(p, e); change != "" {
+				sb.WriteString(": ")
+				sb.WriteString(change)
+			}
+			if e.Reason != "" {
+				sb.WriteString("\n")
+				sb.WriteString(p.Text("label.reason"))
+				sb.WriteString(": ")
+				sb.WriteString(e.Reason)
+			}
+			sb.WriteString("\n")
+			sb.WriteString(p.Text("label.record"))
+			sb.WriteString(": ")
+			sb.WriteString(e.RecordId.String())
+		}
+		return telegram_adapters.TextResponses{
+			telegram_adapters.NewSendableText(sb.String()),
+		}
+	}, nil
+}
+
+func RenderAuditExport(
+	from time.Time,
+	to time.Time,
+	entries []appointment.AuditEntry,
+) (telegram_adapters.LocalizedResponse, error) {
+	var buf bytes.Buffer
+	if err := appointment_presenter.WriteAuditEntriesCSV(&buf, entries); err != nil {
+		return nil, err
+	}
+	data := buf.Bytes()
+	return func(p i18n.Printer) telegram_adapters.Response {
+		return telegram_adapters.DocumentResponse{
+			Document: &telebot.Document{
+				File: telebot.FromReader(bytes.NewReader(data)),
+				FileName: fmt.Sprintf(
+					"audit_%s_%s.csv",
+					from.Format("2006-01-02"),
+					// `to` is exclusive
+					to.Add(-time.Nanosecond).Format("2006-01-02"),
+				),
+				MIME:    "text/csv",
+				Caption: p.Plural("audit.export", len(entries)),
+			},
+			Options: &telebot.SendOptions{},
+		}
+	}, nil
+}
+
+func ResponseErrorPresenter(err error) (telegram_adapters.LocalizedResponse, error) {
+	res, err := TextErrorPresenter(err)
+	if err != nil {
+		return nil, err
+	}
+	return func(p i18n.Printer) telegram_adapters.Response {
+		return res(p)
+	}, nil
+}
@@ -0,0 +1,48 @@
+package appointment_telegram_presenter
+
+import (
+	appointment_telegram_adapters "github.com/x0k/veterinary-clinic-backend/internal/appointment/adapters/telegram"
+	appointment_presenter "github.com/x0k/veterinary-clinic-backend/internal/appointment/presenter"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/i18n"
+	"gopkg.in/telebot.v3"
+)
+
+// Buttons are matched by `Unique`, so only the text is translated
+var buttonMessages = map[string]string{
+	appointment_telegram_adapters.ServicesBtn.Unique:                      "button.services",
+	appointment_telegram_adapters.ScheduleBtn.Unique:                      "button.schedule",
+	appointment_telegram_adapters.StartMakeAppointmentDialogBtn.Unique:    "button.appointment",
+	appointment_telegram_adapters.CancelMakeAppointmentDateBtn.Unique:     "button.back",
+	appointment_telegram_adapters.SelectMakeAppointmentDateBtn.Unique:     "button.continue",
+	appointment_telegram_adapters.CancelMakeAppointmentTimeBtn.Unique:     "button.back",
+	appointment_telegram_adapters.ConfirmMakeAppointmentBtn.Unique:        "button.confirm",
+	appointment_telegram_adapters.CancelConfirmationAppointmentBtn.Unique: "button.back",
+	appointment_telegram_adapters.CancelAppointmentBtn.Unique:             "button.cancel",
+	appointment_telegram_adapters.ApproveAppointmentBtn.Unique:            "button.approve",
+	appointment_telegram_adapters.DeclineAppointmentBtn.Unique:            "button.decline",
+	appointment_telegram_adapters.CompleteAppointmentBtn.Unique:           "button.done",
+	appointment_telegram_adapters.NotAppearAppointmentBtn.Unique:          "button.not_appear",
+	appointment_telegram_adapters.CancelByClinicAppointmentBtn.Unique:     "button.cancel_by_clinic",
+	appointment_telegram_adapters.HistoryAppointmentBtn.Unique:            "button.history",
+	appointment_telegram_adapters.DeclineRescheduleBtn.Unique:             "button.decline_offer",
+}
+
+func button(p i18n.Printer, btn *telebot.InlineButton) telebot.InlineButton {
+	b := *btn
+	if key, ok := buttonMessages[b.Unique]; ok {
+		b.Text = p.Text(key)
+	}
+	return b
+}
+
+func withData(p i18n.Printer, btn *telebot.InlineButton, data string) telebot.InlineButton {
+	b := button(p, btn)
+	b.Data = data
+	return b
+}
+
+// Reply buttons are matched by text, so handlers should be
+// registered for the text of each locale
+func CancelRegistrationTexts() []string {
+	return appointment_presenter.Texts("button.cancel_register")
+}
@@ -0,0 +1,40 @@
+package appointment_telegram_presenter
+
+import (
+	"strings"
+
+	telegram_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/telegram"
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	appointment_presenter "github.com/x0k/veterinary-clinic-backend/internal/appointment/presenter"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/i18n"
+	"gopkg.in/telebot.v3"
+)
+
+// Rendered in the chosen locale, since the chat one is outdated
+// right after the change
+func RenderCustomerLocale(locale appointment.CustomerLocale) (telegram_adapters.LocalizedTextResponses, error) {
+	return func(chat i18n.Printer) telegram_adapters.TextResponses {
+		p := chat
+		if !locale.IsZero() {
+			p = appointment_presenter.Printer(locale.String())
+		}
+		sb := strings.Builder{}
+		writeLabel(&sb, p, "locale")
+		sb.WriteString(telegram_adapters.EscapeMarkdownString(p.Text("locale.name")))
+		if locale.Preferred == "" {
+			sb.WriteString(" \\(")
+			sb.WriteString(telegram_adapters.EscapeMarkdownString(p.Text("locale.auto")))
+			sb.WriteString("\\)")
+		}
+		sb.WriteString("\n\n")
+		writeLabel(&sb, p, "locale.change")
+		sb.WriteString("/language")
+		writeOptions(&sb, append([]i18n.Locale{"auto"}, appointment_presenter.Catalog.Locales()...))
+		return telegram_adapters.TextResponses{{
+			Text: sb.String(),
+			Options: &telebot.SendOptions{
+				ParseMode: telebot.ModeMarkdownV2,
+			},
+		}}
+	}, nil
+}
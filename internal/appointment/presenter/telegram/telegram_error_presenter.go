@@ -7,52 +7,88 @@ import (
 	telegram_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/telegram"
 	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
 	appointment_telegram_adapters "github.com/x0k/veterinary-clinic-backend/internal/appointment/adapters/telegram"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/i18n"
+	"github.com/x0k/veterinary-clinic-backend/internal/shared"
 	"gopkg.in/telebot.v3"
 )
 
-const errorText = "Что-то пошло не так."
-
-func TextErrorPresenter(err error) (telegram_adapters.TextResponses, error) {
-	if errors.Is(err, appointment_telegram_adapters.ErrUnknownState) {
-		return telegram_adapters.TextResponses{{
-			Text: "Выбранное действие устарело\\.\nНачните весь процесс заново\\.",
-			Options: &telebot.SendOptions{
-				ParseMode: telebot.ModeMarkdownV2,
-			},
-		}}, nil
+func textErrorMessage(err error) string {
+	switch {
+	case errors.Is(err, appointment_telegram_adapters.ErrUnknownState):
+		return "error.unknown_state"
+	case errors.Is(err, appointment.ErrForbidden):
+		return "error.forbidden"
+	case errors.Is(err, appointment.ErrInvalidStatusTransition):
+		return "error.invalid_status_transition"
+	case errors.Is(err, shared.ErrNotFound):
+		return "error.not_found"
+	case errors.Is(err, appointment.ErrRescheduleOfferIsClosed) || errors.Is(err, appointment.ErrRescheduleOfferNotFound):
+		return "error.reschedule_offer_closed"
+	case errors.Is(err, appointment.ErrDateTimePeriodIsOccupied) || errors.Is(err, appointment.ErrPeriodIsLocked):
+		return "error.period_occupied"
+	case errors.Is(err, appointment.ErrInvalidDateTimePeriod):
+		return "error.invalid_period"
+	case errors.Is(err, appointment.ErrUnknownNotificationChannel):
+		return "error.unknown_notification_channel"
+	case errors.Is(err, appointment.ErrUnreachableNotificationChannel):
+		return "error.unreachable_notification"
+	case errors.Is(err, appointment.ErrUnknownNotificationEvent):
+		return "error.unknown_notification_event"
+	case errors.Is(err, appointment.ErrInvalidQuietHours):
+		return "error.invalid_quiet_hours"
+	case errors.Is(err, appointment.ErrUnsupportedLocale):
+		return "error.unsupported_locale"
+	default:
+		// TODO: Handle domain errors
+		return "error.unknown"
 	}
-	// TODO: Handle domain errors
-	return telegram_adapters.TextResponses{{
-		Text:    errorText,
-		Options: &telebot.SendOptions{},
-	}}, nil
 }
 
-func QueryErrorPresenter(err error) (telegram_adapters.QueryResponse, error) {
+func TextErrorPresenter(err error) (telegram_adapters.LocalizedTextResponses, error) {
+	key := textErrorMessage(err)
+	return func(p i18n.Printer) telegram_adapters.TextResponses {
+		return telegram_adapters.TextResponses{
+			telegram_adapters.NewSendableText(p.Text(key)),
+		}
+	}, nil
+}
+
+func QueryErrorPresenter(err error) (telegram_adapters.LocalizedQueryResponse, error) {
 	// TODO: Handle domain errors
-	return telegram_adapters.QueryResponse{
-		Result: &telebot.ArticleResult{
-			ResultBase: telebot.ResultBase{
-				ID:   fmt.Sprintf("%p", err),
-				Type: "article",
+	return func(p i18n.Printer) telegram_adapters.QueryResponse {
+		return telegram_adapters.QueryResponse{
+			Result: &telebot.ArticleResult{
+				ResultBase: telebot.ResultBase{
+					ID:   fmt.Sprintf("%p", err),
+					Type: "article",
+				},
+				Title: p.Text("error"),
+				Text:  p.Text("error.unknown"),
 			},
-			Title: "Ошибка",
-			Text:  errorText,
-		},
+		}
 	}, nil
 }
 
-func CallbackErrorPresenter(err error) (telegram_adapters.CallbackResponse, error) {
-	if errors.Is(err, appointment.ErrInvalidAppointmentStatusForCancel) {
+func callbackErrorMessage(err error) string {
+	switch {
+	case errors.Is(err, appointment.ErrForbidden):
+		return "error.forbidden"
+	case errors.Is(err, appointment.ErrInvalidStatusTransition):
+		return "error.status_already_changed"
+	case errors.Is(err, appointment.ErrInvalidAppointmentStatusForCancel):
+		return "error.not_cancelable"
+	default:
+		return "error.unknown"
+	}
+}
+
+func CallbackErrorPresenter(err error) (telegram_adapters.LocalizedCallbackResponse, error) {
+	key := callbackErrorMessage(err)
+	return func(p i18n.Printer) telegram_adapters.CallbackResponse {
 		return telegram_adapters.CallbackResponse{
 			Response: &telebot.CallbackResponse{
-				Text: "Ваша запись не может быть отменена.",
+				Text: p.Text(key),
 			},
-		}, nil
-	}
-	return telegram_adapters.CallbackResponse{
-		Response: &telebot.CallbackResponse{
-			Text: errorText,
-		},
+		}
 	}, nil
 }
@@ -5,55 +5,83 @@ import (
 
 	telegram_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/telegram"
 	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	appointment_telegram_adapters "github.com/x0k/veterinary-clinic-backend/internal/appointment/adapters/telegram"
 	appointment_presenter "github.com/x0k/veterinary-clinic-backend/internal/appointment/presenter"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/i18n"
 	"gopkg.in/telebot.v3"
 )
 
-type AppointmentCreatedEventPresenter struct {
-	recipient telebot.Recipient
+func staffRecipient(member appointment.StaffMember) (telebot.Recipient, error) {
+	id, err := member.Identity.ToTelegramUserId()
+	if err != nil {
+		return nil, err
+	}
+	return &telebot.User{
+		ID: id.Int(),
+	}, nil
 }
 
-func NewAppointmentCreatedEventPresenter(recipient telebot.Recipient) AppointmentCreatedEventPresenter {
-	return AppointmentCreatedEventPresenter{
-		recipient: recipient,
-	}
+func writeTitle(sb *strings.Builder, title string) {
+	sb.WriteByte('*')
+	sb.WriteString(telegram_adapters.EscapeMarkdownString(title))
+	sb.WriteByte('*')
 }
 
-func (p AppointmentCreatedEventPresenter) Present(
+func AppointmentCreatedEventPresenter(
+	member appointment.StaffMember,
 	created appointment.CreatedEvent,
 ) (telegram_adapters.Message, error) {
+	recipient, err := staffRecipient(member)
+	if err != nil {
+		return nil, err
+	}
+	p := appointment_presenter.ClinicPrinter()
 	sb := strings.Builder{}
-	sb.WriteString("*Новая запись*:\n\n")
-	writeAppointmentSummary(&sb, created.Record, created.Customer, created.Service)
+	var markup *telebot.ReplyMarkup
+	if created.Record.Status == appointment.RecordPendingApproval {
+		writeTitle(&sb, p.Text("staff.created.pending"))
+		if member.Can(appointment.ApproveAppointmentsPermission) {
+			markup = &telebot.ReplyMarkup{
+				InlineKeyboard: [][]telebot.InlineButton{
+					{
+						withData(p, appointment_telegram_adapters.ApproveAppointmentBtn, created.Record.Id.String()),
+						withData(p, appointment_telegram_adapters.DeclineAppointmentBtn, created.Record.Id.String()),
+					},
+				},
+			}
+		}
+	} else {
+		writeTitle(&sb, p.Text("staff.created"))
+	}
+	sb.WriteString(":\n\n")
+	writeAppointmentSummary(&sb, p, created.Record, created.Customer, created.Service)
 	return telegram_adapters.NewTextMessages(
-		p.recipient,
+		recipient,
 		telegram_adapters.NewSendableText(
 			sb.String(),
 			&telebot.SendOptions{
-				ParseMode: telebot.ModeMarkdownV2,
+				ParseMode:   telebot.ModeMarkdownV2,
+				ReplyMarkup: markup,
 			},
 		),
 	), nil
 }
 
-type AppointmentCanceledEventPresenter struct {
-	recipient telebot.Recipient
-}
-
-func NewAppointmentCanceledEventPresenter(recipient telebot.Recipient) AppointmentCanceledEventPresenter {
-	return AppointmentCanceledEventPresenter{
-		recipient: recipient,
-	}
-}
-
-func (p AppointmentCanceledEventPresenter) Present(
+func AppointmentCanceledEventPresenter(
+	member appointment.StaffMember,
 	canceled appointment.CanceledEvent,
 ) (telegram_adapters.Message, error) {
+	recipient, err := staffRecipient(member)
+	if err != nil {
+		return nil, err
+	}
+	p := appointment_presenter.ClinicPrinter()
 	sb := strings.Builder{}
-	sb.WriteString("*Запись отменена*:\n\n")
-	writeAppointmentSummary(&sb, canceled.Record, canceled.Customer, canceled.Service)
+	writeTitle(&sb, p.Text("notification.canceled"))
+	sb.WriteString(":\n\n")
+	writeAppointmentSummary(&sb, p, canceled.Record, canceled.Customer, canceled.Service)
 	return telegram_adapters.NewTextMessages(
-		p.recipient,
+		recipient,
 		telegram_adapters.NewSendableText(
 			sb.String(),
 			&telebot.SendOptions{
@@ -63,19 +91,18 @@ func (p AppointmentCanceledEventPresenter) Present(
 	), nil
 }
 
-func writeChangeType(
-	sb *strings.Builder,
-	changeType appointment.ChangeType,
-) {
+func changeTypeTitle(p i18n.Printer, changeType appointment.ChangeType) string {
 	switch changeType {
 	case appointment.CreatedChangeType:
-		sb.WriteString("*Создана запись*")
-	case appointment.StatusChangeType:
-		sb.WriteString("*Статус изменен*")
+		return p.Text("change.created")
 	case appointment.DateTimeChangeType:
-		sb.WriteString("*Дата и время изменены*")
+		return p.Text("change.date_time")
 	case appointment.RemovedChangeType:
-		sb.WriteString("*Запись удалена*")
+		return p.Text("change.removed")
+	case appointment.StatusChangeType:
+		return p.Text("change.status")
+	default:
+		return ""
 	}
 }
 
@@ -83,6 +110,51 @@ func AppointmentChangedEventPresenter(
 	event appointment.ChangedEvent,
 	customer appointment.CustomerEntity,
 	service appointment.ServiceEntity,
+) (telegram_adapters.Message, error) {
+	p := appointment_presenter.CustomerPrinter(customer)
+	return customerRecordMessage(p, changeTypeTitle(p, event.ChangeType), event.Record, customer, service, "")
+}
+
+func statusTransitionTitle(p i18n.Printer, status appointment.RecordStatus) string {
+	switch status {
+	case appointment.RecordAwaits,
+		appointment.RecordDeclined,
+		appointment.RecordConfirmed,
+		appointment.RecordCheckedIn,
+		appointment.RecordInProgress,
+		appointment.RecordDone,
+		appointment.RecordNotAppear,
+		appointment.RecordCanceledByClinic,
+		appointment.RecordRescheduled:
+		return p.Text("transition." + status.String())
+	default:
+		return p.Text("transition.other")
+	}
+}
+
+func AppointmentStatusTransitionPresenter(
+	transition appointment.StatusTransition,
+	customer appointment.CustomerEntity,
+	service appointment.ServiceEntity,
+) (telegram_adapters.Message, error) {
+	p := appointment_presenter.CustomerPrinter(customer)
+	return customerRecordMessage(
+		p,
+		statusTransitionTitle(p, transition.Record.Status),
+		transition.Record,
+		customer,
+		service,
+		transition.Reason,
+	)
+}
+
+func customerRecordMessage(
+	p i18n.Printer,
+	title string,
+	record appointment.RecordEntity,
+	customer appointment.CustomerEntity,
+	service appointment.ServiceEntity,
+	reason string,
 ) (telegram_adapters.Message, error) {
 	id, err := customer.Identity.ToTelegramUserId()
 	if err != nil {
@@ -90,17 +162,26 @@ func AppointmentChangedEventPresenter(
 	}
 
 	sb := strings.Builder{}
-	writeChangeType(&sb, event.ChangeType)
+	if title != "" {
+		writeTitle(&sb, title)
+	}
 	sb.WriteString(":\n\n")
 
-	state, err := appointment_presenter.RecordState(event.Record.Status, event.Record.IsArchived)
+	state, err := appointment_presenter.RecordState(p, record.Status, record.IsArchived)
 	if err != nil {
 		return nil, err
 	}
-	sb.WriteString(state)
+	sb.WriteString(telegram_adapters.EscapeMarkdownString(state))
 	sb.WriteString("\n\n")
 
-	writeAppointmentSummary(&sb, event.Record, customer, service)
+	writeAppointmentSummary(&sb, p, record, customer, service)
+
+	if reason != "" {
+		sb.WriteString("\n\n")
+		sb.WriteString(telegram_adapters.EscapeMarkdownString(p.Text("label.reason")))
+		sb.WriteString(": ")
+		sb.WriteString(telegram_adapters.EscapeMarkdownString(reason))
+	}
 
 	return telegram_adapters.NewTextMessages(
 		&telebot.User{
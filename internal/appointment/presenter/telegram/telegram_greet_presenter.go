@@ -3,36 +3,67 @@ package appointment_telegram_presenter
 import (
 	telegram_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/telegram"
 	appointment_telegram_adapters "github.com/x0k/veterinary-clinic-backend/internal/appointment/adapters/telegram"
+	appointment_presenter "github.com/x0k/veterinary-clinic-backend/internal/appointment/presenter"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/i18n"
 	"gopkg.in/telebot.v3"
 )
 
 type GreetingPresenter struct {
-	buttons [][]telebot.InlineButton
+	buttons []*telebot.InlineButton
 }
 
 func NewGreetingPresenter(
 	createAppointment bool,
 ) *GreetingPresenter {
-	buttons := [][]telebot.InlineButton{
-		{*appointment_telegram_adapters.ScheduleBtn},
-		{*appointment_telegram_adapters.ServicesBtn},
+	buttons := []*telebot.InlineButton{
+		appointment_telegram_adapters.ScheduleBtn,
+		appointment_telegram_adapters.ServicesBtn,
 	}
 	if createAppointment {
-		buttons = append(buttons, []telebot.InlineButton{*appointment_telegram_adapters.StartMakeAppointmentDialogBtn})
+		buttons = append(buttons, appointment_telegram_adapters.StartMakeAppointmentDialogBtn)
 	}
 	return &GreetingPresenter{
 		buttons: buttons,
 	}
 }
 
-func (p *GreetingPresenter) RenderGreeting() (telegram_adapters.TextResponses, error) {
-	return telegram_adapters.TextResponses{{
-		Text: telegram_adapters.EscapeMarkdownString("Привет!"),
-		Options: &telebot.SendOptions{
-			ParseMode: telebot.ModeMarkdownV2,
-			ReplyMarkup: &telebot.ReplyMarkup{
-				InlineKeyboard: p.buttons,
+func (p *GreetingPresenter) RenderGreeting() (telegram_adapters.LocalizedTextResponses, error) {
+	return func(pr i18n.Printer) telegram_adapters.TextResponses {
+		keyboard := make([][]telebot.InlineButton, 0, len(p.buttons))
+		for _, btn := range p.buttons {
+			keyboard = append(keyboard, []telebot.InlineButton{button(pr, btn)})
+		}
+		return telegram_adapters.TextResponses{{
+			Text: telegram_adapters.EscapeMarkdownString(pr.Text("greeting")),
+			Options: &telebot.SendOptions{
+				ParseMode: telebot.ModeMarkdownV2,
+				ReplyMarkup: &telebot.ReplyMarkup{
+					InlineKeyboard: keyboard,
+				},
 			},
-		},
-	}}, nil
+		}}
+	}, nil
+}
+
+// Commands are listed in the language of the clinic by default
+// and in the language of the user when it is supported
+func BotCommands(createAppointment bool) map[string][]telebot.Command {
+	commands := func(p i18n.Printer) []telebot.Command {
+		cmds := []telebot.Command{
+			{Text: "/start", Description: p.Text("command.start")},
+			{Text: "/services", Description: p.Text("command.services")},
+			{Text: "/schedule", Description: p.Text("command.schedule")},
+		}
+		if createAppointment {
+			cmds = append(cmds, telebot.Command{Text: "/appointment", Description: p.Text("command.appointment")})
+		}
+		return append(cmds, telebot.Command{Text: "/language", Description: p.Text("command.language")})
+	}
+	byLanguage := map[string][]telebot.Command{
+		"": commands(appointment_presenter.ClinicPrinter()),
+	}
+	for _, locale := range appointment_presenter.SupportedLocales() {
+		byLanguage[locale] = commands(appointment_presenter.Printer(locale))
+	}
+	return byLanguage
 }
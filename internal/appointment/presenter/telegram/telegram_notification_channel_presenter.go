@@ -0,0 +1,68 @@
+package appointment_telegram_presenter
+
+import (
+	"strings"
+
+	telegram_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/telegram"
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/i18n"
+	"gopkg.in/telebot.v3"
+)
+
+func RenderNotificationChannels(channels []appointment.NotificationChannel) (telegram_adapters.LocalizedTextResponses, error) {
+	return func(p i18n.Printer) telegram_adapters.TextResponses {
+		sb := strings.Builder{}
+		writeTitle(&sb, p.Text("notifications"))
+		sb.WriteString("\n\n")
+		writeChannels(&sb, p, channels)
+		sb.WriteString("\n\n")
+		writeChannelsUsage(&sb, p)
+		return telegram_adapters.TextResponses{{
+			Text: sb.String(),
+			Options: &telebot.SendOptions{
+				ParseMode: telebot.ModeMarkdownV2,
+			},
+		}}
+	}, nil
+}
+
+func writeChannels(sb *strings.Builder, p i18n.Printer, channels []appointment.NotificationChannel) {
+	writeLabel(sb, p, "notifications.channels")
+	if len(channels) == 0 {
+		sb.WriteString(telegram_adapters.EscapeMarkdownString(p.Text("notifications.channels.default")))
+	} else {
+		writeTitles(sb, p, "notifications.channel.", channels)
+	}
+}
+
+func writeChannelsUsage(sb *strings.Builder, p i18n.Printer) {
+	writeLabel(sb, p, "notifications.channels")
+	sb.WriteString("/notifications\\_channels")
+	writeOptions(sb, appointment.NotificationChannels)
+}
+
+func writeLabel(sb *strings.Builder, p i18n.Printer, key string) {
+	sb.WriteString(telegram_adapters.EscapeMarkdownString(p.Text(key)))
+	sb.WriteString(": ")
+}
+
+// Items are translated by the `prefix` of their messages
+func writeTitles[T interface{ String() string }](sb *strings.Builder, p i18n.Printer, prefix string, items []T) {
+	for i, item := range items {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(telegram_adapters.EscapeMarkdownString(p.Text(prefix + item.String())))
+	}
+}
+
+func writeOptions[T interface{ String() string }](sb *strings.Builder, options []T) {
+	sb.WriteString(" \\[")
+	for i, o := range options {
+		if i > 0 {
+			sb.WriteString("\\|")
+		}
+		sb.WriteString(telegram_adapters.EscapeMarkdownString(o.String()))
+	}
+	sb.WriteString("\\]")
+}
@@ -0,0 +1,50 @@
+package appointment_telegram_presenter
+
+import (
+	"strings"
+
+	telegram_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/telegram"
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/i18n"
+	"gopkg.in/telebot.v3"
+)
+
+func RenderNotificationPreferences(preferences appointment.NotificationPreferences) (telegram_adapters.LocalizedTextResponses, error) {
+	return func(p i18n.Printer) telegram_adapters.TextResponses {
+		sb := strings.Builder{}
+		writeTitle(&sb, p.Text("notifications"))
+		sb.WriteString("\n\n")
+		writeChannels(&sb, p, preferences.Channels)
+		sb.WriteString("\n")
+		writeLabel(&sb, p, "notifications.muted")
+		if len(preferences.MutedEvents) == 0 {
+			sb.WriteString(telegram_adapters.EscapeMarkdownString(p.Text("label.none")))
+		} else {
+			writeTitles(&sb, p, "notifications.event.", preferences.MutedEvents)
+		}
+		sb.WriteString("\n")
+		writeLabel(&sb, p, "notifications.quiet_hours")
+		if preferences.QuietHours.IsZero() {
+			sb.WriteString(telegram_adapters.EscapeMarkdownString(p.Text("label.none")))
+		} else {
+			sb.WriteString(telegram_adapters.EscapeMarkdownString(
+				preferences.QuietHours.Start.String() + " - " + preferences.QuietHours.End.String(),
+			))
+		}
+		sb.WriteString("\n\n")
+		writeChannelsUsage(&sb, p)
+		sb.WriteString("\n")
+		writeLabel(&sb, p, "notifications.mute")
+		sb.WriteString("/notifications\\_mute")
+		writeOptions(&sb, appointment.NotificationEvents)
+		sb.WriteString("\n")
+		writeLabel(&sb, p, "notifications.quiet_hours")
+		sb.WriteString("/notifications\\_quiet \\[22:00 08:00\\]")
+		return telegram_adapters.TextResponses{{
+			Text: sb.String(),
+			Options: &telebot.SendOptions{
+				ParseMode: telebot.ModeMarkdownV2,
+			},
+		}}
+	}, nil
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/x0k/veterinary-clinic-backend/internal/adapters"
 	telegram_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/telegram"
 	appointment_telegram_adapters "github.com/x0k/veterinary-clinic-backend/internal/appointment/adapters/telegram"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/i18n"
 	"github.com/x0k/veterinary-clinic-backend/internal/shared"
 	"gopkg.in/telebot.v3"
 )
@@ -22,21 +23,38 @@ func NewRegistrationPresenter(
 	}
 }
 
-func (p *RegistrationPresenter) RenderRegistration(telegramUserId shared.TelegramUserId) (telegram_adapters.TextResponses, error) {
+func (p *RegistrationPresenter) RenderRegistration(telegramUserId shared.TelegramUserId) (telegram_adapters.LocalizedTextResponses, error) {
 	p.stateSaver(
 		adapters.NewStateId(strconv.FormatInt(telegramUserId.Int(), 10)),
 		telegramUserId,
 	)
-	return telegram_adapters.TextResponses{{
-		Text: "Для записи на прием, необходимо уточнить ваш номер телефона.",
-		Options: &telebot.SendOptions{
-			ReplyMarkup: &telebot.ReplyMarkup{
-				OneTimeKeyboard: true,
-				ReplyKeyboard: [][]telebot.ReplyButton{
-					{*appointment_telegram_adapters.RegisterTelegramCustomerBtn},
-					{*appointment_telegram_adapters.CancelRegisterTelegramCustomerBtn},
+	return func(p i18n.Printer) telegram_adapters.TextResponses {
+		register := *appointment_telegram_adapters.RegisterTelegramCustomerBtn
+		register.Text = p.Text("button.share_phone")
+		cancel := *appointment_telegram_adapters.CancelRegisterTelegramCustomerBtn
+		cancel.Text = p.Text("button.cancel_register")
+		return telegram_adapters.TextResponses{{
+			Text: p.Text("registration"),
+			Options: &telebot.SendOptions{
+				ReplyMarkup: &telebot.ReplyMarkup{
+					OneTimeKeyboard: true,
+					ReplyKeyboard: [][]telebot.ReplyButton{
+						{register},
+						{cancel},
+					},
 				},
+			}},
+		}
+	}, nil
+}
+
+func RenderRegistrationCanceled() (telegram_adapters.LocalizedTextResponses, error) {
+	return func(p i18n.Printer) telegram_adapters.TextResponses {
+		return telegram_adapters.TextResponses{{
+			Text: p.Text("registration.canceled"),
+			Options: &telebot.SendOptions{
+				ReplyMarkup: &telebot.ReplyMarkup{RemoveKeyboard: true},
 			},
-		}},
+		}}
 	}, nil
 }
@@ -0,0 +1,139 @@
+package appointment_telegram_presenter
+
+import (
+	"fmt"
+	"strings"
+
+	telegram_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/telegram"
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	appointment_telegram_adapters "github.com/x0k/veterinary-clinic-backend/internal/appointment/adapters/telegram"
+	appointment_presenter "github.com/x0k/veterinary-clinic-backend/internal/appointment/presenter"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/i18n"
+	"github.com/x0k/veterinary-clinic-backend/internal/shared"
+	"gopkg.in/telebot.v3"
+)
+
+func RescheduleOfferPresenter(
+	offer appointment.RescheduleOffer,
+	customer appointment.CustomerEntity,
+	service appointment.ServiceEntity,
+) (telegram_adapters.Message, error) {
+	id, err := customer.Identity.ToTelegramUserId()
+	if err != nil {
+		return nil, err
+	}
+	p := appointment_presenter.CustomerPrinter(customer)
+	sb := strings.Builder{}
+	writeTitle(&sb, p.Text("reschedule.offer"))
+	sb.WriteString("\n\n")
+	sb.WriteString(telegram_adapters.EscapeMarkdownString(service.Title))
+	sb.WriteString("\n\n")
+	if offer.Reason != "" {
+		writeLabel(&sb, p, "reschedule.offer.reason")
+		sb.WriteString(telegram_adapters.EscapeMarkdownString(offer.Reason))
+		sb.WriteString("\n\n")
+	}
+	sb.WriteString(telegram_adapters.EscapeMarkdownString(p.Text("reschedule.offer.pick")))
+	keyboard := make([][]telebot.InlineButton, 0, len(offer.Slots)+1)
+	for i, slot := range offer.Slots {
+		btn := withData(p, appointment_telegram_adapters.RescheduleSlotBtn, fmt.Sprintf("%s|%d", offer.Id, i))
+		btn.Text = p.DateTime(slot)
+		keyboard = append(keyboard, []telebot.InlineButton{btn})
+	}
+	keyboard = append(keyboard, []telebot.InlineButton{
+		withData(p, appointment_telegram_adapters.DeclineRescheduleBtn, offer.Id.String()),
+	})
+	return telegram_adapters.NewTextMessages(
+		&telebot.User{
+			ID: id.Int(),
+		},
+		telegram_adapters.NewSendableText(
+			sb.String(),
+			&telebot.SendOptions{
+				ParseMode: telebot.ModeMarkdownV2,
+				ReplyMarkup: &telebot.ReplyMarkup{
+					InlineKeyboard: keyboard,
+				},
+			},
+		),
+	), nil
+}
+
+func RenderRescheduleDeclined() (telegram_adapters.LocalizedTextResponses, error) {
+	return func(p i18n.Printer) telegram_adapters.TextResponses {
+		return telegram_adapters.TextResponses{
+			telegram_adapters.NewSendableText(p.Text("reschedule.declined")),
+		}
+	}, nil
+}
+
+func RenderPeriodCanceled(
+	period shared.DateTimePeriod,
+	reason string,
+	canceled []appointment.AppointmentDetails,
+	offers []appointment.RescheduleOffer,
+) (telegram_adapters.LocalizedTextResponses, error) {
+	start := shared.DateTimeToGoTime(period.Start)
+	end := shared.DateTimeToGoTime(period.End)
+	return func(p i18n.Printer) telegram_adapters.TextResponses {
+		sb := strings.Builder{}
+		writeTitle(&sb, p.Text("admin.period_blocked"))
+		sb.WriteString("\n\n")
+		sb.WriteString(telegram_adapters.EscapeMarkdownString(p.DateTime(start)))
+		sb.WriteString(" \\- ")
+		sb.WriteString(telegram_adapters.EscapeMarkdownString(p.DateTime(end)))
+		sb.WriteString("\n\n")
+		sb.WriteString(telegram_adapters.EscapeMarkdownString(reason))
+		sb.WriteString("\n\n")
+		sb.WriteString(telegram_adapters.EscapeMarkdownString(
+			p.Plural("period_canceled.records", len(canceled)) + ", " +
+				p.Plural("period_canceled.offers", len(offers)),
+		))
+		for _, app := range canceled {
+			sb.WriteString("\n\n")
+			writeAppointmentSummary(&sb, p, app.Record, app.Customer, app.Service)
+		}
+		return telegram_adapters.TextResponses{{
+			Text: sb.String(),
+			Options: &telebot.SendOptions{
+				ParseMode: telebot.ModeMarkdownV2,
+			},
+		}}
+	}, nil
+}
+
+func rescheduleOfferStatus(p i18n.Printer, offer appointment.RescheduleOffer) string {
+	switch offer.Status {
+	case appointment.RescheduleOfferAccepted:
+		return p.Text("reschedule.offer.accepted", p.DateTime(offer.AcceptedSlot))
+	case appointment.RescheduleOfferDeclined:
+		return p.Text("reschedule.offer.declined")
+	default:
+		return p.Text("reschedule.offer.pending")
+	}
+}
+
+func RenderRescheduleOffers(offers []appointment.RescheduleOfferDetails) (telegram_adapters.LocalizedTextResponses, error) {
+	return func(p i18n.Printer) telegram_adapters.TextResponses {
+		sb := strings.Builder{}
+		writeTitle(&sb, p.Text("reschedule.offers"))
+		if len(offers) == 0 {
+			sb.WriteString("\n\n")
+			sb.WriteString(telegram_adapters.EscapeMarkdownString(p.Text("reschedule.offers.empty")))
+		}
+		for _, o := range offers {
+			sb.WriteString("\n\n")
+			sb.WriteString(telegram_adapters.EscapeMarkdownString(o.Customer.Name))
+			sb.WriteString(" \\- ")
+			sb.WriteString(telegram_adapters.EscapeMarkdownString(o.Service.Title))
+			sb.WriteString("\n")
+			sb.WriteString(telegram_adapters.EscapeMarkdownString(rescheduleOfferStatus(p, o.Offer)))
+		}
+		return telegram_adapters.TextResponses{{
+			Text: sb.String(),
+			Options: &telebot.SendOptions{
+				ParseMode: telebot.ModeMarkdownV2,
+			},
+		}}
+	}, nil
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
 	appointment_telegram_adapters "github.com/x0k/veterinary-clinic-backend/internal/appointment/adapters/telegram"
 	web_calendar_adapters "github.com/x0k/veterinary-clinic-backend/internal/appointment/adapters/web_calendar"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/i18n"
 	"gopkg.in/telebot.v3"
 )
 
@@ -68,20 +69,23 @@ func NewScheduleTextPresenter(
 	}
 }
 
-func (p *ScheduleTextPresenter) RenderSchedule(now time.Time, schedule appointment.Schedule) (telegram_adapters.TextResponses, error) {
-	sb := strings.Builder{}
-	writeSchedule(&sb, schedule)
-	return telegram_adapters.TextResponses{{
-		Text: sb.String(),
-		Options: &telebot.SendOptions{
-			ParseMode: telebot.ModeMarkdownV2,
-			ReplyMarkup: &telebot.ReplyMarkup{
-				InlineKeyboard: [][]telebot.InlineButton{
-					p.scheduleButtons(now, schedule),
+func (p *ScheduleTextPresenter) RenderSchedule(now time.Time, schedule appointment.Schedule) (telegram_adapters.LocalizedTextResponses, error) {
+	buttons := p.scheduleButtons(now, schedule)
+	return func(pr i18n.Printer) telegram_adapters.TextResponses {
+		sb := strings.Builder{}
+		writeSchedule(&sb, pr, schedule)
+		return telegram_adapters.TextResponses{{
+			Text: sb.String(),
+			Options: &telebot.SendOptions{
+				ParseMode: telebot.ModeMarkdownV2,
+				ReplyMarkup: &telebot.ReplyMarkup{
+					InlineKeyboard: [][]telebot.InlineButton{
+						buttons,
+					},
 				},
 			},
-		},
-	}}, nil
+		}}
+	}, nil
 }
 
 type ScheduleQueryPresenter struct {
@@ -100,23 +104,26 @@ func NewScheduleQueryPresenter(
 	}
 }
 
-func (p *ScheduleQueryPresenter) RenderSchedule(now time.Time, schedule appointment.Schedule) (telegram_adapters.QueryResponse, error) {
-	sb := strings.Builder{}
-	writeSchedule(&sb, schedule)
-	return telegram_adapters.QueryResponse{
-		Result: &telebot.ArticleResult{
-			ResultBase: telebot.ResultBase{
-				ID:        fmt.Sprintf("%p", &schedule),
-				Type:      "article",
-				ParseMode: telebot.ModeMarkdownV2,
-				ReplyMarkup: &telebot.ReplyMarkup{
-					InlineKeyboard: [][]telebot.InlineButton{
-						p.scheduleButtons(now, schedule),
+func (p *ScheduleQueryPresenter) RenderSchedule(now time.Time, schedule appointment.Schedule) (telegram_adapters.LocalizedQueryResponse, error) {
+	buttons := p.scheduleButtons(now, schedule)
+	return func(pr i18n.Printer) telegram_adapters.QueryResponse {
+		sb := strings.Builder{}
+		writeSchedule(&sb, pr, schedule)
+		return telegram_adapters.QueryResponse{
+			Result: &telebot.ArticleResult{
+				ResultBase: telebot.ResultBase{
+					ID:        fmt.Sprintf("%p", &schedule),
+					Type:      "article",
+					ParseMode: telebot.ModeMarkdownV2,
+					ReplyMarkup: &telebot.ReplyMarkup{
+						InlineKeyboard: [][]telebot.InlineButton{
+							buttons,
+						},
 					},
 				},
+				Title: pr.Text("schedule"),
+				Text:  sb.String(),
 			},
-			Title: "График работы",
-			Text:  sb.String(),
-		},
+		}
 	}, nil
 }
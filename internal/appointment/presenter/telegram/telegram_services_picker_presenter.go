@@ -5,6 +5,7 @@ import (
 	telegram_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/telegram"
 	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
 	appointment_telegram_adapters "github.com/x0k/veterinary-clinic-backend/internal/appointment/adapters/telegram"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/i18n"
 	"gopkg.in/telebot.v3"
 )
 
@@ -20,7 +21,7 @@ func NewServicesPickerPresenter(
 	}
 }
 
-func (p *ServicesPickerPresenter) RenderServicesList(services []appointment.ServiceEntity) (telegram_adapters.TextResponses, error) {
+func (p *ServicesPickerPresenter) RenderServicesList(services []appointment.ServiceEntity) (telegram_adapters.LocalizedTextResponses, error) {
 	buttons := make([][]telebot.InlineButton, 0, len(services))
 	for _, service := range services {
 		buttons = append(buttons, []telebot.InlineButton{{
@@ -29,12 +30,14 @@ func (p *ServicesPickerPresenter) RenderServicesList(services []appointment.Serv
 			Data:   p.stateSaver(service.Id).String(),
 		}})
 	}
-	return telegram_adapters.TextResponses{{
-		Text: "Выберите услугу:",
-		Options: &telebot.SendOptions{
-			ReplyMarkup: &telebot.ReplyMarkup{
-				InlineKeyboard: buttons,
+	return func(pr i18n.Printer) telegram_adapters.TextResponses {
+		return telegram_adapters.TextResponses{{
+			Text: pr.Text("services.pick"),
+			Options: &telebot.SendOptions{
+				ReplyMarkup: &telebot.ReplyMarkup{
+					InlineKeyboard: buttons,
+				},
 			},
-		},
-	}}, nil
+		}}
+	}, nil
 }
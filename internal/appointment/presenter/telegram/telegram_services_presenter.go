@@ -5,25 +5,29 @@ import (
 
 	telegram_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/telegram"
 	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/i18n"
 	"gopkg.in/telebot.v3"
 )
 
-func ServicesPresenter(services []appointment.ServiceEntity) (telegram_adapters.TextResponses, error) {
-	sb := strings.Builder{}
-	sb.WriteString("Услуги: \n\n")
-	for _, service := range services {
-		sb.WriteByte('*')
-		sb.WriteString(service.Title)
-		sb.WriteString("*\n")
-		if service.Description != "" {
-			sb.WriteString(telegram_adapters.EscapeMarkdownString(service.Description))
-			sb.WriteString("\n")
+func ServicesPresenter(services []appointment.ServiceEntity) (telegram_adapters.LocalizedTextResponses, error) {
+	return func(p i18n.Printer) telegram_adapters.TextResponses {
+		sb := strings.Builder{}
+		sb.WriteString(telegram_adapters.EscapeMarkdownString(p.Text("services")))
+		sb.WriteString(" \n\n")
+		for _, service := range services {
+			sb.WriteByte('*')
+			sb.WriteString(service.Title)
+			sb.WriteString("*\n")
+			if service.Description != "" {
+				sb.WriteString(telegram_adapters.EscapeMarkdownString(service.Description))
+				sb.WriteString("\n")
+			}
+			sb.WriteString(telegram_adapters.EscapeMarkdownString(service.CostDescription))
+			sb.WriteString("\n\n")
 		}
-		sb.WriteString(telegram_adapters.EscapeMarkdownString(service.CostDescription))
-		sb.WriteString("\n\n")
-	}
-	return telegram_adapters.TextResponses{{
-		Text:    sb.String(),
-		Options: &telebot.SendOptions{ParseMode: telebot.ModeMarkdownV2},
-	}}, nil
+		return telegram_adapters.TextResponses{{
+			Text:    sb.String(),
+			Options: &telebot.SendOptions{ParseMode: telebot.ModeMarkdownV2},
+		}}
+	}, nil
 }
@@ -3,11 +3,10 @@ package appointment_telegram_presenter
 import (
 	telegram_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/telegram"
 	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/i18n"
 	"gopkg.in/telebot.v3"
 )
 
-const successRegistrationText = "Вы успешно зарегистрированы!"
-
 type SuccessRegistrationPresenter struct {
 	servicesPickerPresenter *ServicesPickerPresenter
 }
@@ -20,19 +19,21 @@ func NewSuccessRegistrationPresenter(
 	}
 }
 
-func (p *SuccessRegistrationPresenter) RenderSuccessRegistration(services []appointment.ServiceEntity) (telegram_adapters.TextResponses, error) {
+func (p *SuccessRegistrationPresenter) RenderSuccessRegistration(services []appointment.ServiceEntity) (telegram_adapters.LocalizedTextResponses, error) {
 	picker, err := p.servicesPickerPresenter.RenderServicesList(services)
 	if err != nil {
 		return nil, err
 	}
-	return append(telegram_adapters.TextResponses{
-		{
-			Text: successRegistrationText,
-			Options: &telebot.SendOptions{
-				ReplyMarkup: &telebot.ReplyMarkup{
-					RemoveKeyboard: true,
+	return func(p i18n.Printer) telegram_adapters.TextResponses {
+		return append(telegram_adapters.TextResponses{
+			{
+				Text: p.Text("registration.success"),
+				Options: &telebot.SendOptions{
+					ReplyMarkup: &telebot.ReplyMarkup{
+						RemoveKeyboard: true,
+					},
 				},
 			},
-		},
-	}, picker...), nil
+		}, picker(p)...)
+	}, nil
 }
@@ -0,0 +1,55 @@
+package appointment_telegram_presenter
+
+import (
+	"strconv"
+	"strings"
+
+	telegram_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/telegram"
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/i18n"
+	"gopkg.in/telebot.v3"
+)
+
+func RenderFailedWebhookDeliveries(deliveries []appointment.WebhookDelivery) (telegram_adapters.LocalizedTextResponses, error) {
+	return func(p i18n.Printer) telegram_adapters.TextResponses {
+		sb := strings.Builder{}
+		writeTitle(&sb, p.Text("webhooks.failed"))
+		if len(deliveries) == 0 {
+			sb.WriteString("\n\n")
+			sb.WriteString(telegram_adapters.EscapeMarkdownString(p.Text("webhooks.failed.empty")))
+		}
+		for _, d := range deliveries {
+			sb.WriteString("\n\n`")
+			sb.WriteString(strconv.FormatInt(int64(d.Id), 10))
+			sb.WriteString("` ")
+			sb.WriteString(telegram_adapters.EscapeMarkdownString(d.Url))
+			sb.WriteString("\n")
+			sb.WriteString(telegram_adapters.EscapeMarkdownString(
+				p.DateTime(d.CreatedAt) + ", " + p.Plural("webhooks.attempts", d.Attempts),
+			))
+			if d.LastError != "" {
+				sb.WriteString("\n")
+				sb.WriteString(telegram_adapters.EscapeMarkdownString(d.LastError))
+			}
+		}
+		if len(deliveries) > 0 {
+			sb.WriteString("\n\n")
+			sb.WriteString(telegram_adapters.EscapeMarkdownString(p.Text("webhooks.replay")))
+			sb.WriteString(": /webhooks\\_replay \\[id\\.\\.\\.\\]")
+		}
+		return telegram_adapters.TextResponses{{
+			Text: sb.String(),
+			Options: &telebot.SendOptions{
+				ParseMode: telebot.ModeMarkdownV2,
+			},
+		}}
+	}, nil
+}
+
+func RenderWebhookDeliveriesReplayed(count int) (telegram_adapters.LocalizedTextResponses, error) {
+	return func(p i18n.Printer) telegram_adapters.TextResponses {
+		return telegram_adapters.TextResponses{
+			telegram_adapters.NewSendableText(p.Plural("webhooks.replayed", count)),
+		}
+	}, nil
+}
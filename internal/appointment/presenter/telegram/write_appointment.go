@@ -6,10 +6,12 @@ import (
 
 	telegram_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/telegram"
 	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/i18n"
 )
 
 func writeAppointment(
 	w *strings.Builder,
+	p i18n.Printer,
 	service appointment.ServiceEntity,
 	appointmentDateTime time.Time,
 ) {
@@ -21,7 +23,7 @@ func writeAppointment(
 	}
 	w.WriteString(telegram_adapters.EscapeMarkdownString(service.CostDescription))
 	w.WriteString("\n\n")
-	w.WriteString(telegram_adapters.EscapeMarkdownString(appointmentDateTime.Format("02.01.2006 15:04")))
+	w.WriteString(telegram_adapters.EscapeMarkdownString(p.DateTime(appointmentDateTime)))
 	w.WriteString(" \\- ")
 	w.WriteString(telegram_adapters.EscapeMarkdownString(
 		appointmentDateTime.Add(time.Duration(service.DurationInMinutes) * time.Minute).Format("15:04"),
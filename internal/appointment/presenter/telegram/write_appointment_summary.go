@@ -5,11 +5,13 @@ import (
 
 	telegram_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/telegram"
 	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/i18n"
 	"github.com/x0k/veterinary-clinic-backend/internal/shared"
 )
 
 func writeAppointmentSummary(
 	sb *strings.Builder,
+	p i18n.Printer,
 	app appointment.RecordEntity,
 	customer appointment.CustomerEntity,
 	service appointment.ServiceEntity,
@@ -18,7 +20,7 @@ func writeAppointmentSummary(
 	end := shared.DateTimeToGoTime(app.DateTimePeriod.End)
 	sb.WriteString(
 		telegram_adapters.EscapeMarkdownString(
-			start.Format("02.01.2006 15:04"),
+			p.DateTime(start),
 		),
 	)
 	sb.WriteString(" \\- ")
@@ -5,24 +5,28 @@ import (
 
 	telegram_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/telegram"
 	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	appointment_presenter "github.com/x0k/veterinary-clinic-backend/internal/appointment/presenter"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/i18n"
 )
 
-func writeSchedule(sb *strings.Builder, schedule appointment.Schedule) {
-	sb.WriteString("График работы на ")
+func writeSchedule(sb *strings.Builder, p i18n.Printer, schedule appointment.Schedule) {
 	sb.WriteString(telegram_adapters.EscapeMarkdownString(
-		schedule.Date.Format("02.01.2006")),
-	)
-	sb.WriteString(":\n\n")
+		p.Text("schedule.title", p.Date(schedule.Date)),
+	))
+	sb.WriteString("\n\n")
 	for _, period := range schedule.Entries {
 		sb.WriteByte('*')
 		sb.WriteString(period.Start.Time.String())
 		sb.WriteString(" \\- ")
 		sb.WriteString(period.End.Time.String())
 		sb.WriteString("*\n")
-		sb.WriteString(telegram_adapters.EscapeMarkdownString(period.Title))
+		sb.WriteString(telegram_adapters.EscapeMarkdownString(
+			appointment_presenter.ScheduleEntryTitle(p, period),
+		))
 		sb.WriteString("\n\n")
 	}
 	if len(schedule.Entries) == 0 {
-		sb.WriteString("Нет записей\n\n")
+		sb.WriteString(telegram_adapters.EscapeMarkdownString(p.Text("schedule.empty")))
+		sb.WriteString("\n\n")
 	}
 }
@@ -0,0 +1,57 @@
+package appointment_vk_presenter
+
+import (
+	"strings"
+	"time"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/adapters"
+	vk_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/vk"
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	appointment_vk_adapters "github.com/x0k/veterinary-clinic-backend/internal/appointment/adapters/vk"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/i18n"
+)
+
+type ConfirmationPresenter struct {
+	stateSaver adapters.StateSaver[appointment_vk_adapters.AppointmentState]
+}
+
+func NewConfirmationPresenter(
+	stateSaver adapters.StateSaver[appointment_vk_adapters.AppointmentState],
+) *ConfirmationPresenter {
+	return &ConfirmationPresenter{
+		stateSaver: stateSaver,
+	}
+}
+
+func (p *ConfirmationPresenter) RenderConfirmation(
+	service appointment.ServiceEntity,
+	appointmentDateTime time.Time,
+) (vk_adapters.LocalizedTextResponses, error) {
+	stateId := p.stateSaver(appointment_vk_adapters.AppointmentState{
+		ServiceId: service.Id,
+		Date:      appointmentDateTime,
+	}).String()
+	// Time picker starts from the beginning of the day
+	backStateId := p.stateSaver(appointment_vk_adapters.AppointmentState{
+		ServiceId: service.Id,
+		Date: time.Date(
+			appointmentDateTime.Year(),
+			appointmentDateTime.Month(),
+			appointmentDateTime.Day(),
+			0, 0, 0, 0,
+			appointmentDateTime.Location(),
+		),
+	}).String()
+	return func(p i18n.Printer) vk_adapters.TextResponses {
+		sb := strings.Builder{}
+		sb.WriteString(p.Text("appointment.confirm"))
+		sb.WriteString("\n\n")
+		writeAppointment(&sb, p, service, appointmentDateTime)
+		return vk_adapters.TextResponses{
+			vk_adapters.NewText(sb.String(), vk_adapters.NewInlineKeyboard(
+				[]vk_adapters.Button{withData(p, appointment_vk_adapters.ConfirmMakeAppointmentBtn, stateId)},
+				[]vk_adapters.Button{withData(p, appointment_vk_adapters.CancelConfirmationAppointmentBtn, backStateId)},
+			)),
+		}
+	}, nil
+}
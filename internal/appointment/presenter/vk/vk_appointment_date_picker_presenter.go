@@ -0,0 +1,64 @@
+package appointment_vk_presenter
+
+import (
+	"strings"
+	"time"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/adapters"
+	vk_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/vk"
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	appointment_vk_adapters "github.com/x0k/veterinary-clinic-backend/internal/appointment/adapters/vk"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/i18n"
+)
+
+type DatePickerPresenter struct {
+	stateSaver adapters.StateSaver[appointment_vk_adapters.AppointmentState]
+}
+
+func NewDatePickerPresenter(
+	stateSaver adapters.StateSaver[appointment_vk_adapters.AppointmentState],
+) *DatePickerPresenter {
+	return &DatePickerPresenter{
+		stateSaver: stateSaver,
+	}
+}
+
+func (p *DatePickerPresenter) RenderDatePicker(
+	now time.Time,
+	serviceId appointment.ServiceId,
+	schedule appointment.Schedule,
+	availability appointment.Availability,
+) (vk_adapters.LocalizedTextResponses, error) {
+	navigation := make([]vk_adapters.Button, 0, 2)
+	if now.Add(-24 * time.Hour).Before(schedule.PrevDate) {
+		navigation = append(navigation, appointment_vk_adapters.PrevMakeAppointmentDateBtn.With(
+			p.stateSaver(appointment_vk_adapters.AppointmentState{
+				ServiceId: serviceId,
+				Date:      schedule.PrevDate,
+			}).String(),
+		))
+	}
+	navigation = append(navigation, appointment_vk_adapters.NextMakeAppointmentDateBtn.With(
+		p.stateSaver(appointment_vk_adapters.AppointmentState{
+			ServiceId: serviceId,
+			Date:      schedule.NextDate,
+		}).String(),
+	))
+	selectStateId := p.stateSaver(appointment_vk_adapters.AppointmentState{
+		ServiceId: serviceId,
+		Date:      schedule.Date,
+	}).String()
+	return func(pr i18n.Printer) vk_adapters.TextResponses {
+		sb := strings.Builder{}
+		writeSchedule(&sb, pr, schedule)
+		return vk_adapters.TextResponses{
+			vk_adapters.NewText(sb.String(), vk_adapters.NewInlineKeyboard(
+				navigation,
+				[]vk_adapters.Button{
+					button(pr, appointment_vk_adapters.CancelMakeAppointmentDateBtn),
+					withData(pr, appointment_vk_adapters.SelectMakeAppointmentDateBtn, selectStateId),
+				},
+			)),
+		}
+	}, nil
+}
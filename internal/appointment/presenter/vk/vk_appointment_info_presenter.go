@@ -0,0 +1,57 @@
+package appointment_vk_presenter
+
+import (
+	"strings"
+
+	vk_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/vk"
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	appointment_vk_adapters "github.com/x0k/veterinary-clinic-backend/internal/appointment/adapters/vk"
+	appointment_presenter "github.com/x0k/veterinary-clinic-backend/internal/appointment/presenter"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/i18n"
+	"github.com/x0k/veterinary-clinic-backend/internal/shared"
+)
+
+func recordState(record appointment.RecordEntity) (func(p i18n.Printer) string, error) {
+	if _, err := appointment_presenter.RecordState(i18n.Printer{}, record.Status, record.IsArchived); err != nil {
+		return nil, err
+	}
+	return func(p i18n.Printer) string {
+		state, _ := appointment_presenter.RecordState(p, record.Status, record.IsArchived)
+		return state
+	}, nil
+}
+
+func RenderAppointmentInfo(
+	app appointment.RecordEntity,
+	service appointment.ServiceEntity,
+) (vk_adapters.LocalizedTextResponses, error) {
+	status, err := recordState(app)
+	if err != nil {
+		return nil, err
+	}
+	return func(p i18n.Printer) vk_adapters.TextResponses {
+		sb := strings.Builder{}
+		sb.WriteString(p.Text("label.status"))
+		sb.WriteString(": ")
+		sb.WriteString(status(p))
+		sb.WriteString("\n\n")
+		writeAppointment(&sb, p, service, shared.DateTimeToGoTime(app.DateTimePeriod.Start))
+		var keyboard *vk_adapters.Keyboard
+		if app.Status.IsCancelable() {
+			keyboard = vk_adapters.NewInlineKeyboard(
+				[]vk_adapters.Button{button(p, appointment_vk_adapters.CancelAppointmentBtn)},
+			)
+		}
+		return vk_adapters.TextResponses{
+			vk_adapters.NewText(sb.String(), keyboard),
+		}
+	}, nil
+}
+
+func RenderAppointmentCancel() (vk_adapters.LocalizedEventResponse, error) {
+	return func(p i18n.Printer) vk_adapters.EventResponse {
+		return vk_adapters.EventResponse{
+			Text: p.Text("appointment.canceled"),
+		}
+	}, nil
+}
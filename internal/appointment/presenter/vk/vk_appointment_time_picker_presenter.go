@@ -0,0 +1,98 @@
+package appointment_vk_presenter
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/adapters"
+	vk_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/vk"
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	appointment_vk_adapters "github.com/x0k/veterinary-clinic-backend/internal/appointment/adapters/vk"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/i18n"
+	"github.com/x0k/veterinary-clinic-backend/internal/shared"
+)
+
+const (
+	timePickerPageSize = 8
+	timePickerColumns  = 4
+)
+
+type TimePickerPresenter struct {
+	stateSaver adapters.StateSaver[appointment_vk_adapters.AppointmentState]
+}
+
+func NewTimePickerPresenter(stateSaver adapters.StateSaver[appointment_vk_adapters.AppointmentState]) *TimePickerPresenter {
+	return &TimePickerPresenter{
+		stateSaver: stateSaver,
+	}
+}
+
+func (p *TimePickerPresenter) slotState(
+	serviceId appointment.ServiceId,
+	appointmentDate time.Time,
+	slotStart shared.Time,
+) string {
+	return p.stateSaver(appointment_vk_adapters.AppointmentState{
+		ServiceId: serviceId,
+		Date: time.Date(
+			appointmentDate.Year(),
+			appointmentDate.Month(),
+			appointmentDate.Day(),
+			slotStart.Hours,
+			slotStart.Minutes,
+			0,
+			0,
+			appointmentDate.Location(),
+		),
+	}).String()
+}
+
+// Slots do not fit into a single inline keyboard, so they are paged.
+// The time of the `appointmentDate` is the start of the page.
+func (p *TimePickerPresenter) RenderTimePicker(
+	serviceId appointment.ServiceId,
+	appointmentDate time.Time,
+	slots appointment.SampledFreeTimeSlots,
+) (vk_adapters.LocalizedTextResponses, error) {
+	pageStart := shared.GoTimeToTime(appointmentDate)
+	page := make([]vk_adapters.Button, 0, timePickerPageSize)
+	var next *vk_adapters.Button
+	for _, slot := range slots {
+		if shared.CompareTime(slot.Start, pageStart) < 0 {
+			continue
+		}
+		if len(page) == timePickerPageSize {
+			btn := appointment_vk_adapters.MoreMakeAppointmentTimeBtn.With(
+				p.slotState(serviceId, appointmentDate, slot.Start),
+			)
+			next = &btn
+			break
+		}
+		btn := appointment_vk_adapters.MakeAppointmentTimeBtn.With(
+			p.slotState(serviceId, appointmentDate, slot.Start),
+		)
+		btn.Action.Label = fmt.Sprintf("%s - %s", slot.Start.String(), slot.End.String())
+		page = append(page, btn)
+	}
+	backStateId := p.stateSaver(appointment_vk_adapters.AppointmentState{
+		ServiceId: serviceId,
+		Date:      appointmentDate,
+	}).String()
+	return func(pr i18n.Printer) vk_adapters.TextResponses {
+		navigation := []vk_adapters.Button{
+			withData(pr, appointment_vk_adapters.CancelMakeAppointmentTimeBtn, backStateId),
+		}
+		if next != nil {
+			navigation = append(navigation, *next)
+		}
+		text := pr.Text("time.pick")
+		if len(page) == 0 {
+			text = pr.Text("time.empty")
+		}
+		return vk_adapters.TextResponses{
+			vk_adapters.NewText(text, vk_adapters.NewInlineKeyboard(
+				append(columnsGrid(page, timePickerColumns), navigation)...,
+			)),
+		}
+	}, nil
+}
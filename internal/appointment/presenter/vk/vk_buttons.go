@@ -0,0 +1,33 @@
+package appointment_vk_presenter
+
+import (
+	vk_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/vk"
+	appointment_vk_adapters "github.com/x0k/veterinary-clinic-backend/internal/appointment/adapters/vk"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/i18n"
+)
+
+// Buttons are matched by the command of the payload, so only the label is translated
+var buttonMessages = map[string]string{
+	appointment_vk_adapters.ServicesBtn.Command:                      "button.services",
+	appointment_vk_adapters.ScheduleBtn.Command:                      "button.schedule",
+	appointment_vk_adapters.StartMakeAppointmentDialogBtn.Command:    "button.appointment",
+	appointment_vk_adapters.RegisterVkCustomerBtn.Command:            "button.register",
+	appointment_vk_adapters.CancelMakeAppointmentDateBtn.Command:     "button.back",
+	appointment_vk_adapters.SelectMakeAppointmentDateBtn.Command:     "button.continue",
+	appointment_vk_adapters.CancelMakeAppointmentTimeBtn.Command:     "button.back",
+	appointment_vk_adapters.ConfirmMakeAppointmentBtn.Command:        "button.confirm",
+	appointment_vk_adapters.CancelConfirmationAppointmentBtn.Command: "button.back",
+	appointment_vk_adapters.CancelAppointmentBtn.Command:             "button.cancel",
+	appointment_vk_adapters.DeclineRescheduleBtn.Command:             "button.decline_offer",
+}
+
+func withData(p i18n.Printer, btn vk_adapters.CallbackButton, data string) vk_adapters.Button {
+	if key, ok := buttonMessages[btn.Command]; ok {
+		btn.Label = p.Text(key)
+	}
+	return btn.With(data)
+}
+
+func button(p i18n.Printer, btn vk_adapters.CallbackButton) vk_adapters.Button {
+	return withData(p, btn, "")
+}
@@ -0,0 +1,52 @@
+package appointment_vk_presenter
+
+import (
+	"errors"
+
+	vk_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/vk"
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	appointment_vk_adapters "github.com/x0k/veterinary-clinic-backend/internal/appointment/adapters/vk"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/i18n"
+	"github.com/x0k/veterinary-clinic-backend/internal/shared"
+)
+
+func errorMessage(err error) string {
+	switch {
+	case errors.Is(err, appointment_vk_adapters.ErrUnknownState):
+		return "error.unknown_state"
+	case errors.Is(err, appointment.ErrForbidden):
+		return "error.forbidden"
+	case errors.Is(err, appointment.ErrInvalidStatusTransition):
+		return "error.invalid_status_transition"
+	case errors.Is(err, shared.ErrNotFound):
+		return "error.not_found"
+	case errors.Is(err, appointment.ErrRescheduleOfferIsClosed), errors.Is(err, appointment.ErrRescheduleOfferNotFound):
+		return "error.reschedule_offer_closed"
+	case errors.Is(err, appointment.ErrDateTimePeriodIsOccupied), errors.Is(err, appointment.ErrPeriodIsLocked):
+		return "error.period_occupied"
+	case errors.Is(err, appointment.ErrInvalidDateTimePeriod):
+		return "error.invalid_period"
+	case errors.Is(err, appointment.ErrInvalidAppointmentStatusForCancel):
+		return "error.not_cancelable"
+	default:
+		return "error.unknown"
+	}
+}
+
+func TextErrorPresenter(err error) (vk_adapters.LocalizedTextResponses, error) {
+	key := errorMessage(err)
+	return func(p i18n.Printer) vk_adapters.TextResponses {
+		return vk_adapters.TextResponses{
+			vk_adapters.NewText(p.Text(key)),
+		}
+	}, nil
+}
+
+func EventErrorPresenter(err error) (vk_adapters.LocalizedEventResponse, error) {
+	key := errorMessage(err)
+	return func(p i18n.Printer) vk_adapters.EventResponse {
+		return vk_adapters.EventResponse{
+			Text: p.Text(key),
+		}
+	}, nil
+}
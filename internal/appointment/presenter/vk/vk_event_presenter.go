@@ -0,0 +1,118 @@
+package appointment_vk_presenter
+
+import (
+	"strconv"
+	"strings"
+
+	vk_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/vk"
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	appointment_presenter "github.com/x0k/veterinary-clinic-backend/internal/appointment/presenter"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/i18n"
+)
+
+func customerPeerId(customer appointment.CustomerEntity) (int64, error) {
+	id, err := customer.Identity.ToVkUserId()
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(id.String(), 10, 64)
+}
+
+func changeTypeTitle(p i18n.Printer, changeType appointment.ChangeType) string {
+	switch changeType {
+	case appointment.CreatedChangeType:
+		return p.Text("change.created")
+	case appointment.DateTimeChangeType:
+		return p.Text("change.date_time")
+	case appointment.RemovedChangeType:
+		return p.Text("change.removed")
+	case appointment.StatusChangeType:
+		return p.Text("change.status")
+	default:
+		return ""
+	}
+}
+
+func AppointmentChangedEventPresenter(
+	event appointment.ChangedEvent,
+	customer appointment.CustomerEntity,
+	service appointment.ServiceEntity,
+) (vk_adapters.Message, error) {
+	p := appointment_presenter.CustomerPrinter(customer)
+	return customerRecordMessage(p, changeTypeTitle(p, event.ChangeType), event.Record, customer, service, "")
+}
+
+func statusTransitionTitle(p i18n.Printer, status appointment.RecordStatus) string {
+	switch status {
+	case appointment.RecordAwaits,
+		appointment.RecordDeclined,
+		appointment.RecordConfirmed,
+		appointment.RecordCheckedIn,
+		appointment.RecordInProgress,
+		appointment.RecordDone,
+		appointment.RecordNotAppear,
+		appointment.RecordCanceledByClinic,
+		appointment.RecordRescheduled:
+		return p.Text("transition." + status.String())
+	default:
+		return p.Text("transition.other")
+	}
+}
+
+func AppointmentStatusTransitionPresenter(
+	transition appointment.StatusTransition,
+	customer appointment.CustomerEntity,
+	service appointment.ServiceEntity,
+) (vk_adapters.Message, error) {
+	p := appointment_presenter.CustomerPrinter(customer)
+	return customerRecordMessage(
+		p,
+		statusTransitionTitle(p, transition.Record.Status),
+		transition.Record,
+		customer,
+		service,
+		transition.Reason,
+	)
+}
+
+// Customers who booked through the mini app are not in the chat
+// until they are reminded
+func AppointmentReminderPresenter(
+	record appointment.RecordEntity,
+	customer appointment.CustomerEntity,
+	service appointment.ServiceEntity,
+) (vk_adapters.Message, error) {
+	p := appointment_presenter.CustomerPrinter(customer)
+	return customerRecordMessage(p, p.Text("notification.reminder"), record, customer, service, "")
+}
+
+func customerRecordMessage(
+	p i18n.Printer,
+	title string,
+	record appointment.RecordEntity,
+	customer appointment.CustomerEntity,
+	service appointment.ServiceEntity,
+	reason string,
+) (vk_adapters.Message, error) {
+	peerId, err := customerPeerId(customer)
+	if err != nil {
+		return nil, err
+	}
+	sb := strings.Builder{}
+	sb.WriteString(title)
+	sb.WriteString(":\n\n")
+	state, err := appointment_presenter.RecordState(p, record.Status, record.IsArchived)
+	if err != nil {
+		return nil, err
+	}
+	sb.WriteString(state)
+	sb.WriteString("\n\n")
+	writeAppointmentSummary(&sb, p, record, customer, service)
+	if reason != "" {
+		sb.WriteString("\n\n")
+		sb.WriteString(p.Text("label.reason"))
+		sb.WriteString(": ")
+		sb.WriteString(reason)
+	}
+	return vk_adapters.NewTextMessages(peerId, vk_adapters.NewText(sb.String())), nil
+}
@@ -0,0 +1,38 @@
+package appointment_vk_presenter
+
+import (
+	vk_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/vk"
+	appointment_vk_adapters "github.com/x0k/veterinary-clinic-backend/internal/appointment/adapters/vk"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/i18n"
+)
+
+type GreetingPresenter struct {
+	buttons []vk_adapters.CallbackButton
+}
+
+func NewGreetingPresenter(
+	createAppointment bool,
+) *GreetingPresenter {
+	buttons := []vk_adapters.CallbackButton{
+		appointment_vk_adapters.ScheduleBtn,
+		appointment_vk_adapters.ServicesBtn,
+	}
+	if createAppointment {
+		buttons = append(buttons, appointment_vk_adapters.StartMakeAppointmentDialogBtn)
+	}
+	return &GreetingPresenter{
+		buttons: buttons,
+	}
+}
+
+func (p *GreetingPresenter) RenderGreeting() (vk_adapters.LocalizedTextResponses, error) {
+	return func(pr i18n.Printer) vk_adapters.TextResponses {
+		rows := make([][]vk_adapters.Button, len(p.buttons))
+		for i, btn := range p.buttons {
+			rows[i] = []vk_adapters.Button{button(pr, btn)}
+		}
+		return vk_adapters.TextResponses{
+			vk_adapters.NewText(pr.Text("greeting"), vk_adapters.NewInlineKeyboard(rows...)),
+		}
+	}, nil
+}
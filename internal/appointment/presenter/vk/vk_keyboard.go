@@ -0,0 +1,20 @@
+package appointment_vk_presenter
+
+import vk_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/vk"
+
+// Inline keyboards are limited to 6 rows
+const maxInlineKeyboardRows = 6
+
+// Places buttons into as few columns as possible
+func grid(buttons []vk_adapters.Button) [][]vk_adapters.Button {
+	columns := (len(buttons) + maxInlineKeyboardRows - 1) / maxInlineKeyboardRows
+	return columnsGrid(buttons, max(columns, 1))
+}
+
+func columnsGrid(buttons []vk_adapters.Button, columns int) [][]vk_adapters.Button {
+	rows := make([][]vk_adapters.Button, 0, (len(buttons)+columns-1)/columns)
+	for i := 0; i < len(buttons); i += columns {
+		rows = append(rows, buttons[i:min(i+columns, len(buttons))])
+	}
+	return rows
+}
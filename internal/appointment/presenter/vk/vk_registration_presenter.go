@@ -0,0 +1,46 @@
+package appointment_vk_presenter
+
+import (
+	vk_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/vk"
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	appointment_vk_adapters "github.com/x0k/veterinary-clinic-backend/internal/appointment/adapters/vk"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/i18n"
+	"github.com/x0k/veterinary-clinic-backend/internal/shared"
+)
+
+func RenderRegistration(vkUserId shared.VkUserId) (vk_adapters.LocalizedTextResponses, error) {
+	return func(p i18n.Printer) vk_adapters.TextResponses {
+		return vk_adapters.TextResponses{
+			vk_adapters.NewText(
+				p.Text("registration.vk"),
+				vk_adapters.NewInlineKeyboard(
+					[]vk_adapters.Button{button(p, appointment_vk_adapters.RegisterVkCustomerBtn)},
+				),
+			),
+		}
+	}, nil
+}
+
+type SuccessRegistrationPresenter struct {
+	servicesPickerPresenter *ServicesPickerPresenter
+}
+
+func NewSuccessRegistrationPresenter(
+	servicesPickerPresenter *ServicesPickerPresenter,
+) *SuccessRegistrationPresenter {
+	return &SuccessRegistrationPresenter{
+		servicesPickerPresenter: servicesPickerPresenter,
+	}
+}
+
+func (p *SuccessRegistrationPresenter) RenderSuccessRegistration(services []appointment.ServiceEntity) (vk_adapters.LocalizedTextResponses, error) {
+	picker, err := p.servicesPickerPresenter.RenderServicesList(services)
+	if err != nil {
+		return nil, err
+	}
+	return func(pr i18n.Printer) vk_adapters.TextResponses {
+		return append(vk_adapters.TextResponses{
+			vk_adapters.NewText(pr.Text("registration.success")),
+		}, picker(pr)...)
+	}, nil
+}
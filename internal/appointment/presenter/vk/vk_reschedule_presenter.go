@@ -0,0 +1,59 @@
+package appointment_vk_presenter
+
+import (
+	"fmt"
+	"strings"
+
+	vk_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/vk"
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	appointment_vk_adapters "github.com/x0k/veterinary-clinic-backend/internal/appointment/adapters/vk"
+	appointment_presenter "github.com/x0k/veterinary-clinic-backend/internal/appointment/presenter"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/i18n"
+)
+
+func RescheduleOfferPresenter(
+	offer appointment.RescheduleOffer,
+	customer appointment.CustomerEntity,
+	service appointment.ServiceEntity,
+) (vk_adapters.Message, error) {
+	peerId, err := customerPeerId(customer)
+	if err != nil {
+		return nil, err
+	}
+	p := appointment_presenter.CustomerPrinter(customer)
+	sb := strings.Builder{}
+	sb.WriteString(p.Text("reschedule.offer"))
+	sb.WriteString("\n\n")
+	sb.WriteString(service.Title)
+	sb.WriteString("\n\n")
+	if offer.Reason != "" {
+		sb.WriteString(p.Text("reschedule.offer.reason"))
+		sb.WriteString(": ")
+		sb.WriteString(offer.Reason)
+		sb.WriteString("\n\n")
+	}
+	sb.WriteString(p.Text("reschedule.offer.pick"))
+	// One button is reserved for declining
+	slots := offer.Slots[:min(len(offer.Slots), vk_adapters.MaxInlineKeyboardButtons-1)]
+	buttons := make([]vk_adapters.Button, 0, len(slots))
+	for i, slot := range slots {
+		btn := appointment_vk_adapters.RescheduleSlotBtn.With(fmt.Sprintf("%s|%d", offer.Id, i))
+		btn.Action.Label = p.DateTime(slot)
+		buttons = append(buttons, btn)
+	}
+	rows := append(columnsGrid(buttons, 2), []vk_adapters.Button{
+		withData(p, appointment_vk_adapters.DeclineRescheduleBtn, offer.Id.String()),
+	})
+	return vk_adapters.NewTextMessages(
+		peerId,
+		vk_adapters.NewText(sb.String(), vk_adapters.NewInlineKeyboard(rows...)),
+	), nil
+}
+
+func RenderRescheduleDeclined() (vk_adapters.LocalizedTextResponses, error) {
+	return func(p i18n.Printer) vk_adapters.TextResponses {
+		return vk_adapters.TextResponses{
+			vk_adapters.NewText(p.Text("reschedule.declined")),
+		}
+	}, nil
+}
@@ -0,0 +1,26 @@
+package appointment_vk_presenter
+
+import (
+	"strings"
+	"time"
+
+	vk_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/vk"
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	appointment_vk_adapters "github.com/x0k/veterinary-clinic-backend/internal/appointment/adapters/vk"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/i18n"
+)
+
+func RenderSchedule(now time.Time, schedule appointment.Schedule) (vk_adapters.LocalizedTextResponses, error) {
+	buttons := make([]vk_adapters.Button, 0, 2)
+	if now.Add(-24 * time.Hour).Before(schedule.PrevDate) {
+		buttons = append(buttons, appointment_vk_adapters.PreviousScheduleBtn.With(schedule.PrevDate.Format(time.DateOnly)))
+	}
+	buttons = append(buttons, appointment_vk_adapters.NextScheduleBtn.With(schedule.NextDate.Format(time.DateOnly)))
+	return func(p i18n.Printer) vk_adapters.TextResponses {
+		sb := strings.Builder{}
+		writeSchedule(&sb, p, schedule)
+		return vk_adapters.TextResponses{
+			vk_adapters.NewText(sb.String(), vk_adapters.NewInlineKeyboard(buttons)),
+		}
+	}, nil
+}
@@ -0,0 +1,39 @@
+package appointment_vk_presenter
+
+import (
+	"github.com/x0k/veterinary-clinic-backend/internal/adapters"
+	vk_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/vk"
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	appointment_vk_adapters "github.com/x0k/veterinary-clinic-backend/internal/appointment/adapters/vk"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/i18n"
+)
+
+type ServicesPickerPresenter struct {
+	stateSaver adapters.StateSaver[appointment.ServiceId]
+}
+
+func NewServicesPickerPresenter(
+	stateSaver adapters.StateSaver[appointment.ServiceId],
+) *ServicesPickerPresenter {
+	return &ServicesPickerPresenter{
+		stateSaver: stateSaver,
+	}
+}
+
+// Only the first services fit into the inline keyboard
+func (p *ServicesPickerPresenter) RenderServicesList(services []appointment.ServiceEntity) (vk_adapters.LocalizedTextResponses, error) {
+	buttons := make([]vk_adapters.Button, 0, min(len(services), vk_adapters.MaxInlineKeyboardButtons))
+	for _, service := range services[:cap(buttons)] {
+		btn := appointment_vk_adapters.MakeAppointmentServiceBtn.With(p.stateSaver(service.Id).String())
+		btn.Action.Label = service.Title
+		buttons = append(buttons, btn)
+	}
+	return func(pr i18n.Printer) vk_adapters.TextResponses {
+		return vk_adapters.TextResponses{
+			vk_adapters.NewText(
+				pr.Text("services.pick"),
+				vk_adapters.NewInlineKeyboard(grid(buttons)...),
+			),
+		}
+	}, nil
+}
@@ -0,0 +1,30 @@
+package appointment_vk_presenter
+
+import (
+	"strings"
+
+	vk_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/vk"
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/i18n"
+)
+
+func ServicesPresenter(services []appointment.ServiceEntity) (vk_adapters.LocalizedTextResponses, error) {
+	return func(p i18n.Printer) vk_adapters.TextResponses {
+		sb := strings.Builder{}
+		sb.WriteString(p.Text("services"))
+		sb.WriteString("\n\n")
+		for _, service := range services {
+			sb.WriteString(service.Title)
+			sb.WriteString("\n")
+			if service.Description != "" {
+				sb.WriteString(service.Description)
+				sb.WriteString("\n")
+			}
+			sb.WriteString(service.CostDescription)
+			sb.WriteString("\n\n")
+		}
+		return vk_adapters.TextResponses{
+			vk_adapters.NewText(sb.String()),
+		}
+	}, nil
+}
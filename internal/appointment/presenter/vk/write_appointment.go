@@ -0,0 +1,63 @@
+package appointment_vk_presenter
+
+import (
+	"strings"
+	"time"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	appointment_presenter "github.com/x0k/veterinary-clinic-backend/internal/appointment/presenter"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/i18n"
+	"github.com/x0k/veterinary-clinic-backend/internal/shared"
+)
+
+func writeAppointment(
+	w *strings.Builder,
+	p i18n.Printer,
+	service appointment.ServiceEntity,
+	appointmentDateTime time.Time,
+) {
+	w.WriteString(service.Title)
+	w.WriteString("\n\n")
+	if service.Description != "" {
+		w.WriteString(service.Description)
+		w.WriteString("\n\n")
+	}
+	w.WriteString(service.CostDescription)
+	w.WriteString("\n\n")
+	w.WriteString(p.DateTime(appointmentDateTime))
+	w.WriteString(" - ")
+	w.WriteString(appointmentDateTime.Add(time.Duration(service.DurationInMinutes) * time.Minute).Format("15:04"))
+}
+
+func writeAppointmentSummary(
+	sb *strings.Builder,
+	p i18n.Printer,
+	app appointment.RecordEntity,
+	customer appointment.CustomerEntity,
+	service appointment.ServiceEntity,
+) {
+	sb.WriteString(p.DateTime(shared.DateTimeToGoTime(app.DateTimePeriod.Start)))
+	sb.WriteString(" - ")
+	sb.WriteString(shared.DateTimeToGoTime(app.DateTimePeriod.End).Format("15:04"))
+	sb.WriteString("\n\n")
+	sb.WriteString(service.Title)
+	sb.WriteString("\n\n")
+	sb.WriteString(customer.Name)
+}
+
+func writeSchedule(sb *strings.Builder, p i18n.Printer, schedule appointment.Schedule) {
+	sb.WriteString(p.Text("schedule.title", p.Date(schedule.Date)))
+	sb.WriteString("\n\n")
+	for _, period := range schedule.Entries {
+		sb.WriteString(period.Start.Time.String())
+		sb.WriteString(" - ")
+		sb.WriteString(period.End.Time.String())
+		sb.WriteString("\n")
+		sb.WriteString(appointment_presenter.ScheduleEntryTitle(p, period))
+		sb.WriteString("\n\n")
+	}
+	if len(schedule.Entries) == 0 {
+		sb.WriteString(p.Text("schedule.empty"))
+		sb.WriteString("\n\n")
+	}
+}
@@ -0,0 +1,116 @@
+package appointment_webpush_presenter
+
+import (
+	"strings"
+
+	webpush_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/webpush"
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	appointment_webpush_adapters "github.com/x0k/veterinary-clinic-backend/internal/appointment/adapters/webpush"
+	appointment_presenter "github.com/x0k/veterinary-clinic-backend/internal/appointment/presenter"
+	"github.com/x0k/veterinary-clinic-backend/internal/shared"
+)
+
+// Presenters return nil when the event is not worth a notification
+type NotificationPresenter struct {
+	url string
+}
+
+// `url` is opened by the service worker on the notification click
+func NewNotificationPresenter(url string) *NotificationPresenter {
+	return &NotificationPresenter{
+		url: url,
+	}
+}
+
+func (p *NotificationPresenter) RenderCreated(event appointment.CreatedEvent) (*appointment_webpush_adapters.Message, error) {
+	title := "notification.created"
+	if event.Record.Status == appointment.RecordPendingApproval {
+		title = "notification.created.pending"
+	}
+	return p.message(title, event.Record, event.Customer, event.Service, "")
+}
+
+func (p *NotificationPresenter) RenderCanceled(event appointment.CanceledEvent) (*appointment_webpush_adapters.Message, error) {
+	return p.message("notification.canceled", event.Record, event.Customer, event.Service, "")
+}
+
+func (p *NotificationPresenter) RenderChanged(
+	event appointment.ChangedEvent,
+	customer appointment.CustomerEntity,
+	service appointment.ServiceEntity,
+) (*appointment_webpush_adapters.Message, error) {
+	switch event.ChangeType {
+	case appointment.CreatedChangeType:
+		return p.message("change.created", event.Record, customer, service, "")
+	case appointment.DateTimeChangeType:
+		return p.message("change.date_time.short", event.Record, customer, service, "")
+	case appointment.RemovedChangeType:
+		return p.message("change.removed", event.Record, customer, service, "")
+	case appointment.StatusChangeType:
+		return p.message("change.status", event.Record, customer, service, "")
+	default:
+		return nil, nil
+	}
+}
+
+func (p *NotificationPresenter) RenderStatusTransition(
+	transition appointment.StatusTransition,
+	customer appointment.CustomerEntity,
+	service appointment.ServiceEntity,
+) (*appointment_webpush_adapters.Message, error) {
+	record := transition.Record
+	switch record.Status {
+	case appointment.RecordAwaits:
+		return p.message("transition.awaits", record, customer, service, transition.Reason)
+	case appointment.RecordConfirmed:
+		return p.message("transition.confirmed", record, customer, service, transition.Reason)
+	case appointment.RecordRescheduled:
+		return p.message("transition.rescheduled", record, customer, service, transition.Reason)
+	case appointment.RecordDeclined:
+		return p.message("transition.declined", record, customer, service, transition.Reason)
+	case appointment.RecordCanceledByClinic:
+		return p.message("transition.canceled_by_clinic", record, customer, service, transition.Reason)
+	default:
+		return nil, nil
+	}
+}
+
+func (p *NotificationPresenter) RenderReminder(
+	record appointment.RecordEntity,
+	customer appointment.CustomerEntity,
+	service appointment.ServiceEntity,
+) (*appointment_webpush_adapters.Message, error) {
+	return p.message("notification.reminder", record, customer, service, "")
+}
+
+// `title` is a message key
+func (p *NotificationPresenter) message(
+	title string,
+	record appointment.RecordEntity,
+	customer appointment.CustomerEntity,
+	service appointment.ServiceEntity,
+	reason string,
+) (*appointment_webpush_adapters.Message, error) {
+	if customer.Identity == "" {
+		return nil, nil
+	}
+	pr := appointment_presenter.CustomerPrinter(customer)
+	sb := strings.Builder{}
+	sb.WriteString(service.Title)
+	sb.WriteString(", ")
+	sb.WriteString(pr.DateTime(shared.DateTimeToGoTime(record.DateTimePeriod.Start)))
+	if reason != "" {
+		sb.WriteString("\n")
+		sb.WriteString(reason)
+	}
+	return &appointment_webpush_adapters.Message{
+		Identity: customer.Identity,
+		Notification: webpush_adapters.Notification{
+			Title: pr.Text(title),
+			Body:  sb.String(),
+			// Later notifications about the record replace the earlier ones
+			Tag: "record-" + record.Id.String(),
+			Url: p.url,
+		},
+	}, nil
+}
@@ -14,22 +14,6 @@ var ErrInvalidDateTimePeriod = errors.New("invalid date time period")
 var ErrRecordIsArchived = errors.New("record is archived")
 var ErrRecordIdIsNotTemporal = errors.New("id is not temporal")
 
-type RecordStatus string
-
-func NewRecordStatus(str string) RecordStatus {
-	return RecordStatus(str)
-}
-
-func (r RecordStatus) String() string {
-	return string(r)
-}
-
-const (
-	RecordAwaits    RecordStatus = "awaits"
-	RecordDone      RecordStatus = "done"
-	RecordNotAppear RecordStatus = "failed"
-)
-
 type RecordId string
 
 const TemporalRecordId RecordId = "tmp_record_id"
@@ -80,7 +64,7 @@ func NewRecord(
 	serviceId ServiceId,
 	createdAt time.Time,
 ) (RecordEntity, error) {
-	if status == RecordAwaits && isArchived {
+	if isArchived && !status.IsFinished() {
 		return RecordEntity{}, fmt.Errorf("%w: %s", ErrInvalidStatusForArchivedRecord, status)
 	}
 	if !shared.DateTimePeriodApi.IsValidPeriod(dateTimePeriod) {
@@ -114,7 +98,7 @@ func (r *RecordEntity) Archive() error {
 	if r.IsArchived {
 		return nil
 	}
-	if r.Status == RecordAwaits {
+	if !r.Status.IsFinished() {
 		return fmt.Errorf("%w: %s", ErrInvalidStatusForArchivedRecord, r.Status)
 	}
 	r.IsArchived = true
@@ -125,6 +109,12 @@ func (r *RecordEntity) SetStatus(status RecordStatus) error {
 	if r.IsArchived {
 		return ErrRecordIsArchived
 	}
+	if r.Status == status {
+		return nil
+	}
+	if !r.Status.CanTransitionTo(status) {
+		return fmt.Errorf("%w: %s -> %s", ErrInvalidStatusTransition, r.Status, status)
+	}
 	r.Status = status
 	return nil
 }
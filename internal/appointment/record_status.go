@@ -0,0 +1,100 @@
+package appointment
+
+import (
+	"errors"
+	"slices"
+)
+
+var ErrInvalidStatusTransition = errors.New("invalid status transition")
+
+type RecordStatus string
+
+func NewRecordStatus(str string) RecordStatus {
+	return RecordStatus(str)
+}
+
+func (r RecordStatus) String() string {
+	return string(r)
+}
+
+const (
+	RecordPendingApproval  RecordStatus = "pending"
+	RecordDeclined         RecordStatus = "declined"
+	RecordAwaits           RecordStatus = "awaits"
+	RecordConfirmed        RecordStatus = "confirmed"
+	RecordCheckedIn        RecordStatus = "checked_in"
+	RecordInProgress       RecordStatus = "in_progress"
+	RecordDone             RecordStatus = "done"
+	RecordNotAppear        RecordStatus = "failed"
+	RecordCanceledByClinic RecordStatus = "canceled_by_clinic"
+	RecordRescheduled      RecordStatus = "rescheduled"
+)
+
+var RecordStatuses = []RecordStatus{
+	RecordPendingApproval,
+	RecordDeclined,
+	RecordAwaits,
+	RecordConfirmed,
+	RecordCheckedIn,
+	RecordInProgress,
+	RecordDone,
+	RecordNotAppear,
+	RecordCanceledByClinic,
+	RecordRescheduled,
+}
+
+var recordStatusTransitions = map[RecordStatus][]RecordStatus{
+	RecordPendingApproval: {
+		RecordAwaits,
+		RecordDeclined,
+		RecordCanceledByClinic,
+	},
+	RecordAwaits: {
+		RecordConfirmed,
+		RecordCheckedIn,
+		RecordDone,
+		RecordNotAppear,
+		RecordCanceledByClinic,
+		RecordRescheduled,
+	},
+	RecordConfirmed: {
+		RecordCheckedIn,
+		RecordDone,
+		RecordNotAppear,
+		RecordCanceledByClinic,
+		RecordRescheduled,
+	},
+	RecordRescheduled: {
+		RecordConfirmed,
+		RecordCheckedIn,
+		RecordDone,
+		RecordNotAppear,
+		RecordCanceledByClinic,
+		RecordRescheduled,
+	},
+	RecordCheckedIn: {
+		RecordInProgress,
+		RecordDone,
+	},
+	RecordInProgress: {
+		RecordDone,
+	},
+}
+
+func (r RecordStatus) CanTransitionTo(status RecordStatus) bool {
+	return slices.Contains(recordStatusTransitions[r], status)
+}
+
+// Finished records can be archived
+func (r RecordStatus) IsFinished() bool {
+	return r == RecordDone || r == RecordNotAppear || r == RecordCanceledByClinic || r == RecordDeclined
+}
+
+// Active records occupy their time period
+func (r RecordStatus) IsActive() bool {
+	return slices.Contains(RecordStatuses, r) && !r.IsFinished()
+}
+
+func (r RecordStatus) IsCancelable() bool {
+	return r == RecordPendingApproval || r == RecordAwaits || r == RecordConfirmed || r == RecordRescheduled
+}
@@ -0,0 +1,58 @@
+package appointment
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRecordEntitySetStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		from       RecordStatus
+		isArchived bool
+		to         RecordStatus
+		wantErr    error
+	}{
+		{
+			name: "Confirm awaiting record",
+			from: RecordAwaits,
+			to:   RecordConfirmed,
+		},
+		{
+			name: "Same status",
+			from: RecordInProgress,
+			to:   RecordInProgress,
+		},
+		{
+			name:    "Start not checked in record",
+			from:    RecordConfirmed,
+			to:      RecordInProgress,
+			wantErr: ErrInvalidStatusTransition,
+		},
+		{
+			name:    "Reopen finished record",
+			from:    RecordDone,
+			to:      RecordAwaits,
+			wantErr: ErrInvalidStatusTransition,
+		},
+		{
+			name:       "Change archived record",
+			from:       RecordCanceledByClinic,
+			isArchived: true,
+			to:         RecordRescheduled,
+			wantErr:    ErrRecordIsArchived,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := RecordEntity{Status: tt.from, IsArchived: tt.isArchived}
+			err := r.SetStatus(tt.to)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("RecordEntity.SetStatus() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && r.Status != tt.to {
+				t.Errorf("RecordEntity.Status = %s, want %s", r.Status, tt.to)
+			}
+		})
+	}
+}
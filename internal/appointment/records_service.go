@@ -0,0 +1,180 @@
+package appointment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/shared"
+)
+
+type AppointmentDetails struct {
+	Record   RecordEntity
+	Customer CustomerEntity
+	Service  ServiceEntity
+}
+
+type CustomerDetails struct {
+	Customer          CustomerEntity
+	ActiveAppointment *AppointmentDetails
+}
+
+type RescheduleOfferDetails struct {
+	Offer    RescheduleOffer
+	Customer CustomerEntity
+	Service  ServiceEntity
+}
+
+type RecordsService struct {
+	recordLoader                    RecordLoader
+	recordStatusUpdater             RecordStatusUpdater
+	dayAppointmentsLoader           DayAppointmentsLoader
+	appointmentsInPeriodLoader      AppointmentsInPeriodLoader
+	customerLoader                  CustomerByIdLoader
+	customersByPhoneLoader          CustomersByPhoneLoader
+	customerActiveAppointmentLoader CustomerActiveAppointmentLoader
+	serviceLoader                   ServiceLoader
+}
+
+func NewRecordsService(
+	recordLoader RecordLoader,
+	recordStatusUpdater RecordStatusUpdater,
+	dayAppointmentsLoader DayAppointmentsLoader,
+	appointmentsInPeriodLoader AppointmentsInPeriodLoader,
+	customerLoader CustomerByIdLoader,
+	customersByPhoneLoader CustomersByPhoneLoader,
+	customerActiveAppointmentLoader CustomerActiveAppointmentLoader,
+	serviceLoader ServiceLoader,
+) *RecordsService {
+	return &RecordsService{
+		recordLoader:                    recordLoader,
+		recordStatusUpdater:             recordStatusUpdater,
+		dayAppointmentsLoader:           dayAppointmentsLoader,
+		appointmentsInPeriodLoader:      appointmentsInPeriodLoader,
+		customerLoader:                  customerLoader,
+		customersByPhoneLoader:          customersByPhoneLoader,
+		customerActiveAppointmentLoader: customerActiveAppointmentLoader,
+		serviceLoader:                   serviceLoader,
+	}
+}
+
+func (s *RecordsService) ChangeStatus(
+	ctx context.Context,
+	recordId RecordId,
+	status RecordStatus,
+	reason string,
+) (StatusTransitionEvent, error) {
+	record, err := s.recordLoader(ctx, recordId)
+	if err != nil {
+		return nil, err
+	}
+	from := record.Status
+	if from == status {
+		return nil, fmt.Errorf("%w: %s -> %s", ErrInvalidStatusTransition, from, status)
+	}
+	if err := record.SetStatus(status); err != nil {
+		return nil, err
+	}
+	if err := s.recordStatusUpdater(ctx, record); err != nil {
+		return nil, err
+	}
+	return NewStatusTransitionEvent(from, record, reason)
+}
+
+func (s *RecordsService) DayAppointments(ctx context.Context, day time.Time) ([]AppointmentDetails, error) {
+	records, err := s.dayAppointmentsLoader(ctx, day)
+	if err != nil {
+		return nil, err
+	}
+	return s.appointmentsDetails(ctx, records)
+}
+
+func (s *RecordsService) AppointmentsInPeriod(
+	ctx context.Context,
+	period shared.DateTimePeriod,
+) ([]AppointmentDetails, error) {
+	records, err := s.appointmentsInPeriodLoader(ctx, period)
+	if err != nil {
+		return nil, err
+	}
+	return s.appointmentsDetails(ctx, records)
+}
+
+func (s *RecordsService) RescheduleOffersDetails(
+	ctx context.Context,
+	offers []RescheduleOffer,
+) ([]RescheduleOfferDetails, error) {
+	details := make([]RescheduleOfferDetails, 0, len(offers))
+	for _, offer := range offers {
+		customer, err := s.customerLoader(ctx, offer.CustomerId)
+		if err != nil {
+			return nil, err
+		}
+		service, err := s.serviceLoader(ctx, offer.ServiceId)
+		if err != nil {
+			return nil, err
+		}
+		details = append(details, RescheduleOfferDetails{
+			Offer:    offer,
+			Customer: customer,
+			Service:  service,
+		})
+	}
+	return details, nil
+}
+
+func (s *RecordsService) appointmentsDetails(
+	ctx context.Context,
+	records []RecordEntity,
+) ([]AppointmentDetails, error) {
+	details := make([]AppointmentDetails, 0, len(records))
+	for _, record := range records {
+		d, err := s.appointmentDetails(ctx, record)
+		if err != nil {
+			return nil, err
+		}
+		details = append(details, d)
+	}
+	return details, nil
+}
+
+func (s *RecordsService) CustomersByPhone(ctx context.Context, phone string) ([]CustomerDetails, error) {
+	customers, err := s.customersByPhoneLoader(ctx, phone)
+	if err != nil {
+		return nil, err
+	}
+	details := make([]CustomerDetails, 0, len(customers))
+	for _, customer := range customers {
+		d := CustomerDetails{Customer: customer}
+		record, err := s.customerActiveAppointmentLoader(ctx, customer.Id)
+		if err != nil && !errors.Is(err, shared.ErrNotFound) {
+			return nil, err
+		}
+		if err == nil {
+			app, err := s.appointmentDetails(ctx, record)
+			if err != nil {
+				return nil, err
+			}
+			d.ActiveAppointment = &app
+		}
+		details = append(details, d)
+	}
+	return details, nil
+}
+
+func (s *RecordsService) appointmentDetails(ctx context.Context, record RecordEntity) (AppointmentDetails, error) {
+	customer, err := s.customerLoader(ctx, record.CustomerId)
+	if err != nil {
+		return AppointmentDetails{}, err
+	}
+	service, err := s.serviceLoader(ctx, record.ServiceId)
+	if err != nil {
+		return AppointmentDetails{}, err
+	}
+	return AppointmentDetails{
+		Record:   record,
+		Customer: customer,
+		Service:  service,
+	}, nil
+}
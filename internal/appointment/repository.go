@@ -27,16 +27,31 @@ type WorkingHoursLoader func(context.Context) (WorkingHours, error)
 
 type BusyPeriodsLoader func(context.Context, time.Time) (BusyPeriods, error)
 
+type BusyPeriodsRangeLoader func(ctx context.Context, from time.Time, to time.Time) (DatesBusyPeriods, error)
+
 type WorkBreaksLoader func(context.Context) (WorkBreaks, error)
 
 type CustomerActiveAppointmentLoader func(context.Context, CustomerId) (RecordEntity, error)
 
 type AppointmentRemover func(context.Context, RecordId) error
 
+type RecordLoader func(context.Context, RecordId) (RecordEntity, error)
+
+type RecordStatusUpdater func(context.Context, RecordEntity) error
+
+type DayAppointmentsLoader func(context.Context, time.Time) ([]RecordEntity, error)
+
+type CustomersByPhoneLoader func(context.Context, string) ([]CustomerEntity, error)
+
+type WorkBreakCreator func(ctx context.Context, title string, period shared.DateTimePeriod) error
+
 type RecordsArchiver func(context.Context) error
 
 type ActualAppointmentsLoader func(context.Context, time.Time) ([]RecordEntity, error)
 
+// Loads records of any status edited since the given time
+type EditedAppointmentsLoader func(ctx context.Context, since time.Time) ([]RecordEntity, error)
+
 type AppointmentsStateLoader func(context.Context) (AppointmentsState, error)
 
 type AppointmentsStateSaver func(context.Context, AppointmentsState) error
@@ -44,3 +59,102 @@ type AppointmentsStateSaver func(context.Context, AppointmentsState) error
 type DateTimePeriodLocker func(context.Context, shared.DateTimePeriod) error
 
 type DateTimePeriodUnLocker func(context.Context, shared.DateTimePeriod) error
+
+type SlotHolder func(context.Context, SlotHold) error
+
+type SlotHoldReleaser func(context.Context, CustomerIdentity) error
+
+type SlotHoldsLoader func(ctx context.Context, from time.Time, to time.Time) (SlotHolds, error)
+
+// Returns the record created with the key or reserves the key for the
+// new record (`true`). Fails with `ErrIdempotencyKeyIsReserved` while
+// the record is being created by another request.
+type IdempotencyKeyReserver func(context.Context, IdempotencyKey) (RecordEntity, bool, error)
+
+type IdempotencyKeyReleaser func(context.Context, IdempotencyKey) error
+
+type IdempotentRecordSaver func(context.Context, IdempotencyKey, RecordEntity) error
+
+type AppointmentsInPeriodLoader func(context.Context, shared.DateTimePeriod) ([]RecordEntity, error)
+
+type RescheduleOfferSaver func(context.Context, RescheduleOffer) error
+
+type RescheduleOfferLoader func(context.Context, RescheduleOfferId) (RescheduleOffer, error)
+
+type RescheduleOffersLoader func(ctx context.Context, since time.Time) ([]RescheduleOffer, error)
+
+// Changes the offer status only if it is still `from`,
+// otherwise returns `ErrRescheduleOfferIsClosed`
+type RescheduleOfferStatusSwapper func(
+	ctx context.Context,
+	id RescheduleOfferId,
+	from RescheduleOfferStatus,
+	to RescheduleOfferStatus,
+) error
+
+type OutboxEventSaver func(context.Context, Event) error
+
+type OutboxEntriesLoader func(ctx context.Context, now time.Time, limit int) ([]OutboxEntry, error)
+
+type OutboxEntryCompleter func(context.Context, OutboxEntryId) error
+
+type OutboxEntryRetrier func(ctx context.Context, id OutboxEntryId, nextAttemptAt time.Time, err error) error
+
+type OutboxEntryFailer func(ctx context.Context, id OutboxEntryId, err error) error
+
+type OutboxEntriesPurger func(ctx context.Context, before time.Time) error
+
+// Deliveries of the same event to the same url are saved once
+type WebhookDeliveriesSaver func(context.Context, []WebhookDelivery) error
+
+type PendingWebhookDeliveriesLoader func(ctx context.Context, now time.Time, limit int) ([]WebhookDelivery, error)
+
+type WebhookDeliveryCompleter func(context.Context, WebhookDeliveryId) error
+
+type WebhookDeliveryRetrier func(ctx context.Context, id WebhookDeliveryId, nextAttemptAt time.Time, err error) error
+
+type WebhookDeliveryFailer func(ctx context.Context, id WebhookDeliveryId, err error) error
+
+type FailedWebhookDeliveriesLoader func(context.Context) ([]WebhookDelivery, error)
+
+// Returns failed deliveries back to the queue, all of them when `ids` is empty
+type FailedWebhookDeliveriesReplayer func(ctx context.Context, now time.Time, ids []WebhookDeliveryId) (int, error)
+
+type AuditEntriesSaver func(context.Context, []AuditEntry) error
+
+type RecordAuditEntriesLoader func(context.Context, RecordId) ([]AuditEntry, error)
+
+// Newest entries first
+type RecentAuditEntriesLoader func(ctx context.Context, limit int) ([]AuditEntry, error)
+
+// Oldest entries first
+type AuditEntriesInPeriodLoader func(ctx context.Context, from time.Time, to time.Time) ([]AuditEntry, error)
+
+// Returns an empty list when the customer has not chosen channels
+type CustomerNotificationChannelsLoader func(context.Context, CustomerId) ([]NotificationChannel, error)
+
+type CustomerNotificationChannelsSaver func(context.Context, CustomerId, []NotificationChannel) error
+
+// Returns the zero preferences when the customer has not set them
+type CustomerNotificationPreferencesLoader func(context.Context, CustomerId) (NotificationPreferences, error)
+
+type CustomerNotificationPreferencesSaver func(context.Context, CustomerId, NotificationPreferences) error
+
+// Locale is stored by the identity to serve customers before the registration.
+// Returns the zero locale when it is unknown.
+type CustomerLocaleLoader func(context.Context, CustomerIdentity) (CustomerLocale, error)
+
+type CustomerLocaleSaver func(context.Context, CustomerIdentity, CustomerLocale) error
+
+// Subscriptions are identified by the endpoint
+type WebPushSubscriptionSaver func(context.Context, CustomerIdentity, WebPushSubscription) error
+
+type WebPushSubscriptionRemover func(ctx context.Context, identity CustomerIdentity, endpoint string) error
+
+type WebPushSubscriptionsLoader func(context.Context, CustomerIdentity) ([]WebPushSubscription, error)
+
+type DeferredNotificationSaver func(context.Context, DeferredNotification) error
+
+type DueDeferredNotificationsLoader func(ctx context.Context, now time.Time) ([]DeferredNotification, error)
+
+type DeferredNotificationRemover func(context.Context, DeferredNotificationId) error
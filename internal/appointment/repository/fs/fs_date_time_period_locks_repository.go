@@ -0,0 +1,303 @@
+package appointment_fs_repository
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	"github.com/x0k/veterinary-clinic-backend/internal/shared"
+)
+
+const dateTimePeriodLocksRepositoryName = "appointment_fs_repository.DateTimePeriodLocksRepository"
+
+const (
+	guardRetryInterval = 20 * time.Millisecond
+	guardStaleTimeout  = 10 * time.Second
+)
+
+var errGuardLost = errors.New("guard was taken over as a stale one")
+
+type periodLease struct {
+	Owner     string                `json:"owner"`
+	Period    shared.DateTimePeriod `json:"period"`
+	ExpiresAt time.Time             `json:"expiresAt"`
+}
+
+type slotHold struct {
+	Owner     appointment.CustomerIdentity `json:"owner"`
+	Period    shared.DateTimePeriod        `json:"period"`
+	ExpiresAt time.Time                    `json:"expiresAt"`
+}
+
+type locks struct {
+	Leases []periodLease `json:"leases"`
+	Holds  []slotHold    `json:"holds"`
+}
+
+type DateTimePeriodLocksRepository struct {
+	filePath  string
+	guardPath string
+	owner     string
+	leaseTTL  time.Duration
+}
+
+func NewDateTimePeriodLocksRepository(
+	filePath string,
+	owner string,
+	leaseTTL time.Duration,
+) *DateTimePeriodLocksRepository {
+	return &DateTimePeriodLocksRepository{
+		filePath:  filePath,
+		guardPath: filePath + ".lock",
+		owner:     owner,
+		leaseTTL:  leaseTTL,
+	}
+}
+
+// Creates the guard file with a random token,
+// which should be passed to `releaseGuard`
+func (r *DateTimePeriodLocksRepository) acquireGuard(ctx context.Context) (string, error) {
+	token, err := newGuardToken()
+	if err != nil {
+		return "", err
+	}
+	ticker := time.NewTicker(guardRetryInterval)
+	defer ticker.Stop()
+	for {
+		f, err := os.OpenFile(r.guardPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if err == nil {
+			_, err = f.WriteString(token)
+			return token, errors.Join(err, f.Close())
+		}
+		if !errors.Is(err, fs.ErrExist) {
+			return "", err
+		}
+		if stale, ok := r.staleGuardToken(); ok {
+			if _, err := r.removeGuard(stale); err != nil {
+				return "", err
+			}
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *DateTimePeriodLocksRepository) releaseGuard(token string) error {
+	removed, err := r.removeGuard(token)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return errGuardLost
+	}
+	return nil
+}
+
+func newGuardToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// Returns the token of the guard left by a crashed process
+func (r *DateTimePeriodLocksRepository) staleGuardToken() (string, bool) {
+	info, err := os.Stat(r.guardPath)
+	if err != nil || time.Since(info.ModTime()) <= guardStaleTimeout {
+		return "", false
+	}
+	token, err := os.ReadFile(r.guardPath)
+	if err != nil {
+		return "", false
+	}
+	return string(token), true
+}
+
+// Removes the guard only when it contains the token,
+// a guard of another process is never touched
+func (r *DateTimePeriodLocksRepository) removeGuard(token string) (bool, error) {
+	actual, err := os.ReadFile(r.guardPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if !bytes.Equal(actual, []byte(token)) {
+		return false, nil
+	}
+	if err := os.Remove(r.guardPath); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *DateTimePeriodLocksRepository) locks() (locks, error) {
+	data, err := os.ReadFile(r.filePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return locks{}, nil
+	}
+	if err != nil {
+		return locks{}, err
+	}
+	var l locks
+	if len(data) == 0 {
+		return l, nil
+	}
+	err = json.Unmarshal(data, &l)
+	return l, err
+}
+
+func (r *DateTimePeriodLocksRepository) saveLocks(l locks) error {
+	data, err := json.Marshal(l)
+	if err != nil {
+		return err
+	}
+	tmpPath := r.filePath + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, r.filePath)
+}
+
+func removeExpired(l locks, now time.Time) locks {
+	l.Leases = slices.DeleteFunc(l.Leases, func(lease periodLease) bool {
+		return !now.Before(lease.ExpiresAt)
+	})
+	l.Holds = slices.DeleteFunc(l.Holds, func(hold slotHold) bool {
+		return !now.Before(hold.ExpiresAt)
+	})
+	return l
+}
+
+func (r *DateTimePeriodLocksRepository) update(
+	ctx context.Context,
+	mutate func(l locks) (locks, error),
+) (err error) {
+	token, err := r.acquireGuard(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, r.releaseGuard(token))
+	}()
+	l, err := r.locks()
+	if err != nil {
+		return err
+	}
+	l, err = mutate(removeExpired(l, time.Now()))
+	if err != nil {
+		return err
+	}
+	return r.saveLocks(l)
+}
+
+func (r *DateTimePeriodLocksRepository) Lock(ctx context.Context, period shared.DateTimePeriod) error {
+	const op = dateTimePeriodLocksRepositoryName + ".Lock"
+	err := r.update(ctx, func(l locks) (locks, error) {
+		for _, lease := range l.Leases {
+			if shared.DateTimePeriodApi.IsValidPeriod(
+				shared.DateTimePeriodApi.IntersectPeriods(lease.Period, period),
+			) {
+				return l, fmt.Errorf("%w: %s", appointment.ErrPeriodIsLocked, period)
+			}
+		}
+		l.Leases = append(l.Leases, periodLease{
+			Owner:     r.owner,
+			Period:    period,
+			ExpiresAt: time.Now().Add(r.leaseTTL),
+		})
+		return l, nil
+	})
+	if errors.Is(err, appointment.ErrPeriodIsLocked) {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func (r *DateTimePeriodLocksRepository) UnLock(ctx context.Context, period shared.DateTimePeriod) error {
+	const op = dateTimePeriodLocksRepositoryName + ".UnLock"
+	if err := r.update(ctx, func(l locks) (locks, error) {
+		l.Leases = slices.DeleteFunc(l.Leases, func(lease periodLease) bool {
+			return lease.Owner == r.owner && lease.Period == period
+		})
+		return l, nil
+	}); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func (r *DateTimePeriodLocksRepository) Hold(ctx context.Context, hold appointment.SlotHold) error {
+	const op = dateTimePeriodLocksRepositoryName + ".Hold"
+	if err := r.update(ctx, func(l locks) (locks, error) {
+		l.Holds = slices.DeleteFunc(l.Holds, func(h slotHold) bool {
+			return h.Owner == hold.Owner
+		})
+		l.Holds = append(l.Holds, slotHold{
+			Owner:     hold.Owner,
+			Period:    hold.DateTimePeriod,
+			ExpiresAt: hold.ExpiresAt,
+		})
+		return l, nil
+	}); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func (r *DateTimePeriodLocksRepository) Release(ctx context.Context, owner appointment.CustomerIdentity) error {
+	const op = dateTimePeriodLocksRepositoryName + ".Release"
+	if err := r.update(ctx, func(l locks) (locks, error) {
+		l.Holds = slices.DeleteFunc(l.Holds, func(h slotHold) bool {
+			return h.Owner == owner
+		})
+		return l, nil
+	}); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// The file is replaced atomically, so it is read without the guard
+func (r *DateTimePeriodLocksRepository) Holds(
+	ctx context.Context,
+	from time.Time,
+	to time.Time,
+) (appointment.SlotHolds, error) {
+	const op = dateTimePeriodLocksRepositoryName + ".Holds"
+	l, err := r.locks()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	l = removeExpired(l, time.Now())
+	start, end := shared.GoTimeToDateTime(from), shared.GoTimeToDateTime(to)
+	holds := make(appointment.SlotHolds, 0, len(l.Holds))
+	for _, h := range l.Holds {
+		if shared.CompareDateTime(start, h.Period.Start) <= 0 &&
+			shared.CompareDateTime(h.Period.Start, end) < 0 {
+			holds = append(holds, appointment.NewSlotHold(h.Owner, h.Period, h.ExpiresAt))
+		}
+	}
+	return holds, nil
+}
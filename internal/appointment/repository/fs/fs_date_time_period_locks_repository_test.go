@@ -0,0 +1,155 @@
+package appointment_fs_repository
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	"github.com/x0k/veterinary-clinic-backend/internal/shared"
+)
+
+func period(startHour int, endHour int) shared.DateTimePeriod {
+	return shared.DateTimePeriod{
+		Start: shared.GoTimeToDateTime(time.Date(2024, 5, 1, startHour, 0, 0, 0, time.Local)),
+		End:   shared.GoTimeToDateTime(time.Date(2024, 5, 1, endHour, 0, 0, 0, time.Local)),
+	}
+}
+
+// Repositories of different processes which share the file
+func newRepositories(t *testing.T, leaseTTL time.Duration, owners ...string) []*DateTimePeriodLocksRepository {
+	filePath := filepath.Join(t.TempDir(), "locks.json")
+	repos := make([]*DateTimePeriodLocksRepository, len(owners))
+	for i, owner := range owners {
+		repos[i] = NewDateTimePeriodLocksRepository(filePath, owner, leaseTTL)
+	}
+	return repos
+}
+
+func TestDateTimePeriodLocksContention(t *testing.T) {
+	repos := newRepositories(t, time.Minute, "a", "b", "c", "d")
+	var (
+		wg         sync.WaitGroup
+		mu         sync.Mutex
+		locked     int
+		unexpected []error
+	)
+	for _, repo := range repos {
+		for range 5 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				err := repo.Lock(context.Background(), period(10, 11))
+				mu.Lock()
+				defer mu.Unlock()
+				if err == nil {
+					locked++
+				} else if !errors.Is(err, appointment.ErrPeriodIsLocked) {
+					unexpected = append(unexpected, err)
+				}
+			}()
+		}
+	}
+	wg.Wait()
+	if locked != 1 {
+		t.Errorf("period is locked %d times, want once", locked)
+	}
+	if len(unexpected) > 0 {
+		t.Errorf("unexpected errors: %v", unexpected)
+	}
+	if err := repos[0].Lock(context.Background(), period(11, 12)); err != nil {
+		t.Errorf("adjacent period is not locked: %v", err)
+	}
+}
+
+func TestDateTimePeriodLocksLeaseExpiry(t *testing.T) {
+	ctx := context.Background()
+	repos := newRepositories(t, 50*time.Millisecond, "a", "b")
+	if err := repos[0].Lock(ctx, period(10, 12)); err != nil {
+		t.Fatal(err)
+	}
+	if err := repos[1].Lock(ctx, period(11, 13)); !errors.Is(err, appointment.ErrPeriodIsLocked) {
+		t.Fatalf("Lock() error = %v, want %v", err, appointment.ErrPeriodIsLocked)
+	}
+	// Leases of other owners are kept
+	if err := repos[1].UnLock(ctx, period(10, 12)); err != nil {
+		t.Fatal(err)
+	}
+	if err := repos[1].Lock(ctx, period(10, 12)); !errors.Is(err, appointment.ErrPeriodIsLocked) {
+		t.Fatalf("Lock() error = %v, want %v", err, appointment.ErrPeriodIsLocked)
+	}
+	time.Sleep(60 * time.Millisecond)
+	if err := repos[1].Lock(ctx, period(11, 13)); err != nil {
+		t.Errorf("expired lease is not released: %v", err)
+	}
+}
+
+func TestSlotHolds(t *testing.T) {
+	ctx := context.Background()
+	repos := newRepositories(t, time.Minute, "a", "b")
+	now := time.Now()
+	day := time.Date(2024, 5, 1, 0, 0, 0, 0, time.Local)
+	holds := []appointment.SlotHold{
+		appointment.NewSlotHold("tg-1", period(10, 11), now.Add(time.Minute)),
+		appointment.NewSlotHold("tg-2", period(12, 13), now.Add(50*time.Millisecond)),
+		appointment.NewSlotHold("tg-1", period(14, 15), now.Add(time.Minute)),
+	}
+	for _, hold := range holds {
+		if err := repos[0].Hold(ctx, hold); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got, err := repos[1].Holds(ctx, day, day.AddDate(0, 0, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[1].Owner != "tg-1" || got[1].DateTimePeriod != period(14, 15) {
+		t.Errorf("holds = %v, want the latest hold of each owner", got)
+	}
+	time.Sleep(60 * time.Millisecond)
+	if err := repos[1].Release(ctx, "tg-1"); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := repos[0].Holds(ctx, day, day.AddDate(0, 0, 1)); err != nil || len(got) != 0 {
+		t.Errorf("holds = %v, %v, want released and expired holds to be removed", got, err)
+	}
+}
+
+func TestGuardOwnership(t *testing.T) {
+	ctx := context.Background()
+	repo := newRepositories(t, time.Minute, "a")[0]
+
+	// Guard of a crashed process
+	if err := os.WriteFile(repo.guardPath, []byte("stale"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	past := time.Now().Add(-2 * guardStaleTimeout)
+	if err := os.Chtimes(repo.guardPath, past, past); err != nil {
+		t.Fatal(err)
+	}
+	token, err := repo.acquireGuard(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Guard is taken over while its owner is still working
+	if err := os.WriteFile(repo.guardPath, []byte("other"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.releaseGuard(token); !errors.Is(err, errGuardLost) {
+		t.Errorf("releaseGuard() error = %v, want %v", err, errGuardLost)
+	}
+	if data, err := os.ReadFile(repo.guardPath); err != nil || string(data) != "other" {
+		t.Errorf("guard of another owner = %q, %v, want it to be kept", data, err)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	if _, err := repo.acquireGuard(timeoutCtx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("acquireGuard() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
@@ -5,24 +5,39 @@ package appointment_js_repository
 import (
 	"context"
 	"syscall/js"
+	"time"
 
 	"github.com/x0k/vert"
 	js_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/js"
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	appointment_js_adapters "github.com/x0k/veterinary-clinic-backend/internal/appointment/adapters/js"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/slicex"
 	"github.com/x0k/veterinary-clinic-backend/internal/shared"
 	shared_js_adapters "github.com/x0k/veterinary-clinic-backend/internal/shared/adapters/js"
 )
 
 type DateTimePeriodLocksRepositoryConfig struct {
-	Lock   *js.Value `js:"lock"`
-	UnLock *js.Value `js:"unLock"`
+	Lock    *js.Value `js:"lock"`
+	UnLock  *js.Value `js:"unLock"`
+	Hold    *js.Value `js:"hold"`
+	Release *js.Value `js:"release"`
+	Holds   *js.Value `js:"holds"`
 }
 
 type DateTimePeriodLocksRepository struct {
-	cfg DateTimePeriodLocksRepositoryConfig
+	cfg       DateTimePeriodLocksRepositoryConfig
+	holdsFrom func(js.Value) (appointment.SlotHolds, error)
 }
 
 func NewDateTimePeriodLocksRepository(cfg DateTimePeriodLocksRepositoryConfig) *DateTimePeriodLocksRepository {
-	return &DateTimePeriodLocksRepository{cfg: cfg}
+	return &DateTimePeriodLocksRepository{
+		cfg: cfg,
+		holdsFrom: js_adapters.From(
+			slicex.MapEx[[]appointment_js_adapters.SlotHoldDTO, appointment.SlotHolds](
+				appointment_js_adapters.SlotHoldFromDTO,
+			),
+		),
+	}
 }
 
 func (r *DateTimePeriodLocksRepository) Lock(ctx context.Context, period shared.DateTimePeriod) error {
@@ -40,3 +55,38 @@ func (r *DateTimePeriodLocksRepository) UnLock(ctx context.Context, period share
 	_, err := js_adapters.Await(ctx, promise)
 	return err
 }
+
+func (r *DateTimePeriodLocksRepository) Hold(ctx context.Context, hold appointment.SlotHold) error {
+	promise := r.cfg.Hold.Invoke(
+		vert.ValueOf(appointment_js_adapters.SlotHoldToDTO(hold)),
+	)
+	_, err := js_adapters.Await(ctx, promise)
+	return err
+}
+
+func (r *DateTimePeriodLocksRepository) Release(ctx context.Context, owner appointment.CustomerIdentity) error {
+	promise := r.cfg.Release.Invoke(owner.String())
+	_, err := js_adapters.Await(ctx, promise)
+	return err
+}
+
+func (r *DateTimePeriodLocksRepository) Holds(
+	ctx context.Context,
+	from time.Time,
+	to time.Time,
+) (appointment.SlotHolds, error) {
+	promise := r.cfg.Holds.Invoke(
+		vert.ValueOf(shared_js_adapters.DateTimePeriodToDTO(shared.DateTimePeriod{
+			Start: shared.GoTimeToDateTime(from),
+			End:   shared.GoTimeToDateTime(to),
+		})),
+	)
+	res, err := js_adapters.Await(ctx, promise)
+	if err != nil {
+		return nil, err
+	}
+	if res.IsNull() || res.IsUndefined() {
+		return appointment.SlotHolds{}, nil
+	}
+	return r.holdsFrom(res)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"slices"
 	"sync"
+	"time"
 
 	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
 	"github.com/x0k/veterinary-clinic-backend/internal/shared"
@@ -13,10 +14,13 @@ import (
 type DateTimePeriodLocksRepository struct {
 	mu      sync.Mutex
 	periods []shared.DateTimePeriod
+	holds   map[appointment.CustomerIdentity]appointment.SlotHold
 }
 
 func NewDateTimePeriodLocksRepository() *DateTimePeriodLocksRepository {
-	return &DateTimePeriodLocksRepository{}
+	return &DateTimePeriodLocksRepository{
+		holds: make(map[appointment.CustomerIdentity]appointment.SlotHold),
+	}
 }
 
 func (r *DateTimePeriodLocksRepository) Lock(ctx context.Context, period shared.DateTimePeriod) error {
@@ -43,3 +47,45 @@ func (r *DateTimePeriodLocksRepository) UnLock(ctx context.Context, period share
 	r.periods = slices.Delete(r.periods, index, index+1)
 	return nil
 }
+
+func (r *DateTimePeriodLocksRepository) removeExpiredHolds(now time.Time) {
+	for owner, hold := range r.holds {
+		if hold.IsExpired(now) {
+			delete(r.holds, owner)
+		}
+	}
+}
+
+func (r *DateTimePeriodLocksRepository) Hold(ctx context.Context, hold appointment.SlotHold) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.removeExpiredHolds(time.Now())
+	r.holds[hold.Owner] = hold
+	return nil
+}
+
+func (r *DateTimePeriodLocksRepository) Release(ctx context.Context, owner appointment.CustomerIdentity) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.holds, owner)
+	return nil
+}
+
+func (r *DateTimePeriodLocksRepository) Holds(
+	ctx context.Context,
+	from time.Time,
+	to time.Time,
+) (appointment.SlotHolds, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.removeExpiredHolds(time.Now())
+	start, end := shared.GoTimeToDateTime(from), shared.GoTimeToDateTime(to)
+	holds := make(appointment.SlotHolds, 0, len(r.holds))
+	for _, hold := range r.holds {
+		if shared.CompareDateTime(start, hold.DateTimePeriod.Start) <= 0 &&
+			shared.CompareDateTime(hold.DateTimePeriod.Start, end) < 0 {
+			holds = append(holds, hold)
+		}
+	}
+	return holds, nil
+}
@@ -0,0 +1,88 @@
+package appointment_in_memory_repository
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+)
+
+const idempotencyKeyReservationTTL = time.Minute
+
+type idempotentRecord struct {
+	reserved  bool
+	record    appointment.RecordEntity
+	expiresAt time.Time
+}
+
+type IdempotentRecordsRepository struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	records map[appointment.IdempotencyKey]idempotentRecord
+}
+
+func NewIdempotentRecordsRepository(ttl time.Duration) *IdempotentRecordsRepository {
+	return &IdempotentRecordsRepository{
+		ttl:     ttl,
+		records: make(map[appointment.IdempotencyKey]idempotentRecord),
+	}
+}
+
+func (r *IdempotentRecordsRepository) Reserve(
+	ctx context.Context,
+	key appointment.IdempotencyKey,
+) (appointment.RecordEntity, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	r.deleteExpired(now)
+	if rec, ok := r.records[key]; ok {
+		if rec.reserved {
+			return appointment.RecordEntity{}, false, fmt.Errorf("%w: %s", appointment.ErrIdempotencyKeyIsReserved, key)
+		}
+		return rec.record, false, nil
+	}
+	r.records[key] = idempotentRecord{
+		reserved:  true,
+		expiresAt: now.Add(idempotencyKeyReservationTTL),
+	}
+	return appointment.RecordEntity{}, true, nil
+}
+
+func (r *IdempotentRecordsRepository) Release(
+	ctx context.Context,
+	key appointment.IdempotencyKey,
+) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if rec, ok := r.records[key]; ok && rec.reserved {
+		delete(r.records, key)
+	}
+	return nil
+}
+
+func (r *IdempotentRecordsRepository) SaveRecord(
+	ctx context.Context,
+	key appointment.IdempotencyKey,
+	record appointment.RecordEntity,
+) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	r.deleteExpired(now)
+	r.records[key] = idempotentRecord{
+		record:    record,
+		expiresAt: now.Add(r.ttl),
+	}
+	return nil
+}
+
+func (r *IdempotentRecordsRepository) deleteExpired(now time.Time) {
+	for k, rec := range r.records {
+		if !rec.expiresAt.After(now) {
+			delete(r.records, k)
+		}
+	}
+}
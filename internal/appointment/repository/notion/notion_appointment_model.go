@@ -15,34 +15,62 @@ import (
 var ErrUnknownRecordStatus = errors.New("unknown record status")
 
 const (
-	RecordAwaits            = "Ожидает"
-	RecordDone              = "Выполнено"
-	RecordNotAppear         = "Не пришел"
-	RecordDoneArchived      = "Архив выполнено"
-	RecordNotAppearArchived = "Архив не пришел"
+	RecordPendingApproval          = "На подтверждении"
+	RecordDeclined                 = "Отклонено"
+	RecordDeclinedArchived         = "Архив отклонено"
+	RecordAwaits                   = "Ожидает"
+	RecordConfirmed                = "Подтверждено"
+	RecordCheckedIn                = "Пришел"
+	RecordInProgress               = "На приеме"
+	RecordDone                     = "Выполнено"
+	RecordNotAppear                = "Не пришел"
+	RecordCanceledByClinic         = "Отменено клиникой"
+	RecordRescheduled              = "Перенесено"
+	RecordDoneArchived             = "Архив выполнено"
+	RecordNotAppearArchived        = "Архив не пришел"
+	RecordCanceledByClinicArchived = "Архив отменено клиникой"
 )
 
+var recordStatusesToNotion = map[appointment.RecordStatus]string{
+	appointment.RecordPendingApproval:  RecordPendingApproval,
+	appointment.RecordDeclined:         RecordDeclined,
+	appointment.RecordAwaits:           RecordAwaits,
+	appointment.RecordConfirmed:        RecordConfirmed,
+	appointment.RecordCheckedIn:        RecordCheckedIn,
+	appointment.RecordInProgress:       RecordInProgress,
+	appointment.RecordDone:             RecordDone,
+	appointment.RecordNotAppear:        RecordNotAppear,
+	appointment.RecordCanceledByClinic: RecordCanceledByClinic,
+	appointment.RecordRescheduled:      RecordRescheduled,
+}
+
+var archivedRecordStatusesToNotion = map[appointment.RecordStatus]string{
+	appointment.RecordDeclined:         RecordDeclinedArchived,
+	appointment.RecordDone:             RecordDoneArchived,
+	appointment.RecordNotAppear:        RecordNotAppearArchived,
+	appointment.RecordCanceledByClinic: RecordCanceledByClinicArchived,
+}
+
 func RecordStatusToNotion(status appointment.RecordStatus, isArchived bool) (string, error) {
+	statuses := recordStatusesToNotion
 	if isArchived {
-		switch status {
-		case appointment.RecordDone:
-			return RecordDoneArchived, nil
-		case appointment.RecordNotAppear:
-			return RecordNotAppearArchived, nil
-		default:
-			return "", fmt.Errorf("%w: %s", ErrUnknownRecordStatus, status)
-		}
+		statuses = archivedRecordStatusesToNotion
+	}
+	if notionStatus, ok := statuses[status]; ok {
+		return notionStatus, nil
 	}
-	switch status {
-	case appointment.RecordAwaits:
-		return RecordAwaits, nil
-	case appointment.RecordDone:
-		return RecordDone, nil
-	case appointment.RecordNotAppear:
-		return RecordNotAppear, nil
-	default:
-		return "", fmt.Errorf("%w: %s", ErrUnknownRecordStatus, status)
+	return "", fmt.Errorf("%w: %s", ErrUnknownRecordStatus, status)
+}
+
+// Not archived statuses that matches the predicate
+func notionRecordStatuses(predicate func(appointment.RecordStatus) bool) []string {
+	statuses := make([]string, 0, len(appointment.RecordStatuses))
+	for _, status := range appointment.RecordStatuses {
+		if predicate(status) {
+			statuses = append(statuses, recordStatusesToNotion[status])
+		}
 	}
+	return statuses
 }
 
 const (
@@ -50,6 +78,7 @@ const (
 	ServiceDurationInMinutes = "Продолжительность в минутах"
 	ServiceDescription       = "Описание"
 	ServiceCost              = "Стоимость"
+	ServiceRequiresApproval  = "Требует подтверждения"
 )
 
 func NotionToService(page notionapi.Page) appointment.ServiceEntity {
@@ -61,6 +90,7 @@ func NotionToService(page notionapi.Page) appointment.ServiceEntity {
 		),
 		notion.Text(page.Properties, ServiceDescription),
 		notion.Text(page.Properties, ServiceCost),
+		notion.Checkbox(page.Properties, ServiceRequiresApproval),
 	)
 }
 
@@ -96,20 +126,17 @@ const (
 )
 
 func NotionToRecordStatus(notionStatus string) (appointment.RecordStatus, bool, error) {
-	switch notionStatus {
-	case RecordAwaits:
-		return appointment.RecordAwaits, false, nil
-	case RecordDone:
-		return appointment.RecordDone, false, nil
-	case RecordNotAppear:
-		return appointment.RecordNotAppear, false, nil
-	case RecordDoneArchived:
-		return appointment.RecordDone, true, nil
-	case RecordNotAppearArchived:
-		return appointment.RecordNotAppear, true, nil
-	default:
-		return "", false, fmt.Errorf("%w: %s", ErrUnknownRecordStatus, notionStatus)
+	for status, ns := range recordStatusesToNotion {
+		if ns == notionStatus {
+			return status, false, nil
+		}
+	}
+	for status, ns := range archivedRecordStatusesToNotion {
+		if ns == notionStatus {
+			return status, true, nil
+		}
 	}
+	return "", false, fmt.Errorf("%w: %s", ErrUnknownRecordStatus, notionStatus)
 }
 
 func NotionToRecord(page notionapi.Page) (appointment.RecordEntity, error) {
@@ -94,52 +94,68 @@ func (r *AppointmentRepository) CreateAppointment(ctx context.Context, app *appo
 }
 
 func (s *AppointmentRepository) BusyPeriods(ctx context.Context, t time.Time) (appointment.BusyPeriods, error) {
-	const op = appointmentRepositoryName + ".BusyPeriods"
 	after := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
-	afterDate := notionapi.Date(after)
-	beforeDate := notionapi.Date(after.AddDate(0, 0, 1))
-	r, err := s.client.Database.Query(ctx, s.recordsDatabaseId, &notionapi.DatabaseQueryRequest{
-		Filter: notionapi.AndCompoundFilter{
-			notionapi.PropertyFilter{
-				Property: RecordDateTimePeriod,
-				Date: &notionapi.DateFilterCondition{
-					After: &afterDate,
+	periods, err := s.BusyPeriodsInRange(ctx, after, after.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+	return periods.ForDay(after), nil
+}
+
+func (s *AppointmentRepository) BusyPeriodsInRange(
+	ctx context.Context,
+	from time.Time,
+	to time.Time,
+) (appointment.DatesBusyPeriods, error) {
+	const op = appointmentRepositoryName + ".BusyPeriodsInRange"
+	afterDate := notionapi.Date(from)
+	beforeDate := notionapi.Date(to)
+	periods := make(appointment.DatesBusyPeriods)
+	var cursor notionapi.Cursor
+	for {
+		r, err := s.client.Database.Query(ctx, s.recordsDatabaseId, &notionapi.DatabaseQueryRequest{
+			Filter: notionapi.AndCompoundFilter{
+				notionapi.PropertyFilter{
+					Property: RecordDateTimePeriod,
+					Date: &notionapi.DateFilterCondition{
+						After: &afterDate,
+					},
 				},
-			},
-			notionapi.PropertyFilter{
-				Property: RecordDateTimePeriod,
-				Date: &notionapi.DateFilterCondition{
-					Before: &beforeDate,
+				notionapi.PropertyFilter{
+					Property: RecordDateTimePeriod,
+					Date: &notionapi.DateFilterCondition{
+						Before: &beforeDate,
+					},
 				},
+				recordStatesFilter(notionRecordStatuses(appointment.RecordStatus.IsActive)),
 			},
-			notionapi.PropertyFilter{
-				Property: RecordState,
-				Select: &notionapi.SelectFilterCondition{
-					Equals: RecordAwaits,
+			Sorts: []notionapi.SortObject{
+				{
+					Property:  RecordDateTimePeriod,
+					Direction: notionapi.SortOrderASC,
 				},
 			},
-		},
-		Sorts: []notionapi.SortObject{
-			{
-				Property:  RecordDateTimePeriod,
-				Direction: notionapi.SortOrderASC,
-			},
-		},
-	})
-	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
-	}
-	periods := make([]shared.TimePeriod, 0, len(r.Results))
-	for _, page := range r.Results {
-		period, err := notion.DatePeriod(page.Properties, RecordDateTimePeriod)
+			StartCursor: cursor,
+		})
 		if err != nil {
-			s.log.Error(ctx, "failed to parse record period", sl.Op(op), sl.Err(err))
-			continue
+			return nil, fmt.Errorf("%s: %w", op, err)
 		}
-		periods = append(periods, shared.TimePeriod{
-			Start: shared.GoTimeToTime(period.Start),
-			End:   shared.GoTimeToTime(period.End),
-		})
+		for _, page := range r.Results {
+			period, err := notion.DatePeriod(page.Properties, RecordDateTimePeriod)
+			if err != nil {
+				s.log.Error(ctx, "failed to parse record period", sl.Op(op), sl.Err(err))
+				continue
+			}
+			date := shared.GoTimeToJsonDate(period.Start)
+			periods[date] = append(periods[date], shared.TimePeriod{
+				Start: shared.GoTimeToTime(period.Start),
+				End:   shared.GoTimeToTime(period.End),
+			})
+		}
+		if !r.HasMore {
+			break
+		}
+		cursor = r.NextCursor
 	}
 	return periods, nil
 }
@@ -157,35 +173,112 @@ func (s *AppointmentRepository) CustomerActiveAppointment(
 					Contains: customerId.String(),
 				},
 			},
-			notionapi.OrCompoundFilter{
-				notionapi.PropertyFilter{
-					Property: RecordState,
-					Select: &notionapi.SelectFilterCondition{
-						Equals: RecordAwaits,
-					},
-				},
-				notionapi.PropertyFilter{
-					Property: RecordState,
-					Select: &notionapi.SelectFilterCondition{
-						Equals: RecordDone,
-					},
+			recordStatesFilter(notionRecordStatuses(isNotCanceledByClinic)),
+		},
+	})
+	if err != nil {
+		return appointment.RecordEntity{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if res == nil || len(res.Results) == 0 {
+		return appointment.RecordEntity{}, fmt.Errorf("%s: %w", op, shared.ErrNotFound)
+	}
+	return NotionToRecord(res.Results[0])
+}
+
+func (s *AppointmentRepository) DayAppointments(
+	ctx context.Context,
+	day time.Time,
+) ([]appointment.RecordEntity, error) {
+	from := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+	return s.appointmentsInRange(ctx, appointmentRepositoryName+".DayAppointments", from, from.AddDate(0, 0, 1))
+}
+
+func (s *AppointmentRepository) AppointmentsInPeriod(
+	ctx context.Context,
+	period shared.DateTimePeriod,
+) ([]appointment.RecordEntity, error) {
+	return s.appointmentsInRange(
+		ctx,
+		appointmentRepositoryName+".AppointmentsInPeriod",
+		shared.DateTimeToGoTime(period.Start),
+		shared.DateTimeToGoTime(period.End),
+	)
+}
+
+func (s *AppointmentRepository) appointmentsInRange(
+	ctx context.Context,
+	op string,
+	from time.Time,
+	to time.Time,
+) ([]appointment.RecordEntity, error) {
+	afterDate := notionapi.Date(from)
+	beforeDate := notionapi.Date(to)
+	res, err := s.client.Database.Query(ctx, s.recordsDatabaseId, &notionapi.DatabaseQueryRequest{
+		Filter: notionapi.AndCompoundFilter{
+			notionapi.PropertyFilter{
+				Property: RecordDateTimePeriod,
+				Date: &notionapi.DateFilterCondition{
+					OnOrAfter: &afterDate,
 				},
-				notionapi.PropertyFilter{
-					Property: RecordState,
-					Select: &notionapi.SelectFilterCondition{
-						Equals: RecordNotAppear,
-					},
+			},
+			notionapi.PropertyFilter{
+				Property: RecordDateTimePeriod,
+				Date: &notionapi.DateFilterCondition{
+					Before: &beforeDate,
 				},
 			},
+			recordStatesFilter(notionRecordStatuses(isAnyStatus)),
+		},
+		Sorts: []notionapi.SortObject{
+			{
+				Property:  RecordDateTimePeriod,
+				Direction: notionapi.SortOrderASC,
+			},
 		},
 	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	records := make([]appointment.RecordEntity, 0, len(res.Results))
+	for _, page := range res.Results {
+		record, err := NotionToRecord(page)
+		if err != nil {
+			s.log.Error(ctx, "failed to convert record", sl.Op(op), sl.Err(err))
+			continue
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func (s *AppointmentRepository) Record(ctx context.Context, recordId appointment.RecordId) (appointment.RecordEntity, error) {
+	const op = appointmentRepositoryName + ".Record"
+	page, err := s.client.Page.Get(ctx, notionapi.PageID(recordId.String()))
 	if err != nil {
 		return appointment.RecordEntity{}, fmt.Errorf("%s: %w", op, err)
 	}
-	if res == nil || len(res.Results) == 0 {
+	if page.Archived {
 		return appointment.RecordEntity{}, fmt.Errorf("%s: %w", op, shared.ErrNotFound)
 	}
-	return NotionToRecord(res.Results[0])
+	return NotionToRecord(*page)
+}
+
+func (s *AppointmentRepository) UpdateRecordStatus(ctx context.Context, record appointment.RecordEntity) error {
+	const op = appointmentRepositoryName + ".UpdateRecordStatus"
+	status, err := RecordStatusToNotion(record.Status, record.IsArchived)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if _, err := s.client.Page.Update(ctx, notionapi.PageID(record.Id.String()), &notionapi.PageUpdateRequest{
+		Properties: notionapi.Properties{
+			RecordState: notionapi.SelectProperty{
+				Select: notionapi.Option{Name: status},
+			},
+		},
+	}); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
 }
 
 func (s *AppointmentRepository) RemoveAppointment(ctx context.Context, recordId appointment.RecordId) error {
@@ -214,26 +307,7 @@ func (s *AppointmentRepository) ActualAppointments(
 					After: &after,
 				},
 			},
-			notionapi.OrCompoundFilter{
-				notionapi.PropertyFilter{
-					Property: RecordState,
-					Select: &notionapi.SelectFilterCondition{
-						Equals: RecordAwaits,
-					},
-				},
-				notionapi.PropertyFilter{
-					Property: RecordState,
-					Select: &notionapi.SelectFilterCondition{
-						Equals: RecordDone,
-					},
-				},
-				notionapi.PropertyFilter{
-					Property: RecordState,
-					Select: &notionapi.SelectFilterCondition{
-						Equals: RecordNotAppear,
-					},
-				},
-			},
+			recordStatesFilter(notionRecordStatuses(isAnyStatus)),
 		},
 		Sorts: []notionapi.SortObject{
 			{
@@ -260,23 +334,53 @@ func (s *AppointmentRepository) ActualAppointments(
 	return records, nil
 }
 
-func (r *AppointmentRepository) ArchiveRecords(ctx context.Context) error {
-	res, err := r.client.Database.Query(ctx, r.recordsDatabaseId, &notionapi.DatabaseQueryRequest{
-		Filter: notionapi.AndCompoundFilter{
-			notionapi.OrCompoundFilter{
-				notionapi.PropertyFilter{
-					Property: RecordState,
-					Select: &notionapi.SelectFilterCondition{
-						Equals: RecordDone,
-					},
+func (s *AppointmentRepository) EditedAppointments(
+	ctx context.Context,
+	since time.Time,
+) ([]appointment.RecordEntity, error) {
+	const op = appointmentRepositoryName + ".EditedAppointments"
+	sinceDate := notionapi.Date(since)
+	records := make([]appointment.RecordEntity, 0)
+	var cursor notionapi.Cursor
+	for {
+		r, err := s.client.Database.Query(ctx, s.recordsDatabaseId, &notionapi.DatabaseQueryRequest{
+			Filter: notionapi.TimestampFilter{
+				Timestamp: notionapi.TimestampLastEdited,
+				LastEditedTime: &notionapi.DateFilterCondition{
+					OnOrAfter: &sinceDate,
 				},
-				notionapi.PropertyFilter{
-					Property: RecordState,
-					Select: &notionapi.SelectFilterCondition{
-						Equals: RecordNotAppear,
-					},
+			},
+			Sorts: []notionapi.SortObject{
+				{
+					Timestamp: notionapi.TimestampLastEdited,
+					Direction: notionapi.SortOrderASC,
 				},
 			},
+			StartCursor: cursor,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		for _, page := range r.Results {
+			record, err := NotionToRecord(page)
+			if err != nil {
+				s.log.Error(ctx, "failed to convert record", sl.Op(op), sl.Err(err))
+				continue
+			}
+			records = append(records, record)
+		}
+		if !r.HasMore {
+			break
+		}
+		cursor = r.NextCursor
+	}
+	return records, nil
+}
+
+func (r *AppointmentRepository) ArchiveRecords(ctx context.Context) error {
+	res, err := r.client.Database.Query(ctx, r.recordsDatabaseId, &notionapi.DatabaseQueryRequest{
+		Filter: notionapi.AndCompoundFilter{
+			recordStatesFilter(notionRecordStatuses(appointment.RecordStatus.IsFinished)),
 		},
 		Sorts: []notionapi.SortObject{
 			{
@@ -295,9 +399,10 @@ func (r *AppointmentRepository) ArchiveRecords(ctx context.Context) error {
 			errs = append(errs, err)
 			continue
 		}
-		newState := RecordDoneArchived
-		if status == RecordNotAppear {
-			newState = RecordNotAppearArchived
+		newState, err := RecordStatusToNotion(status, true)
+		if err != nil {
+			errs = append(errs, err)
+			continue
 		}
 		if _, err = r.client.Page.Update(ctx, notionapi.PageID(page.ID), &notionapi.PageUpdateRequest{
 			Properties: notionapi.Properties{
@@ -311,3 +416,24 @@ func (r *AppointmentRepository) ArchiveRecords(ctx context.Context) error {
 	}
 	return errors.Join(errs...)
 }
+
+func recordStatesFilter(states []string) notionapi.OrCompoundFilter {
+	filter := make(notionapi.OrCompoundFilter, 0, len(states))
+	for _, state := range states {
+		filter = append(filter, notionapi.PropertyFilter{
+			Property: RecordState,
+			Select: &notionapi.SelectFilterCondition{
+				Equals: state,
+			},
+		})
+	}
+	return filter
+}
+
+func isAnyStatus(appointment.RecordStatus) bool {
+	return true
+}
+
+func isNotCanceledByClinic(status appointment.RecordStatus) bool {
+	return status != appointment.RecordCanceledByClinic
+}
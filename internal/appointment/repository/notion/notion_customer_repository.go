@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
+	"unicode"
 
 	"github.com/jomei/notionapi"
 	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
@@ -59,6 +61,73 @@ func (s *CustomerRepository) CustomerById(ctx context.Context, customerId appoin
 	return NotionToCustomer(*res)
 }
 
+// Notion API client does not support phone number filters,
+// the embedded filter provides the interface implementation
+type phoneNumberFilter struct {
+	notionapi.PropertyFilter
+	PhoneNumber *notionapi.TextFilterCondition `json:"phone_number,omitempty"`
+}
+
+// Only rows containing the normalized digits are fetched,
+// the country code is checked by the suffix match
+func (r *CustomerRepository) CustomersByPhone(ctx context.Context, phone string) ([]appointment.CustomerEntity, error) {
+	const op = customerRepositoryName + ".CustomersByPhone"
+	query := phoneDigits(phone)
+	if len(query) == 0 {
+		return nil, fmt.Errorf("%s: %w", op, shared.ErrNotFound)
+	}
+	customers := make([]appointment.CustomerEntity, 0)
+	var cursor notionapi.Cursor
+	for {
+		res, err := r.client.Database.Query(ctx, r.customersDatabaseId, &notionapi.DatabaseQueryRequest{
+			Filter: phoneNumberFilter{
+				PropertyFilter: notionapi.PropertyFilter{
+					Property: CustomerPhoneNumber,
+				},
+				PhoneNumber: &notionapi.TextFilterCondition{
+					Contains: query,
+				},
+			},
+			StartCursor: cursor,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		for _, page := range res.Results {
+			if !strings.HasSuffix(phoneDigits(notion.Phone(page.Properties, CustomerPhoneNumber)), query) {
+				continue
+			}
+			customer, err := NotionToCustomer(page)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", op, err)
+			}
+			customers = append(customers, customer)
+		}
+		if !res.HasMore {
+			break
+		}
+		cursor = res.NextCursor
+	}
+	if len(customers) == 0 {
+		return nil, fmt.Errorf("%s: %w", op, shared.ErrNotFound)
+	}
+	return customers, nil
+}
+
+// Country code is dropped to match both `+7...` and `8...` forms
+func phoneDigits(phone string) string {
+	digits := strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, phone)
+	if len(digits) > 10 {
+		return digits[len(digits)-10:]
+	}
+	return digits
+}
+
 func (r *CustomerRepository) CreateCustomer(ctx context.Context, customer *appointment.CustomerEntity) error {
 	const op = customerRepositoryName + ".CreateCustomer"
 	if _, err := r.CustomerByIdentity(ctx, customer.Identity); !errors.Is(err, shared.ErrNotFound) {
@@ -2,11 +2,13 @@ package appointment_notion_repository
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/jomei/notionapi"
 	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
 	"github.com/x0k/veterinary-clinic-backend/internal/lib/logger"
 	"github.com/x0k/veterinary-clinic-backend/internal/lib/logger/sl"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/notion"
 	"github.com/x0k/veterinary-clinic-backend/internal/shared"
 )
 
@@ -66,3 +68,34 @@ func (s *WorkBreaksRepository) WorkBreaks(ctx context.Context) (appointment.Work
 	}
 	return workBreaks, nil
 }
+
+func (s *WorkBreaksRepository) CreateWorkBreak(
+	ctx context.Context,
+	title string,
+	period shared.DateTimePeriod,
+) error {
+	const op = workBreaksRepositoryName + ".CreateWorkBreak"
+	start := notionapi.Date(shared.DateTimeToGoTime(period.Start))
+	end := notionapi.Date(shared.DateTimeToGoTime(period.End))
+	if _, err := s.client.Page.Create(ctx, &notionapi.PageCreateRequest{
+		Parent: notionapi.Parent{
+			DatabaseID: s.breaksDatabaseId,
+		},
+		Properties: notionapi.Properties{
+			BreakTitle: notionapi.TitleProperty{
+				Type:  notionapi.PropertyTypeTitle,
+				Title: notion.ToRichText(title),
+			},
+			BreakPeriod: notionapi.DateProperty{
+				Type: notionapi.PropertyTypeDate,
+				Date: &notionapi.DateObject{
+					Start: &start,
+					End:   &end,
+				},
+			},
+		},
+	}); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package appointment_sqlite_repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+)
+
+const appointmentsStateRepositoryName = "appointment_sqlite_repository.AppointmentsStateRepository"
+
+type AppointmentsStateRepository struct {
+	db *sql.DB
+}
+
+func NewAppointmentsStateRepository(db *sql.DB) *AppointmentsStateRepository {
+	return &AppointmentsStateRepository{
+		db: db,
+	}
+}
+
+func (r *AppointmentsStateRepository) AppointmentsState(ctx context.Context) (appointment.AppointmentsState, error) {
+	const op = appointmentsStateRepositoryName + ".AppointmentsState"
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return appointment.AppointmentsState{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+	var cursor, reconciledAt int64
+	err = tx.QueryRowContext(
+		ctx,
+		`SELECT cursor, reconciled_at FROM tracking_state WHERE id = 1`,
+	).Scan(&cursor, &reconciledAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return appointment.NewAppointmentsState(
+			map[appointment.RecordId]appointment.RecordEntity{},
+			time.Time{},
+			time.Time{},
+		), nil
+	}
+	if err != nil {
+		return appointment.AppointmentsState{}, fmt.Errorf("%s: %w", op, err)
+	}
+	rows, err := tx.QueryContext(ctx, `SELECT record FROM tracking_records`)
+	if err != nil {
+		return appointment.AppointmentsState{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+	records := make(map[appointment.RecordId]appointment.RecordEntity)
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return appointment.AppointmentsState{}, fmt.Errorf("%s: %w", op, err)
+		}
+		var dto recordDTO
+		if err := json.Unmarshal(data, &dto); err != nil {
+			return appointment.AppointmentsState{}, fmt.Errorf(
+				"%s: %w: %w", op, appointment.ErrAppointmentsStateCorrupted, err,
+			)
+		}
+		record := recordFromDTO(dto)
+		records[record.Id] = record
+	}
+	if err := rows.Err(); err != nil {
+		return appointment.AppointmentsState{}, fmt.Errorf("%s: %w", op, err)
+	}
+	return appointment.NewAppointmentsState(
+		records,
+		time.UnixMilli(cursor),
+		time.UnixMilli(reconciledAt),
+	), nil
+}
+
+func (r *AppointmentsStateRepository) SaveAppointmentsState(
+	ctx context.Context,
+	state appointment.AppointmentsState,
+) error {
+	const op = appointmentsStateRepositoryName + ".SaveAppointmentsState"
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+	if _, err := tx.ExecContext(ctx, `DELETE FROM tracking_records`); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	stmt, err := tx.PrepareContext(ctx, `INSERT INTO tracking_records (id, record) VALUES (?, ?)`)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer stmt.Close()
+	for id, record := range state.Appointments() {
+		data, err := json.Marshal(recordToDTO(record))
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		if _, err := stmt.ExecContext(ctx, id.String(), string(data)); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	if _, err := tx.ExecContext(
+		ctx,
+		`INSERT INTO tracking_state (id, cursor, reconciled_at) VALUES (1, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET cursor = excluded.cursor, reconciled_at = excluded.reconciled_at`,
+		state.Cursor().UnixMilli(),
+		state.ReconciledAt().UnixMilli(),
+	); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+package appointment_sqlite_repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+)
+
+const auditLogRepositoryName = "appointment_sqlite_repository.AuditLogRepository"
+
+type AuditLogRepository struct {
+	db *sql.DB
+}
+
+func NewAuditLogRepository(db *sql.DB) *AuditLogRepository {
+	return &AuditLogRepository{
+		db: db,
+	}
+}
+
+func encodeAuditRecord(record *appointment.RecordEntity) (sql.NullString, error) {
+	if record == nil {
+		return sql.NullString{}, nil
+	}
+	data, err := json.Marshal(recordToDTO(*record))
+	if err != nil {
+		return sql.NullString{}, err
+	}
+	return sql.NullString{String: string(data), Valid: true}, nil
+}
+
+func decodeAuditRecord(data sql.NullString) (*appointment.RecordEntity, error) {
+	if !data.Valid {
+		return nil, nil
+	}
+	var dto recordDTO
+	if err := json.Unmarshal([]byte(data.String), &dto); err != nil {
+		return nil, err
+	}
+	record := recordFromDTO(dto)
+	return &record, nil
+}
+
+func (r *AuditLogRepository) SaveEntries(ctx context.Context, entries []appointment.AuditEntry) error {
+	const op = auditLogRepositoryName + ".SaveEntries"
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+	for _, e := range entries {
+		before, err := encodeAuditRecord(e.Before)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		after, err := encodeAuditRecord(e.After)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		if _, err := tx.ExecContext(
+			ctx,
+			`INSERT INTO audit_log
+			(record_id, action, actor_type, actor_identity, before, after, reason, occurred_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+			e.RecordId.String(),
+			string(e.Action),
+			string(e.Actor.Type),
+			e.Actor.Identity.String(),
+			before,
+			after,
+			e.Reason,
+			e.OccurredAt.UnixMilli(),
+		); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func (r *AuditLogRepository) entries(
+	ctx context.Context,
+	op string,
+	query string,
+	args ...any,
+) ([]appointment.AuditEntry, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+	entries := make([]appointment.AuditEntry, 0)
+	for rows.Next() {
+		var (
+			e             appointment.AuditEntry
+			recordId      string
+			action        string
+			actorType     string
+			actorIdentity string
+			before        sql.NullString
+			after         sql.NullString
+			occurredAt    int64
+		)
+		if err := rows.Scan(
+			&recordId, &action, &actorType, &actorIdentity,
+			&before, &after, &e.Reason, &occurredAt,
+		); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		e.RecordId = appointment.NewRecordId(recordId)
+		e.Action = appointment.AuditAction(action)
+		e.Actor = appointment.AuditActor{
+			Type:     appointment.AuditActorType(actorType),
+			Identity: appointment.CustomerIdentity(actorIdentity),
+		}
+		if e.Before, err = decodeAuditRecord(before); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if e.After, err = decodeAuditRecord(after); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		e.OccurredAt = time.UnixMilli(occurredAt)
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return entries, nil
+}
+
+func (r *AuditLogRepository) RecordEntries(
+	ctx context.Context,
+	recordId appointment.RecordId,
+) ([]appointment.AuditEntry, error) {
+	return r.entries(
+		ctx,
+		auditLogRepositoryName+".RecordEntries",
+		`SELECT record_id, action, actor_type, actor_identity, before, after, reason, occurred_at
+		FROM audit_log
+		WHERE record_id = ?
+		ORDER BY id`,
+		recordId.String(),
+	)
+}
+
+func (r *AuditLogRepository) RecentEntries(ctx context.Context, limit int) ([]appointment.AuditEntry, error) {
+	return r.entries(
+		ctx,
+		auditLogRepositoryName+".RecentEntries",
+		`SELECT record_id, action, actor_type, actor_identity, before, after, reason, occurred_at
+		FROM audit_log
+		ORDER BY id DESC LIMIT ?`,
+		limit,
+	)
+}
+
+func (r *AuditLogRepository) EntriesInPeriod(
+	ctx context.Context,
+	from time.Time,
+	to time.Time,
+) ([]appointment.AuditEntry, error) {
+	return r.entries(
+		ctx,
+		auditLogRepositoryName+".EntriesInPeriod",
+		`SELECT record_id, action, actor_type, actor_identity, before, after, reason, occurred_at
+		FROM audit_log
+		WHERE occurred_at >= ? AND occurred_at < ?
+		ORDER BY occurred_at, id`,
+		from.UnixMilli(),
+		to.UnixMilli(),
+	)
+}
@@ -0,0 +1,73 @@
+package appointment_sqlite_repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+)
+
+const customerLocaleRepositoryName = "appointment_sqlite_repository.CustomerLocaleRepository"
+
+type CustomerLocaleRepository struct {
+	db *sql.DB
+}
+
+func NewCustomerLocaleRepository(db *sql.DB) *CustomerLocaleRepository {
+	return &CustomerLocaleRepository{
+		db: db,
+	}
+}
+
+func (r *CustomerLocaleRepository) CustomerLocale(
+	ctx context.Context,
+	identity appointment.CustomerIdentity,
+) (appointment.CustomerLocale, error) {
+	const op = customerLocaleRepositoryName + ".CustomerLocale"
+	var locale appointment.CustomerLocale
+	err := r.db.QueryRowContext(
+		ctx,
+		`SELECT preferred, detected FROM customer_locale WHERE customer_identity = ?`,
+		identity.String(),
+	).Scan(&locale.Preferred, &locale.Detected)
+	if errors.Is(err, sql.ErrNoRows) {
+		return appointment.CustomerLocale{}, nil
+	}
+	if err != nil {
+		return appointment.CustomerLocale{}, fmt.Errorf("%s: %w", op, err)
+	}
+	return locale, nil
+}
+
+func (r *CustomerLocaleRepository) SaveCustomerLocale(
+	ctx context.Context,
+	identity appointment.CustomerIdentity,
+	locale appointment.CustomerLocale,
+) error {
+	const op = customerLocaleRepositoryName + ".SaveCustomerLocale"
+	var err error
+	if locale.IsZero() {
+		_, err = r.db.ExecContext(
+			ctx,
+			`DELETE FROM customer_locale WHERE customer_identity = ?`,
+			identity.String(),
+		)
+	} else {
+		_, err = r.db.ExecContext(
+			ctx,
+			`INSERT INTO customer_locale (customer_identity, preferred, detected) VALUES (?, ?, ?)
+			ON CONFLICT (customer_identity) DO UPDATE SET
+				preferred = excluded.preferred,
+				detected = excluded.detected`,
+			identity.String(),
+			locale.Preferred,
+			locale.Detected,
+		)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
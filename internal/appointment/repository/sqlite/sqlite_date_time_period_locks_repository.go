@@ -0,0 +1,190 @@
+package appointment_sqlite_repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	"github.com/x0k/veterinary-clinic-backend/internal/shared"
+)
+
+const dateTimePeriodLocksRepositoryName = "appointment_sqlite_repository.DateTimePeriodLocksRepository"
+
+const dateTimeLayout = "2006-01-02 15:04"
+
+type DateTimePeriodLocksRepository struct {
+	db       *sql.DB
+	owner    string
+	leaseTTL time.Duration
+}
+
+func NewDateTimePeriodLocksRepository(
+	db *sql.DB,
+	owner string,
+	leaseTTL time.Duration,
+) *DateTimePeriodLocksRepository {
+	return &DateTimePeriodLocksRepository{
+		db:       db,
+		owner:    owner,
+		leaseTTL: leaseTTL,
+	}
+}
+
+func (r *DateTimePeriodLocksRepository) Lock(ctx context.Context, period shared.DateTimePeriod) error {
+	const op = dateTimePeriodLocksRepositoryName + ".Lock"
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+	now := time.Now()
+	if _, err := tx.ExecContext(
+		ctx,
+		`DELETE FROM date_time_period_lock WHERE expires_at <= ?`,
+		now.UnixMilli(),
+	); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	var count int
+	if err := tx.QueryRowContext(
+		ctx,
+		`SELECT COUNT(*) FROM date_time_period_lock WHERE period_start < ? AND period_end > ?`,
+		period.End.String(),
+		period.Start.String(),
+	).Scan(&count); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if count > 0 {
+		return fmt.Errorf("%w: %s", appointment.ErrPeriodIsLocked, period)
+	}
+	if _, err := tx.ExecContext(
+		ctx,
+		`INSERT INTO date_time_period_lock (owner, period_start, period_end, expires_at) VALUES (?, ?, ?, ?)`,
+		r.owner,
+		period.Start.String(),
+		period.End.String(),
+		now.Add(r.leaseTTL).UnixMilli(),
+	); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func (r *DateTimePeriodLocksRepository) UnLock(ctx context.Context, period shared.DateTimePeriod) error {
+	const op = dateTimePeriodLocksRepositoryName + ".UnLock"
+	if _, err := r.db.ExecContext(
+		ctx,
+		`DELETE FROM date_time_period_lock WHERE owner = ? AND period_start = ? AND period_end = ?`,
+		r.owner,
+		period.Start.String(),
+		period.End.String(),
+	); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func (r *DateTimePeriodLocksRepository) Hold(ctx context.Context, hold appointment.SlotHold) error {
+	const op = dateTimePeriodLocksRepositoryName + ".Hold"
+	if _, err := r.db.ExecContext(
+		ctx,
+		`INSERT INTO slot_hold (owner, period_start, period_end, expires_at) VALUES (?, ?, ?, ?)
+		ON CONFLICT (owner) DO UPDATE SET
+			period_start = excluded.period_start,
+			period_end = excluded.period_end,
+			expires_at = excluded.expires_at`,
+		hold.Owner.String(),
+		hold.DateTimePeriod.Start.String(),
+		hold.DateTimePeriod.End.String(),
+		hold.ExpiresAt.UnixMilli(),
+	); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func (r *DateTimePeriodLocksRepository) Release(ctx context.Context, owner appointment.CustomerIdentity) error {
+	const op = dateTimePeriodLocksRepositoryName + ".Release"
+	if _, err := r.db.ExecContext(
+		ctx,
+		`DELETE FROM slot_hold WHERE owner = ?`,
+		owner.String(),
+	); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func (r *DateTimePeriodLocksRepository) Holds(
+	ctx context.Context,
+	from time.Time,
+	to time.Time,
+) (appointment.SlotHolds, error) {
+	const op = dateTimePeriodLocksRepositoryName + ".Holds"
+	rows, err := r.db.QueryContext(
+		ctx,
+		`SELECT owner, period_start, period_end, expires_at FROM slot_hold
+		WHERE period_start >= ? AND period_start < ? AND expires_at > ?`,
+		shared.GoTimeToDateTime(from).String(),
+		shared.GoTimeToDateTime(to).String(),
+		time.Now().UnixMilli(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+	holds := make(appointment.SlotHolds, 0)
+	for rows.Next() {
+		var (
+			owner       string
+			periodStart string
+			periodEnd   string
+			expiresAt   int64
+		)
+		if err := rows.Scan(&owner, &periodStart, &periodEnd, &expiresAt); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		hold, err := slotHold(owner, periodStart, periodEnd, expiresAt)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		holds = append(holds, hold)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return holds, nil
+}
+
+func slotHold(
+	owner string,
+	periodStart string,
+	periodEnd string,
+	expiresAt int64,
+) (appointment.SlotHold, error) {
+	identity, err := appointment.NewCustomerIdentity(owner)
+	if err != nil {
+		return appointment.SlotHold{}, err
+	}
+	start, err := time.ParseInLocation(dateTimeLayout, periodStart, time.Local)
+	if err != nil {
+		return appointment.SlotHold{}, err
+	}
+	end, err := time.ParseInLocation(dateTimeLayout, periodEnd, time.Local)
+	if err != nil {
+		return appointment.SlotHold{}, err
+	}
+	return appointment.NewSlotHold(
+		identity,
+		shared.DateTimePeriod{
+			Start: shared.GoTimeToDateTime(start),
+			End:   shared.GoTimeToDateTime(end),
+		},
+		time.UnixMilli(expiresAt),
+	), nil
+}
@@ -0,0 +1,77 @@
+package appointment_sqlite_repository
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	"github.com/x0k/veterinary-clinic-backend/internal/shared"
+)
+
+func period(startHour int, endHour int) shared.DateTimePeriod {
+	return shared.DateTimePeriod{
+		Start: shared.GoTimeToDateTime(time.Date(2024, 5, 1, startHour, 0, 0, 0, time.Local)),
+		End:   shared.GoTimeToDateTime(time.Date(2024, 5, 1, endHour, 0, 0, 0, time.Local)),
+	}
+}
+
+func TestDateTimePeriodLocksContention(t *testing.T) {
+	db := newTestDB(t, 0)
+	var (
+		wg         sync.WaitGroup
+		mu         sync.Mutex
+		locked     int
+		unexpected []error
+	)
+	for _, owner := range []string{"a", "b", "c", "d"} {
+		repo := NewDateTimePeriodLocksRepository(db, owner, time.Minute)
+		for range 5 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				err := repo.Lock(context.Background(), period(10, 11))
+				mu.Lock()
+				defer mu.Unlock()
+				if err == nil {
+					locked++
+				} else if !errors.Is(err, appointment.ErrPeriodIsLocked) {
+					unexpected = append(unexpected, err)
+				}
+			}()
+		}
+	}
+	wg.Wait()
+	if locked != 1 {
+		t.Errorf("period is locked %d times, want once", locked)
+	}
+	if len(unexpected) > 0 {
+		t.Errorf("unexpected errors: %v", unexpected)
+	}
+}
+
+func TestDateTimePeriodLocksLeaseExpiry(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t, 0)
+	a := NewDateTimePeriodLocksRepository(db, "a", 50*time.Millisecond)
+	b := NewDateTimePeriodLocksRepository(db, "b", 50*time.Millisecond)
+	if err := a.Lock(ctx, period(10, 12)); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Lock(ctx, period(11, 13)); !errors.Is(err, appointment.ErrPeriodIsLocked) {
+		t.Fatalf("Lock() error = %v, want %v", err, appointment.ErrPeriodIsLocked)
+	}
+	// Leases of other owners are kept
+	if err := b.UnLock(ctx, period(10, 12)); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Lock(ctx, period(10, 12)); !errors.Is(err, appointment.ErrPeriodIsLocked) {
+		t.Fatalf("Lock() error = %v, want %v", err, appointment.ErrPeriodIsLocked)
+	}
+	time.Sleep(60 * time.Millisecond)
+	if err := b.Lock(ctx, period(11, 13)); err != nil {
+		t.Errorf("expired lease is not released: %v", err)
+	}
+}
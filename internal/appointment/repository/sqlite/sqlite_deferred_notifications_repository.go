@@ -0,0 +1,96 @@
+package appointment_sqlite_repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+)
+
+const deferredNotificationsRepositoryName = "appointment_sqlite_repository.DeferredNotificationsRepository"
+
+type DeferredNotificationsRepository struct {
+	db *sql.DB
+}
+
+func NewDeferredNotificationsRepository(db *sql.DB) *DeferredNotificationsRepository {
+	return &DeferredNotificationsRepository{
+		db: db,
+	}
+}
+
+func (r *DeferredNotificationsRepository) SaveNotification(
+	ctx context.Context,
+	notification appointment.DeferredNotification,
+) error {
+	const op = deferredNotificationsRepositoryName + ".SaveNotification"
+	channels := make([]string, len(notification.Channels))
+	for i, c := range notification.Channels {
+		channels[i] = c.String()
+	}
+	if _, err := r.db.ExecContext(
+		ctx,
+		`INSERT INTO deferred_notification (payload, channels, send_at) VALUES (?, ?, ?)`,
+		notification.Payload,
+		strings.Join(channels, ","),
+		notification.SendAt.UnixMilli(),
+	); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func (r *DeferredNotificationsRepository) DueNotifications(
+	ctx context.Context,
+	now time.Time,
+) ([]appointment.DeferredNotification, error) {
+	const op = deferredNotificationsRepositoryName + ".DueNotifications"
+	rows, err := r.db.QueryContext(
+		ctx,
+		`SELECT id, payload, channels, send_at FROM deferred_notification
+		WHERE send_at <= ? ORDER BY id`,
+		now.UnixMilli(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+	notifications := make([]appointment.DeferredNotification, 0)
+	for rows.Next() {
+		var (
+			n        appointment.DeferredNotification
+			id       int64
+			channels string
+			sendAt   int64
+		)
+		if err := rows.Scan(&id, &n.Payload, &channels, &sendAt); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		n.Id = appointment.DeferredNotificationId(id)
+		n.Channels = channelsFromList(channels)
+		n.SendAt = time.UnixMilli(sendAt)
+		notifications = append(notifications, n)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return notifications, nil
+}
+
+func (r *DeferredNotificationsRepository) RemoveNotification(
+	ctx context.Context,
+	id appointment.DeferredNotificationId,
+) error {
+	const op = deferredNotificationsRepositoryName + ".RemoveNotification"
+	if _, err := r.db.ExecContext(
+		ctx,
+		`DELETE FROM deferred_notification WHERE id = ?`,
+		int64(id),
+	); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package appointment_sqlite_repository
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+)
+
+func TestDeferredNotificationsRepository(t *testing.T) {
+	ctx := context.Background()
+	repo := NewDeferredNotificationsRepository(newTestDB(t, 0))
+	morning := time.Date(2024, 5, 6, 8, 0, 0, 0, time.UTC)
+	for _, at := range []time.Time{morning, morning.Add(time.Hour)} {
+		if err := repo.SaveNotification(ctx, appointment.DeferredNotification{
+			Payload:  []byte(`{}`),
+			Channels: []appointment.NotificationChannel{appointment.SmsNotificationChannel},
+			SendAt:   at,
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	due, err := repo.DueNotifications(ctx, morning)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(due) != 1 || !due[0].SendAt.Equal(morning) || string(due[0].Payload) != `{}` ||
+		!slices.Equal(due[0].Channels, []appointment.NotificationChannel{appointment.SmsNotificationChannel}) {
+		t.Fatalf("due = %+v, want the morning notification", due)
+	}
+	if err := repo.RemoveNotification(ctx, due[0].Id); err != nil {
+		t.Fatal(err)
+	}
+	if due, err = repo.DueNotifications(ctx, morning.Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if len(due) != 1 || !due[0].SendAt.Equal(morning.Add(time.Hour)) {
+		t.Errorf("due = %+v, want the later notification", due)
+	}
+}
@@ -0,0 +1,104 @@
+package appointment_sqlite_repository
+
+import (
+	"time"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	"github.com/x0k/veterinary-clinic-backend/internal/shared"
+)
+
+type recordDTO struct {
+	Id         string                `json:"id"`
+	Title      string                `json:"title"`
+	Status     string                `json:"status"`
+	IsArchived bool                  `json:"isArchived"`
+	Period     shared.DateTimePeriod `json:"period"`
+	CustomerId string                `json:"customerId"`
+	ServiceId  string                `json:"serviceId"`
+	CreatedAt  time.Time             `json:"createdAt"`
+}
+
+func recordToDTO(record appointment.RecordEntity) recordDTO {
+	return recordDTO{
+		Id:         record.Id.String(),
+		Title:      record.Title,
+		Status:     record.Status.String(),
+		IsArchived: record.IsArchived,
+		Period:     record.DateTimePeriod,
+		CustomerId: record.CustomerId.String(),
+		ServiceId:  record.ServiceId.String(),
+		CreatedAt:  record.CreatedAt,
+	}
+}
+
+func recordFromDTO(dto recordDTO) appointment.RecordEntity {
+	return appointment.RecordEntity{
+		Id:             appointment.NewRecordId(dto.Id),
+		Title:          dto.Title,
+		Status:         appointment.NewRecordStatus(dto.Status),
+		IsArchived:     dto.IsArchived,
+		DateTimePeriod: dto.Period,
+		CustomerId:     appointment.NewCustomerId(dto.CustomerId),
+		ServiceId:      appointment.NewServiceId(dto.ServiceId),
+		CreatedAt:      dto.CreatedAt,
+	}
+}
+
+type customerDTO struct {
+	Id          string `json:"id"`
+	Identity    string `json:"identity"`
+	Name        string `json:"name"`
+	PhoneNumber string `json:"phoneNumber"`
+	Email       string `json:"email"`
+}
+
+func customerToDTO(customer appointment.CustomerEntity) customerDTO {
+	return customerDTO{
+		Id:          customer.Id.String(),
+		Identity:    customer.Identity.String(),
+		Name:        customer.Name,
+		PhoneNumber: customer.PhoneNumber,
+		Email:       customer.Email,
+	}
+}
+
+func customerFromDTO(dto customerDTO) appointment.CustomerEntity {
+	return appointment.CustomerEntity{
+		Id:          appointment.NewCustomerId(dto.Id),
+		Identity:    appointment.CustomerIdentity(dto.Identity),
+		Name:        dto.Name,
+		PhoneNumber: dto.PhoneNumber,
+		Email:       dto.Email,
+	}
+}
+
+type serviceDTO struct {
+	Id                string `json:"id"`
+	Title             string `json:"title"`
+	DurationInMinutes int    `json:"durationInMinutes"`
+	Description       string `json:"description"`
+	CostDescription   string `json:"costDescription"`
+	RequiresApproval  bool   `json:"requiresApproval"`
+}
+
+func serviceToDTO(service appointment.ServiceEntity) serviceDTO {
+	return serviceDTO{
+		Id:                service.Id.String(),
+		Title:             service.Title,
+		DurationInMinutes: service.DurationInMinutes.Int(),
+		Description:       service.Description,
+		CostDescription:   service.CostDescription,
+		RequiresApproval:  service.RequiresApproval,
+	}
+}
+
+func serviceFromDTO(dto serviceDTO) appointment.ServiceEntity {
+	return appointment.ServiceEntity{
+		Id:                appointment.NewServiceId(dto.Id),
+		Title:             dto.Title,
+		DurationInMinutes: shared.DurationInMinutes(dto.DurationInMinutes),
+		Description:       dto.Description,
+		CostDescription:   dto.CostDescription,
+		RequiresApproval:  dto.RequiresApproval,
+	}
+}
@@ -0,0 +1,145 @@
+package appointment_sqlite_repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+)
+
+const idempotentRecordsRepositoryName = "appointment_sqlite_repository.IdempotentRecordsRepository"
+
+// Lease of the reserved key, expired reservation of the crashed request
+// does not block the key forever
+const idempotencyKeyReservationTTL = time.Minute
+
+type IdempotentRecordsRepository struct {
+	db  *sql.DB
+	ttl time.Duration
+}
+
+func NewIdempotentRecordsRepository(db *sql.DB, ttl time.Duration) *IdempotentRecordsRepository {
+	return &IdempotentRecordsRepository{
+		db:  db,
+		ttl: ttl,
+	}
+}
+
+func (r *IdempotentRecordsRepository) Reserve(
+	ctx context.Context,
+	key appointment.IdempotencyKey,
+) (appointment.RecordEntity, bool, error) {
+	const op = idempotentRecordsRepositoryName + ".Reserve"
+	now := time.Now()
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return appointment.RecordEntity{}, false, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+	if _, err := tx.ExecContext(
+		ctx,
+		`DELETE FROM idempotent_record WHERE expires_at <= ?`,
+		now.UnixMilli(),
+	); err != nil {
+		return appointment.RecordEntity{}, false, fmt.Errorf("%s: %w", op, err)
+	}
+	var data sql.NullString
+	err = tx.QueryRowContext(
+		ctx,
+		`SELECT record FROM idempotent_record WHERE key = ?`,
+		key.String(),
+	).Scan(&data)
+	if err == nil {
+		if !data.Valid {
+			return appointment.RecordEntity{}, false, fmt.Errorf("%s: %w", op, appointment.ErrIdempotencyKeyIsReserved)
+		}
+		var dto recordDTO
+		if err := json.Unmarshal([]byte(data.String), &dto); err != nil {
+			return appointment.RecordEntity{}, false, fmt.Errorf("%s: %w", op, err)
+		}
+		return recordFromDTO(dto), false, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return appointment.RecordEntity{}, false, fmt.Errorf("%s: %w", op, err)
+	}
+	res, err := tx.ExecContext(
+		ctx,
+		`INSERT INTO idempotent_record (key, record, expires_at) VALUES (?, NULL, ?)
+		ON CONFLICT (key) DO NOTHING`,
+		key.String(),
+		now.Add(idempotencyKeyReservationTTL).UnixMilli(),
+	)
+	if err != nil {
+		return appointment.RecordEntity{}, false, fmt.Errorf("%s: %w", op, err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return appointment.RecordEntity{}, false, fmt.Errorf("%s: %w", op, err)
+	} else if n == 0 {
+		return appointment.RecordEntity{}, false, fmt.Errorf("%s: %w", op, appointment.ErrIdempotencyKeyIsReserved)
+	}
+	if err := tx.Commit(); err != nil {
+		return appointment.RecordEntity{}, false, fmt.Errorf("%s: %w", op, err)
+	}
+	return appointment.RecordEntity{}, true, nil
+}
+
+// Removes the reservation, the saved record is kept
+func (r *IdempotentRecordsRepository) Release(
+	ctx context.Context,
+	key appointment.IdempotencyKey,
+) error {
+	const op = idempotentRecordsRepositoryName + ".Release"
+	if _, err := r.db.ExecContext(
+		ctx,
+		`DELETE FROM idempotent_record WHERE key = ? AND record IS NULL`,
+		key.String(),
+	); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func (r *IdempotentRecordsRepository) SaveRecord(
+	ctx context.Context,
+	key appointment.IdempotencyKey,
+	record appointment.RecordEntity,
+) error {
+	const op = idempotentRecordsRepositoryName + ".SaveRecord"
+	data, err := json.Marshal(recordToDTO(record))
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	now := time.Now()
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+	if _, err := tx.ExecContext(
+		ctx,
+		`DELETE FROM idempotent_record WHERE expires_at <= ?`,
+		now.UnixMilli(),
+	); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if _, err := tx.ExecContext(
+		ctx,
+		`INSERT INTO idempotent_record (key, record, expires_at) VALUES (?, ?, ?)
+		ON CONFLICT (key) DO UPDATE SET
+			record = excluded.record,
+			expires_at = excluded.expires_at`,
+		key.String(),
+		string(data),
+		now.Add(r.ttl).UnixMilli(),
+	); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package appointment_sqlite_repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+)
+
+func TestIdempotentRecordsRepository(t *testing.T) {
+	ctx := context.Background()
+	repo := NewIdempotentRecordsRepository(newTestDB(t, 0), time.Hour)
+	key := appointment.NewIdempotencyKey("key")
+
+	if _, reserved, err := repo.Reserve(ctx, key); err != nil || !reserved {
+		t.Fatalf("Reserve() = %t, %v, want the reservation", reserved, err)
+	}
+	if _, _, err := repo.Reserve(ctx, key); !errors.Is(err, appointment.ErrIdempotencyKeyIsReserved) {
+		t.Fatalf("Reserve() error = %v, want %v", err, appointment.ErrIdempotencyKeyIsReserved)
+	}
+
+	if err := repo.Release(ctx, key); err != nil {
+		t.Fatal(err)
+	}
+	if _, reserved, err := repo.Reserve(ctx, key); err != nil || !reserved {
+		t.Fatalf("Reserve() = %t, %v, want the reservation after release", reserved, err)
+	}
+
+	record := appointment.RecordEntity{
+		Id:         appointment.NewRecordId("record"),
+		Status:     appointment.RecordAwaits,
+		CustomerId: appointment.NewCustomerId("customer"),
+		ServiceId:  appointment.NewServiceId("service"),
+		CreatedAt:  time.Date(2024, 5, 6, 10, 0, 0, 0, time.UTC),
+	}
+	if err := repo.SaveRecord(ctx, key, record); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Release(ctx, key); err != nil {
+		t.Fatal(err)
+	}
+	saved, reserved, err := repo.Reserve(ctx, key)
+	if err != nil || reserved {
+		t.Fatalf("Reserve() = %t, %v, want the saved record", reserved, err)
+	}
+	if saved.Id != record.Id || !saved.CreatedAt.Equal(record.CreatedAt) {
+		t.Errorf("record = %+v, want %+v", saved, record)
+	}
+}
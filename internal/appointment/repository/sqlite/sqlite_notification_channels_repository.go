@@ -0,0 +1,103 @@
+package appointment_sqlite_repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+)
+
+const notificationChannelsRepositoryName = "appointment_sqlite_repository.NotificationChannelsRepository"
+
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+type NotificationChannelsRepository struct {
+	db *sql.DB
+}
+
+func NewNotificationChannelsRepository(db *sql.DB) *NotificationChannelsRepository {
+	return &NotificationChannelsRepository{
+		db: db,
+	}
+}
+
+func (r *NotificationChannelsRepository) NotificationChannels(
+	ctx context.Context,
+	customerId appointment.CustomerId,
+) ([]appointment.NotificationChannel, error) {
+	const op = notificationChannelsRepositoryName + ".NotificationChannels"
+	var channels string
+	err := r.db.QueryRowContext(
+		ctx,
+		`SELECT channels FROM customer_notification_channel WHERE customer_id = ?`,
+		customerId.String(),
+	).Scan(&channels)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return channelsFromList(channels), nil
+}
+
+func (r *NotificationChannelsRepository) SaveNotificationChannels(
+	ctx context.Context,
+	customerId appointment.CustomerId,
+	channels []appointment.NotificationChannel,
+) error {
+	const op = notificationChannelsRepositoryName + ".SaveNotificationChannels"
+	if err := saveNotificationChannels(ctx, r.db, customerId, channels); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// Empty `channels` remove the preference
+func saveNotificationChannels(
+	ctx context.Context,
+	db execer,
+	customerId appointment.CustomerId,
+	channels []appointment.NotificationChannel,
+) error {
+	if len(channels) == 0 {
+		_, err := db.ExecContext(
+			ctx,
+			`DELETE FROM customer_notification_channel WHERE customer_id = ?`,
+			customerId.String(),
+		)
+		return err
+	}
+	list := make([]string, len(channels))
+	for i, c := range channels {
+		list[i] = c.String()
+	}
+	_, err := db.ExecContext(
+		ctx,
+		`INSERT INTO customer_notification_channel (customer_id, channels) VALUES (?, ?)
+		ON CONFLICT (customer_id) DO UPDATE SET channels = excluded.channels`,
+		customerId.String(),
+		strings.Join(list, ","),
+	)
+	return err
+}
+
+func channelsFromList(list string) []appointment.NotificationChannel {
+	var channels []appointment.NotificationChannel
+	for _, c := range splitList(list) {
+		channels = append(channels, appointment.NotificationChannel(c))
+	}
+	return channels
+}
+
+func splitList(list string) []string {
+	if list == "" {
+		return nil
+	}
+	return strings.Split(list, ",")
+}
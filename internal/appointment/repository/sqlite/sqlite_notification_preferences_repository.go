@@ -0,0 +1,121 @@
+package appointment_sqlite_repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	"github.com/x0k/veterinary-clinic-backend/internal/shared"
+)
+
+const notificationPreferencesRepositoryName = "appointment_sqlite_repository.NotificationPreferencesRepository"
+
+// Channels are kept in the table of `NotificationChannelsRepository`
+type NotificationPreferencesRepository struct {
+	db *sql.DB
+}
+
+func NewNotificationPreferencesRepository(db *sql.DB) *NotificationPreferencesRepository {
+	return &NotificationPreferencesRepository{
+		db: db,
+	}
+}
+
+func (r *NotificationPreferencesRepository) NotificationPreferences(
+	ctx context.Context,
+	customerId appointment.CustomerId,
+) (appointment.NotificationPreferences, error) {
+	const op = notificationPreferencesRepositoryName + ".NotificationPreferences"
+	var channels, mutedEvents sql.NullString
+	var quietHoursStart, quietHoursEnd sql.NullInt64
+	err := r.db.QueryRowContext(
+		ctx,
+		`SELECT c.channels, p.muted_events, p.quiet_hours_start, p.quiet_hours_end
+		FROM (SELECT ? AS customer_id) AS k
+		LEFT JOIN customer_notification_channel AS c ON c.customer_id = k.customer_id
+		LEFT JOIN customer_notification_preferences AS p ON p.customer_id = k.customer_id`,
+		customerId.String(),
+	).Scan(&channels, &mutedEvents, &quietHoursStart, &quietHoursEnd)
+	if err != nil {
+		return appointment.NotificationPreferences{}, fmt.Errorf("%s: %w", op, err)
+	}
+	preferences := appointment.NotificationPreferences{
+		Channels: channelsFromList(channels.String),
+	}
+	for _, e := range splitList(mutedEvents.String) {
+		preferences.MutedEvents = append(preferences.MutedEvents, appointment.NotificationEvent(e))
+	}
+	if quietHoursStart.Valid && quietHoursEnd.Valid {
+		preferences.QuietHours = appointment.QuietHours{
+			Start: minutesToTime(quietHoursStart.Int64),
+			End:   minutesToTime(quietHoursEnd.Int64),
+		}
+	}
+	return preferences, nil
+}
+
+func (r *NotificationPreferencesRepository) SaveNotificationPreferences(
+	ctx context.Context,
+	customerId appointment.CustomerId,
+	preferences appointment.NotificationPreferences,
+) error {
+	const op = notificationPreferencesRepositoryName + ".SaveNotificationPreferences"
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+	if err := saveNotificationChannels(ctx, tx, customerId, preferences.Channels); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if len(preferences.MutedEvents) == 0 && preferences.QuietHours.IsZero() {
+		_, err = tx.ExecContext(
+			ctx,
+			`DELETE FROM customer_notification_preferences WHERE customer_id = ?`,
+			customerId.String(),
+		)
+	} else {
+		mutedEvents := make([]string, len(preferences.MutedEvents))
+		for i, e := range preferences.MutedEvents {
+			mutedEvents[i] = e.String()
+		}
+		var quietHoursStart, quietHoursEnd sql.NullInt64
+		if !preferences.QuietHours.IsZero() {
+			quietHoursStart = sql.NullInt64{Int64: timeToMinutes(preferences.QuietHours.Start), Valid: true}
+			quietHoursEnd = sql.NullInt64{Int64: timeToMinutes(preferences.QuietHours.End), Valid: true}
+		}
+		_, err = tx.ExecContext(
+			ctx,
+			`INSERT INTO customer_notification_preferences
+			(customer_id, muted_events, quiet_hours_start, quiet_hours_end) VALUES (?, ?, ?, ?)
+			ON CONFLICT (customer_id) DO UPDATE SET
+				muted_events = excluded.muted_events,
+				quiet_hours_start = excluded.quiet_hours_start,
+				quiet_hours_end = excluded.quiet_hours_end`,
+			customerId.String(),
+			strings.Join(mutedEvents, ","),
+			quietHoursStart,
+			quietHoursEnd,
+		)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func timeToMinutes(t shared.Time) int64 {
+	return int64(t.Hours*60 + t.Minutes)
+}
+
+func minutesToTime(minutes int64) shared.Time {
+	return shared.Time{
+		Hours:   int(minutes / 60),
+		Minutes: int(minutes % 60),
+	}
+}
@@ -0,0 +1,100 @@
+package appointment_sqlite_repository
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	"github.com/x0k/veterinary-clinic-backend/internal/shared"
+)
+
+func TestNotificationPreferencesKeepChosenChannel(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t, 10)
+	if _, err := db.Exec(
+		`INSERT INTO customer_notification_channel (customer_id, channel) VALUES (?, ?)`,
+		"customer", "sms",
+	); err != nil {
+		t.Fatal(err)
+	}
+	migrate(t, db, 10, 0)
+
+	preferences, err := NewNotificationPreferencesRepository(db).NotificationPreferences(ctx, "customer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := appointment.NotificationPreferences{
+		Channels: []appointment.NotificationChannel{appointment.SmsNotificationChannel},
+	}
+	if !reflect.DeepEqual(preferences, want) {
+		t.Errorf("preferences = %+v, want %+v", preferences, want)
+	}
+}
+
+func TestNotificationPreferencesRepository(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t, 0)
+	channelsRepo := NewNotificationChannelsRepository(db)
+	repo := NewNotificationPreferencesRepository(db)
+
+	empty, err := repo.NotificationPreferences(ctx, "customer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(empty, appointment.NotificationPreferences{}) {
+		t.Errorf("preferences = %+v, want the zero value", empty)
+	}
+
+	preferences := appointment.NotificationPreferences{
+		Channels: []appointment.NotificationChannel{
+			appointment.EmailNotificationChannel,
+			appointment.WebPushNotificationChannel,
+		},
+		MutedEvents: []appointment.NotificationEvent{appointment.ReminderNotificationEvent},
+		QuietHours: appointment.QuietHours{
+			Start: shared.Time{Hours: 22},
+			End:   shared.Time{Hours: 8, Minutes: 30},
+		},
+	}
+	if err := repo.SaveNotificationPreferences(ctx, "customer", preferences); err != nil {
+		t.Fatal(err)
+	}
+	saved, err := repo.NotificationPreferences(ctx, "customer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(saved, preferences) {
+		t.Errorf("preferences = %+v, want %+v", saved, preferences)
+	}
+	channels, err := channelsRepo.NotificationChannels(ctx, "customer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(channels, preferences.Channels) {
+		t.Errorf("channels = %v, want %v", channels, preferences.Channels)
+	}
+
+	if err := channelsRepo.SaveNotificationChannels(ctx, "customer", nil); err != nil {
+		t.Fatal(err)
+	}
+	saved, err = repo.NotificationPreferences(ctx, "customer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	preferences.Channels = nil
+	if !reflect.DeepEqual(saved, preferences) {
+		t.Errorf("preferences = %+v, want %+v", saved, preferences)
+	}
+
+	if err := repo.SaveNotificationPreferences(ctx, "customer", appointment.NotificationPreferences{}); err != nil {
+		t.Fatal(err)
+	}
+	var rows int
+	if err := db.QueryRow(`SELECT count(*) FROM customer_notification_preferences`).Scan(&rows); err != nil {
+		t.Fatal(err)
+	}
+	if rows != 0 {
+		t.Errorf("rows = %d, want 0", rows)
+	}
+}
@@ -0,0 +1,259 @@
+package appointment_sqlite_repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+)
+
+const outboxRepositoryName = "appointment_sqlite_repository.OutboxRepository"
+
+const (
+	outboxPending = "pending"
+	outboxDone    = "done"
+	outboxFailed  = "failed"
+)
+
+// Names are stored instead of the numbers of event types,
+// so the order of the constants can be changed
+var eventTypeNames = map[appointment.EventType]string{
+	appointment.CreatedEventType:  "created",
+	appointment.CanceledEventType: "canceled",
+	appointment.ChangedEventType:  "changed",
+}
+
+func eventTypeFromName(name string) (appointment.EventType, error) {
+	for t, n := range eventTypeNames {
+		if n == name {
+			return t, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %s", appointment.ErrUnsupportedOutboxEvent, name)
+}
+
+type outboxEventDTO struct {
+	Record     recordDTO   `json:"record"`
+	Customer   customerDTO `json:"customer"`
+	Service    serviceDTO  `json:"service"`
+	ChangeType int         `json:"changeType"`
+	Previous   *recordDTO  `json:"previous,omitempty"`
+}
+
+func outboxEventToDTO(event appointment.Event) (outboxEventDTO, error) {
+	switch e := event.(type) {
+	case appointment.CreatedEvent:
+		return outboxEventDTO{
+			Record:   recordToDTO(e.Record),
+			Customer: customerToDTO(e.Customer),
+			Service:  serviceToDTO(e.Service),
+		}, nil
+	case appointment.CanceledEvent:
+		return outboxEventDTO{
+			Record:   recordToDTO(e.Record),
+			Customer: customerToDTO(e.Customer),
+			Service:  serviceToDTO(e.Service),
+		}, nil
+	case appointment.ChangedEvent:
+		dto := outboxEventDTO{
+			Record:     recordToDTO(e.Record),
+			ChangeType: int(e.ChangeType),
+		}
+		if e.ChangeType == appointment.DateTimeChangeType || e.ChangeType == appointment.StatusChangeType {
+			previous := recordToDTO(e.Previous)
+			dto.Previous = &previous
+		}
+		return dto, nil
+	default:
+		return outboxEventDTO{}, fmt.Errorf("%w: %v", appointment.ErrUnsupportedOutboxEvent, event.Type())
+	}
+}
+
+func outboxEventFromDTO(eventType appointment.EventType, dto outboxEventDTO) (appointment.Event, error) {
+	switch eventType {
+	case appointment.CreatedEventType:
+		return appointment.NewCreated(
+			recordFromDTO(dto.Record),
+			customerFromDTO(dto.Customer),
+			serviceFromDTO(dto.Service),
+		), nil
+	case appointment.CanceledEventType:
+		return appointment.NewAppointmentCanceled(
+			recordFromDTO(dto.Record),
+			customerFromDTO(dto.Customer),
+			serviceFromDTO(dto.Service),
+		), nil
+	case appointment.ChangedEventType:
+		event := appointment.NewChanged(
+			appointment.ChangeType(dto.ChangeType),
+			recordFromDTO(dto.Record),
+		)
+		if dto.Previous != nil {
+			event.Previous = recordFromDTO(*dto.Previous)
+		}
+		return event, nil
+	default:
+		return nil, fmt.Errorf("%w: %v", appointment.ErrUnsupportedOutboxEvent, eventType)
+	}
+}
+
+func outboxEvent(eventTypeName string, payload []byte) (appointment.Event, error) {
+	eventType, err := eventTypeFromName(eventTypeName)
+	if err != nil {
+		return nil, err
+	}
+	var dto outboxEventDTO
+	if err := json.Unmarshal(payload, &dto); err != nil {
+		return nil, err
+	}
+	return outboxEventFromDTO(eventType, dto)
+}
+
+type OutboxRepository struct {
+	db *sql.DB
+}
+
+func NewOutboxRepository(db *sql.DB) *OutboxRepository {
+	return &OutboxRepository{
+		db: db,
+	}
+}
+
+func (r *OutboxRepository) SaveEvent(ctx context.Context, event appointment.Event) error {
+	const op = outboxRepositoryName + ".SaveEvent"
+	eventType, ok := eventTypeNames[event.Type()]
+	if !ok {
+		return fmt.Errorf("%s: %w: %v", op, appointment.ErrUnsupportedOutboxEvent, event.Type())
+	}
+	dto, err := outboxEventToDTO(event)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	data, err := json.Marshal(dto)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	now := time.Now().UnixMilli()
+	if _, err := r.db.ExecContext(
+		ctx,
+		`INSERT INTO outbox (event_type, payload, next_attempt_at, created_at) VALUES (?, ?, ?, ?)`,
+		eventType,
+		string(data),
+		now,
+		now,
+	); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// Entries with undecodable events are returned with the decoding error
+// instead of the event, so they do not block the rest of the outbox
+func (r *OutboxRepository) Entries(
+	ctx context.Context,
+	now time.Time,
+	limit int,
+) ([]appointment.OutboxEntry, error) {
+	const op = outboxRepositoryName + ".Entries"
+	rows, err := r.db.QueryContext(
+		ctx,
+		`SELECT id, event_type, payload, attempts FROM outbox
+		WHERE status = ? AND next_attempt_at <= ?
+		ORDER BY id LIMIT ?`,
+		outboxPending,
+		now.UnixMilli(),
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+	entries := make([]appointment.OutboxEntry, 0, limit)
+	for rows.Next() {
+		var (
+			id        int64
+			eventType string
+			payload   []byte
+			attempts  int
+		)
+		if err := rows.Scan(&id, &eventType, &payload, &attempts); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		event, err := outboxEvent(eventType, payload)
+		entries = append(entries, appointment.OutboxEntry{
+			Id:       appointment.OutboxEntryId(id),
+			Event:    event,
+			Attempts: attempts,
+			Err:      err,
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return entries, nil
+}
+
+func (r *OutboxRepository) Complete(ctx context.Context, id appointment.OutboxEntryId) error {
+	const op = outboxRepositoryName + ".Complete"
+	if _, err := r.db.ExecContext(
+		ctx,
+		`UPDATE outbox SET status = ?, attempts = attempts + 1, processed_at = ? WHERE id = ?`,
+		outboxDone,
+		time.Now().UnixMilli(),
+		int64(id),
+	); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func (r *OutboxRepository) Retry(
+	ctx context.Context,
+	id appointment.OutboxEntryId,
+	nextAttemptAt time.Time,
+	cause error,
+) error {
+	const op = outboxRepositoryName + ".Retry"
+	if _, err := r.db.ExecContext(
+		ctx,
+		`UPDATE outbox SET attempts = attempts + 1, next_attempt_at = ?, last_error = ? WHERE id = ?`,
+		nextAttemptAt.UnixMilli(),
+		cause.Error(),
+		int64(id),
+	); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func (r *OutboxRepository) Fail(ctx context.Context, id appointment.OutboxEntryId, cause error) error {
+	const op = outboxRepositoryName + ".Fail"
+	if _, err := r.db.ExecContext(
+		ctx,
+		`UPDATE outbox SET status = ?, attempts = attempts + 1, last_error = ?, processed_at = ? WHERE id = ?`,
+		outboxFailed,
+		cause.Error(),
+		time.Now().UnixMilli(),
+		int64(id),
+	); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// Removes delivered entries processed before the given time
+func (r *OutboxRepository) Purge(ctx context.Context, before time.Time) error {
+	const op = outboxRepositoryName + ".Purge"
+	if _, err := r.db.ExecContext(
+		ctx,
+		`DELETE FROM outbox WHERE status = ? AND processed_at < ?`,
+		outboxDone,
+		before.UnixMilli(),
+	); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package appointment_sqlite_repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+)
+
+func TestOutboxRepositoryEntries(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t, 0)
+	repo := NewOutboxRepository(db)
+
+	if err := repo.SaveEvent(ctx, appointment.NewCreated(
+		appointment.RecordEntity{Id: "record", Status: appointment.RecordAwaits},
+		appointment.CustomerEntity{Id: "customer"},
+		appointment.ServiceEntity{Id: "service"},
+	)); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now().UnixMilli()
+	for _, row := range [][2]string{
+		{"created", "{"},
+		{"unknown", "{}"},
+	} {
+		if _, err := db.Exec(
+			`INSERT INTO outbox (event_type, payload, next_attempt_at, created_at) VALUES (?, ?, ?, ?)`,
+			row[0], row[1], now, now,
+		); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := repo.SaveEvent(ctx, appointment.NewChanged(
+		appointment.RemovedChangeType,
+		appointment.RecordEntity{Id: "removed"},
+	)); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := repo.Entries(ctx, time.Now(), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 4 {
+		t.Fatalf("entries = %v, want 4", entries)
+	}
+	if created, ok := entries[0].Event.(appointment.CreatedEvent); !ok || entries[0].Err != nil || created.Record.Id != "record" {
+		t.Errorf("entries[0] = %+v, want the created event", entries[0])
+	}
+	if entries[1].Err == nil || entries[1].Event != nil {
+		t.Errorf("entries[1] = %+v, want the decoding error", entries[1])
+	}
+	if !errors.Is(entries[2].Err, appointment.ErrUnsupportedOutboxEvent) {
+		t.Errorf("entries[2] error = %v, want %v", entries[2].Err, appointment.ErrUnsupportedOutboxEvent)
+	}
+	if changed, ok := entries[3].Event.(appointment.ChangedEvent); !ok || changed.ChangeType != appointment.RemovedChangeType {
+		t.Errorf("entries[3] = %+v, want the changed event", entries[3])
+	}
+
+	var eventType string
+	if err := db.QueryRow(`SELECT event_type FROM outbox WHERE id = ?`, int64(entries[0].Id)).Scan(&eventType); err != nil {
+		t.Fatal(err)
+	}
+	if eventType != "created" {
+		t.Errorf("event_type = %q, want %q", eventType, "created")
+	}
+}
+
+func TestEventTypeNames(t *testing.T) {
+	want := map[appointment.EventType]string{
+		appointment.CreatedEventType:  "created",
+		appointment.CanceledEventType: "canceled",
+		appointment.ChangedEventType:  "changed",
+	}
+	for eventType, name := range want {
+		if eventTypeNames[eventType] != name {
+			t.Errorf("event type %d is stored as %q, want %q", eventType, eventTypeNames[eventType], name)
+		}
+		if got, err := eventTypeFromName(name); err != nil || got != eventType {
+			t.Errorf("eventTypeFromName(%q) = %d, %v, want %d", name, got, err, eventType)
+		}
+	}
+}
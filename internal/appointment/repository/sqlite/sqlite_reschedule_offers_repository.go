@@ -0,0 +1,175 @@
+package appointment_sqlite_repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+)
+
+const rescheduleOffersRepositoryName = "appointment_sqlite_repository.RescheduleOffersRepository"
+
+type rescheduleOfferDTO struct {
+	Id               string      `json:"id"`
+	CanceledRecordId string      `json:"canceledRecordId"`
+	CustomerId       string      `json:"customerId"`
+	ServiceId        string      `json:"serviceId"`
+	Reason           string      `json:"reason"`
+	Slots            []time.Time `json:"slots"`
+	Status           string      `json:"status"`
+	AcceptedSlot     time.Time   `json:"acceptedSlot"`
+	NewRecordId      string      `json:"newRecordId"`
+	CreatedAt        time.Time   `json:"createdAt"`
+}
+
+func rescheduleOfferToDTO(offer appointment.RescheduleOffer) rescheduleOfferDTO {
+	return rescheduleOfferDTO{
+		Id:               offer.Id.String(),
+		CanceledRecordId: offer.CanceledRecordId.String(),
+		CustomerId:       offer.CustomerId.String(),
+		ServiceId:        offer.ServiceId.String(),
+		Reason:           offer.Reason,
+		Slots:            offer.Slots,
+		Status:           string(offer.Status),
+		AcceptedSlot:     offer.AcceptedSlot,
+		NewRecordId:      offer.NewRecordId.String(),
+		CreatedAt:        offer.CreatedAt,
+	}
+}
+
+func rescheduleOfferFromDTO(dto rescheduleOfferDTO) appointment.RescheduleOffer {
+	return appointment.RescheduleOffer{
+		Id:               appointment.NewRescheduleOfferId(dto.Id),
+		CanceledRecordId: appointment.NewRecordId(dto.CanceledRecordId),
+		CustomerId:       appointment.NewCustomerId(dto.CustomerId),
+		ServiceId:        appointment.NewServiceId(dto.ServiceId),
+		Reason:           dto.Reason,
+		Slots:            dto.Slots,
+		Status:           appointment.NewRescheduleOfferStatus(dto.Status),
+		AcceptedSlot:     dto.AcceptedSlot,
+		NewRecordId:      appointment.NewRecordId(dto.NewRecordId),
+		CreatedAt:        dto.CreatedAt,
+	}
+}
+
+type RescheduleOffersRepository struct {
+	db *sql.DB
+}
+
+func NewRescheduleOffersRepository(db *sql.DB) *RescheduleOffersRepository {
+	return &RescheduleOffersRepository{
+		db: db,
+	}
+}
+
+func (r *RescheduleOffersRepository) RescheduleOffer(
+	ctx context.Context,
+	id appointment.RescheduleOfferId,
+) (appointment.RescheduleOffer, error) {
+	const op = rescheduleOffersRepositoryName + ".RescheduleOffer"
+	var data []byte
+	err := r.db.QueryRowContext(
+		ctx,
+		`SELECT offer FROM reschedule_offer WHERE id = ?`,
+		id.String(),
+	).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return appointment.RescheduleOffer{}, fmt.Errorf("%s: %w", op, appointment.ErrRescheduleOfferNotFound)
+	}
+	if err != nil {
+		return appointment.RescheduleOffer{}, fmt.Errorf("%s: %w", op, err)
+	}
+	var dto rescheduleOfferDTO
+	if err := json.Unmarshal(data, &dto); err != nil {
+		return appointment.RescheduleOffer{}, fmt.Errorf("%s: %w", op, err)
+	}
+	return rescheduleOfferFromDTO(dto), nil
+}
+
+func (r *RescheduleOffersRepository) RescheduleOffers(
+	ctx context.Context,
+	since time.Time,
+) ([]appointment.RescheduleOffer, error) {
+	const op = rescheduleOffersRepositoryName + ".RescheduleOffers"
+	rows, err := r.db.QueryContext(
+		ctx,
+		`SELECT offer FROM reschedule_offer WHERE created_at >= ? ORDER BY created_at`,
+		since.UnixMilli(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+	offers := make([]appointment.RescheduleOffer, 0)
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		var dto rescheduleOfferDTO
+		if err := json.Unmarshal(data, &dto); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		offers = append(offers, rescheduleOfferFromDTO(dto))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return offers, nil
+}
+
+func (r *RescheduleOffersRepository) SaveRescheduleOffer(
+	ctx context.Context,
+	offer appointment.RescheduleOffer,
+) error {
+	const op = rescheduleOffersRepositoryName + ".SaveRescheduleOffer"
+	data, err := json.Marshal(rescheduleOfferToDTO(offer))
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if _, err := r.db.ExecContext(
+		ctx,
+		`INSERT INTO reschedule_offer (id, offer, created_at) VALUES (?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET
+			offer = excluded.offer,
+			created_at = excluded.created_at`,
+		offer.Id.String(),
+		string(data),
+		offer.CreatedAt.UnixMilli(),
+	); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func (r *RescheduleOffersRepository) SwapRescheduleOfferStatus(
+	ctx context.Context,
+	id appointment.RescheduleOfferId,
+	from appointment.RescheduleOfferStatus,
+	to appointment.RescheduleOfferStatus,
+) error {
+	const op = rescheduleOffersRepositoryName + ".SwapRescheduleOfferStatus"
+	res, err := r.db.ExecContext(
+		ctx,
+		`UPDATE reschedule_offer SET offer = json_set(offer, '$.status', ?)
+		WHERE id = ? AND json_extract(offer, '$.status') = ?`,
+		string(to),
+		id.String(),
+		string(from),
+	)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if n == 0 {
+		return fmt.Errorf("%s: %w: %s", op, appointment.ErrRescheduleOfferIsClosed, id)
+	}
+	return nil
+}
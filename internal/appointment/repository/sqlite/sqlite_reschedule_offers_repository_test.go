@@ -0,0 +1,43 @@
+package appointment_sqlite_repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+)
+
+func TestSwapRescheduleOfferStatus(t *testing.T) {
+	ctx := context.Background()
+	repo := NewRescheduleOffersRepository(newTestDB(t, 0))
+	offer := appointment.NewRescheduleOffer(
+		appointment.RecordEntity{Id: "record", CustomerId: "customer", ServiceId: "service"},
+		"reason",
+		[]time.Time{time.Date(2024, 5, 7, 9, 0, 0, 0, time.UTC)},
+		time.Date(2024, 5, 6, 12, 0, 0, 0, time.UTC),
+	)
+	if err := repo.SaveRescheduleOffer(ctx, offer); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := repo.SwapRescheduleOfferStatus(
+		ctx, offer.Id, appointment.RescheduleOfferPending, appointment.RescheduleOfferAccepted,
+	); err != nil {
+		t.Fatal(err)
+	}
+	err := repo.SwapRescheduleOfferStatus(
+		ctx, offer.Id, appointment.RescheduleOfferPending, appointment.RescheduleOfferAccepted,
+	)
+	if !errors.Is(err, appointment.ErrRescheduleOfferIsClosed) {
+		t.Fatalf("second claim error = %v, want %v", err, appointment.ErrRescheduleOfferIsClosed)
+	}
+	saved, err := repo.RescheduleOffer(ctx, offer.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.Status != appointment.RescheduleOfferAccepted || len(saved.Slots) != 1 {
+		t.Errorf("offer = %+v, want the accepted offer with its slots", saved)
+	}
+}
@@ -0,0 +1,49 @@
+package appointment_sqlite_repository
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	sqlite_adapters "github.com/x0k/veterinary-clinic-backend/internal/adapters/sqlite"
+)
+
+const migrationsPath = "../../../../db/migrations"
+
+// Opens a database in the temporary directory with the up migrations
+// applied until the `last` one, all migrations are applied when it is zero
+func newTestDB(t *testing.T, last int) *sql.DB {
+	t.Helper()
+	db, err := sqlite_adapters.Open(filepath.Join(t.TempDir(), "storage.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	migrate(t, db, 0, last)
+	return db
+}
+
+// Applies the up migrations with numbers in (`after`, `last`]
+func migrate(t *testing.T, db *sql.DB, after int, last int) {
+	t.Helper()
+	files, err := filepath.Glob(filepath.Join(migrationsPath, "*.up.sql"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	slices.Sort(files)
+	for i, file := range files {
+		if n := i + 1; n <= after || last > 0 && n > last {
+			continue
+		}
+		query, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := db.Exec(string(query)); err != nil {
+			t.Fatalf("%s: %s", strings.TrimPrefix(file, migrationsPath), err)
+		}
+	}
+}
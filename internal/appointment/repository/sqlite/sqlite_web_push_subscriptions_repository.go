@@ -0,0 +1,91 @@
+package appointment_sqlite_repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+)
+
+const webPushSubscriptionsRepositoryName = "appointment_sqlite_repository.WebPushSubscriptionsRepository"
+
+type WebPushSubscriptionsRepository struct {
+	db *sql.DB
+}
+
+func NewWebPushSubscriptionsRepository(db *sql.DB) *WebPushSubscriptionsRepository {
+	return &WebPushSubscriptionsRepository{
+		db: db,
+	}
+}
+
+// Browsers reuse the endpoint after a login of another customer,
+// so the subscription is moved to the latest one
+func (r *WebPushSubscriptionsRepository) SaveSubscription(
+	ctx context.Context,
+	identity appointment.CustomerIdentity,
+	subscription appointment.WebPushSubscription,
+) error {
+	const op = webPushSubscriptionsRepositoryName + ".SaveSubscription"
+	if _, err := r.db.ExecContext(
+		ctx,
+		`INSERT INTO web_push_subscription (endpoint, customer_identity, p256dh, auth) VALUES (?, ?, ?, ?)
+		ON CONFLICT (endpoint) DO UPDATE SET
+			customer_identity = excluded.customer_identity,
+			p256dh = excluded.p256dh,
+			auth = excluded.auth`,
+		subscription.Endpoint,
+		identity.String(),
+		subscription.P256dh,
+		subscription.Auth,
+	); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func (r *WebPushSubscriptionsRepository) RemoveSubscription(
+	ctx context.Context,
+	identity appointment.CustomerIdentity,
+	endpoint string,
+) error {
+	const op = webPushSubscriptionsRepositoryName + ".RemoveSubscription"
+	if _, err := r.db.ExecContext(
+		ctx,
+		`DELETE FROM web_push_subscription WHERE endpoint = ? AND customer_identity = ?`,
+		endpoint,
+		identity.String(),
+	); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func (r *WebPushSubscriptionsRepository) Subscriptions(
+	ctx context.Context,
+	identity appointment.CustomerIdentity,
+) ([]appointment.WebPushSubscription, error) {
+	const op = webPushSubscriptionsRepositoryName + ".Subscriptions"
+	rows, err := r.db.QueryContext(
+		ctx,
+		`SELECT endpoint, p256dh, auth FROM web_push_subscription WHERE customer_identity = ?`,
+		identity.String(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+	var subscriptions []appointment.WebPushSubscription
+	for rows.Next() {
+		var s appointment.WebPushSubscription
+		if err := rows.Scan(&s.Endpoint, &s.P256dh, &s.Auth); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		subscriptions = append(subscriptions, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return subscriptions, nil
+}
@@ -0,0 +1,210 @@
+package appointment_sqlite_repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+)
+
+const webhookDeliveriesRepositoryName = "appointment_sqlite_repository.WebhookDeliveriesRepository"
+
+const (
+	webhookDeliveryPending   = "pending"
+	webhookDeliveryDelivered = "delivered"
+	webhookDeliveryFailed    = "failed"
+)
+
+const failedWebhookDeliveriesLimit = 50
+
+type WebhookDeliveriesRepository struct {
+	db *sql.DB
+}
+
+func NewWebhookDeliveriesRepository(db *sql.DB) *WebhookDeliveriesRepository {
+	return &WebhookDeliveriesRepository{
+		db: db,
+	}
+}
+
+func (r *WebhookDeliveriesRepository) SaveDeliveries(
+	ctx context.Context,
+	deliveries []appointment.WebhookDelivery,
+) error {
+	const op = webhookDeliveriesRepositoryName + ".SaveDeliveries"
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+	for _, d := range deliveries {
+		eventType, ok := eventTypeNames[d.Event.Type]
+		if !ok {
+			return fmt.Errorf("%s: %w: %v", op, appointment.ErrUnsupportedOutboxEvent, d.Event.Type)
+		}
+		if _, err := tx.ExecContext(
+			ctx,
+			`INSERT OR IGNORE INTO webhook_deliveries
+			(url, event_id, event_type, payload, next_attempt_at, created_at)
+			VALUES (?, ?, ?, ?, ?, ?)`,
+			d.Url,
+			d.Event.Id,
+			eventType,
+			d.Event.Payload,
+			d.CreatedAt.UnixMilli(),
+			d.CreatedAt.UnixMilli(),
+		); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func (r *WebhookDeliveriesRepository) deliveries(
+	ctx context.Context,
+	op string,
+	query string,
+	args ...any,
+) ([]appointment.WebhookDelivery, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+	deliveries := make([]appointment.WebhookDelivery, 0)
+	for rows.Next() {
+		var (
+			d         appointment.WebhookDelivery
+			id        int64
+			eventType string
+			createdAt int64
+		)
+		if err := rows.Scan(
+			&id, &d.Url, &d.Event.Id, &eventType, &d.Event.Payload,
+			&d.Attempts, &d.LastError, &createdAt,
+		); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		d.Id = appointment.WebhookDeliveryId(id)
+		if d.Event.Type, err = eventTypeFromName(eventType); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		d.CreatedAt = time.UnixMilli(createdAt)
+		deliveries = append(deliveries, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return deliveries, nil
+}
+
+func (r *WebhookDeliveriesRepository) PendingDeliveries(
+	ctx context.Context,
+	now time.Time,
+	limit int,
+) ([]appointment.WebhookDelivery, error) {
+	return r.deliveries(
+		ctx,
+		webhookDeliveriesRepositoryName+".PendingDeliveries",
+		`SELECT id, url, event_id, event_type, payload, attempts, last_error, created_at
+		FROM webhook_deliveries
+		WHERE status = ? AND next_attempt_at <= ?
+		ORDER BY id LIMIT ?`,
+		webhookDeliveryPending,
+		now.UnixMilli(),
+		limit,
+	)
+}
+
+func (r *WebhookDeliveriesRepository) FailedDeliveries(ctx context.Context) ([]appointment.WebhookDelivery, error) {
+	return r.deliveries(
+		ctx,
+		webhookDeliveriesRepositoryName+".FailedDeliveries",
+		`SELECT id, url, event_id, event_type, payload, attempts, last_error, created_at
+		FROM webhook_deliveries
+		WHERE status = ?
+		ORDER BY id DESC LIMIT ?`,
+		webhookDeliveryFailed,
+		failedWebhookDeliveriesLimit,
+	)
+}
+
+func (r *WebhookDeliveriesRepository) Complete(ctx context.Context, id appointment.WebhookDeliveryId) error {
+	const op = webhookDeliveriesRepositoryName + ".Complete"
+	if _, err := r.db.ExecContext(
+		ctx,
+		`UPDATE webhook_deliveries SET status = ?, attempts = attempts + 1, processed_at = ? WHERE id = ?`,
+		webhookDeliveryDelivered,
+		time.Now().UnixMilli(),
+		int64(id),
+	); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func (r *WebhookDeliveriesRepository) Retry(
+	ctx context.Context,
+	id appointment.WebhookDeliveryId,
+	nextAttemptAt time.Time,
+	cause error,
+) error {
+	const op = webhookDeliveriesRepositoryName + ".Retry"
+	if _, err := r.db.ExecContext(
+		ctx,
+		`UPDATE webhook_deliveries SET attempts = attempts + 1, next_attempt_at = ?, last_error = ? WHERE id = ?`,
+		nextAttemptAt.UnixMilli(),
+		cause.Error(),
+		int64(id),
+	); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func (r *WebhookDeliveriesRepository) Fail(ctx context.Context, id appointment.WebhookDeliveryId, cause error) error {
+	const op = webhookDeliveriesRepositoryName + ".Fail"
+	if _, err := r.db.ExecContext(
+		ctx,
+		`UPDATE webhook_deliveries SET status = ?, attempts = attempts + 1, last_error = ?, processed_at = ? WHERE id = ?`,
+		webhookDeliveryFailed,
+		cause.Error(),
+		time.Now().UnixMilli(),
+		int64(id),
+	); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func (r *WebhookDeliveriesRepository) Replay(
+	ctx context.Context,
+	now time.Time,
+	ids []appointment.WebhookDeliveryId,
+) (int, error) {
+	const op = webhookDeliveriesRepositoryName + ".Replay"
+	query := `UPDATE webhook_deliveries SET status = ?, attempts = 0, next_attempt_at = ?, processed_at = NULL
+	WHERE status = ?`
+	args := []any{webhookDeliveryPending, now.UnixMilli(), webhookDeliveryFailed}
+	if len(ids) > 0 {
+		query += ` AND id IN (?` + strings.Repeat(", ?", len(ids)-1) + `)`
+		for _, id := range ids {
+			args = append(args, int64(id))
+		}
+	}
+	res, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	count, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	return int(count), nil
+}
@@ -0,0 +1,41 @@
+package appointment_sqlite_repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+)
+
+func TestWebhookDeliveriesRepositorySaveDeliveries(t *testing.T) {
+	ctx := context.Background()
+	repo := NewWebhookDeliveriesRepository(newTestDB(t, 0))
+	now := time.Now()
+	payload := []byte(`{"type":"appointment.changed"}`)
+	save := func(id string) {
+		t.Helper()
+		event := appointment.WebhookEvent{Id: id, Type: appointment.ChangedEventType, Payload: payload}
+		if err := repo.SaveDeliveries(ctx, appointment.NewWebhookDeliveries(event, []string{"https://example.com"}, now)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	save("1")
+	// Redelivery of the same outbox entry
+	save("1")
+	// The same change made again
+	save("2")
+
+	deliveries, err := repo.PendingDeliveries(ctx, now, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deliveries) != 2 {
+		t.Fatalf("deliveries = %+v, want 2 deliveries", deliveries)
+	}
+	for i, id := range []string{"1", "2"} {
+		if deliveries[i].Event.Id != id || deliveries[i].Event.Type != appointment.ChangedEventType {
+			t.Errorf("deliveries[%d].Event = %+v, want the changed event %q", i, deliveries[i].Event, id)
+		}
+	}
+}
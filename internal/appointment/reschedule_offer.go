@@ -0,0 +1,106 @@
+package appointment
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/shared"
+)
+
+var ErrRescheduleOfferIsClosed = errors.New("reschedule offer is closed")
+var ErrRescheduleSlotNotFound = errors.New("reschedule slot not found")
+var ErrRescheduleOfferNotFound = errors.New("reschedule offer not found")
+
+type RescheduleOfferId string
+
+func NewRescheduleOfferId(id string) RescheduleOfferId {
+	return RescheduleOfferId(id)
+}
+
+func (id RescheduleOfferId) String() string {
+	return string(id)
+}
+
+type RescheduleOfferStatus string
+
+const (
+	RescheduleOfferPending  RescheduleOfferStatus = "pending"
+	RescheduleOfferAccepted RescheduleOfferStatus = "accepted"
+	RescheduleOfferDeclined RescheduleOfferStatus = "declined"
+)
+
+func NewRescheduleOfferStatus(status string) RescheduleOfferStatus {
+	return RescheduleOfferStatus(status)
+}
+
+// Alternative slots proposed to the customer
+// whose appointment was canceled by the clinic.
+// There is at most one offer per canceled record, so
+// the record id is used as the offer id
+type RescheduleOffer struct {
+	Id               RescheduleOfferId
+	CanceledRecordId RecordId
+	CustomerId       CustomerId
+	ServiceId        ServiceId
+	Reason           string
+	Slots            []time.Time
+	Status           RescheduleOfferStatus
+	AcceptedSlot     time.Time
+	NewRecordId      RecordId
+	CreatedAt        time.Time
+}
+
+func NewRescheduleOffer(
+	canceled RecordEntity,
+	reason string,
+	slots []time.Time,
+	now time.Time,
+) RescheduleOffer {
+	return RescheduleOffer{
+		Id:               RescheduleOfferId(canceled.Id.String()),
+		CanceledRecordId: canceled.Id,
+		CustomerId:       canceled.CustomerId,
+		ServiceId:        canceled.ServiceId,
+		Reason:           reason,
+		Slots:            slots,
+		Status:           RescheduleOfferPending,
+		CreatedAt:        now,
+	}
+}
+
+func (o *RescheduleOffer) Slot(index int) (time.Time, error) {
+	if o.Status != RescheduleOfferPending {
+		return time.Time{}, fmt.Errorf("%w: %s", ErrRescheduleOfferIsClosed, o.Status)
+	}
+	if index < 0 || index >= len(o.Slots) {
+		return time.Time{}, fmt.Errorf("%w: %d", ErrRescheduleSlotNotFound, index)
+	}
+	return o.Slots[index], nil
+}
+
+func (o *RescheduleOffer) SlotPeriods(service ServiceEntity) []shared.DateTimePeriod {
+	periods := make([]shared.DateTimePeriod, len(o.Slots))
+	for i, slot := range o.Slots {
+		periods[i] = appointmentDateTimePeriod(slot, service)
+	}
+	return periods
+}
+
+func (o *RescheduleOffer) Accept(slot time.Time, record RecordEntity) error {
+	if o.Status != RescheduleOfferPending {
+		return fmt.Errorf("%w: %s", ErrRescheduleOfferIsClosed, o.Status)
+	}
+	o.Status = RescheduleOfferAccepted
+	o.AcceptedSlot = slot
+	o.NewRecordId = record.Id
+	return nil
+}
+
+func (o *RescheduleOffer) Decline() error {
+	if o.Status != RescheduleOfferPending {
+		return fmt.Errorf("%w: %s", ErrRescheduleOfferIsClosed, o.Status)
+	}
+	o.Status = RescheduleOfferDeclined
+	return nil
+}
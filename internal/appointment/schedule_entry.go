@@ -17,6 +17,12 @@ const (
 	BusyPeriod
 )
 
+// Titles of the periods without a title of their own
+const (
+	FreePeriodTitle = "Свободно"
+	BusyPeriodTitle = "Занято"
+)
+
 type ScheduleEntry struct {
 	shared.DateTimePeriod
 	Type  ScheduleEntryType
@@ -109,7 +115,7 @@ func newScheduleEntries(
 				},
 			},
 			Type:  FreePeriod,
-			Title: "Свободно",
+			Title: FreePeriodTitle,
 		})
 	}
 	for _, p := range busyPeriods {
@@ -125,7 +131,7 @@ func newScheduleEntries(
 				},
 			},
 			Type:  BusyPeriod,
-			Title: "Занято",
+			Title: BusyPeriodTitle,
 		})
 	}
 	for _, p := range workBreaks {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"log/slog"
@@ -24,10 +25,16 @@ type SchedulingService struct {
 	periodUnLocker DateTimePeriodUnLocker
 
 	sampleRateInMinutes             SampleRateInMinutes
+	slotHoldTTL                     time.Duration
+	slotHolder                      SlotHolder
+	slotHoldReleaser                SlotHoldReleaser
+	slotHoldsLoader                 SlotHoldsLoader
 	appointmentCreator              AppointmentCreator
 	productionCalendarLoader        ProductionCalendarLoader
 	workingHoursLoader              WorkingHoursLoader
 	busyPeriodsLoader               BusyPeriodsLoader
+	busyPeriodsRangeLoader          BusyPeriodsRangeLoader
+	serviceLoader                   ServiceLoader
 	workBreaksLoader                WorkBreaksLoader
 	customerActiveAppointmentLoader CustomerActiveAppointmentLoader
 	appointmentRemover              AppointmentRemover
@@ -38,10 +45,16 @@ func NewSchedulingService(
 	sampleRateInMinutes SampleRateInMinutes,
 	periodLocker DateTimePeriodLocker,
 	periodUnLocker DateTimePeriodUnLocker,
+	slotHoldTTL time.Duration,
+	slotHolder SlotHolder,
+	slotHoldReleaser SlotHoldReleaser,
+	slotHoldsLoader SlotHoldsLoader,
 	appointmentCreator AppointmentCreator,
 	productionCalendarLoader ProductionCalendarLoader,
 	workingHoursLoader WorkingHoursLoader,
 	busyPeriodsLoader BusyPeriodsLoader,
+	busyPeriodsRangeLoader BusyPeriodsRangeLoader,
+	serviceLoader ServiceLoader,
 	workBreaksLoader WorkBreaksLoader,
 	customerActiveAppointmentLoader CustomerActiveAppointmentLoader,
 	appointmentRemover AppointmentRemover,
@@ -51,25 +64,28 @@ func NewSchedulingService(
 		periodLocker:                    periodLocker,
 		periodUnLocker:                  periodUnLocker,
 		sampleRateInMinutes:             sampleRateInMinutes,
+		slotHoldTTL:                     slotHoldTTL,
+		slotHolder:                      slotHolder,
+		slotHoldReleaser:                slotHoldReleaser,
+		slotHoldsLoader:                 slotHoldsLoader,
 		appointmentCreator:              appointmentCreator,
 		productionCalendarLoader:        productionCalendarLoader,
 		workingHoursLoader:              workingHoursLoader,
 		busyPeriodsLoader:               busyPeriodsLoader,
+		busyPeriodsRangeLoader:          busyPeriodsRangeLoader,
+		serviceLoader:                   serviceLoader,
 		workBreaksLoader:                workBreaksLoader,
 		customerActiveAppointmentLoader: customerActiveAppointmentLoader,
 		appointmentRemover:              appointmentRemover,
 	}
 }
 
-func (s *SchedulingService) MakeAppointment(
-	ctx context.Context,
-	now time.Time,
+func appointmentDateTimePeriod(
 	appointmentDate time.Time,
-	customer CustomerEntity,
 	service ServiceEntity,
-) (RecordEntity, error) {
+) shared.DateTimePeriod {
 	appointmentDateTime := shared.GoTimeToDateTime(appointmentDate)
-	dateTimePeriod := shared.DateTimePeriod{
+	return shared.DateTimePeriod{
 		Start: appointmentDateTime,
 		End: shared.DateTime{
 			Date: appointmentDateTime.Date,
@@ -78,6 +94,16 @@ func (s *SchedulingService) MakeAppointment(
 			})(appointmentDateTime.Time),
 		},
 	}
+}
+
+func (s *SchedulingService) MakeAppointment(
+	ctx context.Context,
+	now time.Time,
+	appointmentDate time.Time,
+	customer CustomerEntity,
+	service ServiceEntity,
+) (RecordEntity, error) {
+	dateTimePeriod := appointmentDateTimePeriod(appointmentDate, service)
 	if err := s.periodLocker(ctx, dateTimePeriod); err != nil {
 		return RecordEntity{}, err
 	}
@@ -93,43 +119,21 @@ func (s *SchedulingService) MakeAppointment(
 		}
 		return RecordEntity{}, fmt.Errorf("%w: %s", ErrAnotherAppointmentIsAlreadyScheduled, existedAppointment.Id)
 	}
-	productionCalendar, err := s.productionCalendar(ctx)
-	if err != nil {
+	if err := s.checkPeriodIsFree(ctx, now, appointmentDate, customer.Identity, dateTimePeriod); err != nil {
 		return RecordEntity{}, err
 	}
-	busyPeriods, err := s.busyPeriodsLoader(ctx, appointmentDate)
-	if err != nil {
-		return RecordEntity{}, err
-	}
-	datWorkBreaks, err := s.dayWorkBreaks(ctx, appointmentDate)
-	if err != nil {
-		return RecordEntity{}, err
-	}
-	freeTimeSlots, err := s.freeTimeSlots(
-		ctx,
-		now,
-		appointmentDate,
-		productionCalendar,
-		busyPeriods,
-		datWorkBreaks,
-	)
-	if err != nil {
-		return RecordEntity{}, err
-	}
-	if !freeTimeSlots.Includes(shared.TimePeriod{
-		Start: dateTimePeriod.Start.Time,
-		End:   dateTimePeriod.End.Time,
-	}) {
-		return RecordEntity{}, fmt.Errorf("%w: %s", ErrDateTimePeriodIsOccupied, dateTimePeriod)
-	}
 	title, err := RecordTitle(customer, service, now)
 	if err != nil {
 		return RecordEntity{}, err
 	}
+	status := RecordAwaits
+	if service.RequiresApproval {
+		status = RecordPendingApproval
+	}
 	record, err := NewRecord(
 		TemporalRecordId,
 		title,
-		RecordAwaits,
+		status,
 		false,
 		dateTimePeriod,
 		customer.Id,
@@ -145,9 +149,92 @@ func (s *SchedulingService) MakeAppointment(
 	if record.Id == TemporalRecordId {
 		return RecordEntity{}, fmt.Errorf("%w: %s", ErrInvalidRecordId, record.Id)
 	}
+	if err := s.slotHoldReleaser(ctx, customer.Identity); err != nil {
+		s.log.Error(ctx, "failed to release slot hold", sl.Err(err))
+	}
 	return record, nil
 }
 
+func (s *SchedulingService) HoldSlot(
+	ctx context.Context,
+	now time.Time,
+	appointmentDate time.Time,
+	owner CustomerIdentity,
+	service ServiceEntity,
+) error {
+	dateTimePeriod := appointmentDateTimePeriod(appointmentDate, service)
+	if err := s.periodLocker(ctx, dateTimePeriod); err != nil {
+		return err
+	}
+	defer func() {
+		if err := s.periodUnLocker(ctx, dateTimePeriod); err != nil {
+			s.log.Error(ctx, "failed to unlock period", sl.Err(err))
+		}
+	}()
+	if err := s.checkPeriodIsFree(ctx, now, appointmentDate, owner, dateTimePeriod); err != nil {
+		return err
+	}
+	return s.slotHolder(ctx, NewSlotHold(owner, dateTimePeriod, now.Add(s.slotHoldTTL)))
+}
+
+func (s *SchedulingService) checkPeriodIsFree(
+	ctx context.Context,
+	now time.Time,
+	appointmentDate time.Time,
+	owner CustomerIdentity,
+	dateTimePeriod shared.DateTimePeriod,
+) error {
+	productionCalendar, err := s.productionCalendar(ctx)
+	if err != nil {
+		return err
+	}
+	busyPeriods, err := s.busyPeriodsLoader(ctx, appointmentDate)
+	if err != nil {
+		return err
+	}
+	dayWorkBreaks, err := s.dayWorkBreaks(ctx, appointmentDate)
+	if err != nil {
+		return err
+	}
+	period := shared.TimePeriod{
+		Start: dateTimePeriod.Start.Time,
+		End:   dateTimePeriod.End.Time,
+	}
+	freeTimeSlots, err := s.freeTimeSlots(
+		ctx,
+		now,
+		appointmentDate,
+		productionCalendar,
+		busyPeriods,
+		dayWorkBreaks,
+	)
+	if err != nil {
+		return err
+	}
+	if !freeTimeSlots.Includes(period) {
+		return fmt.Errorf("%w: %s", ErrDateTimePeriodIsOccupied, dateTimePeriod)
+	}
+	heldPeriods, err := s.dayHeldPeriods(ctx, now, appointmentDate, owner)
+	if err != nil {
+		return err
+	}
+	freeTimeSlots, err = s.freeTimeSlots(
+		ctx,
+		now,
+		appointmentDate,
+		productionCalendar,
+		append(busyPeriods, heldPeriods...),
+		dayWorkBreaks,
+	)
+	if err != nil {
+		return err
+	}
+	if !freeTimeSlots.Includes(period) {
+		return fmt.Errorf("%w: %s", ErrSlotIsHeld, dateTimePeriod)
+	}
+	return nil
+}
+
 func (s *SchedulingService) Schedule(
 	ctx context.Context,
 	now time.Time,
@@ -192,6 +279,7 @@ func (s *SchedulingService) SampledFreeTimeSlots(
 	now time.Time,
 	appointmentDate time.Time,
 	durationInMinutes shared.DurationInMinutes,
+	owner CustomerIdentity,
 ) (SampledFreeTimeSlots, error) {
 	productionCalendar, err := s.productionCalendar(ctx)
 	if err != nil {
@@ -201,6 +289,11 @@ func (s *SchedulingService) SampledFreeTimeSlots(
 	if err != nil {
 		return SampledFreeTimeSlots{}, err
 	}
+	heldPeriods, err := s.dayHeldPeriods(ctx, now, appointmentDate, owner)
+	if err != nil {
+		return SampledFreeTimeSlots{}, err
+	}
+	busyPeriods = append(busyPeriods, heldPeriods...)
 	dayWorkBreaks, err := s.dayWorkBreaks(ctx, appointmentDate)
 	if err != nil {
 		return SampledFreeTimeSlots{}, err
@@ -223,6 +316,126 @@ func (s *SchedulingService) SampledFreeTimeSlots(
 	), nil
 }
 
+func (s *SchedulingService) Availability(
+	ctx context.Context,
+	now time.Time,
+	month time.Time,
+	serviceId ServiceId,
+) (Availability, error) {
+	service, err := s.serviceLoader(ctx, serviceId)
+	if err != nil {
+		return nil, err
+	}
+	productionCalendar, err := s.productionCalendar(ctx)
+	if err != nil {
+		return nil, err
+	}
+	workingHours, err := s.workingHoursLoader(ctx)
+	if err != nil {
+		return nil, err
+	}
+	workBreaks, err := s.workBreaksLoader(ctx)
+	if err != nil {
+		return nil, err
+	}
+	from := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, month.Location())
+	to := from.AddDate(0, 1, 0)
+	busyPeriods, err := s.busyPeriodsRangeLoader(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	// The caller is unknown, so held slots are busy for everyone
+	heldPeriods, err := s.heldPeriods(ctx, now, from, to, "")
+	if err != nil {
+		return nil, err
+	}
+	nowDateTime := shared.GoTimeToDateTime(now)
+	availability := make(Availability, 0, 31)
+	for day := from; day.Before(to); day = day.AddDate(0, 0, 1) {
+		dayTimePeriods, err := workingHours.ForDay(day).
+			ConsiderProductionCalendar(productionCalendar)
+		if err != nil {
+			return nil, err
+		}
+		dayWorkBreaks, err := workBreaks.ForDay(day)
+		if err != nil {
+			return nil, err
+		}
+		freeTimeSlots, err := NewFreeTimeSlots(
+			dayTimePeriods.OmitPast(nowDateTime),
+			slices.Concat(busyPeriods.ForDay(day), heldPeriods.ForDay(day)),
+			dayWorkBreaks,
+		)
+		if err != nil {
+			return nil, err
+		}
+		availability = append(availability, NewDayAvailability(
+			nowDateTime,
+			day,
+			dayTimePeriods,
+			NewSampleFreeTimeSlots(
+				service.DurationInMinutes,
+				s.sampleRateInMinutes,
+				freeTimeSlots,
+			),
+			service.DurationInMinutes,
+		))
+	}
+	return availability, nil
+}
+
+// Returns up to `limit` free appointment dates starting from `from`
+// within the `days` days window.
+// Slots intersecting the `excluded` periods are skipped
+func (s *SchedulingService) NearestFreeSlots(
+	ctx context.Context,
+	now time.Time,
+	from time.Time,
+	service ServiceEntity,
+	limit int,
+	days int,
+	excluded []shared.DateTimePeriod,
+) ([]time.Time, error) {
+	slots := make([]time.Time, 0, limit)
+	day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location())
+	for i := 0; i < days && len(slots) < limit; i++ {
+		sampled, err := s.SampledFreeTimeSlots(ctx, now, day, service.DurationInMinutes, "")
+		if err != nil {
+			return nil, err
+		}
+		for _, slot := range sampled {
+			if shared.TimePeriodDurationInMinutes(slot) < service.DurationInMinutes {
+				continue
+			}
+			start := time.Date(
+				day.Year(), day.Month(), day.Day(),
+				slot.Start.Hours, slot.Start.Minutes, 0, 0,
+				day.Location(),
+			)
+			if start.Before(from) || intersectsAny(appointmentDateTimePeriod(start, service), excluded) {
+				continue
+			}
+			slots = append(slots, start)
+			if len(slots) == limit {
+				break
+			}
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+	return slots, nil
+}
+
+func intersectsAny(period shared.DateTimePeriod, periods []shared.DateTimePeriod) bool {
+	for _, p := range periods {
+		if shared.DateTimePeriodApi.IsValidPeriod(
+			shared.DateTimePeriodApi.IntersectPeriods(p, period),
+		) {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *SchedulingService) CancelAppointmentForCustomer(
 	ctx context.Context,
 	customerId CustomerId,
@@ -231,12 +444,40 @@ func (s *SchedulingService) CancelAppointmentForCustomer(
 	if err != nil {
 		return RecordEntity{}, err
 	}
-	if rec.Status != RecordAwaits {
+	if !rec.Status.IsCancelable() {
 		return RecordEntity{}, fmt.Errorf("%w: %s", ErrInvalidAppointmentStatusForCancel, rec.Status)
 	}
 	return rec, s.appointmentRemover(ctx, rec.Id)
 }
 
+func (s *SchedulingService) heldPeriods(
+	ctx context.Context,
+	now time.Time,
+	from time.Time,
+	to time.Time,
+	owner CustomerIdentity,
+) (DatesBusyPeriods, error) {
+	holds, err := s.slotHoldsLoader(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return holds.BusyPeriodsForOthers(now, owner), nil
+}
+
+func (s *SchedulingService) dayHeldPeriods(
+	ctx context.Context,
+	now time.Time,
+	day time.Time,
+	owner CustomerIdentity,
+) (BusyPeriods, error) {
+	from := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+	periods, err := s.heldPeriods(ctx, now, from, from.AddDate(0, 0, 1), owner)
+	if err != nil {
+		return nil, err
+	}
+	return periods.ForDay(day), nil
+}
+
 func (s *SchedulingService) productionCalendar(ctx context.Context) (ProductionCalendar, error) {
 	pc, err := s.productionCalendarLoader(ctx)
 	if err != nil {
@@ -0,0 +1,164 @@
+package appointment
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/logger"
+	"github.com/x0k/veterinary-clinic-backend/internal/shared"
+)
+
+func TestSchedulingServiceAvailability(t *testing.T) {
+	hour := shared.TimePeriod{
+		Start: shared.Time{Hours: 9},
+		End:   shared.Time{Hours: 10},
+	}
+	workingHours := WorkingHoursData{}
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		workingHours[day] = hour
+	}
+	now := time.Date(2024, 5, 6, 12, 0, 0, 0, time.UTC)
+	holds := SlotHolds{
+		NewSlotHold(
+			"tg-1",
+			shared.DateTimePeriod{
+				Start: shared.DateTime{Date: shared.Date{Year: 2024, Month: 5, Day: 8}, Time: hour.Start},
+				End:   shared.DateTime{Date: shared.Date{Year: 2024, Month: 5, Day: 8}, Time: hour.End},
+			},
+			now.Add(time.Hour),
+		),
+	}
+	holdsLoads := 0
+	s := NewSchedulingService(
+		logger.New(slog.New(slog.NewTextHandler(io.Discard, nil))),
+		30,
+		nil,
+		nil,
+		time.Hour,
+		nil,
+		nil,
+		func(context.Context, time.Time, time.Time) (SlotHolds, error) {
+			holdsLoads++
+			return holds, nil
+		},
+		nil,
+		func(context.Context) (ProductionCalendar, error) {
+			return ProductionCalendar{}, nil
+		},
+		func(context.Context) (WorkingHours, error) {
+			return NewWorkingHours(workingHours), nil
+		},
+		nil,
+		func(context.Context, time.Time, time.Time) (DatesBusyPeriods, error) {
+			return DatesBusyPeriods{}, nil
+		},
+		func(context.Context, ServiceId) (ServiceEntity, error) {
+			return ServiceEntity{DurationInMinutes: 60}, nil
+		},
+		func(context.Context) (WorkBreaks, error) {
+			return WorkBreaks{}, nil
+		},
+		nil,
+		nil,
+	)
+
+	availability, err := s.Availability(context.Background(), now, now, "service")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(availability) != 31 {
+		t.Fatalf("len(availability) = %d, want 31", len(availability))
+	}
+	if holdsLoads != 1 {
+		t.Errorf("holds are loaded %d times, want once per month", holdsLoads)
+	}
+	for day, want := range map[int]DayStatus{
+		5: PastDay,
+		6: PastDay,
+		7: HasSlotsDay,
+		8: FullDay,
+	} {
+		if got := availability[day-1].Status; got != want {
+			t.Errorf("May %d status = %v, want %v", day, got, want)
+		}
+	}
+}
+
+func TestSchedulingServiceNearestFreeSlots(t *testing.T) {
+	workingHours := WorkingHoursData{}
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		workingHours[day] = shared.TimePeriod{
+			Start: shared.Time{Hours: 9},
+			End:   shared.Time{Hours: 11},
+		}
+	}
+	s := NewSchedulingService(
+		logger.New(slog.New(slog.NewTextHandler(io.Discard, nil))),
+		60,
+		nil,
+		nil,
+		time.Hour,
+		nil,
+		nil,
+		func(context.Context, time.Time, time.Time) (SlotHolds, error) {
+			return SlotHolds{}, nil
+		},
+		nil,
+		func(context.Context) (ProductionCalendar, error) {
+			return ProductionCalendar{}, nil
+		},
+		func(context.Context) (WorkingHours, error) {
+			return NewWorkingHours(workingHours), nil
+		},
+		func(context.Context, time.Time) (BusyPeriods, error) {
+			return BusyPeriods{}, nil
+		},
+		nil,
+		nil,
+		func(context.Context) (WorkBreaks, error) {
+			return WorkBreaks{}, nil
+		},
+		nil,
+		nil,
+	)
+	now := time.Date(2024, 5, 6, 12, 0, 0, 0, time.UTC)
+	from := time.Date(2024, 5, 7, 0, 0, 0, 0, time.UTC)
+	service := ServiceEntity{DurationInMinutes: 60}
+	at := func(day int, hour int) time.Time {
+		return time.Date(2024, 5, day, hour, 0, 0, 0, time.UTC)
+	}
+
+	cases := []struct {
+		name     string
+		excluded []shared.DateTimePeriod
+		want     []time.Time
+	}{
+		{
+			name: "nearest slots",
+			want: []time.Time{at(7, 9), at(7, 10), at(8, 9)},
+		},
+		{
+			name: "skips offered slots",
+			excluded: []shared.DateTimePeriod{
+				appointmentDateTimePeriod(at(7, 9), service),
+				appointmentDateTimePeriod(at(8, 9), service),
+			},
+			want: []time.Time{at(7, 10), at(8, 10), at(9, 9)},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			slots, err := s.NearestFreeSlots(context.Background(), now, from, service, 3, 14, tc.excluded)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.EqualFunc(slots, tc.want, time.Time.Equal) {
+				t.Errorf("slots = %v, want %v", slots, tc.want)
+			}
+		})
+	}
+}
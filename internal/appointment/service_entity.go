@@ -20,6 +20,7 @@ type ServiceEntity struct {
 	DurationInMinutes shared.DurationInMinutes
 	Description       string
 	CostDescription   string
+	RequiresApproval  bool
 }
 
 func NewService(
@@ -28,6 +29,7 @@ func NewService(
 	durationInMinutes shared.DurationInMinutes,
 	description string,
 	costDescription string,
+	requiresApproval bool,
 ) ServiceEntity {
 	return ServiceEntity{
 		Id:                id,
@@ -35,5 +37,6 @@ func NewService(
 		DurationInMinutes: durationInMinutes,
 		Description:       description,
 		CostDescription:   costDescription,
+		RequiresApproval:  requiresApproval,
 	}
 }
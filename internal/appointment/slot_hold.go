@@ -0,0 +1,52 @@
+package appointment
+
+import (
+	"errors"
+	"time"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/shared"
+)
+
+var ErrSlotIsHeld = errors.New("slot is held by another customer")
+
+type SlotHold struct {
+	Owner          CustomerIdentity
+	DateTimePeriod shared.DateTimePeriod
+	ExpiresAt      time.Time
+}
+
+func NewSlotHold(
+	owner CustomerIdentity,
+	dateTimePeriod shared.DateTimePeriod,
+	expiresAt time.Time,
+) SlotHold {
+	return SlotHold{
+		Owner:          owner,
+		DateTimePeriod: dateTimePeriod,
+		ExpiresAt:      expiresAt,
+	}
+}
+
+func (h SlotHold) IsExpired(now time.Time) bool {
+	return !now.Before(h.ExpiresAt)
+}
+
+type SlotHolds []SlotHold
+
+func (holds SlotHolds) BusyPeriodsForOthers(
+	now time.Time,
+	owner CustomerIdentity,
+) DatesBusyPeriods {
+	periods := make(DatesBusyPeriods)
+	for _, h := range holds {
+		if h.Owner == owner || h.IsExpired(now) {
+			continue
+		}
+		date := shared.JsonDate(h.DateTimePeriod.Start.Date.String())
+		periods[date] = append(periods[date], shared.TimePeriod{
+			Start: h.DateTimePeriod.Start.Time,
+			End:   h.DateTimePeriod.End.Time,
+		})
+	}
+	return periods
+}
@@ -0,0 +1,219 @@
+package appointment
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+var ErrUnknownStaffRole = errors.New("unknown staff role")
+var ErrUnknownStaffNotificationTopic = errors.New("unknown staff notification topic")
+var ErrNoStaffMembers = errors.New("no staff members")
+
+type StaffRole string
+
+const (
+	OwnerStaffRole        StaffRole = "owner"
+	VetStaffRole          StaffRole = "vet"
+	ReceptionistStaffRole StaffRole = "receptionist"
+)
+
+var StaffRoles = []StaffRole{OwnerStaffRole, VetStaffRole, ReceptionistStaffRole}
+
+type Permission string
+
+const (
+	ViewAppointmentsPermission    Permission = "view_appointments"
+	ApproveAppointmentsPermission Permission = "approve_appointments"
+	ChangeRecordStatusPermission  Permission = "change_record_status"
+	CancelAppointmentsPermission  Permission = "cancel_appointments"
+	BlockPeriodsPermission        Permission = "block_periods"
+	FindCustomersPermission       Permission = "find_customers"
+	ManageWebhooksPermission      Permission = "manage_webhooks"
+	ViewAuditLogPermission        Permission = "view_audit_log"
+)
+
+var rolePermissions = map[StaffRole][]Permission{
+	OwnerStaffRole: {
+		ViewAppointmentsPermission,
+		ApproveAppointmentsPermission,
+		ChangeRecordStatusPermission,
+		CancelAppointmentsPermission,
+		BlockPeriodsPermission,
+		FindCustomersPermission,
+		ManageWebhooksPermission,
+		ViewAuditLogPermission,
+	},
+	VetStaffRole: {
+		ViewAppointmentsPermission,
+		ChangeRecordStatusPermission,
+		FindCustomersPermission,
+	},
+	ReceptionistStaffRole: {
+		ViewAppointmentsPermission,
+		ApproveAppointmentsPermission,
+		ChangeRecordStatusPermission,
+		CancelAppointmentsPermission,
+		BlockPeriodsPermission,
+		FindCustomersPermission,
+		ViewAuditLogPermission,
+	},
+}
+
+func NewStaffRole(role string) (StaffRole, error) {
+	r := StaffRole(role)
+	if !slices.Contains(StaffRoles, r) {
+		return "", fmt.Errorf("%w: %s", ErrUnknownStaffRole, role)
+	}
+	return r, nil
+}
+
+// Permission required to move a record into the given status
+func StatusPermission(status RecordStatus) Permission {
+	switch status {
+	case RecordAwaits, RecordDeclined:
+		return ApproveAppointmentsPermission
+	case RecordCanceledByClinic:
+		return CancelAppointmentsPermission
+	default:
+		return ChangeRecordStatusPermission
+	}
+}
+
+type StaffNotificationTopic string
+
+const (
+	CreatedStaffNotificationTopic  StaffNotificationTopic = "created"
+	CanceledStaffNotificationTopic StaffNotificationTopic = "canceled"
+)
+
+var StaffNotificationTopics = []StaffNotificationTopic{
+	CreatedStaffNotificationTopic,
+	CanceledStaffNotificationTopic,
+}
+
+func NewStaffNotificationTopic(topic string) (StaffNotificationTopic, error) {
+	t := StaffNotificationTopic(topic)
+	if !slices.Contains(StaffNotificationTopics, t) {
+		return "", fmt.Errorf("%w: %s", ErrUnknownStaffNotificationTopic, topic)
+	}
+	return t, nil
+}
+
+type StaffSubscription struct {
+	Topics []StaffNotificationTopic
+	// Only appointments for services assigned to the staff member
+	OwnAppointmentsOnly bool
+}
+
+var DefaultStaffSubscriptions = map[StaffRole]StaffSubscription{
+	OwnerStaffRole: {
+		Topics: StaffNotificationTopics,
+	},
+	VetStaffRole: {
+		Topics:              StaffNotificationTopics,
+		OwnAppointmentsOnly: true,
+	},
+	ReceptionistStaffRole: {
+		Topics: StaffNotificationTopics,
+	},
+}
+
+type StaffMember struct {
+	Identity   CustomerIdentity
+	Role       StaffRole
+	ServiceIds []ServiceId
+}
+
+func NewStaffMember(
+	identity CustomerIdentity,
+	role StaffRole,
+	serviceIds []ServiceId,
+) (StaffMember, error) {
+	if !slices.Contains(StaffRoles, role) {
+		return StaffMember{}, fmt.Errorf("%w: %s", ErrUnknownStaffRole, role)
+	}
+	return StaffMember{
+		Identity:   identity,
+		Role:       role,
+		ServiceIds: serviceIds,
+	}, nil
+}
+
+func (m StaffMember) Can(permission Permission) bool {
+	return slices.Contains(rolePermissions[m.Role], permission)
+}
+
+func (m StaffMember) IsAssignedTo(serviceId ServiceId) bool {
+	return slices.Contains(m.ServiceIds, serviceId)
+}
+
+type Staff struct {
+	members       []StaffMember
+	subscriptions map[StaffRole]StaffSubscription
+}
+
+// The first member with the given identity wins,
+// roles without subscription fall back to the defaults
+func NewStaff(
+	members []StaffMember,
+	subscriptions map[StaffRole]StaffSubscription,
+) (*Staff, error) {
+	unique := make([]StaffMember, 0, len(members))
+	for _, member := range members {
+		if member.Identity == "" || slices.ContainsFunc(unique, func(m StaffMember) bool {
+			return m.Identity == member.Identity
+		}) {
+			continue
+		}
+		unique = append(unique, member)
+	}
+	if len(unique) == 0 {
+		return nil, ErrNoStaffMembers
+	}
+	subs := make(map[StaffRole]StaffSubscription, len(StaffRoles))
+	for _, role := range StaffRoles {
+		if sub, ok := subscriptions[role]; ok {
+			subs[role] = sub
+		} else {
+			subs[role] = DefaultStaffSubscriptions[role]
+		}
+	}
+	return &Staff{
+		members:       unique,
+		subscriptions: subs,
+	}, nil
+}
+
+func (s *Staff) Member(identity CustomerIdentity) (StaffMember, bool) {
+	idx := slices.IndexFunc(s.members, func(m StaffMember) bool {
+		return m.Identity == identity
+	})
+	if idx < 0 {
+		return StaffMember{}, false
+	}
+	return s.members[idx], true
+}
+
+func (s *Staff) Check(identity CustomerIdentity, permission Permission) error {
+	member, ok := s.Member(identity)
+	if !ok || !member.Can(permission) {
+		return fmt.Errorf("%w: %s %s", ErrForbidden, identity, permission)
+	}
+	return nil
+}
+
+func (s *Staff) Subscribers(topic StaffNotificationTopic, serviceId ServiceId) []StaffMember {
+	subscribers := make([]StaffMember, 0, len(s.members))
+	for _, member := range s.members {
+		sub := s.subscriptions[member.Role]
+		if !slices.Contains(sub.Topics, topic) {
+			continue
+		}
+		if sub.OwnAppointmentsOnly && !member.IsAssignedTo(serviceId) {
+			continue
+		}
+		subscribers = append(subscribers, member)
+	}
+	return subscribers
+}
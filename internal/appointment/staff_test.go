@@ -0,0 +1,33 @@
+package appointment
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestStaffSubscribers(t *testing.T) {
+	staff, err := NewStaff([]StaffMember{
+		{Identity: "tg-1", Role: OwnerStaffRole},
+		{Identity: "tg-2", Role: VetStaffRole, ServiceIds: []ServiceId{"surgery"}},
+		{Identity: "tg-3", Role: VetStaffRole, ServiceIds: []ServiceId{"vaccination"}},
+		{Identity: "tg-1", Role: VetStaffRole},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	subscribers := staff.Subscribers(CreatedStaffNotificationTopic, "surgery")
+	identities := make([]CustomerIdentity, 0, len(subscribers))
+	for _, s := range subscribers {
+		identities = append(identities, s.Identity)
+	}
+	if !slices.Equal(identities, []CustomerIdentity{"tg-1", "tg-2"}) {
+		t.Errorf("Subscribers() = %v", identities)
+	}
+	if err := staff.Check("tg-2", CancelAppointmentsPermission); !errors.Is(err, ErrForbidden) {
+		t.Errorf("Check() error = %v, want %v", err, ErrForbidden)
+	}
+	if err := staff.Check("tg-1", CancelAppointmentsPermission); err != nil {
+		t.Errorf("Check() error = %v", err)
+	}
+}
@@ -2,36 +2,57 @@ package appointment
 
 import (
 	"context"
+	"errors"
 	"sync"
 	"time"
 )
 
+// Notion rounds edit timestamps down to the minute,
+// so edited records are requested with an overlap
+const editedAppointmentsOverlap = time.Minute
+
 type TrackingService struct {
-	appointmentsLoader ActualAppointmentsLoader
-	stateMu            sync.Mutex
-	stateLoader        AppointmentsStateLoader
-	stateSaver         AppointmentsStateSaver
+	appointmentsLoader       ActualAppointmentsLoader
+	editedAppointmentsLoader EditedAppointmentsLoader
+	reconciliationInterval   time.Duration
+	stateMu                  sync.Mutex
+	stateLoader              AppointmentsStateLoader
+	stateSaver               AppointmentsStateSaver
 }
 
 func NewTracking(
 	appointmentsLoader ActualAppointmentsLoader,
+	editedAppointmentsLoader EditedAppointmentsLoader,
+	reconciliationInterval time.Duration,
 	stateLoader AppointmentsStateLoader,
 	stateSaver AppointmentsStateSaver,
 ) *TrackingService {
 	return &TrackingService{
-		appointmentsLoader: appointmentsLoader,
-		stateLoader:        stateLoader,
-		stateSaver:         stateSaver,
+		appointmentsLoader:       appointmentsLoader,
+		editedAppointmentsLoader: editedAppointmentsLoader,
+		reconciliationInterval:   reconciliationInterval,
+		stateLoader:              stateLoader,
+		stateSaver:               stateSaver,
 	}
 }
 
+// Corrupted state is replaced by the empty one, which is rebuilt
+// on the next changes detection
+func (s *TrackingService) load(ctx context.Context) (AppointmentsState, error) {
+	state, err := s.stateLoader(ctx)
+	if errors.Is(err, ErrAppointmentsStateCorrupted) {
+		return NewEmptyAppointmentsState(), nil
+	}
+	return state, err
+}
+
 func (s *TrackingService) state(
 	ctx context.Context,
 	mutate func(*AppointmentsState),
 ) error {
 	s.stateMu.Lock()
 	defer s.stateMu.Unlock()
-	state, err := s.stateLoader(ctx)
+	state, err := s.load(ctx)
 	if err != nil {
 		return err
 	}
@@ -42,16 +63,45 @@ func (s *TrackingService) state(
 func (s *TrackingService) DetectChanges(
 	ctx context.Context,
 	now time.Time,
-) ([]ChangedEvent, error) {
-	actualAppointments, err := s.appointmentsLoader(ctx, now)
+) ([]Event, error) {
+	s.stateMu.Lock()
+	state, err := s.load(ctx)
+	s.stateMu.Unlock()
 	if err != nil {
 		return nil, err
 	}
-	var changes []ChangedEvent
-	err = s.state(ctx, func(state *AppointmentsState) {
-		changes = state.Reconcile(actualAppointments)
-	})
-	return changes, err
+	var changes []Event
+	if state.NeedsReconciliation(now, s.reconciliationInterval) {
+		actualAppointments, err := s.appointmentsLoader(ctx, now)
+		if err != nil {
+			return nil, err
+		}
+		err = s.state(ctx, func(state *AppointmentsState) {
+			// Recovery must not notify customers about every actual appointment
+			if state.IsEmpty() {
+				state.Rebuild(now, actualAppointments)
+				return
+			}
+			changes = state.Reconcile(now, actualAppointments)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return changes, nil
+	}
+	editedAppointments, err := s.editedAppointmentsLoader(
+		ctx,
+		state.Cursor().Add(-editedAppointmentsOverlap),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.state(ctx, func(state *AppointmentsState) {
+		changes = state.Merge(now, editedAppointments)
+	}); err != nil {
+		return nil, err
+	}
+	return changes, nil
 }
 
 func (s *TrackingService) AddAppointment(
@@ -0,0 +1,72 @@
+package appointment_use_case
+
+import (
+	"context"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/logger"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/logger/sl"
+)
+
+const auditLogUseCaseName = "appointment_use_case.AuditLogUseCase"
+
+type AuditLogUseCase[R any] struct {
+	log                   *logger.Logger
+	staff                 *appointment.Staff
+	recentLimit           int
+	recentEntriesLoader   appointment.RecentAuditEntriesLoader
+	recordEntriesLoader   appointment.RecordAuditEntriesLoader
+	auditEntriesPresenter appointment.AuditEntriesPresenter[R]
+	errorPresenter        appointment.ErrorPresenter[R]
+}
+
+func NewAuditLogUseCase[R any](
+	log *logger.Logger,
+	staff *appointment.Staff,
+	recentLimit int,
+	recentEntriesLoader appointment.RecentAuditEntriesLoader,
+	recordEntriesLoader appointment.RecordAuditEntriesLoader,
+	auditEntriesPresenter appointment.AuditEntriesPresenter[R],
+	errorPresenter appointment.ErrorPresenter[R],
+) *AuditLogUseCase[R] {
+	return &AuditLogUseCase[R]{
+		log:                   log.With(sl.Component(auditLogUseCaseName)),
+		staff:                 staff,
+		recentLimit:           recentLimit,
+		recentEntriesLoader:   recentEntriesLoader,
+		recordEntriesLoader:   recordEntriesLoader,
+		auditEntriesPresenter: auditEntriesPresenter,
+		errorPresenter:        errorPresenter,
+	}
+}
+
+func (u *AuditLogUseCase[R]) RecentEntries(
+	ctx context.Context,
+	identity appointment.CustomerIdentity,
+) (R, error) {
+	if err := u.staff.Check(identity, appointment.ViewAuditLogPermission); err != nil {
+		return u.errorPresenter(err)
+	}
+	entries, err := u.recentEntriesLoader(ctx, u.recentLimit)
+	if err != nil {
+		u.log.Debug(ctx, "failed to load recent audit entries", sl.Err(err))
+		return u.errorPresenter(err)
+	}
+	return u.auditEntriesPresenter(entries)
+}
+
+func (u *AuditLogUseCase[R]) RecordHistory(
+	ctx context.Context,
+	identity appointment.CustomerIdentity,
+	recordId appointment.RecordId,
+) (R, error) {
+	if err := u.staff.Check(identity, appointment.ViewAuditLogPermission); err != nil {
+		return u.errorPresenter(err)
+	}
+	entries, err := u.recordEntriesLoader(ctx, recordId)
+	if err != nil {
+		u.log.Debug(ctx, "failed to load record audit entries", sl.Err(err))
+		return u.errorPresenter(err)
+	}
+	return u.auditEntriesPresenter(entries)
+}
@@ -0,0 +1,47 @@
+package appointment_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/logger"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/logger/sl"
+)
+
+const availabilityUseCaseName = "appointment_use_case.AvailabilityUseCase"
+
+type AvailabilityUseCase[R any] struct {
+	log                   *logger.Logger
+	schedulingService     *appointment.SchedulingService
+	availabilityPresenter appointment.AvailabilityPresenter[R]
+	errorPresenter        appointment.ErrorPresenter[R]
+}
+
+func NewAvailabilityUseCase[R any](
+	log *logger.Logger,
+	schedulingService *appointment.SchedulingService,
+	availabilityPresenter appointment.AvailabilityPresenter[R],
+	errorPresenter appointment.ErrorPresenter[R],
+) *AvailabilityUseCase[R] {
+	return &AvailabilityUseCase[R]{
+		log:                   log.With(sl.Component(availabilityUseCaseName)),
+		schedulingService:     schedulingService,
+		availabilityPresenter: availabilityPresenter,
+		errorPresenter:        errorPresenter,
+	}
+}
+
+func (u *AvailabilityUseCase[R]) Availability(
+	ctx context.Context,
+	now time.Time,
+	month time.Time,
+	serviceId appointment.ServiceId,
+) (R, error) {
+	availability, err := u.schedulingService.Availability(ctx, now, month, serviceId)
+	if err != nil {
+		u.log.Debug(ctx, "failed to get availability", sl.Err(err))
+		return u.errorPresenter(err)
+	}
+	return u.availabilityPresenter(availability)
+}
@@ -0,0 +1,55 @@
+package appointment_use_case
+
+import (
+	"context"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/logger"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/logger/sl"
+	"github.com/x0k/veterinary-clinic-backend/internal/shared"
+)
+
+const blockPeriodUseCaseName = "appointment_use_case.BlockPeriodUseCase"
+
+type BlockPeriodUseCase[R any] struct {
+	log                    *logger.Logger
+	staff                  *appointment.Staff
+	workBreakCreator       appointment.WorkBreakCreator
+	periodBlockedPresenter appointment.PeriodBlockedPresenter[R]
+	errorPresenter         appointment.ErrorPresenter[R]
+}
+
+func NewBlockPeriodUseCase[R any](
+	log *logger.Logger,
+	staff *appointment.Staff,
+	workBreakCreator appointment.WorkBreakCreator,
+	periodBlockedPresenter appointment.PeriodBlockedPresenter[R],
+	errorPresenter appointment.ErrorPresenter[R],
+) *BlockPeriodUseCase[R] {
+	return &BlockPeriodUseCase[R]{
+		log:                    log.With(sl.Component(blockPeriodUseCaseName)),
+		staff:                  staff,
+		workBreakCreator:       workBreakCreator,
+		periodBlockedPresenter: periodBlockedPresenter,
+		errorPresenter:         errorPresenter,
+	}
+}
+
+func (u *BlockPeriodUseCase[R]) BlockPeriod(
+	ctx context.Context,
+	identity appointment.CustomerIdentity,
+	title string,
+	period shared.DateTimePeriod,
+) (R, error) {
+	if err := u.staff.Check(identity, appointment.BlockPeriodsPermission); err != nil {
+		return u.errorPresenter(err)
+	}
+	if !shared.DateTimePeriodApi.IsValidPeriod(period) {
+		return u.errorPresenter(appointment.ErrInvalidDateTimePeriod)
+	}
+	if err := u.workBreakCreator(ctx, title, period); err != nil {
+		u.log.Debug(ctx, "failed to create work break", sl.Err(err))
+		return u.errorPresenter(err)
+	}
+	return u.periodBlockedPresenter(title, period)
+}
@@ -2,6 +2,7 @@ package appointment_use_case
 
 import (
 	"context"
+	"time"
 
 	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
 	"github.com/x0k/veterinary-clinic-backend/internal/lib/logger"
@@ -16,6 +17,7 @@ type CancelAppointmentUseCase[R any] struct {
 	schedulingService          *appointment.SchedulingService
 	customerLoader             appointment.CustomerByIdentityLoader
 	serviceLoader              appointment.ServiceLoader
+	auditEntriesSaver          appointment.AuditEntriesSaver
 	appointmentCancelPresenter appointment.AppointmentCancelPresenter[R]
 	errorPresenter             appointment.ErrorPresenter[R]
 	publisher                  pubsub.Publisher[appointment.EventType]
@@ -26,6 +28,7 @@ func NewCancelAppointmentUseCase[R any](
 	schedulingService *appointment.SchedulingService,
 	customerLoader appointment.CustomerByIdentityLoader,
 	serviceLoader appointment.ServiceLoader,
+	auditEntriesSaver appointment.AuditEntriesSaver,
 	appointmentCancelPresenter appointment.AppointmentCancelPresenter[R],
 	errorPresenter appointment.ErrorPresenter[R],
 	publisher pubsub.Publisher[appointment.EventType],
@@ -35,6 +38,7 @@ func NewCancelAppointmentUseCase[R any](
 		schedulingService:          schedulingService,
 		serviceLoader:              serviceLoader,
 		customerLoader:             customerLoader,
+		auditEntriesSaver:          auditEntriesSaver,
 		appointmentCancelPresenter: appointmentCancelPresenter,
 		errorPresenter:             errorPresenter,
 		publisher:                  publisher,
@@ -58,10 +62,15 @@ func (s *CancelAppointmentUseCase[R]) CancelAppointment(
 		res, err := s.errorPresenter(err)
 		return false, res, err
 	}
+	if err := s.auditEntriesSaver(ctx, []appointment.AuditEntry{
+		appointment.NewCanceledAuditEntry(appointment.NewCustomerAuditActor(customerIdentity), rec, time.Now()),
+	}); err != nil {
+		s.log.Error(ctx, "failed to save audit entries", sl.Err(err))
+	}
 	if service, err := s.serviceLoader(ctx, rec.ServiceId); err != nil {
 		s.log.Debug(ctx, "failed to load service", sl.Err(err))
 	} else if err = s.publisher.Publish(appointment.NewAppointmentCanceled(rec, customer, service)); err != nil {
-		s.log.Debug(ctx, "failed to publish event", sl.Err(err))
+		s.log.Error(ctx, "failed to publish event", sl.Err(err))
 	}
 	res, err := s.appointmentCancelPresenter()
 	return true, res, err
@@ -0,0 +1,167 @@
+package appointment_use_case
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/logger"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/logger/sl"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/pubsub"
+	"github.com/x0k/veterinary-clinic-backend/internal/shared"
+)
+
+const cancelPeriodUseCaseName = "appointment_use_case.CancelPeriodUseCase"
+
+const (
+	rescheduleSlotsLimit = 3
+	rescheduleSearchDays = 14
+)
+
+type CancelPeriodUseCase[R any] struct {
+	log                     *logger.Logger
+	staff                   *appointment.Staff
+	recordsService          *appointment.RecordsService
+	trackingService         *appointment.TrackingService
+	schedulingService       *appointment.SchedulingService
+	workBreakCreator        appointment.WorkBreakCreator
+	rescheduleOfferSaver    appointment.RescheduleOfferSaver
+	auditEntriesSaver       appointment.AuditEntriesSaver
+	periodCanceledPresenter appointment.PeriodCanceledPresenter[R]
+	errorPresenter          appointment.ErrorPresenter[R]
+	publisher               pubsub.Publisher[appointment.EventType]
+}
+
+func NewCancelPeriodUseCase[R any](
+	log *logger.Logger,
+	staff *appointment.Staff,
+	recordsService *appointment.RecordsService,
+	trackingService *appointment.TrackingService,
+	schedulingService *appointment.SchedulingService,
+	workBreakCreator appointment.WorkBreakCreator,
+	rescheduleOfferSaver appointment.RescheduleOfferSaver,
+	auditEntriesSaver appointment.AuditEntriesSaver,
+	periodCanceledPresenter appointment.PeriodCanceledPresenter[R],
+	errorPresenter appointment.ErrorPresenter[R],
+	publisher pubsub.Publisher[appointment.EventType],
+) *CancelPeriodUseCase[R] {
+	return &CancelPeriodUseCase[R]{
+		log:                     log.With(sl.Component(cancelPeriodUseCaseName)),
+		staff:                   staff,
+		recordsService:          recordsService,
+		trackingService:         trackingService,
+		schedulingService:       schedulingService,
+		workBreakCreator:        workBreakCreator,
+		rescheduleOfferSaver:    rescheduleOfferSaver,
+		auditEntriesSaver:       auditEntriesSaver,
+		periodCanceledPresenter: periodCanceledPresenter,
+		errorPresenter:          errorPresenter,
+		publisher:               publisher,
+	}
+}
+
+// Blocks the period and cancels all appointments in it.
+// With `offerAlternatives` each affected customer receives
+// the nearest free slots to choose from
+func (u *CancelPeriodUseCase[R]) CancelPeriod(
+	ctx context.Context,
+	identity appointment.CustomerIdentity,
+	now time.Time,
+	period shared.DateTimePeriod,
+	reason string,
+	offerAlternatives bool,
+) (R, error) {
+	if err := u.staff.Check(identity, appointment.CancelAppointmentsPermission); err != nil {
+		return u.errorPresenter(err)
+	}
+	if err := u.staff.Check(identity, appointment.BlockPeriodsPermission); err != nil {
+		return u.errorPresenter(err)
+	}
+	if !shared.DateTimePeriodApi.IsValidPeriod(period) {
+		return u.errorPresenter(appointment.ErrInvalidDateTimePeriod)
+	}
+	appointments, err := u.recordsService.AppointmentsInPeriod(ctx, period)
+	if err != nil {
+		u.log.Debug(ctx, "failed to load appointments", sl.Err(err))
+		return u.errorPresenter(err)
+	}
+	// Block the period first so it will not be offered as an alternative
+	if err := u.workBreakCreator(ctx, reason, period); err != nil {
+		u.log.Debug(ctx, "failed to create work break", sl.Err(err))
+		return u.errorPresenter(err)
+	}
+	canceled := make([]appointment.AppointmentDetails, 0, len(appointments))
+	offers := make([]appointment.RescheduleOffer, 0, len(appointments))
+	auditEntries := make([]appointment.AuditEntry, 0, len(appointments))
+	// Customers are offered different slots, so accepting one offer
+	// does not take the slot offered to someone else
+	offered := make([]shared.DateTimePeriod, 0, len(appointments)*rescheduleSlotsLimit)
+	actor := appointment.NewStaffAuditActor(identity)
+	for _, app := range appointments {
+		if !app.Record.Status.CanTransitionTo(appointment.RecordCanceledByClinic) {
+			continue
+		}
+		event, err := u.recordsService.ChangeStatus(ctx, app.Record.Id, appointment.RecordCanceledByClinic, reason)
+		if err != nil {
+			u.log.Error(ctx, "failed to cancel appointment", sl.Err(err))
+			continue
+		}
+		if err := u.trackingService.AddAppointment(ctx, event.Transition().Record); err != nil {
+			u.log.Error(ctx, "failed to track appointment", sl.Err(err))
+		}
+		auditEntries = append(auditEntries, appointment.NewStatusChangedAuditEntry(actor, event.Transition(), now))
+		if err := u.publisher.Publish(event); err != nil {
+			u.log.Error(ctx, "failed to publish event", sl.Err(err))
+		}
+		app.Record = event.Transition().Record
+		canceled = append(canceled, app)
+		if !offerAlternatives {
+			continue
+		}
+		offer, ok := u.offerAlternatives(ctx, now, app, reason, offered)
+		if ok {
+			offers = append(offers, offer)
+			offered = append(offered, offer.SlotPeriods(app.Service)...)
+		}
+	}
+	if err := u.auditEntriesSaver(ctx, auditEntries); err != nil {
+		u.log.Error(ctx, "failed to save audit entries", sl.Err(err))
+	}
+	return u.periodCanceledPresenter(period, reason, canceled, offers)
+}
+
+func (u *CancelPeriodUseCase[R]) offerAlternatives(
+	ctx context.Context,
+	now time.Time,
+	app appointment.AppointmentDetails,
+	reason string,
+	offered []shared.DateTimePeriod,
+) (appointment.RescheduleOffer, bool) {
+	slots, err := u.schedulingService.NearestFreeSlots(
+		ctx,
+		now,
+		shared.DateTimeToGoTime(app.Record.DateTimePeriod.Start),
+		app.Service,
+		rescheduleSlotsLimit,
+		rescheduleSearchDays,
+		offered,
+	)
+	if err != nil {
+		u.log.Error(ctx, "failed to find free slots", sl.Err(err))
+		return appointment.RescheduleOffer{}, false
+	}
+	if len(slots) == 0 {
+		u.log.Info(ctx, "no free slots to offer", slog.String("record_id", app.Record.Id.String()))
+		return appointment.RescheduleOffer{}, false
+	}
+	offer := appointment.NewRescheduleOffer(app.Record, reason, slots, now)
+	if err := u.rescheduleOfferSaver(ctx, offer); err != nil {
+		u.log.Error(ctx, "failed to save reschedule offer", sl.Err(err))
+		return appointment.RescheduleOffer{}, false
+	}
+	if err := u.publisher.Publish(appointment.NewRescheduleOffered(offer)); err != nil {
+		u.log.Error(ctx, "failed to publish event", sl.Err(err))
+	}
+	return offer, true
+}
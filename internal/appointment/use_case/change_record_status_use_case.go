@@ -0,0 +1,81 @@
+package appointment_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/logger"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/logger/sl"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/pubsub"
+)
+
+const changeRecordStatusUseCaseName = "appointment_use_case.ChangeRecordStatusUseCase"
+
+type ChangeRecordStatusUseCase[R any] struct {
+	log                   *logger.Logger
+	staff                 *appointment.Staff
+	recordsService        *appointment.RecordsService
+	trackingService       *appointment.TrackingService
+	auditEntriesSaver     appointment.AuditEntriesSaver
+	recordStatusPresenter appointment.RecordStatusPresenter[R]
+	errorPresenter        appointment.ErrorPresenter[R]
+	publisher             pubsub.Publisher[appointment.EventType]
+}
+
+func NewChangeRecordStatusUseCase[R any](
+	log *logger.Logger,
+	staff *appointment.Staff,
+	recordsService *appointment.RecordsService,
+	trackingService *appointment.TrackingService,
+	auditEntriesSaver appointment.AuditEntriesSaver,
+	recordStatusPresenter appointment.RecordStatusPresenter[R],
+	errorPresenter appointment.ErrorPresenter[R],
+	publisher pubsub.Publisher[appointment.EventType],
+) *ChangeRecordStatusUseCase[R] {
+	return &ChangeRecordStatusUseCase[R]{
+		log:                   log.With(sl.Component(changeRecordStatusUseCaseName)),
+		staff:                 staff,
+		recordsService:        recordsService,
+		trackingService:       trackingService,
+		auditEntriesSaver:     auditEntriesSaver,
+		recordStatusPresenter: recordStatusPresenter,
+		errorPresenter:        errorPresenter,
+		publisher:             publisher,
+	}
+}
+
+// returns (changed, response, error)
+func (u *ChangeRecordStatusUseCase[R]) ChangeStatus(
+	ctx context.Context,
+	identity appointment.CustomerIdentity,
+	recordId appointment.RecordId,
+	status appointment.RecordStatus,
+	reason string,
+) (bool, R, error) {
+	if err := u.staff.Check(identity, appointment.StatusPermission(status)); err != nil {
+		res, err := u.errorPresenter(err)
+		return false, res, err
+	}
+	event, err := u.recordsService.ChangeStatus(ctx, recordId, status, reason)
+	if err != nil {
+		u.log.Debug(ctx, "failed to change record status", sl.Err(err))
+		res, err := u.errorPresenter(err)
+		return false, res, err
+	}
+	// The change is tracked before it is published, so the changes
+	// detection does not report it once again
+	if err := u.trackingService.AddAppointment(ctx, event.Transition().Record); err != nil {
+		u.log.Error(ctx, "failed to track appointment", sl.Err(err))
+	}
+	if err := u.auditEntriesSaver(ctx, []appointment.AuditEntry{
+		appointment.NewStatusChangedAuditEntry(appointment.NewStaffAuditActor(identity), event.Transition(), time.Now()),
+	}); err != nil {
+		u.log.Error(ctx, "failed to save audit entries", sl.Err(err))
+	}
+	if err := u.publisher.Publish(event); err != nil {
+		u.log.Error(ctx, "failed to publish event", sl.Err(err))
+	}
+	res, err := u.recordStatusPresenter(event.Transition().Record)
+	return true, res, err
+}
@@ -0,0 +1,94 @@
+package appointment_use_case
+
+import (
+	"context"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/logger"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/logger/sl"
+)
+
+const customerLocaleUseCaseName = "appointment_use_case.CustomerLocaleUseCase"
+
+type CustomerLocaleUseCase[R any] struct {
+	log                     *logger.Logger
+	supportedLocales        []string
+	localeLoader            appointment.CustomerLocaleLoader
+	localeSaver             appointment.CustomerLocaleSaver
+	customerLocalePresenter appointment.CustomerLocalePresenter[R]
+	errorPresenter          appointment.ErrorPresenter[R]
+}
+
+func NewCustomerLocaleUseCase[R any](
+	log *logger.Logger,
+	supportedLocales []string,
+	localeLoader appointment.CustomerLocaleLoader,
+	localeSaver appointment.CustomerLocaleSaver,
+	customerLocalePresenter appointment.CustomerLocalePresenter[R],
+	errorPresenter appointment.ErrorPresenter[R],
+) *CustomerLocaleUseCase[R] {
+	return &CustomerLocaleUseCase[R]{
+		log:                     log.With(sl.Component(customerLocaleUseCaseName)),
+		supportedLocales:        supportedLocales,
+		localeLoader:            localeLoader,
+		localeSaver:             localeSaver,
+		customerLocalePresenter: customerLocalePresenter,
+		errorPresenter:          errorPresenter,
+	}
+}
+
+// Remembers the `detected` locale for notifications and returns the effective one.
+// Returns the `detected` locale when the stored one is unavailable.
+func (u *CustomerLocaleUseCase[R]) DetectLocale(
+	ctx context.Context,
+	identity appointment.CustomerIdentity,
+	detected string,
+) string {
+	locale, err := u.localeLoader(ctx, identity)
+	if err != nil {
+		u.log.Error(ctx, "failed to load customer locale", sl.Err(err))
+		return detected
+	}
+	if detected != "" && locale.Detected != detected {
+		locale.Detected = detected
+		if err := u.localeSaver(ctx, identity, locale); err != nil {
+			u.log.Error(ctx, "failed to save customer locale", sl.Err(err))
+		}
+	}
+	return locale.String()
+}
+
+func (u *CustomerLocaleUseCase[R]) CustomerLocale(
+	ctx context.Context,
+	identity appointment.CustomerIdentity,
+) (R, error) {
+	locale, err := u.localeLoader(ctx, identity)
+	if err != nil {
+		u.log.Error(ctx, "failed to load customer locale", sl.Err(err))
+		return u.errorPresenter(err)
+	}
+	return u.customerLocalePresenter(locale)
+}
+
+// Empty `preferred` locale restores the detection
+func (u *CustomerLocaleUseCase[R]) SetPreferredLocale(
+	ctx context.Context,
+	identity appointment.CustomerIdentity,
+	preferred string,
+) (R, error) {
+	preferred, err := appointment.NewPreferredLocale(preferred, u.supportedLocales)
+	if err != nil {
+		return u.errorPresenter(err)
+	}
+	locale, err := u.localeLoader(ctx, identity)
+	if err != nil {
+		u.log.Error(ctx, "failed to load customer locale", sl.Err(err))
+		return u.errorPresenter(err)
+	}
+	locale.Preferred = preferred
+	if err := u.localeSaver(ctx, identity, locale); err != nil {
+		u.log.Error(ctx, "failed to save customer locale", sl.Err(err))
+		return u.errorPresenter(err)
+	}
+	return u.customerLocalePresenter(locale)
+}
@@ -0,0 +1,52 @@
+package appointment_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/logger"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/logger/sl"
+)
+
+const dayAppointmentsUseCaseName = "appointment_use_case.DayAppointmentsUseCase"
+
+type DayAppointmentsUseCase[R any] struct {
+	log                      *logger.Logger
+	staff                    *appointment.Staff
+	recordsService           *appointment.RecordsService
+	dayAppointmentsPresenter appointment.DayAppointmentsPresenter[R]
+	errorPresenter           appointment.ErrorPresenter[R]
+}
+
+func NewDayAppointmentsUseCase[R any](
+	log *logger.Logger,
+	staff *appointment.Staff,
+	recordsService *appointment.RecordsService,
+	dayAppointmentsPresenter appointment.DayAppointmentsPresenter[R],
+	errorPresenter appointment.ErrorPresenter[R],
+) *DayAppointmentsUseCase[R] {
+	return &DayAppointmentsUseCase[R]{
+		log:                      log.With(sl.Component(dayAppointmentsUseCaseName)),
+		staff:                    staff,
+		recordsService:           recordsService,
+		dayAppointmentsPresenter: dayAppointmentsPresenter,
+		errorPresenter:           errorPresenter,
+	}
+}
+
+func (u *DayAppointmentsUseCase[R]) DayAppointments(
+	ctx context.Context,
+	identity appointment.CustomerIdentity,
+	day time.Time,
+) (R, error) {
+	if err := u.staff.Check(identity, appointment.ViewAppointmentsPermission); err != nil {
+		return u.errorPresenter(err)
+	}
+	appointments, err := u.recordsService.DayAppointments(ctx, day)
+	if err != nil {
+		u.log.Debug(ctx, "failed to load day appointments", sl.Err(err))
+		return u.errorPresenter(err)
+	}
+	return u.dayAppointmentsPresenter(day, appointments)
+}
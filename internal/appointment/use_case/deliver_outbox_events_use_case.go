@@ -0,0 +1,110 @@
+package appointment_use_case
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/logger"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/logger/sl"
+)
+
+const deliverOutboxEventsUseCaseName = "appointment_use_case.DeliverOutboxEventsUseCase"
+
+type DeliverOutboxEventsUseCase struct {
+	log           *logger.Logger
+	batchSize     int
+	maxAttempts   int
+	baseBackoff   time.Duration
+	retention     time.Duration
+	entriesLoader appointment.OutboxEntriesLoader
+	completer     appointment.OutboxEntryCompleter
+	retrier       appointment.OutboxEntryRetrier
+	failer        appointment.OutboxEntryFailer
+	purger        appointment.OutboxEntriesPurger
+	handler       appointment.OutboxEventHandler
+}
+
+func NewDeliverOutboxEventsUseCase(
+	log *logger.Logger,
+	batchSize int,
+	maxAttempts int,
+	baseBackoff time.Duration,
+	retention time.Duration,
+	entriesLoader appointment.OutboxEntriesLoader,
+	completer appointment.OutboxEntryCompleter,
+	retrier appointment.OutboxEntryRetrier,
+	failer appointment.OutboxEntryFailer,
+	purger appointment.OutboxEntriesPurger,
+	handler appointment.OutboxEventHandler,
+) *DeliverOutboxEventsUseCase {
+	return &DeliverOutboxEventsUseCase{
+		log:           log.With(sl.Component(deliverOutboxEventsUseCaseName)),
+		batchSize:     batchSize,
+		maxAttempts:   maxAttempts,
+		baseBackoff:   baseBackoff,
+		retention:     retention,
+		entriesLoader: entriesLoader,
+		completer:     completer,
+		retrier:       retrier,
+		failer:        failer,
+		purger:        purger,
+		handler:       handler,
+	}
+}
+
+func (u *DeliverOutboxEventsUseCase) Deliver(ctx context.Context, now time.Time) {
+	entries, err := u.entriesLoader(ctx, now, u.batchSize)
+	if err != nil {
+		u.log.Error(ctx, "failed to load outbox entries", sl.Err(err))
+		return
+	}
+	for _, entry := range entries {
+		if ctx.Err() != nil {
+			return
+		}
+		u.deliver(ctx, now, entry)
+	}
+}
+
+func (u *DeliverOutboxEventsUseCase) deliver(ctx context.Context, now time.Time, entry appointment.OutboxEntry) {
+	// Retries will not help to restore the event
+	if entry.Err != nil {
+		log := u.log.With(slog.Int64("entry_id", int64(entry.Id)))
+		log.Error(ctx, "outbox entry is corrupted", sl.Err(entry.Err))
+		if err := u.failer(ctx, entry.Id, entry.Err); err != nil {
+			log.Error(ctx, "failed to mark outbox entry as failed", sl.Err(err))
+		}
+		return
+	}
+	handleErr := u.handler(ctx, entry.Id, entry.Event)
+	if handleErr == nil {
+		if err := u.completer(ctx, entry.Id); err != nil {
+			u.log.Error(ctx, "failed to complete outbox entry", sl.Err(err))
+		}
+		return
+	}
+	entry.Attempts++
+	log := u.log.With(
+		slog.Int64("entry_id", int64(entry.Id)),
+		slog.Int("attempts", entry.Attempts),
+	)
+	if entry.Attempts >= u.maxAttempts {
+		log.Error(ctx, "outbox entry delivery failed", sl.Err(handleErr))
+		if err := u.failer(ctx, entry.Id, handleErr); err != nil {
+			log.Error(ctx, "failed to mark outbox entry as failed", sl.Err(err))
+		}
+		return
+	}
+	log.Info(ctx, "outbox entry delivery will be retried", sl.Err(handleErr))
+	if err := u.retrier(ctx, entry.Id, entry.NextAttemptAt(now, u.baseBackoff), handleErr); err != nil {
+		log.Error(ctx, "failed to schedule outbox entry retry", sl.Err(err))
+	}
+}
+
+func (u *DeliverOutboxEventsUseCase) Purge(ctx context.Context, now time.Time) {
+	if err := u.purger(ctx, now.Add(-u.retention)); err != nil {
+		u.log.Error(ctx, "failed to purge outbox entries", sl.Err(err))
+	}
+}
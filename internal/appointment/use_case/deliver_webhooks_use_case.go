@@ -0,0 +1,91 @@
+package appointment_use_case
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/logger"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/logger/sl"
+)
+
+const deliverWebhooksUseCaseName = "appointment_use_case.DeliverWebhooksUseCase"
+
+type DeliverWebhooksUseCase struct {
+	log              *logger.Logger
+	batchSize        int
+	maxAttempts      int
+	baseBackoff      time.Duration
+	deliveriesLoader appointment.PendingWebhookDeliveriesLoader
+	sender           appointment.WebhookSender
+	completer        appointment.WebhookDeliveryCompleter
+	retrier          appointment.WebhookDeliveryRetrier
+	failer           appointment.WebhookDeliveryFailer
+}
+
+func NewDeliverWebhooksUseCase(
+	log *logger.Logger,
+	batchSize int,
+	maxAttempts int,
+	baseBackoff time.Duration,
+	deliveriesLoader appointment.PendingWebhookDeliveriesLoader,
+	sender appointment.WebhookSender,
+	completer appointment.WebhookDeliveryCompleter,
+	retrier appointment.WebhookDeliveryRetrier,
+	failer appointment.WebhookDeliveryFailer,
+) *DeliverWebhooksUseCase {
+	return &DeliverWebhooksUseCase{
+		log:              log.With(sl.Component(deliverWebhooksUseCaseName)),
+		batchSize:        batchSize,
+		maxAttempts:      maxAttempts,
+		baseBackoff:      baseBackoff,
+		deliveriesLoader: deliveriesLoader,
+		sender:           sender,
+		completer:        completer,
+		retrier:          retrier,
+		failer:           failer,
+	}
+}
+
+func (u *DeliverWebhooksUseCase) Deliver(ctx context.Context, now time.Time) {
+	deliveries, err := u.deliveriesLoader(ctx, now, u.batchSize)
+	if err != nil {
+		u.log.Error(ctx, "failed to load webhook deliveries", sl.Err(err))
+		return
+	}
+	for _, delivery := range deliveries {
+		if ctx.Err() != nil {
+			return
+		}
+		u.deliver(ctx, now, delivery)
+	}
+}
+
+func (u *DeliverWebhooksUseCase) deliver(ctx context.Context, now time.Time, delivery appointment.WebhookDelivery) {
+	sendErr := u.sender(ctx, delivery.Url, delivery.Event)
+	if sendErr == nil {
+		if err := u.completer(ctx, delivery.Id); err != nil {
+			u.log.Error(ctx, "failed to complete webhook delivery", sl.Err(err))
+		}
+		return
+	}
+	delivery.Attempts++
+	log := u.log.With(
+		slog.Int64("delivery_id", int64(delivery.Id)),
+		slog.String("url", delivery.Url),
+		slog.Int("attempts", delivery.Attempts),
+	)
+	if delivery.Attempts >= u.maxAttempts {
+		log.Error(ctx, "webhook delivery moved to dead letters", sl.Err(sendErr))
+		if err := u.failer(ctx, delivery.Id, sendErr); err != nil {
+			log.Error(ctx, "failed to mark webhook delivery as failed", sl.Err(err))
+		}
+		return
+	}
+	log.Info(ctx, "webhook delivery will be retried", sl.Err(sendErr))
+	nextAttemptAt := appointment.NextAttemptAt(delivery.Attempts, now, u.baseBackoff)
+	if err := u.retrier(ctx, delivery.Id, nextAttemptAt, sendErr); err != nil {
+		log.Error(ctx, "failed to schedule webhook delivery retry", sl.Err(err))
+	}
+}
@@ -12,20 +12,23 @@ import (
 const detectChangesUseCaseName = "appointment_use_case.DetectChangesUseCase"
 
 type DetectChangesUseCase struct {
-	log             *logger.Logger
-	trackingService *appointment.TrackingService
-	publisher       appointment.Publisher
+	log               *logger.Logger
+	trackingService   *appointment.TrackingService
+	auditEntriesSaver appointment.AuditEntriesSaver
+	publisher         appointment.Publisher
 }
 
 func NewDetectChangesUseCase(
 	log *logger.Logger,
 	trackingService *appointment.TrackingService,
+	auditEntriesSaver appointment.AuditEntriesSaver,
 	publisher appointment.Publisher,
 ) *DetectChangesUseCase {
 	return &DetectChangesUseCase{
-		log:             log.With(sl.Component(detectChangesUseCaseName)),
-		trackingService: trackingService,
-		publisher:       publisher,
+		log:               log.With(sl.Component(detectChangesUseCaseName)),
+		trackingService:   trackingService,
+		auditEntriesSaver: auditEntriesSaver,
+		publisher:         publisher,
 	}
 }
 
@@ -33,11 +36,16 @@ func (u *DetectChangesUseCase) DetectChanges(ctx context.Context, now time.Time)
 	changes, err := u.trackingService.DetectChanges(ctx, now)
 	if err != nil {
 		u.log.Error(ctx, "failed to detect changes", sl.Err(err))
-		return
+	}
+	if len(changes) > 0 {
+		if err := u.auditEntriesSaver(ctx, appointment.NewExternalAuditEntries(changes, now)); err != nil {
+			u.log.Error(ctx, "failed to save audit entries", sl.Err(err))
+		}
 	}
 	for _, change := range changes {
-		if change.ChangeType == appointment.RemovedChangeType &&
-			change.Record.Status != appointment.RecordAwaits {
+		if changed, ok := change.(appointment.ChangedEvent); ok &&
+			changed.ChangeType == appointment.RemovedChangeType &&
+			!changed.Record.Status.IsActive() {
 			continue
 		}
 		if err := u.publisher.Publish(change); err != nil {
@@ -0,0 +1,56 @@
+package appointment_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/logger"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/logger/sl"
+)
+
+const enqueueWebhooksUseCaseName = "appointment_use_case.EnqueueWebhooksUseCase"
+
+type EnqueueWebhooksUseCase struct {
+	log             *logger.Logger
+	urls            []string
+	encoder         appointment.WebhookEventEncoder
+	deliveriesSaver appointment.WebhookDeliveriesSaver
+}
+
+func NewEnqueueWebhooksUseCase(
+	log *logger.Logger,
+	urls []string,
+	encoder appointment.WebhookEventEncoder,
+	deliveriesSaver appointment.WebhookDeliveriesSaver,
+) *EnqueueWebhooksUseCase {
+	return &EnqueueWebhooksUseCase{
+		log:             log.With(sl.Component(enqueueWebhooksUseCaseName)),
+		urls:            urls,
+		encoder:         encoder,
+		deliveriesSaver: deliveriesSaver,
+	}
+}
+
+// Deliveries of the same outbox entry are saved once
+func (u *EnqueueWebhooksUseCase) Enqueue(
+	ctx context.Context,
+	id appointment.OutboxEntryId,
+	event appointment.Event,
+) error {
+	if len(u.urls) == 0 {
+		return nil
+	}
+	webhookEvent, err := u.encoder(id, event)
+	if err != nil {
+		// Encoding is deterministic, retrying will not help
+		u.log.Error(ctx, "failed to encode webhook event", sl.Err(err))
+		return nil
+	}
+	deliveries := appointment.NewWebhookDeliveries(webhookEvent, u.urls, time.Now())
+	if err := u.deliveriesSaver(ctx, deliveries); err != nil {
+		u.log.Error(ctx, "failed to save webhook deliveries", sl.Err(err))
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package appointment_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/logger"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/logger/sl"
+)
+
+const exportAuditLogUseCaseName = "appointment_use_case.ExportAuditLogUseCase"
+
+type ExportAuditLogUseCase[R any] struct {
+	log                  *logger.Logger
+	staff                *appointment.Staff
+	entriesLoader        appointment.AuditEntriesInPeriodLoader
+	auditExportPresenter appointment.AuditExportPresenter[R]
+	errorPresenter       appointment.ErrorPresenter[R]
+}
+
+func NewExportAuditLogUseCase[R any](
+	log *logger.Logger,
+	staff *appointment.Staff,
+	entriesLoader appointment.AuditEntriesInPeriodLoader,
+	auditExportPresenter appointment.AuditExportPresenter[R],
+	errorPresenter appointment.ErrorPresenter[R],
+) *ExportAuditLogUseCase[R] {
+	return &ExportAuditLogUseCase[R]{
+		log:                  log.With(sl.Component(exportAuditLogUseCaseName)),
+		staff:                staff,
+		entriesLoader:        entriesLoader,
+		auditExportPresenter: auditExportPresenter,
+		errorPresenter:       errorPresenter,
+	}
+}
+
+// Exports entries that occurred in [from, to)
+func (u *ExportAuditLogUseCase[R]) Export(
+	ctx context.Context,
+	identity appointment.CustomerIdentity,
+	from time.Time,
+	to time.Time,
+) (R, error) {
+	if err := u.staff.Check(identity, appointment.ViewAuditLogPermission); err != nil {
+		return u.errorPresenter(err)
+	}
+	if !from.Before(to) {
+		return u.errorPresenter(appointment.ErrInvalidDateTimePeriod)
+	}
+	entries, err := u.entriesLoader(ctx, from, to)
+	if err != nil {
+		u.log.Debug(ctx, "failed to load audit entries", sl.Err(err))
+		return u.errorPresenter(err)
+	}
+	return u.auditExportPresenter(from, to, entries)
+}
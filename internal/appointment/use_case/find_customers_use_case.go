@@ -0,0 +1,51 @@
+package appointment_use_case
+
+import (
+	"context"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/logger"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/logger/sl"
+)
+
+const findCustomersUseCaseName = "appointment_use_case.FindCustomersUseCase"
+
+type FindCustomersUseCase[R any] struct {
+	log                *logger.Logger
+	staff              *appointment.Staff
+	recordsService     *appointment.RecordsService
+	customersPresenter appointment.CustomersPresenter[R]
+	errorPresenter     appointment.ErrorPresenter[R]
+}
+
+func NewFindCustomersUseCase[R any](
+	log *logger.Logger,
+	staff *appointment.Staff,
+	recordsService *appointment.RecordsService,
+	customersPresenter appointment.CustomersPresenter[R],
+	errorPresenter appointment.ErrorPresenter[R],
+) *FindCustomersUseCase[R] {
+	return &FindCustomersUseCase[R]{
+		log:                log.With(sl.Component(findCustomersUseCaseName)),
+		staff:              staff,
+		recordsService:     recordsService,
+		customersPresenter: customersPresenter,
+		errorPresenter:     errorPresenter,
+	}
+}
+
+func (u *FindCustomersUseCase[R]) CustomersByPhone(
+	ctx context.Context,
+	identity appointment.CustomerIdentity,
+	phone string,
+) (R, error) {
+	if err := u.staff.Check(identity, appointment.FindCustomersPermission); err != nil {
+		return u.errorPresenter(err)
+	}
+	customers, err := u.recordsService.CustomersByPhone(ctx, phone)
+	if err != nil {
+		u.log.Debug(ctx, "failed to find customers", sl.Err(err))
+		return u.errorPresenter(err)
+	}
+	return u.customersPresenter(customers)
+}
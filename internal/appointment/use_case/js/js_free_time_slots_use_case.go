@@ -37,6 +37,7 @@ func NewFreeTimeSlotsUseCase[R any](
 
 func (u *FreeTimeSlotsUseCase[R]) FreeTimeSlots(
 	ctx context.Context,
+	customerIdentity appointment.CustomerIdentity,
 	serviceId appointment.ServiceId,
 	now time.Time,
 	appointmentDate time.Time,
@@ -51,6 +52,7 @@ func (u *FreeTimeSlotsUseCase[R]) FreeTimeSlots(
 		now,
 		appointmentDate,
 		service.DurationInMinutes,
+		customerIdentity,
 	)
 	if err != nil {
 		u.log.Debug(ctx, "failed to get sampled free time slots", sl.Err(err))
@@ -0,0 +1,61 @@
+package appointment_js_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/logger"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/logger/sl"
+)
+
+const holdSlotUseCaseName = "appointment_js_use_case.HoldSlotUseCase"
+
+type HoldSlotUseCase[R any] struct {
+	log               *logger.Logger
+	schedulingService *appointment.SchedulingService
+	serviceLoader     appointment.ServiceLoader
+	slotHeldPresenter appointment.SlotHeldPresenter[R]
+	errorPresenter    appointment.ErrorPresenter[R]
+}
+
+func NewHoldSlotUseCase[R any](
+	log *logger.Logger,
+	schedulingService *appointment.SchedulingService,
+	serviceLoader appointment.ServiceLoader,
+	slotHeldPresenter appointment.SlotHeldPresenter[R],
+	errorPresenter appointment.ErrorPresenter[R],
+) *HoldSlotUseCase[R] {
+	return &HoldSlotUseCase[R]{
+		log:               log.With(sl.Component(holdSlotUseCaseName)),
+		schedulingService: schedulingService,
+		serviceLoader:     serviceLoader,
+		slotHeldPresenter: slotHeldPresenter,
+		errorPresenter:    errorPresenter,
+	}
+}
+
+func (u *HoldSlotUseCase[R]) HoldSlot(
+	ctx context.Context,
+	now time.Time,
+	appointmentDate time.Time,
+	customerIdentity appointment.CustomerIdentity,
+	serviceId appointment.ServiceId,
+) (R, error) {
+	service, err := u.serviceLoader(ctx, serviceId)
+	if err != nil {
+		u.log.Debug(ctx, "failed to load service", sl.Err(err))
+		return u.errorPresenter(err)
+	}
+	if err := u.schedulingService.HoldSlot(
+		ctx,
+		now,
+		appointmentDate,
+		customerIdentity,
+		service,
+	); err != nil {
+		u.log.Debug(ctx, "failed to hold slot", sl.Err(err))
+		return u.errorPresenter(err)
+	}
+	return u.slotHeldPresenter()
+}
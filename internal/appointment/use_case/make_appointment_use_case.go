@@ -2,6 +2,7 @@ package appointment_use_case
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
@@ -12,11 +13,18 @@ import (
 
 const makeAppointmentUseCaseName = "appointment_use_case.MakeAppointmentUseCase"
 
+// Interval of checking the key reserved by the concurrent request
+const idempotencyKeyPollInterval = 200 * time.Millisecond
+
 type MakeAppointmentUseCase[R any] struct {
 	log                      *logger.Logger
 	schedulingService        *appointment.SchedulingService
 	customerLoader           appointment.CustomerByIdentityLoader
 	serviceLoader            appointment.ServiceLoader
+	idempotencyKeyReserver   appointment.IdempotencyKeyReserver
+	idempotencyKeyReleaser   appointment.IdempotencyKeyReleaser
+	idempotentRecordSaver    appointment.IdempotentRecordSaver
+	auditEntriesSaver        appointment.AuditEntriesSaver
 	appointmentInfoPresenter appointment.AppointmentInfoPresenter[R]
 	errorPresenter           appointment.ErrorPresenter[R]
 	publisher                pubsub.Publisher[appointment.EventType]
@@ -27,6 +35,10 @@ func NewMakeAppointmentUseCase[R any](
 	schedulingService *appointment.SchedulingService,
 	customerLoader appointment.CustomerByIdentityLoader,
 	serviceLoader appointment.ServiceLoader,
+	idempotencyKeyReserver appointment.IdempotencyKeyReserver,
+	idempotencyKeyReleaser appointment.IdempotencyKeyReleaser,
+	idempotentRecordSaver appointment.IdempotentRecordSaver,
+	auditEntriesSaver appointment.AuditEntriesSaver,
 	appointmentInfoPresenter appointment.AppointmentInfoPresenter[R],
 	errorPresenter appointment.ErrorPresenter[R],
 	publisher pubsub.Publisher[appointment.EventType],
@@ -36,6 +48,10 @@ func NewMakeAppointmentUseCase[R any](
 		schedulingService:        schedulingService,
 		customerLoader:           customerLoader,
 		serviceLoader:            serviceLoader,
+		idempotencyKeyReserver:   idempotencyKeyReserver,
+		idempotencyKeyReleaser:   idempotencyKeyReleaser,
+		idempotentRecordSaver:    idempotentRecordSaver,
+		auditEntriesSaver:        auditEntriesSaver,
 		appointmentInfoPresenter: appointmentInfoPresenter,
 		errorPresenter:           errorPresenter,
 		publisher:                publisher,
@@ -48,28 +64,89 @@ func (s *MakeAppointmentUseCase[R]) CreateAppointment(
 	appointmentDate time.Time,
 	customerId appointment.CustomerIdentity,
 	serviceId appointment.ServiceId,
+	idempotencyKey appointment.IdempotencyKey,
 ) (R, error) {
+	if !idempotencyKey.IsEmpty() {
+		idempotencyKey = idempotencyKey.Scoped(customerId, serviceId, appointmentDate)
+		app, reserved, err := s.reserveIdempotencyKey(ctx, idempotencyKey)
+		if err != nil {
+			s.log.Error(ctx, "failed to reserve idempotency key", sl.Err(err))
+			return s.errorPresenter(err)
+		}
+		if !reserved {
+			service, err := s.serviceLoader(ctx, serviceId)
+			if err != nil {
+				s.log.Debug(ctx, "failed to load service", sl.Err(err))
+				return s.errorPresenter(err)
+			}
+			return s.appointmentInfoPresenter(app, service)
+		}
+	}
 	customer, err := s.customerLoader(ctx, customerId)
 	if err != nil {
 		s.log.Debug(ctx, "failed to load customer", sl.Err(err))
+		s.releaseIdempotencyKey(ctx, idempotencyKey)
 		return s.errorPresenter(err)
 	}
 	service, err := s.serviceLoader(ctx, serviceId)
 	if err != nil {
 		s.log.Debug(ctx, "failed to load service", sl.Err(err))
+		s.releaseIdempotencyKey(ctx, idempotencyKey)
 		return s.errorPresenter(err)
 	}
 	app, err := s.schedulingService.MakeAppointment(ctx, now, appointmentDate, customer, service)
 	if err != nil {
 		s.log.Debug(ctx, "failed to make appointment", sl.Err(err))
+		s.releaseIdempotencyKey(ctx, idempotencyKey)
 		return s.errorPresenter(err)
 	}
+	if !idempotencyKey.IsEmpty() {
+		if err := s.idempotentRecordSaver(ctx, idempotencyKey, app); err != nil {
+			s.log.Error(ctx, "failed to save idempotent record", sl.Err(err))
+		}
+	}
+	if err := s.auditEntriesSaver(ctx, []appointment.AuditEntry{
+		appointment.NewCreatedAuditEntry(appointment.NewCustomerAuditActor(customerId), app, now),
+	}); err != nil {
+		s.log.Error(ctx, "failed to save audit entries", sl.Err(err))
+	}
 	if err := s.publisher.Publish(appointment.NewCreated(
 		app,
 		customer,
 		service,
 	)); err != nil {
-		s.log.Debug(ctx, "failed to publish event", sl.Err(err))
+		s.log.Error(ctx, "failed to publish event", sl.Err(err))
 	}
 	return s.appointmentInfoPresenter(app, service)
 }
+
+// Waits until the concurrent request with the same key creates the
+// record or its reservation expires
+func (s *MakeAppointmentUseCase[R]) reserveIdempotencyKey(
+	ctx context.Context,
+	idempotencyKey appointment.IdempotencyKey,
+) (appointment.RecordEntity, bool, error) {
+	for {
+		app, reserved, err := s.idempotencyKeyReserver(ctx, idempotencyKey)
+		if !errors.Is(err, appointment.ErrIdempotencyKeyIsReserved) {
+			return app, reserved, err
+		}
+		select {
+		case <-ctx.Done():
+			return appointment.RecordEntity{}, false, ctx.Err()
+		case <-time.After(idempotencyKeyPollInterval):
+		}
+	}
+}
+
+func (s *MakeAppointmentUseCase[R]) releaseIdempotencyKey(
+	ctx context.Context,
+	idempotencyKey appointment.IdempotencyKey,
+) {
+	if idempotencyKey.IsEmpty() {
+		return
+	}
+	if err := s.idempotencyKeyReleaser(ctx, idempotencyKey); err != nil {
+		s.log.Error(ctx, "failed to release idempotency key", sl.Err(err))
+	}
+}
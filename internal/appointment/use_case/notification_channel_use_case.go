@@ -0,0 +1,80 @@
+package appointment_use_case
+
+import (
+	"context"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/logger"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/logger/sl"
+)
+
+const notificationChannelUseCaseName = "appointment_use_case.NotificationChannelUseCase"
+
+type NotificationChannelUseCase[R any] struct {
+	log                           *logger.Logger
+	customerLoader                appointment.CustomerByIdentityLoader
+	channelsLoader                appointment.CustomerNotificationChannelsLoader
+	channelsSaver                 appointment.CustomerNotificationChannelsSaver
+	notificationChannelsPresenter appointment.NotificationChannelsPresenter[R]
+	errorPresenter                appointment.ErrorPresenter[R]
+}
+
+func NewNotificationChannelUseCase[R any](
+	log *logger.Logger,
+	customerLoader appointment.CustomerByIdentityLoader,
+	channelsLoader appointment.CustomerNotificationChannelsLoader,
+	channelsSaver appointment.CustomerNotificationChannelsSaver,
+	notificationChannelsPresenter appointment.NotificationChannelsPresenter[R],
+	errorPresenter appointment.ErrorPresenter[R],
+) *NotificationChannelUseCase[R] {
+	return &NotificationChannelUseCase[R]{
+		log:                           log.With(sl.Component(notificationChannelUseCaseName)),
+		customerLoader:                customerLoader,
+		channelsLoader:                channelsLoader,
+		channelsSaver:                 channelsSaver,
+		notificationChannelsPresenter: notificationChannelsPresenter,
+		errorPresenter:                errorPresenter,
+	}
+}
+
+func (u *NotificationChannelUseCase[R]) NotificationChannels(
+	ctx context.Context,
+	identity appointment.CustomerIdentity,
+) (R, error) {
+	customer, err := u.customerLoader(ctx, identity)
+	if err != nil {
+		u.log.Debug(ctx, "failed to load customer", sl.Err(err))
+		return u.errorPresenter(err)
+	}
+	channels, err := u.channelsLoader(ctx, customer.Id)
+	if err != nil {
+		u.log.Error(ctx, "failed to load notification channels", sl.Err(err))
+		return u.errorPresenter(err)
+	}
+	return u.notificationChannelsPresenter(channels)
+}
+
+// Empty `channelNames` restore the default channels
+func (u *NotificationChannelUseCase[R]) SetNotificationChannels(
+	ctx context.Context,
+	identity appointment.CustomerIdentity,
+	channelNames []string,
+) (R, error) {
+	channels, err := appointment.NewNotificationChannels(channelNames)
+	if err != nil {
+		return u.errorPresenter(err)
+	}
+	customer, err := u.customerLoader(ctx, identity)
+	if err != nil {
+		u.log.Debug(ctx, "failed to load customer", sl.Err(err))
+		return u.errorPresenter(err)
+	}
+	if err := customer.SetNotificationChannels(channels); err != nil {
+		return u.errorPresenter(err)
+	}
+	if err := u.channelsSaver(ctx, customer.Id, channels); err != nil {
+		u.log.Error(ctx, "failed to save notification channels", sl.Err(err))
+		return u.errorPresenter(err)
+	}
+	return u.notificationChannelsPresenter(channels)
+}
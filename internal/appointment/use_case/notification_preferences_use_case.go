@@ -0,0 +1,132 @@
+package appointment_use_case
+
+import (
+	"context"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/logger"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/logger/sl"
+)
+
+const notificationPreferencesUseCaseName = "appointment_use_case.NotificationPreferencesUseCase"
+
+// Extends `NotificationChannelUseCase` with muted events and quiet hours
+
+type NotificationPreferencesUseCase[R any] struct {
+	log                              *logger.Logger
+	customerLoader                   appointment.CustomerByIdentityLoader
+	preferencesLoader                appointment.CustomerNotificationPreferencesLoader
+	preferencesSaver                 appointment.CustomerNotificationPreferencesSaver
+	notificationPreferencesPresenter appointment.NotificationPreferencesPresenter[R]
+	errorPresenter                   appointment.ErrorPresenter[R]
+}
+
+func NewNotificationPreferencesUseCase[R any](
+	log *logger.Logger,
+	customerLoader appointment.CustomerByIdentityLoader,
+	preferencesLoader appointment.CustomerNotificationPreferencesLoader,
+	preferencesSaver appointment.CustomerNotificationPreferencesSaver,
+	notificationPreferencesPresenter appointment.NotificationPreferencesPresenter[R],
+	errorPresenter appointment.ErrorPresenter[R],
+) *NotificationPreferencesUseCase[R] {
+	return &NotificationPreferencesUseCase[R]{
+		log:                              log.With(sl.Component(notificationPreferencesUseCaseName)),
+		customerLoader:                   customerLoader,
+		preferencesLoader:                preferencesLoader,
+		preferencesSaver:                 preferencesSaver,
+		notificationPreferencesPresenter: notificationPreferencesPresenter,
+		errorPresenter:                   errorPresenter,
+	}
+}
+
+func (u *NotificationPreferencesUseCase[R]) NotificationPreferences(
+	ctx context.Context,
+	identity appointment.CustomerIdentity,
+) (R, error) {
+	customer, err := u.customerLoader(ctx, identity)
+	if err != nil {
+		u.log.Debug(ctx, "failed to load customer", sl.Err(err))
+		return u.errorPresenter(err)
+	}
+	preferences, err := u.preferencesLoader(ctx, customer.Id)
+	if err != nil {
+		u.log.Error(ctx, "failed to load notification preferences", sl.Err(err))
+		return u.errorPresenter(err)
+	}
+	return u.notificationPreferencesPresenter(preferences)
+}
+
+// Quiet hours are disabled when `quietHoursStart` and `quietHoursEnd` are empty
+func (u *NotificationPreferencesUseCase[R]) SetNotificationPreferences(
+	ctx context.Context,
+	identity appointment.CustomerIdentity,
+	channels []string,
+	mutedEvents []string,
+	quietHoursStart string,
+	quietHoursEnd string,
+) (R, error) {
+	return u.update(ctx, identity, func(p *appointment.NotificationPreferences) error {
+		var err error
+		if p.Channels, err = appointment.NewNotificationChannels(channels); err != nil {
+			return err
+		}
+		if p.MutedEvents, err = appointment.NewNotificationEvents(mutedEvents); err != nil {
+			return err
+		}
+		p.QuietHours, err = appointment.NewQuietHours(quietHoursStart, quietHoursEnd)
+		return err
+	})
+}
+
+func (u *NotificationPreferencesUseCase[R]) SetMutedNotificationEvents(
+	ctx context.Context,
+	identity appointment.CustomerIdentity,
+	events []string,
+) (R, error) {
+	return u.update(ctx, identity, func(p *appointment.NotificationPreferences) error {
+		var err error
+		p.MutedEvents, err = appointment.NewNotificationEvents(events)
+		return err
+	})
+}
+
+func (u *NotificationPreferencesUseCase[R]) SetQuietHours(
+	ctx context.Context,
+	identity appointment.CustomerIdentity,
+	start string,
+	end string,
+) (R, error) {
+	return u.update(ctx, identity, func(p *appointment.NotificationPreferences) error {
+		var err error
+		p.QuietHours, err = appointment.NewQuietHours(start, end)
+		return err
+	})
+}
+
+func (u *NotificationPreferencesUseCase[R]) update(
+	ctx context.Context,
+	identity appointment.CustomerIdentity,
+	change func(*appointment.NotificationPreferences) error,
+) (R, error) {
+	customer, err := u.customerLoader(ctx, identity)
+	if err != nil {
+		u.log.Debug(ctx, "failed to load customer", sl.Err(err))
+		return u.errorPresenter(err)
+	}
+	preferences, err := u.preferencesLoader(ctx, customer.Id)
+	if err != nil {
+		u.log.Error(ctx, "failed to load notification preferences", sl.Err(err))
+		return u.errorPresenter(err)
+	}
+	if err := change(&preferences); err != nil {
+		return u.errorPresenter(err)
+	}
+	if err := customer.SetNotificationPreferences(preferences); err != nil {
+		return u.errorPresenter(err)
+	}
+	if err := u.preferencesSaver(ctx, customer.Id, preferences); err != nil {
+		u.log.Error(ctx, "failed to save notification preferences", sl.Err(err))
+		return u.errorPresenter(err)
+	}
+	return u.notificationPreferencesPresenter(preferences)
+}
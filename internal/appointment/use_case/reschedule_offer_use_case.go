@@ -0,0 +1,172 @@
+package appointment_use_case
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/logger"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/logger/sl"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/pubsub"
+)
+
+const rescheduleOfferUseCaseName = "appointment_use_case.RescheduleOfferUseCase"
+
+type RescheduleOfferUseCase[R any] struct {
+	log                         *logger.Logger
+	schedulingService           *appointment.SchedulingService
+	customerLoader              appointment.CustomerByIdentityLoader
+	serviceLoader               appointment.ServiceLoader
+	rescheduleOfferLoader       appointment.RescheduleOfferLoader
+	rescheduleOfferSaver        appointment.RescheduleOfferSaver
+	rescheduleOfferSwapper      appointment.RescheduleOfferStatusSwapper
+	auditEntriesSaver           appointment.AuditEntriesSaver
+	appointmentInfoPresenter    appointment.AppointmentInfoPresenter[R]
+	rescheduleDeclinedPresenter appointment.RescheduleDeclinedPresenter[R]
+	errorPresenter              appointment.ErrorPresenter[R]
+	publisher                   pubsub.Publisher[appointment.EventType]
+}
+
+func NewRescheduleOfferUseCase[R any](
+	log *logger.Logger,
+	schedulingService *appointment.SchedulingService,
+	customerLoader appointment.CustomerByIdentityLoader,
+	serviceLoader appointment.ServiceLoader,
+	rescheduleOfferLoader appointment.RescheduleOfferLoader,
+	rescheduleOfferSaver appointment.RescheduleOfferSaver,
+	rescheduleOfferSwapper appointment.RescheduleOfferStatusSwapper,
+	auditEntriesSaver appointment.AuditEntriesSaver,
+	appointmentInfoPresenter appointment.AppointmentInfoPresenter[R],
+	rescheduleDeclinedPresenter appointment.RescheduleDeclinedPresenter[R],
+	errorPresenter appointment.ErrorPresenter[R],
+	publisher pubsub.Publisher[appointment.EventType],
+) *RescheduleOfferUseCase[R] {
+	return &RescheduleOfferUseCase[R]{
+		log:                         log.With(sl.Component(rescheduleOfferUseCaseName)),
+		schedulingService:           schedulingService,
+		customerLoader:              customerLoader,
+		serviceLoader:               serviceLoader,
+		rescheduleOfferLoader:       rescheduleOfferLoader,
+		rescheduleOfferSaver:        rescheduleOfferSaver,
+		rescheduleOfferSwapper:      rescheduleOfferSwapper,
+		auditEntriesSaver:           auditEntriesSaver,
+		appointmentInfoPresenter:    appointmentInfoPresenter,
+		rescheduleDeclinedPresenter: rescheduleDeclinedPresenter,
+		errorPresenter:              errorPresenter,
+		publisher:                   publisher,
+	}
+}
+
+// returns (accepted, response, error)
+func (u *RescheduleOfferUseCase[R]) Accept(
+	ctx context.Context,
+	now time.Time,
+	identity appointment.CustomerIdentity,
+	offerId appointment.RescheduleOfferId,
+	slotIndex int,
+) (bool, R, error) {
+	offer, customer, err := u.customerOffer(ctx, identity, offerId)
+	if err != nil {
+		res, err := u.errorPresenter(err)
+		return false, res, err
+	}
+	slot, err := offer.Slot(slotIndex)
+	if err != nil {
+		res, err := u.errorPresenter(err)
+		return false, res, err
+	}
+	service, err := u.serviceLoader(ctx, offer.ServiceId)
+	if err != nil {
+		u.log.Debug(ctx, "failed to load service", sl.Err(err))
+		res, err := u.errorPresenter(err)
+		return false, res, err
+	}
+	// Claim the offer first, so a repeated accept can not make a second appointment
+	if err := u.rescheduleOfferSwapper(
+		ctx, offer.Id, appointment.RescheduleOfferPending, appointment.RescheduleOfferAccepted,
+	); err != nil {
+		u.log.Debug(ctx, "failed to claim reschedule offer", sl.Err(err))
+		res, err := u.errorPresenter(err)
+		return false, res, err
+	}
+	record, err := u.schedulingService.MakeAppointment(ctx, now, slot, customer, service)
+	if err != nil {
+		u.log.Debug(ctx, "failed to make appointment", sl.Err(err))
+		if err := u.rescheduleOfferSwapper(
+			ctx, offer.Id, appointment.RescheduleOfferAccepted, appointment.RescheduleOfferPending,
+		); err != nil {
+			u.log.Error(ctx, "failed to release reschedule offer", sl.Err(err))
+		}
+		res, err := u.errorPresenter(err)
+		return false, res, err
+	}
+	actor := appointment.NewCustomerAuditActor(identity)
+	if err := u.auditEntriesSaver(ctx, []appointment.AuditEntry{
+		appointment.NewRescheduledAuditEntry(actor, offer, record, now),
+		appointment.NewCreatedAuditEntry(actor, record, now),
+	}); err != nil {
+		u.log.Error(ctx, "failed to save audit entries", sl.Err(err))
+	}
+	if err := u.publisher.Publish(appointment.NewCreated(record, customer, service)); err != nil {
+		u.log.Error(ctx, "failed to publish event", sl.Err(err))
+	}
+	if err := offer.Accept(slot, record); err != nil {
+		res, err := u.errorPresenter(err)
+		return false, res, err
+	}
+	if err := u.rescheduleOfferSaver(ctx, offer); err != nil {
+		u.log.Error(ctx, "failed to save reschedule offer", sl.Err(err))
+	}
+	res, err := u.appointmentInfoPresenter(record, service)
+	return true, res, err
+}
+
+// returns (declined, response, error)
+func (u *RescheduleOfferUseCase[R]) Decline(
+	ctx context.Context,
+	identity appointment.CustomerIdentity,
+	offerId appointment.RescheduleOfferId,
+) (bool, R, error) {
+	offer, _, err := u.customerOffer(ctx, identity, offerId)
+	if err != nil {
+		res, err := u.errorPresenter(err)
+		return false, res, err
+	}
+	if err := offer.Decline(); err != nil {
+		res, err := u.errorPresenter(err)
+		return false, res, err
+	}
+	if err := u.rescheduleOfferSwapper(
+		ctx, offer.Id, appointment.RescheduleOfferPending, offer.Status,
+	); err != nil {
+		u.log.Debug(ctx, "failed to decline reschedule offer", sl.Err(err))
+		res, err := u.errorPresenter(err)
+		return false, res, err
+	}
+	res, err := u.rescheduleDeclinedPresenter()
+	return true, res, err
+}
+
+func (u *RescheduleOfferUseCase[R]) customerOffer(
+	ctx context.Context,
+	identity appointment.CustomerIdentity,
+	offerId appointment.RescheduleOfferId,
+) (appointment.RescheduleOffer, appointment.CustomerEntity, error) {
+	offer, err := u.rescheduleOfferLoader(ctx, offerId)
+	if err != nil {
+		u.log.Debug(ctx, "failed to load reschedule offer", sl.Err(err))
+		return appointment.RescheduleOffer{}, appointment.CustomerEntity{}, err
+	}
+	customer, err := u.customerLoader(ctx, identity)
+	if err != nil {
+		u.log.Debug(ctx, "failed to load customer", sl.Err(err))
+		return appointment.RescheduleOffer{}, appointment.CustomerEntity{}, err
+	}
+	if offer.CustomerId != customer.Id {
+		return appointment.RescheduleOffer{}, appointment.CustomerEntity{}, fmt.Errorf(
+			"%w: %s", appointment.ErrForbidden, identity,
+		)
+	}
+	return offer, customer, nil
+}
@@ -0,0 +1,60 @@
+package appointment_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/logger"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/logger/sl"
+)
+
+const rescheduleOffersUseCaseName = "appointment_use_case.RescheduleOffersUseCase"
+
+type RescheduleOffersUseCase[R any] struct {
+	log                       *logger.Logger
+	staff                     *appointment.Staff
+	recordsService            *appointment.RecordsService
+	rescheduleOffersLoader    appointment.RescheduleOffersLoader
+	rescheduleOffersPresenter appointment.RescheduleOffersPresenter[R]
+	errorPresenter            appointment.ErrorPresenter[R]
+}
+
+func NewRescheduleOffersUseCase[R any](
+	log *logger.Logger,
+	staff *appointment.Staff,
+	recordsService *appointment.RecordsService,
+	rescheduleOffersLoader appointment.RescheduleOffersLoader,
+	rescheduleOffersPresenter appointment.RescheduleOffersPresenter[R],
+	errorPresenter appointment.ErrorPresenter[R],
+) *RescheduleOffersUseCase[R] {
+	return &RescheduleOffersUseCase[R]{
+		log:                       log.With(sl.Component(rescheduleOffersUseCaseName)),
+		staff:                     staff,
+		recordsService:            recordsService,
+		rescheduleOffersLoader:    rescheduleOffersLoader,
+		rescheduleOffersPresenter: rescheduleOffersPresenter,
+		errorPresenter:            errorPresenter,
+	}
+}
+
+func (u *RescheduleOffersUseCase[R]) RescheduleOffers(
+	ctx context.Context,
+	identity appointment.CustomerIdentity,
+	since time.Time,
+) (R, error) {
+	if err := u.staff.Check(identity, appointment.ViewAppointmentsPermission); err != nil {
+		return u.errorPresenter(err)
+	}
+	offers, err := u.rescheduleOffersLoader(ctx, since)
+	if err != nil {
+		u.log.Debug(ctx, "failed to load reschedule offers", sl.Err(err))
+		return u.errorPresenter(err)
+	}
+	details, err := u.recordsService.RescheduleOffersDetails(ctx, offers)
+	if err != nil {
+		u.log.Debug(ctx, "failed to load reschedule offers details", sl.Err(err))
+		return u.errorPresenter(err)
+	}
+	return u.rescheduleOffersPresenter(details)
+}
@@ -2,11 +2,11 @@ package appointment_use_case
 
 import (
 	"context"
+	"time"
 
 	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
 	"github.com/x0k/veterinary-clinic-backend/internal/lib/logger"
 	"github.com/x0k/veterinary-clinic-backend/internal/lib/logger/sl"
-	"github.com/x0k/veterinary-clinic-backend/internal/shared"
 )
 
 const sendCustomerNotificationUseCaseName = "appointment_use_case.SendCustomerNotificationUseCase"
@@ -14,48 +14,172 @@ const sendCustomerNotificationUseCaseName = "appointment_use_case.SendCustomerNo
 type SendCustomerNotificationUseCase[R any] struct {
 	log                         *logger.Logger
 	customerLoader              appointment.CustomerByIdLoader
+	dispatcher                  *appointment.NotificationDispatcher
 	serviceLoader               appointment.ServiceLoader
-	sender                      shared.Sender[R]
+	sender                      appointment.CustomerNotificationSender[R]
+	deferrer                    appointment.CustomerNotificationDeferrer[R]
 	appointmentChangedPresenter appointment.ChangedEventPresenter[R]
+	statusTransitionPresenter   appointment.StatusTransitionPresenter[R]
+	rescheduleOfferPresenter    appointment.RescheduleOfferPresenter[R]
+	createdPresenter            appointment.CreatedEventPresenter[R]
+	canceledPresenter           appointment.CanceledEventPresenter[R]
+	reminderPresenter           appointment.ReminderPresenter[R]
 }
 
 func NewSendCustomerNotificationUseCase[R any](
 	log *logger.Logger,
 	customerLoader appointment.CustomerByIdLoader,
+	dispatcher *appointment.NotificationDispatcher,
 	serviceLoader appointment.ServiceLoader,
-	sender shared.Sender[R],
+	sender appointment.CustomerNotificationSender[R],
+	deferrer appointment.CustomerNotificationDeferrer[R],
 	appointmentChangedPresenter appointment.ChangedEventPresenter[R],
+	statusTransitionPresenter appointment.StatusTransitionPresenter[R],
+	rescheduleOfferPresenter appointment.RescheduleOfferPresenter[R],
+	createdPresenter appointment.CreatedEventPresenter[R],
+	canceledPresenter appointment.CanceledEventPresenter[R],
+	reminderPresenter appointment.ReminderPresenter[R],
 ) *SendCustomerNotificationUseCase[R] {
 	return &SendCustomerNotificationUseCase[R]{
 		log:                         log.With(sl.Component(sendCustomerNotificationUseCaseName)),
 		customerLoader:              customerLoader,
+		dispatcher:                  dispatcher,
 		serviceLoader:               serviceLoader,
 		sender:                      sender,
+		deferrer:                    deferrer,
 		appointmentChangedPresenter: appointmentChangedPresenter,
+		statusTransitionPresenter:   statusTransitionPresenter,
+		rescheduleOfferPresenter:    rescheduleOfferPresenter,
+		createdPresenter:            createdPresenter,
+		canceledPresenter:           canceledPresenter,
+		reminderPresenter:           reminderPresenter,
 	}
 }
 
 func (u *SendCustomerNotificationUseCase[R]) SendCustomerNotification(
 	ctx context.Context,
 	event appointment.ChangedEvent,
+	now time.Time,
+) error {
+	return u.send(ctx, event.Record.CustomerId, event.Record.ServiceId, appointment.ChangedNotificationEvent, now, func(customer appointment.CustomerEntity, service appointment.ServiceEntity) (R, error) {
+		return u.appointmentChangedPresenter(event, customer, service)
+	})
+}
+
+func (u *SendCustomerNotificationUseCase[R]) SendStatusTransitionNotification(
+	ctx context.Context,
+	event appointment.StatusTransitionEvent,
+	now time.Time,
 ) {
-	customer, err := u.customerLoader(ctx, event.Record.CustomerId)
+	transition := event.Transition()
+	u.send(ctx, transition.Record.CustomerId, transition.Record.ServiceId, appointment.StatusNotificationEvent, now, func(customer appointment.CustomerEntity, service appointment.ServiceEntity) (R, error) {
+		return u.statusTransitionPresenter(transition, customer, service)
+	})
+}
+
+func (u *SendCustomerNotificationUseCase[R]) SendRescheduleOffer(
+	ctx context.Context,
+	event appointment.RescheduleOfferedEvent,
+	now time.Time,
+) error {
+	return u.send(ctx, event.Offer.CustomerId, event.Offer.ServiceId, appointment.RescheduleOfferNotificationEvent, now, func(customer appointment.CustomerEntity, service appointment.ServiceEntity) (R, error) {
+		return u.rescheduleOfferPresenter(event.Offer, customer, service)
+	})
+}
+
+// Customers receive the result of their own actions in the chat,
+// so created and canceled events are confirmed by the other channels
+func (u *SendCustomerNotificationUseCase[R]) SendCreatedNotification(
+	ctx context.Context,
+	event appointment.CreatedEvent,
+	now time.Time,
+) error {
+	return u.dispatch(ctx, &event.Customer, appointment.CreatedNotificationEvent, now, func() (R, error) {
+		return u.createdPresenter(event)
+	})
+}
+
+func (u *SendCustomerNotificationUseCase[R]) SendCanceledNotification(
+	ctx context.Context,
+	event appointment.CanceledEvent,
+	now time.Time,
+) error {
+	return u.dispatch(ctx, &event.Customer, appointment.CanceledNotificationEvent, now, func() (R, error) {
+		return u.canceledPresenter(event)
+	})
+}
+
+func (u *SendCustomerNotificationUseCase[R]) SendReminder(
+	ctx context.Context,
+	record appointment.RecordEntity,
+	now time.Time,
+) error {
+	return u.send(ctx, record.CustomerId, record.ServiceId, appointment.ReminderNotificationEvent, now, func(customer appointment.CustomerEntity, service appointment.ServiceEntity) (R, error) {
+		return u.reminderPresenter(record, customer, service)
+	})
+}
+
+func (u *SendCustomerNotificationUseCase[R]) send(
+	ctx context.Context,
+	customerId appointment.CustomerId,
+	serviceId appointment.ServiceId,
+	event appointment.NotificationEvent,
+	now time.Time,
+	present func(appointment.CustomerEntity, appointment.ServiceEntity) (R, error),
+) error {
+	customer, err := u.customerLoader(ctx, customerId)
 	if err != nil {
 		u.log.Error(ctx, "failed to load customer", sl.Err(err))
-		return
+		return err
 	}
-	service, err := u.serviceLoader(ctx, event.Record.ServiceId)
+	service, err := u.serviceLoader(ctx, serviceId)
 	if err != nil {
 		u.log.Error(ctx, "failed to load service", sl.Err(err))
-		return
+		return err
+	}
+	return u.dispatch(ctx, &customer, event, now, func() (R, error) {
+		return present(customer, service)
+	})
+}
+
+// Presenters receive the customer with the loaded preferences
+func (u *SendCustomerNotificationUseCase[R]) dispatch(
+	ctx context.Context,
+	customer *appointment.CustomerEntity,
+	event appointment.NotificationEvent,
+	now time.Time,
+	present func() (R, error),
+) error {
+	delivery, ok, err := u.dispatcher.Dispatch(ctx, customer, event, now)
+	if err != nil {
+		u.log.Error(ctx, "failed to dispatch notification", sl.Err(err))
+		return err
+	}
+	if !ok {
+		return nil
 	}
-	notification, err := u.appointmentChangedPresenter(event, customer, service)
+	notification, err := present()
 	if err != nil {
+		// Rendering is deterministic, retrying will not help
 		u.log.Error(ctx, "failed to render notification", sl.Err(err))
-		return
+		return nil
+	}
+	if len(delivery.Channels) > 0 {
+		if err := u.sender(ctx, notification, delivery); err != nil {
+			u.log.Error(ctx, "failed to send notification", sl.Err(err))
+			return err
+		}
 	}
-	if err := u.sender(ctx, notification); err != nil {
-		u.log.Error(ctx, "failed to send notification", sl.Err(err))
-		return
+	if len(delivery.Deferred) > 0 {
+		if err := u.deferrer(
+			ctx,
+			notification,
+			appointment.NotificationDelivery{Channels: delivery.Deferred},
+			delivery.DeferredUntil,
+		); err != nil {
+			u.log.Error(ctx, "failed to defer notification", sl.Err(err))
+			return err
+		}
 	}
+	return nil
 }
@@ -0,0 +1,75 @@
+package appointment_use_case
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/logger"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/logger/sl"
+	"github.com/x0k/veterinary-clinic-backend/internal/shared"
+)
+
+const sendRemindersUseCaseName = "appointment_use_case.SendRemindersUseCase"
+
+type SendRemindersUseCase[R any] struct {
+	log                             *logger.Logger
+	leadTime                        time.Duration
+	interval                        time.Duration
+	appointmentsLoader              appointment.ActualAppointmentsLoader
+	sendCustomerNotificationUseCase *SendCustomerNotificationUseCase[R]
+	checkedUntilMu                  sync.Mutex
+	checkedUntil                    time.Time
+}
+
+func NewSendRemindersUseCase[R any](
+	log *logger.Logger,
+	leadTime time.Duration,
+	interval time.Duration,
+	appointmentsLoader appointment.ActualAppointmentsLoader,
+	sendCustomerNotificationUseCase *SendCustomerNotificationUseCase[R],
+) *SendRemindersUseCase[R] {
+	return &SendRemindersUseCase[R]{
+		log:                             log.With(sl.Component(sendRemindersUseCaseName)),
+		leadTime:                        leadTime,
+		interval:                        interval,
+		appointmentsLoader:              appointmentsLoader,
+		sendCustomerNotificationUseCase: sendCustomerNotificationUseCase,
+	}
+}
+
+// Reminds about appointments starting within the lead time since the previous run.
+// The progress is kept in memory, so a restart may repeat or skip one interval.
+func (u *SendRemindersUseCase[R]) SendReminders(ctx context.Context, now time.Time) {
+	u.checkedUntilMu.Lock()
+	defer u.checkedUntilMu.Unlock()
+	from := u.checkedUntil
+	if from.IsZero() {
+		from = now.Add(u.leadTime - u.interval)
+	}
+	to := now.Add(u.leadTime)
+	if !to.After(from) {
+		return
+	}
+	appointments, err := u.appointmentsLoader(ctx, now)
+	if err != nil {
+		u.log.Error(ctx, "failed to load appointments", sl.Err(err))
+		return
+	}
+	for _, app := range appointments {
+		start := shared.DateTimeToGoTime(app.DateTimePeriod.Start)
+		if !app.Status.IsCancelable() || !start.After(from) || start.After(to) {
+			continue
+		}
+		if err := u.sendCustomerNotificationUseCase.SendReminder(ctx, app, now); err != nil {
+			u.log.Error(
+				ctx, "failed to send reminder",
+				slog.String("record_id", app.Id.String()),
+				sl.Err(err),
+			)
+		}
+	}
+	u.checkedUntil = to
+}
@@ -0,0 +1,78 @@
+package appointment_use_case
+
+import (
+	"context"
+	"errors"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/logger"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/logger/sl"
+	"github.com/x0k/veterinary-clinic-backend/internal/shared"
+)
+
+const sendStaffNotificationUseCaseName = "appointment_use_case.SendStaffNotificationUseCase"
+
+type SendStaffNotificationUseCase[R any] struct {
+	log                          *logger.Logger
+	staff                        *appointment.Staff
+	sender                       shared.Sender[R]
+	appointmentCreatedPresenter  appointment.StaffEventPresenter[appointment.CreatedEvent, R]
+	appointmentCanceledPresenter appointment.StaffEventPresenter[appointment.CanceledEvent, R]
+}
+
+func NewSendStaffNotificationUseCase[R any](
+	log *logger.Logger,
+	staff *appointment.Staff,
+	sender shared.Sender[R],
+	appointmentCreatedPresenter appointment.StaffEventPresenter[appointment.CreatedEvent, R],
+	appointmentCanceledPresenter appointment.StaffEventPresenter[appointment.CanceledEvent, R],
+) *SendStaffNotificationUseCase[R] {
+	return &SendStaffNotificationUseCase[R]{
+		log:                          log.With(sl.Component(sendStaffNotificationUseCaseName)),
+		staff:                        staff,
+		sender:                       sender,
+		appointmentCreatedPresenter:  appointmentCreatedPresenter,
+		appointmentCanceledPresenter: appointmentCanceledPresenter,
+	}
+}
+
+func sendStaffNotification[E appointment.Event, R any](
+	ctx context.Context,
+	log *logger.Logger,
+	sender shared.Sender[R],
+	presenter appointment.StaffEventPresenter[E, R],
+	subscribers []appointment.StaffMember,
+	event E,
+) error {
+	var errs []error
+	for _, member := range subscribers {
+		notification, err := presenter(member, event)
+		if err != nil {
+			log.Error(ctx, "failed to render notification", sl.Err(err))
+			continue
+		}
+		if err := sender(ctx, notification); err != nil {
+			log.Error(ctx, "failed to send notification", sl.Err(err))
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (u *SendStaffNotificationUseCase[R]) SendStaffNotification(ctx context.Context, event appointment.Event) error {
+	switch e := event.(type) {
+	case appointment.CreatedEvent:
+		return sendStaffNotification(
+			ctx, u.log, u.sender, u.appointmentCreatedPresenter,
+			u.staff.Subscribers(appointment.CreatedStaffNotificationTopic, e.Record.ServiceId),
+			e,
+		)
+	case appointment.CanceledEvent:
+		return sendStaffNotification(
+			ctx, u.log, u.sender, u.appointmentCanceledPresenter,
+			u.staff.Subscribers(appointment.CanceledStaffNotificationTopic, e.Record.ServiceId),
+			e,
+		)
+	}
+	return nil
+}
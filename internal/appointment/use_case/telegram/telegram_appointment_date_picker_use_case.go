@@ -43,5 +43,10 @@ func (u *AppointmentDatePickerUseCase[R]) DatePicker(
 		u.log.Error(ctx, "failed to get a schedule", sl.Err(err))
 		return u.errorPresenter(err)
 	}
-	return u.datePickerPresenter(now, serviceId, schedule)
+	availability, err := u.schedulingService.Availability(ctx, now, schedule.Date, serviceId)
+	if err != nil {
+		u.log.Error(ctx, "failed to get availability", sl.Err(err))
+		return u.errorPresenter(err)
+	}
+	return u.datePickerPresenter(now, serviceId, schedule, availability)
 }
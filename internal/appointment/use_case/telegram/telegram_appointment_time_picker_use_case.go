@@ -37,6 +37,7 @@ func NewAppointmentTimePickerUseCase[R any](
 
 func (u *AppointmentTimePickerUseCase[R]) TimePicker(
 	ctx context.Context,
+	customerIdentity appointment.CustomerIdentity,
 	serviceId appointment.ServiceId,
 	now time.Time,
 	appointmentDate time.Time,
@@ -51,6 +52,7 @@ func (u *AppointmentTimePickerUseCase[R]) TimePicker(
 		now,
 		appointmentDate,
 		service.DurationInMinutes,
+		customerIdentity,
 	)
 	if err != nil {
 		u.log.Debug(ctx, "failed to get sampled free time slots", sl.Err(err))
@@ -58,3 +60,31 @@ func (u *AppointmentTimePickerUseCase[R]) TimePicker(
 	}
 	return u.timePickerPresenter(serviceId, appointmentDate, sampledFreeTimeSlots)
 }
+
+// returns (held, response, error)
+func (u *AppointmentTimePickerUseCase[R]) HoldTime(
+	ctx context.Context,
+	customerIdentity appointment.CustomerIdentity,
+	serviceId appointment.ServiceId,
+	now time.Time,
+	appointmentDateTime time.Time,
+) (bool, R, error) {
+	service, err := u.serviceLoader(ctx, serviceId)
+	if err != nil {
+		u.log.Debug(ctx, "failed to load service", sl.Err(err))
+		res, err := u.errorPresenter(err)
+		return false, res, err
+	}
+	if err := u.schedulingService.HoldSlot(
+		ctx,
+		now,
+		appointmentDateTime,
+		customerIdentity,
+		service,
+	); err != nil {
+		u.log.Debug(ctx, "failed to hold slot", sl.Err(err))
+		res, err := u.errorPresenter(err)
+		return false, res, err
+	}
+	return true, *new(R), nil
+}
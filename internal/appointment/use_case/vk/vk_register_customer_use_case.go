@@ -0,0 +1,74 @@
+package appointment_vk_use_case
+
+import (
+	"context"
+	"strings"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/logger"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/logger/sl"
+	"github.com/x0k/veterinary-clinic-backend/internal/shared"
+)
+
+const registerCustomerUseCaseName = "appointment_vk_use_case.RegisterCustomerUseCase"
+
+type RegisterCustomerUseCase[R any] struct {
+	log                          *logger.Logger
+	customerCreator              appointment.CustomerCreator
+	servicesLoader               appointment.ServicesLoader
+	successRegistrationPresenter appointment.SuccessRegistrationPresenter[R]
+	errorPresenter               appointment.ErrorPresenter[R]
+}
+
+func NewRegisterCustomerUseCase[R any](
+	log *logger.Logger,
+	customerCreator appointment.CustomerCreator,
+	servicesLoader appointment.ServicesLoader,
+	successRegistrationPresenter appointment.SuccessRegistrationPresenter[R],
+	errorPresenter appointment.ErrorPresenter[R],
+) *RegisterCustomerUseCase[R] {
+	return &RegisterCustomerUseCase[R]{
+		log:                          log.With(sl.Component(registerCustomerUseCaseName)),
+		customerCreator:              customerCreator,
+		servicesLoader:               servicesLoader,
+		successRegistrationPresenter: successRegistrationPresenter,
+		errorPresenter:               errorPresenter,
+	}
+}
+
+// VK does not share phone numbers with communities,
+// so the customer is reachable through the VK profile only
+func (u *RegisterCustomerUseCase[R]) RegisterCustomer(
+	ctx context.Context,
+	vkUserId shared.VkUserId,
+	vkUserFirstName string,
+	vkUserLastName string,
+) (R, error) {
+	customerIdentity, err := appointment.NewVkCustomerIdentity(vkUserId)
+	if err != nil {
+		u.log.Debug(ctx, "failed to create customer identity", sl.Err(err))
+		return u.errorPresenter(err)
+	}
+	customer := appointment.NewCustomer(
+		appointment.TemporalCustomerId,
+		customerIdentity,
+		strings.TrimSpace(vkUserFirstName+" "+vkUserLastName),
+		"",
+		"",
+	)
+	if err := u.customerCreator(ctx, &customer); err != nil {
+		u.log.Debug(ctx, "failed to create customer", sl.Err(err))
+		return u.errorPresenter(err)
+	}
+	if customer.Id == appointment.TemporalCustomerId {
+		err := appointment.ErrInvalidCustomerId
+		u.log.Debug(ctx, "failed to create customer", sl.Err(err))
+		return u.errorPresenter(err)
+	}
+	services, err := u.servicesLoader(ctx)
+	if err != nil {
+		u.log.Debug(ctx, "failed to load services", sl.Err(err))
+		return u.errorPresenter(err)
+	}
+	return u.successRegistrationPresenter(services)
+}
@@ -0,0 +1,82 @@
+package appointment_use_case
+
+import (
+	"context"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/logger"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/logger/sl"
+)
+
+const webPushSubscriptionUseCaseName = "appointment_use_case.WebPushSubscriptionUseCase"
+
+type WebPushSubscriptionUseCase[R any] struct {
+	log                          *logger.Logger
+	publicKey                    string
+	subscriptionSaver            appointment.WebPushSubscriptionSaver
+	subscriptionRemover          appointment.WebPushSubscriptionRemover
+	publicKeyPresenter           appointment.WebPushPublicKeyPresenter[R]
+	webPushSubscriptionPresenter appointment.WebPushSubscriptionPresenter[R]
+	errorPresenter               appointment.ErrorPresenter[R]
+}
+
+// Web push is disabled when `publicKey` is empty
+func NewWebPushSubscriptionUseCase[R any](
+	log *logger.Logger,
+	publicKey string,
+	subscriptionSaver appointment.WebPushSubscriptionSaver,
+	subscriptionRemover appointment.WebPushSubscriptionRemover,
+	publicKeyPresenter appointment.WebPushPublicKeyPresenter[R],
+	webPushSubscriptionPresenter appointment.WebPushSubscriptionPresenter[R],
+	errorPresenter appointment.ErrorPresenter[R],
+) *WebPushSubscriptionUseCase[R] {
+	return &WebPushSubscriptionUseCase[R]{
+		log:                          log.With(sl.Component(webPushSubscriptionUseCaseName)),
+		publicKey:                    publicKey,
+		subscriptionSaver:            subscriptionSaver,
+		subscriptionRemover:          subscriptionRemover,
+		publicKeyPresenter:           publicKeyPresenter,
+		webPushSubscriptionPresenter: webPushSubscriptionPresenter,
+		errorPresenter:               errorPresenter,
+	}
+}
+
+func (u *WebPushSubscriptionUseCase[R]) PublicKey(ctx context.Context) (R, error) {
+	if u.publicKey == "" {
+		return u.errorPresenter(appointment.ErrWebPushIsDisabled)
+	}
+	return u.publicKeyPresenter(u.publicKey)
+}
+
+func (u *WebPushSubscriptionUseCase[R]) Subscribe(
+	ctx context.Context,
+	identity appointment.CustomerIdentity,
+	endpoint string,
+	p256dh string,
+	auth string,
+) (R, error) {
+	if u.publicKey == "" {
+		return u.errorPresenter(appointment.ErrWebPushIsDisabled)
+	}
+	subscription, err := appointment.NewWebPushSubscription(endpoint, p256dh, auth)
+	if err != nil {
+		return u.errorPresenter(err)
+	}
+	if err := u.subscriptionSaver(ctx, identity, subscription); err != nil {
+		u.log.Error(ctx, "failed to save web push subscription", sl.Err(err))
+		return u.errorPresenter(err)
+	}
+	return u.webPushSubscriptionPresenter()
+}
+
+func (u *WebPushSubscriptionUseCase[R]) Unsubscribe(
+	ctx context.Context,
+	identity appointment.CustomerIdentity,
+	endpoint string,
+) (R, error) {
+	if err := u.subscriptionRemover(ctx, identity, endpoint); err != nil {
+		u.log.Error(ctx, "failed to remove web push subscription", sl.Err(err))
+		return u.errorPresenter(err)
+	}
+	return u.webPushSubscriptionPresenter()
+}
@@ -0,0 +1,78 @@
+package appointment_use_case
+
+import (
+	"context"
+	"time"
+
+	"github.com/x0k/veterinary-clinic-backend/internal/appointment"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/logger"
+	"github.com/x0k/veterinary-clinic-backend/internal/lib/logger/sl"
+	"github.com/x0k/veterinary-clinic-backend/internal/shared"
+)
+
+const webhookDeliveriesUseCaseName = "appointment_use_case.WebhookDeliveriesUseCase"
+
+type WebhookDeliveriesUseCase[R any] struct {
+	log               *logger.Logger
+	staff             *appointment.Staff
+	failedLoader      appointment.FailedWebhookDeliveriesLoader
+	failedReplayer    appointment.FailedWebhookDeliveriesReplayer
+	failedPresenter   appointment.FailedWebhookDeliveriesPresenter[R]
+	replayedPresenter appointment.WebhookDeliveriesReplayedPresenter[R]
+	errorPresenter    appointment.ErrorPresenter[R]
+}
+
+func NewWebhookDeliveriesUseCase[R any](
+	log *logger.Logger,
+	staff *appointment.Staff,
+	failedLoader appointment.FailedWebhookDeliveriesLoader,
+	failedReplayer appointment.FailedWebhookDeliveriesReplayer,
+	failedPresenter appointment.FailedWebhookDeliveriesPresenter[R],
+	replayedPresenter appointment.WebhookDeliveriesReplayedPresenter[R],
+	errorPresenter appointment.ErrorPresenter[R],
+) *WebhookDeliveriesUseCase[R] {
+	return &WebhookDeliveriesUseCase[R]{
+		log:               log.With(sl.Component(webhookDeliveriesUseCaseName)),
+		staff:             staff,
+		failedLoader:      failedLoader,
+		failedReplayer:    failedReplayer,
+		failedPresenter:   failedPresenter,
+		replayedPresenter: replayedPresenter,
+		errorPresenter:    errorPresenter,
+	}
+}
+
+func (u *WebhookDeliveriesUseCase[R]) FailedDeliveries(
+	ctx context.Context,
+	identity appointment.CustomerIdentity,
+) (R, error) {
+	if err := u.staff.Check(identity, appointment.ManageWebhooksPermission); err != nil {
+		return u.errorPresenter(err)
+	}
+	deliveries, err := u.failedLoader(ctx)
+	if err != nil {
+		u.log.Debug(ctx, "failed to load failed webhook deliveries", sl.Err(err))
+		return u.errorPresenter(err)
+	}
+	return u.failedPresenter(deliveries)
+}
+
+func (u *WebhookDeliveriesUseCase[R]) Replay(
+	ctx context.Context,
+	identity appointment.CustomerIdentity,
+	now time.Time,
+	ids []appointment.WebhookDeliveryId,
+) (R, error) {
+	if err := u.staff.Check(identity, appointment.ManageWebhooksPermission); err != nil {
+		return u.errorPresenter(err)
+	}
+	count, err := u.failedReplayer(ctx, now, ids)
+	if err != nil {
+		u.log.Debug(ctx, "failed to replay webhook deliveries", sl.Err(err))
+		return u.errorPresenter(err)
+	}
+	if count == 0 && len(ids) > 0 {
+		return u.errorPresenter(shared.ErrNotFound)
+	}
+	return u.replayedPresenter(count)
+}
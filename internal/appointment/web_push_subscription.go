@@ -0,0 +1,33 @@
+package appointment
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+var ErrInvalidWebPushSubscription = errors.New("invalid web push subscription")
+var ErrWebPushIsDisabled = errors.New("web push is disabled")
+
+// Browser push subscription of the web app, a customer
+// can be subscribed from several devices
+type WebPushSubscription struct {
+	Endpoint string
+	P256dh   string
+	Auth     string
+}
+
+func NewWebPushSubscription(endpoint string, p256dh string, auth string) (WebPushSubscription, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return WebPushSubscription{}, fmt.Errorf("%w: endpoint %q", ErrInvalidWebPushSubscription, endpoint)
+	}
+	if p256dh == "" || auth == "" {
+		return WebPushSubscription{}, fmt.Errorf("%w: missing keys", ErrInvalidWebPushSubscription)
+	}
+	return WebPushSubscription{
+		Endpoint: endpoint,
+		P256dh:   p256dh,
+		Auth:     auth,
+	}, nil
+}
@@ -0,0 +1,41 @@
+package appointment
+
+import (
+	"context"
+	"time"
+)
+
+// Serialized event with an id of its occurrence, so receivers can
+// deduplicate repeated deliveries
+type WebhookEvent struct {
+	Id      string
+	Type    EventType
+	Payload []byte
+}
+
+type WebhookEventEncoder func(OutboxEntryId, Event) (WebhookEvent, error)
+
+type WebhookSender func(ctx context.Context, url string, event WebhookEvent) error
+
+type WebhookDeliveryId int64
+
+type WebhookDelivery struct {
+	Id        WebhookDeliveryId
+	Url       string
+	Event     WebhookEvent
+	Attempts  int
+	LastError string
+	CreatedAt time.Time
+}
+
+func NewWebhookDeliveries(event WebhookEvent, urls []string, now time.Time) []WebhookDelivery {
+	deliveries := make([]WebhookDelivery, 0, len(urls))
+	for _, url := range urls {
+		deliveries = append(deliveries, WebhookDelivery{
+			Url:       url,
+			Event:     event,
+			CreatedAt: now,
+		})
+	}
+	return deliveries
+}
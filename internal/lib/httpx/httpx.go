@@ -105,9 +105,10 @@ func JSONBody[T any](
 			return dst, mr
 		}
 		log.LogAttrs(r.Context(), slog.LevelError, "failed to decode request body", slog.String("error", err.Error()))
-		mr.Status = http.StatusInternalServerError
-		mr.Text = http.StatusText(http.StatusInternalServerError)
-		return dst, mr
+		return dst, &HttpError{
+			Status: http.StatusInternalServerError,
+			Text:   http.StatusText(http.StatusInternalServerError),
+		}
 	}
 	return dst, nil
 }
@@ -0,0 +1,188 @@
+package i18n
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Primary subtag of the IETF language tag
+type Locale string
+
+// "en-US" -> "en"
+func NewLocale(tag string) Locale {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		tag = tag[:i]
+	}
+	return Locale(tag)
+}
+
+func (l Locale) String() string {
+	return string(l)
+}
+
+type PluralForm int
+
+const (
+	One PluralForm = iota
+	Few
+	Many
+	Other
+)
+
+type PluralRule func(n int) PluralForm
+
+func EnglishPluralRule(n int) PluralForm {
+	if n == 1 || n == -1 {
+		return One
+	}
+	return Other
+}
+
+func RussianPluralRule(n int) PluralForm {
+	if n < 0 {
+		n = -n
+	}
+	switch mod10, mod100 := n%10, n%100; {
+	case mod10 == 1 && mod100 != 11:
+		return One
+	case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+		return Few
+	default:
+		return Many
+	}
+}
+
+type Dictionary struct {
+	Locale     Locale
+	PluralRule PluralRule
+	// Formats of `fmt.Sprintf` by the message key
+	Messages map[string]string
+	// Formats by the plural form, the count is the first argument
+	Plurals        map[string]map[PluralForm]string
+	DateLayout     string
+	DateTimeLayout string
+	// Day of the month is the first argument, the month name is the second
+	DayMonthFormat string
+	Months         [12]string
+	Weekdays       [7]string
+}
+
+type Catalog struct {
+	fallback     *Dictionary
+	dictionaries map[Locale]*Dictionary
+	locales      []Locale
+}
+
+// Messages missing in a dictionary are taken from the `fallback` one
+func NewCatalog(fallback *Dictionary, dictionaries ...*Dictionary) *Catalog {
+	c := &Catalog{
+		fallback:     fallback,
+		dictionaries: make(map[Locale]*Dictionary, len(dictionaries)+1),
+		locales:      make([]Locale, 0, len(dictionaries)+1),
+	}
+	for _, d := range append([]*Dictionary{fallback}, dictionaries...) {
+		c.dictionaries[d.Locale] = d
+		c.locales = append(c.locales, d.Locale)
+	}
+	return c
+}
+
+func (c *Catalog) Locales() []Locale {
+	return c.locales
+}
+
+func (c *Catalog) Supports(locale Locale) bool {
+	_, ok := c.dictionaries[locale]
+	return ok
+}
+
+// Unsupported locales are replaced by the fallback one
+func (c *Catalog) Printer(locale Locale) Printer {
+	d, ok := c.dictionaries[locale]
+	if !ok {
+		d = c.fallback
+	}
+	return Printer{
+		dictionary: d,
+		fallback:   c.fallback,
+	}
+}
+
+// Zero value prints message keys
+type Printer struct {
+	dictionary *Dictionary
+	fallback   *Dictionary
+}
+
+func (p Printer) Locale() Locale {
+	if p.dictionary == nil {
+		return ""
+	}
+	return p.dictionary.Locale
+}
+
+func (p Printer) Text(key string, args ...any) string {
+	for _, d := range p.chain() {
+		if format, ok := d.Messages[key]; ok {
+			return fmt.Sprintf(format, args...)
+		}
+	}
+	return key
+}
+
+func (p Printer) Plural(key string, n int, args ...any) string {
+	args = append([]any{n}, args...)
+	for _, d := range p.chain() {
+		forms, ok := d.Plurals[key]
+		if !ok {
+			continue
+		}
+		format, ok := forms[d.PluralRule(n)]
+		if !ok {
+			format = forms[Other]
+		}
+		return fmt.Sprintf(format, args...)
+	}
+	return key
+}
+
+func (p Printer) Date(t time.Time) string {
+	return p.format(t, func(d *Dictionary) string { return d.DateLayout })
+}
+
+func (p Printer) DateTime(t time.Time) string {
+	return p.format(t, func(d *Dictionary) string { return d.DateTimeLayout })
+}
+
+func (p Printer) DayMonth(t time.Time) string {
+	if p.dictionary == nil {
+		return t.Format("2 January")
+	}
+	return fmt.Sprintf(p.dictionary.DayMonthFormat, t.Day(), p.dictionary.Months[t.Month()-1])
+}
+
+func (p Printer) Weekday(d time.Weekday) string {
+	if p.dictionary == nil {
+		return d.String()
+	}
+	return p.dictionary.Weekdays[d]
+}
+
+func (p Printer) format(t time.Time, layout func(d *Dictionary) string) string {
+	if p.dictionary == nil {
+		return t.Format(time.DateTime)
+	}
+	return t.Format(layout(p.dictionary))
+}
+
+func (p Printer) chain() []*Dictionary {
+	if p.dictionary == nil {
+		return nil
+	}
+	if p.fallback == nil || p.fallback == p.dictionary {
+		return []*Dictionary{p.dictionary}
+	}
+	return []*Dictionary{p.dictionary, p.fallback}
+}
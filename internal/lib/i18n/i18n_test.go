@@ -0,0 +1,88 @@
+package i18n
+
+import (
+	"testing"
+	"time"
+)
+
+var ru = &Dictionary{
+	Locale:     "ru",
+	PluralRule: RussianPluralRule,
+	Messages: map[string]string{
+		"hello": "Привет, %s",
+		"bye":   "Пока",
+	},
+	Plurals: map[string]map[PluralForm]string{
+		"records": {
+			One:  "%d запись",
+			Few:  "%d записи",
+			Many: "%d записей",
+		},
+	},
+	DateLayout:     "02.01.2006",
+	DateTimeLayout: "02.01.2006 15:04",
+	DayMonthFormat: "%d %s",
+	Months:         [12]string{"января", "февраля", "марта"},
+}
+
+var en = &Dictionary{
+	Locale:     "en",
+	PluralRule: EnglishPluralRule,
+	Messages: map[string]string{
+		"hello": "Hello, %s",
+	},
+	Plurals: map[string]map[PluralForm]string{
+		"records": {
+			One:   "%d record",
+			Other: "%d records",
+		},
+	},
+	DateLayout:     "01/02/2006",
+	DateTimeLayout: "01/02/2006 3:04 PM",
+	DayMonthFormat: "%[2]s %[1]d",
+	Months:         [12]string{"January", "February", "March"},
+}
+
+func TestNewLocale(t *testing.T) {
+	for tag, want := range map[string]Locale{
+		"en-US": "en",
+		"pt_BR": "pt",
+		" RU ":  "ru",
+		"":      "",
+	} {
+		if got := NewLocale(tag); got != want {
+			t.Errorf("NewLocale(%q) = %q, want %q", tag, got, want)
+		}
+	}
+}
+
+func TestPrinter(t *testing.T) {
+	c := NewCatalog(ru, en)
+	date := time.Date(2024, time.March, 5, 14, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"text", c.Printer("en").Text("hello", "Bob"), "Hello, Bob"},
+		{"fallback message", c.Printer("en").Text("bye"), "Пока"},
+		{"unsupported locale", c.Printer("de").Text("hello", "Bob"), "Привет, Bob"},
+		{"missing message", c.Printer("en").Text("missing"), "missing"},
+		{"ru one", c.Printer("ru").Plural("records", 21), "21 запись"},
+		{"ru few", c.Printer("ru").Plural("records", 3), "3 записи"},
+		{"ru many", c.Printer("ru").Plural("records", 12), "12 записей"},
+		{"en one", c.Printer("en").Plural("records", 1), "1 record"},
+		{"en other", c.Printer("en").Plural("records", 0), "0 records"},
+		{"ru date time", c.Printer("ru").DateTime(date), "05.03.2024 14:30"},
+		{"en date time", c.Printer("en").DateTime(date), "03/05/2024 2:30 PM"},
+		{"ru day month", c.Printer("ru").DayMonth(date), "5 марта"},
+		{"en day month", c.Printer("en").DayMonth(date), "March 5"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got %q, want %q", tt.got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+// Minimal iCalendar (RFC 5545) writer for single event invitations
+package ics
+
+import (
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+type Method string
+
+const (
+	PublishMethod Method = "PUBLISH"
+	CancelMethod  Method = "CANCEL"
+)
+
+type Status string
+
+const (
+	ConfirmedStatus Status = "CONFIRMED"
+	TentativeStatus Status = "TENTATIVE"
+	CancelledStatus Status = "CANCELLED"
+)
+
+const ContentType = "text/calendar; charset=utf-8"
+
+const (
+	dateTimeFormat = "20060102T150405Z"
+	maxLineOctets  = 75
+)
+
+type Event struct {
+	Uid         string
+	Sequence    int64
+	Stamp       time.Time
+	Start       time.Time
+	End         time.Time
+	Summary     string
+	Description string
+	Location    string
+	Status      Status
+}
+
+type Calendar struct {
+	ProdId string
+	Method Method
+	Events []Event
+}
+
+func (c Calendar) String() string {
+	w := writer{}
+	w.line("BEGIN", "VCALENDAR")
+	w.line("VERSION", "2.0")
+	w.line("PRODID", c.ProdId)
+	w.line("CALSCALE", "GREGORIAN")
+	if c.Method != "" {
+		w.line("METHOD", string(c.Method))
+	}
+	for _, e := range c.Events {
+		w.line("BEGIN", "VEVENT")
+		w.line("UID", e.Uid)
+		w.line("SEQUENCE", strconv.FormatInt(e.Sequence, 10))
+		w.line("DTSTAMP", e.Stamp.UTC().Format(dateTimeFormat))
+		w.line("DTSTART", e.Start.UTC().Format(dateTimeFormat))
+		w.line("DTEND", e.End.UTC().Format(dateTimeFormat))
+		w.line("SUMMARY", escape(e.Summary))
+		if e.Description != "" {
+			w.line("DESCRIPTION", escape(e.Description))
+		}
+		if e.Location != "" {
+			w.line("LOCATION", escape(e.Location))
+		}
+		if e.Status != "" {
+			w.line("STATUS", string(e.Status))
+		}
+		w.line("END", "VEVENT")
+	}
+	w.line("END", "VCALENDAR")
+	return w.String()
+}
+
+var escaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+)
+
+func escape(text string) string {
+	return escaper.Replace(text)
+}
+
+type writer struct {
+	strings.Builder
+}
+
+// Lines longer than 75 octets are folded without splitting UTF-8 sequences
+func (w *writer) line(name string, value string) {
+	line := name + ":" + value
+	limit := maxLineOctets
+	for len(line) > limit {
+		i := limit
+		for i > 0 && !utf8.RuneStart(line[i]) {
+			i--
+		}
+		w.WriteString(line[:i])
+		w.WriteString("\r\n ")
+		line = line[i:]
+		// The leading space of the continuation counts towards the limit
+		limit = maxLineOctets - 1
+	}
+	w.WriteString(line)
+	w.WriteString("\r\n")
+}
@@ -66,6 +66,12 @@ func Select(properties notionapi.Properties, selectKey string) string {
 	return properties[selectKey].(*notionapi.SelectProperty).Select.Name
 }
 
+// Missing property is treated as unchecked
+func Checkbox(properties notionapi.Properties, checkboxKey string) bool {
+	p, ok := properties[checkboxKey].(*notionapi.CheckboxProperty)
+	return ok && p.Checkbox
+}
+
 func CreatedTime(properties notionapi.Properties, createdTimeKey string) time.Time {
 	return properties[createdTimeKey].(*notionapi.CreatedTimeProperty).CreatedTime
 }
@@ -0,0 +1,480 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+type schema struct {
+	Ref         string             `json:"$ref"`
+	Type        string             `json:"type"`
+	Format      string             `json:"format"`
+	Nullable    bool               `json:"nullable"`
+	Description string             `json:"description"`
+	Items       *schema            `json:"items"`
+	Properties  map[string]*schema `json:"properties"`
+	Required    []string           `json:"required"`
+}
+
+type mediaType struct {
+	Schema *schema `json:"schema"`
+}
+
+type response struct {
+	Ref     string               `json:"$ref"`
+	Content map[string]mediaType `json:"content"`
+}
+
+type parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Required    bool    `json:"required"`
+	Description string  `json:"description"`
+	Schema      *schema `json:"schema"`
+}
+
+type requestBody struct {
+	Content map[string]mediaType `json:"content"`
+}
+
+type operation struct {
+	OperationId string              `json:"operationId"`
+	Summary     string              `json:"summary"`
+	Parameters  []parameter         `json:"parameters"`
+	RequestBody *requestBody        `json:"requestBody"`
+	Responses   map[string]response `json:"responses"`
+}
+
+type document struct {
+	Paths      map[string]map[string]*operation `json:"paths"`
+	Components struct {
+		Schemas map[string]*schema `json:"schemas"`
+	} `json:"components"`
+}
+
+const jsonMediaType = "application/json"
+
+var methods = []string{
+	http.MethodGet,
+	http.MethodPut,
+	http.MethodPost,
+	http.MethodPatch,
+	http.MethodDelete,
+}
+
+type clientGenerator struct {
+	types     bytes.Buffer
+	usesTime  bool
+	generated map[string]struct{}
+}
+
+// Generates the source of a Go client for the JSON API described by the
+// OpenAPI document, every operation becomes a method of the `Client`
+func GenerateClient(spec []byte, packageName string) ([]byte, error) {
+	const op = "openapi.GenerateClient"
+	var doc document
+	if err := json.Unmarshal(spec, &doc); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	g := &clientGenerator{
+		generated: make(map[string]struct{}),
+	}
+	for _, name := range sortedKeys(doc.Components.Schemas) {
+		if err := g.namedType(name, doc.Components.Schemas[name]); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	var operations bytes.Buffer
+	for _, path := range sortedKeys(doc.Paths) {
+		for _, method := range methods {
+			o, ok := doc.Paths[path][strings.ToLower(method)]
+			if !ok {
+				continue
+			}
+			if err := g.operation(&operations, method, path, o); err != nil {
+				return nil, fmt.Errorf("%s: %s %s: %w", op, method, path, err)
+			}
+		}
+	}
+
+	var src bytes.Buffer
+	fmt.Fprintf(&src, "// Code generated by cmd/apiclient from the OpenAPI document. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&src, "package %s\n\n", packageName)
+	src.WriteString("import (\n\"bytes\"\n\"context\"\n\"encoding/json\"\n\"fmt\"\n\"io\"\n\"net/http\"\n\"net/url\"\n")
+	if g.usesTime {
+		src.WriteString("\"time\"\n")
+	}
+	src.WriteString(")\n\n")
+	src.WriteString(clientRuntime)
+	src.Write(g.types.Bytes())
+	src.Write(operations.Bytes())
+	formatted, err := format.Source(src.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return formatted, nil
+}
+
+func (g *clientGenerator) namedType(name string, s *schema) error {
+	if _, ok := g.generated[name]; ok {
+		return fmt.Errorf("duplicate type %q", name)
+	}
+	g.generated[name] = struct{}{}
+	if s.Type != "object" || len(s.Properties) == 0 {
+		t, err := g.goType(name, s, true)
+		if err != nil {
+			return err
+		}
+		writeComment(&g.types, s.Description)
+		fmt.Fprintf(&g.types, "type %s %s\n\n", name, t)
+		return nil
+	}
+	var fields bytes.Buffer
+	for _, property := range sortedKeys(s.Properties) {
+		p := s.Properties[property]
+		required := slices.Contains(s.Required, property)
+		t, err := g.goType(name+exported(property), p, required && !p.Nullable)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", name, property, err)
+		}
+		tag := property
+		if !required {
+			tag += ",omitempty"
+		}
+		writeComment(&fields, p.Description)
+		fmt.Fprintf(&fields, "%s %s `json:%q`\n", exported(property), t, tag)
+	}
+	writeComment(&g.types, s.Description)
+	fmt.Fprintf(&g.types, "type %s struct {\n%s}\n\n", name, fields.Bytes())
+	return nil
+}
+
+// Inline objects become named types prefixed with the name of the owner
+func (g *clientGenerator) goType(name string, s *schema, required bool) (string, error) {
+	optional := func(t string) string {
+		if required {
+			return t
+		}
+		return "*" + t
+	}
+	if s.Ref != "" {
+		ref, err := refName(s.Ref, "#/components/schemas/")
+		if err != nil {
+			return "", err
+		}
+		return optional(ref), nil
+	}
+	switch s.Type {
+	case "string":
+		if s.Format == "date-time" {
+			g.usesTime = true
+			return optional("time.Time"), nil
+		}
+		return optional("string"), nil
+	case "integer":
+		if s.Format == "int64" {
+			return optional("int64"), nil
+		}
+		return optional("int"), nil
+	case "number":
+		return optional("float64"), nil
+	case "boolean":
+		return optional("bool"), nil
+	case "array":
+		if s.Items == nil {
+			return "", fmt.Errorf("array without items")
+		}
+		item, err := g.goType(name+"Item", s.Items, true)
+		if err != nil {
+			return "", err
+		}
+		return "[]" + item, nil
+	case "object":
+		if len(s.Properties) == 0 {
+			return "json.RawMessage", nil
+		}
+		// The owner property already carries the description
+		inline := *s
+		inline.Description = ""
+		if err := g.namedType(name, &inline); err != nil {
+			return "", err
+		}
+		return optional(name), nil
+	}
+	return "", fmt.Errorf("unsupported schema type %q", s.Type)
+}
+
+func (g *clientGenerator) operation(w *bytes.Buffer, method string, path string, o *operation) error {
+	if o.OperationId == "" {
+		return fmt.Errorf("operation id is required")
+	}
+	name := exported(o.OperationId)
+	var (
+		args    []string
+		query   bytes.Buffer
+		headers bytes.Buffer
+		docs    bytes.Buffer
+	)
+	args = append(args, "ctx context.Context")
+	urlExpr := fmt.Sprintf("%q", path)
+	for _, p := range o.Parameters {
+		if p.Schema == nil {
+			return fmt.Errorf("parameter %q without schema", p.Name)
+		}
+		arg := unexported(p.Name)
+		t, err := g.goType(name+exported(arg), p.Schema, true)
+		if err != nil {
+			return fmt.Errorf("parameter %q: %w", p.Name, err)
+		}
+		args = append(args, arg+" "+t)
+		if p.Description != "" {
+			fmt.Fprintf(&docs, "//   - %s: %s\n", arg, p.Description)
+		}
+		value := arg
+		isSet := arg + ` != ""`
+		switch t {
+		case "time.Time":
+			value = arg + ".Format(time.RFC3339)"
+			isSet = "!" + arg + ".IsZero()"
+		case "string":
+		default:
+			value = fmt.Sprintf("fmt.Sprint(%s)", arg)
+			isSet = "true"
+		}
+		switch p.In {
+		case "path":
+			placeholder := "{" + p.Name + "}"
+			if !strings.Contains(path, placeholder) {
+				return fmt.Errorf("path parameter %q is not in the path", p.Name)
+			}
+			urlExpr = strings.Replace(urlExpr, placeholder, fmt.Sprintf(`" + url.PathEscape(%s) + "`, value), 1)
+		case "query":
+			if p.Required {
+				fmt.Fprintf(&query, "query.Set(%q, %s)\n", p.Name, value)
+			} else {
+				fmt.Fprintf(&query, "if %s {\nquery.Set(%q, %s)\n}\n", isSet, p.Name, value)
+			}
+		case "header":
+			if p.Required {
+				fmt.Fprintf(&headers, "req.Header.Set(%q, %s)\n", p.Name, value)
+			} else {
+				fmt.Fprintf(&headers, "if %s {\nreq.Header.Set(%q, %s)\n}\n", isSet, p.Name, value)
+			}
+		default:
+			return fmt.Errorf("unsupported parameter location %q", p.In)
+		}
+	}
+	urlExpr = strings.TrimSuffix(urlExpr, ` + ""`)
+
+	body := "nil"
+	if o.RequestBody != nil {
+		m, ok := o.RequestBody.Content[jsonMediaType]
+		if !ok || m.Schema == nil {
+			return fmt.Errorf("request body is not JSON")
+		}
+		t, err := g.goType(name+"Body", m.Schema, true)
+		if err != nil {
+			return fmt.Errorf("request body: %w", err)
+		}
+		args = append(args, "body "+t)
+		body = "body"
+	}
+
+	result, err := g.successType(name, o)
+	if err != nil {
+		return err
+	}
+
+	summary := o.Summary
+	if summary == "" {
+		summary = method + " " + path
+	}
+	fmt.Fprintf(w, "// %s\n", summary)
+	if docs.Len() > 0 {
+		w.WriteString("//\n")
+		w.Write(docs.Bytes())
+	}
+	if result == "" {
+		fmt.Fprintf(w, "func (c *Client) %s(%s) error {\n", name, strings.Join(args, ", "))
+	} else {
+		fmt.Fprintf(w, "func (c *Client) %s(%s) (%s, error) {\n", name, strings.Join(args, ", "), result)
+		fmt.Fprintf(w, "var result %s\n", result)
+	}
+	w.WriteString("query := url.Values{}\n")
+	w.Write(query.Bytes())
+	fmt.Fprintf(w, "req, err := c.newRequest(ctx, %q, %s, query, %s)\n", method, urlExpr, body)
+	if result == "" {
+		w.WriteString("if err != nil {\nreturn err\n}\n")
+		w.Write(headers.Bytes())
+		w.WriteString("return c.do(req, nil)\n}\n\n")
+	} else {
+		w.WriteString("if err != nil {\nreturn result, err\n}\n")
+		w.Write(headers.Bytes())
+		w.WriteString("err = c.do(req, &result)\nreturn result, err\n}\n\n")
+	}
+	return nil
+}
+
+// Returns an empty string for operations without a response body
+func (g *clientGenerator) successType(name string, o *operation) (string, error) {
+	for _, status := range sortedKeys(o.Responses) {
+		if !strings.HasPrefix(status, "2") {
+			continue
+		}
+		r := o.Responses[status]
+		if r.Ref != "" {
+			return "", fmt.Errorf("success response references are not supported")
+		}
+		m, ok := r.Content[jsonMediaType]
+		if !ok || m.Schema == nil {
+			return "", nil
+		}
+		t, err := g.goType(name+"Result", m.Schema, true)
+		if err != nil {
+			return "", fmt.Errorf("response %s: %w", status, err)
+		}
+		return t, nil
+	}
+	return "", fmt.Errorf("no success response")
+}
+
+func refName(ref string, prefix string) (string, error) {
+	name, ok := strings.CutPrefix(ref, prefix)
+	if !ok {
+		return "", fmt.Errorf("unsupported reference %q", ref)
+	}
+	return name, nil
+}
+
+func exported(name string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range name {
+		if r == '-' || r == '_' {
+			upper = true
+			continue
+		}
+		if upper {
+			b.WriteString(strings.ToUpper(string(r)))
+			upper = false
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func unexported(name string) string {
+	e := exported(name)
+	if e == "" {
+		return e
+	}
+	return strings.ToLower(e[:1]) + e[1:]
+}
+
+func writeComment(w *bytes.Buffer, text string) {
+	if text == "" {
+		return
+	}
+	fmt.Fprintf(w, "// %s\n", strings.ReplaceAll(text, "\n", "\n// "))
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+const clientRuntime = `// Error response of the API
+type ResponseError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *ResponseError) Error() string {
+	if e.Message == "" {
+		return http.StatusText(e.StatusCode)
+	}
+	return fmt.Sprintf("%s: %s", http.StatusText(e.StatusCode), e.Message)
+}
+
+type Client struct {
+	baseUrl       string
+	httpClient    *http.Client
+	authorization string
+}
+
+// ` + "`baseUrl`" + ` is the origin of the server without the trailing slash
+func NewClient(baseUrl string, httpClient *http.Client) *Client {
+	return &Client{
+		baseUrl:    baseUrl,
+		httpClient: httpClient,
+	}
+}
+
+// Returns a copy of the client which sends the ` + "`Authorization`" + ` header,
+// e.g. ` + "`tma <initData>`" + ` or ` + "`vk <launch params>`" + `
+func (c *Client) WithAuthorization(authorization string) *Client {
+	clone := *c
+	clone.authorization = authorization
+	return &clone
+}
+
+func (c *Client) newRequest(ctx context.Context, method string, path string, query url.Values, body any) (*http.Request, error) {
+	u := c.baseUrl + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, reader)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.authorization != "" {
+		req.Header.Set("Authorization", c.authorization)
+	}
+	return req, nil
+}
+
+func (c *Client) do(req *http.Request, result any) error {
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		responseErr := &ResponseError{StatusCode: res.StatusCode}
+		var body struct {
+			Error string ` + "`json:\"error\"`" + `
+		}
+		if err := json.NewDecoder(res.Body).Decode(&body); err == nil {
+			responseErr.Message = body.Error
+		}
+		return responseErr
+	}
+	if result == nil {
+		return nil
+	}
+	return json.NewDecoder(res.Body).Decode(result)
+}
+
+`
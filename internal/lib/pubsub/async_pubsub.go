@@ -0,0 +1,308 @@
+package pubsub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+var ErrClosed = errors.New("pubsub is closed")
+
+type OverflowPolicy string
+
+const (
+	BlockOverflowPolicy      OverflowPolicy = "block"
+	DropOldestOverflowPolicy OverflowPolicy = "drop_oldest"
+	SpillOverflowPolicy      OverflowPolicy = "spill"
+)
+
+var ErrUnknownOverflowPolicy = errors.New("unknown overflow policy")
+
+type AsyncOptions[T EventType] struct {
+	QueueSize      int
+	OverflowPolicy OverflowPolicy
+	// Required for the spill overflow policy, called once per subscriber
+	SpillFactory func() (Spill[T], error)
+	// Receives errors which can not be returned to the caller, optional
+	OnError func(error)
+}
+
+type SubscriberMetrics[T EventType] struct {
+	EventType T      `json:"eventType"`
+	Published uint64 `json:"published"`
+	Delivered uint64 `json:"delivered"`
+	Dropped   uint64 `json:"dropped"`
+	Spilled   uint64 `json:"spilled"`
+	Queued    int    `json:"queued"`
+}
+
+type subscriber[T EventType] struct {
+	handler  Handler[T]
+	policy   OverflowPolicy
+	capacity int
+	spill    Spill[T]
+	mu       sync.Mutex
+	notEmpty *sync.Cond
+	notFull  *sync.Cond
+	queue    []Event[T]
+	spilled  int
+	draining bool
+	stopped  bool
+	done     chan struct{}
+	metrics  SubscriberMetrics[T]
+}
+
+func newSubscriber[T EventType](
+	handler Handler[T],
+	policy OverflowPolicy,
+	capacity int,
+	spill Spill[T],
+) *subscriber[T] {
+	s := &subscriber[T]{
+		handler:  handler,
+		policy:   policy,
+		capacity: capacity,
+		spill:    spill,
+		queue:    make([]Event[T], 0, capacity),
+		done:     make(chan struct{}),
+		metrics: SubscriberMetrics[T]{
+			EventType: handler.Type(),
+		},
+	}
+	s.notEmpty = sync.NewCond(&s.mu)
+	s.notFull = sync.NewCond(&s.mu)
+	go s.run()
+	return s
+}
+
+func (s *subscriber[T]) enqueue(event Event[T]) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.draining || s.stopped {
+		return ErrClosed
+	}
+	s.metrics.Published++
+	// Spilled events are older than any new one
+	if s.spilled > 0 || len(s.queue) >= s.capacity {
+		switch s.policy {
+		case BlockOverflowPolicy:
+			for len(s.queue) >= s.capacity && !s.stopped {
+				s.notFull.Wait()
+			}
+			if s.stopped {
+				s.metrics.Dropped++
+				return ErrClosed
+			}
+		case DropOldestOverflowPolicy:
+			s.queue = s.queue[1:]
+			s.metrics.Dropped++
+		case SpillOverflowPolicy:
+			if err := s.spill.Push(event); err != nil {
+				s.metrics.Dropped++
+				return fmt.Errorf("failed to spill event %v: %w", event.Type(), err)
+			}
+			s.spilled++
+			s.metrics.Spilled++
+			s.notEmpty.Signal()
+			return nil
+		}
+	}
+	s.queue = append(s.queue, event)
+	s.notEmpty.Signal()
+	return nil
+}
+
+func (s *subscriber[T]) next() (Event[T], bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for {
+		for len(s.queue) == 0 && s.spilled == 0 && !s.draining && !s.stopped {
+			s.notEmpty.Wait()
+		}
+		if s.stopped || (s.draining && len(s.queue) == 0 && s.spilled == 0) {
+			return nil, false
+		}
+		if len(s.queue) > 0 {
+			event := s.queue[0]
+			s.queue[0] = nil
+			s.queue = s.queue[1:]
+			s.notFull.Signal()
+			return event, true
+		}
+		s.spilled--
+		event, err := s.spill.Pop()
+		if err == nil {
+			return event, true
+		}
+		s.metrics.Dropped++
+		if errors.Is(err, ErrSpillIsEmpty) {
+			s.spilled = 0
+		}
+	}
+}
+
+func (s *subscriber[T]) run() {
+	defer close(s.done)
+	for {
+		event, ok := s.next()
+		if !ok {
+			return
+		}
+		s.handler.Handle(event)
+		s.mu.Lock()
+		s.metrics.Delivered++
+		s.mu.Unlock()
+	}
+}
+
+func (s *subscriber[T]) drain() {
+	s.mu.Lock()
+	s.draining = true
+	s.notEmpty.Broadcast()
+	s.mu.Unlock()
+}
+
+func (s *subscriber[T]) stop() {
+	s.mu.Lock()
+	s.stopped = true
+	s.metrics.Dropped += uint64(len(s.queue) + s.spilled)
+	s.queue = nil
+	s.spilled = 0
+	s.notEmpty.Broadcast()
+	s.notFull.Broadcast()
+	s.mu.Unlock()
+}
+
+func (s *subscriber[T]) close() error {
+	if s.spill == nil {
+		return nil
+	}
+	return s.spill.Close()
+}
+
+func (s *subscriber[T]) snapshot() SubscriberMetrics[T] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	m := s.metrics
+	m.Queued = len(s.queue) + s.spilled
+	return m
+}
+
+// Delivers events to each handler from its own bounded queue,
+// so slow handlers do not block publishers
+type asyncPubSub[T EventType] struct {
+	options     AsyncOptions[T]
+	mu          sync.RWMutex
+	closed      bool
+	subscribers map[Handler[T]]*subscriber[T]
+}
+
+func NewAsync[T EventType](options AsyncOptions[T]) (*asyncPubSub[T], error) {
+	switch options.OverflowPolicy {
+	case BlockOverflowPolicy, DropOldestOverflowPolicy:
+	case SpillOverflowPolicy:
+		if options.SpillFactory == nil {
+			return nil, errors.New("spill factory is required for the spill overflow policy")
+		}
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownOverflowPolicy, options.OverflowPolicy)
+	}
+	if options.QueueSize < 1 {
+		return nil, fmt.Errorf("invalid queue size: %d", options.QueueSize)
+	}
+	return &asyncPubSub[T]{
+		options:     options,
+		subscribers: map[Handler[T]]*subscriber[T]{},
+	}, nil
+}
+
+// Falls back to the block policy when a spill can not be created,
+// the error is reported to `OnError`
+func (p *asyncPubSub[T]) AddHandler(h Handler[T]) func() {
+	var spill Spill[T]
+	policy := p.options.OverflowPolicy
+	if policy == SpillOverflowPolicy {
+		var err error
+		if spill, err = p.options.SpillFactory(); err != nil {
+			policy = BlockOverflowPolicy
+			p.reportError(fmt.Errorf("failed to create spill for %v handler, falling back to the block policy: %w", h.Type(), err))
+		}
+	}
+	s := newSubscriber(h, policy, p.options.QueueSize, spill)
+	p.mu.Lock()
+	p.subscribers[h] = s
+	p.mu.Unlock()
+	return func() {
+		p.mu.Lock()
+		delete(p.subscribers, h)
+		p.mu.Unlock()
+		s.stop()
+		<-s.done
+		s.close()
+	}
+}
+
+func (p *asyncPubSub[T]) reportError(err error) {
+	if p.options.OnError != nil {
+		p.options.OnError(err)
+	}
+}
+
+func (p *asyncPubSub[T]) Publish(event Event[T]) error {
+	p.mu.RLock()
+	if p.closed {
+		p.mu.RUnlock()
+		return ErrClosed
+	}
+	subscribers := make([]*subscriber[T], 0, len(p.subscribers))
+	for _, s := range p.subscribers {
+		if s.handler.Type() == event.Type() {
+			subscribers = append(subscribers, s)
+		}
+	}
+	p.mu.RUnlock()
+	var errs []error
+	for _, s := range subscribers {
+		if err := s.enqueue(event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Rejects new events and waits until queued ones are delivered
+func (p *asyncPubSub[T]) Drain(ctx context.Context) error {
+	p.mu.Lock()
+	p.closed = true
+	subscribers := make([]*subscriber[T], 0, len(p.subscribers))
+	for _, s := range p.subscribers {
+		subscribers = append(subscribers, s)
+	}
+	p.mu.Unlock()
+	for _, s := range subscribers {
+		s.drain()
+	}
+	for _, s := range subscribers {
+		select {
+		case <-s.done:
+		case <-ctx.Done():
+			var queued int
+			for _, s := range subscribers {
+				queued += s.snapshot().Queued
+			}
+			return fmt.Errorf("%d events were not delivered: %w", queued, ctx.Err())
+		}
+	}
+	return nil
+}
+
+func (p *asyncPubSub[T]) Metrics() []SubscriberMetrics[T] {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	metrics := make([]SubscriberMetrics[T], 0, len(p.subscribers))
+	for _, s := range p.subscribers {
+		metrics = append(metrics, s.snapshot())
+	}
+	return metrics
+}
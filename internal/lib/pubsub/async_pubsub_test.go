@@ -0,0 +1,266 @@
+package pubsub
+
+import (
+	"context"
+	"encoding/gob"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+const testEventType = 1
+
+type testEvent struct {
+	N int
+}
+
+func (testEvent) Type() int {
+	return testEventType
+}
+
+func init() {
+	gob.Register(testEvent{})
+}
+
+// Holds every event until the gate is opened
+type testHandler struct {
+	gate     chan struct{}
+	started  chan int
+	received chan int
+}
+
+func newTestHandler() *testHandler {
+	return &testHandler{
+		gate:     make(chan struct{}),
+		started:  make(chan int, 100),
+		received: make(chan int, 100),
+	}
+}
+
+func (h *testHandler) Type() int {
+	return testEventType
+}
+
+func (h *testHandler) Handle(event Event[int]) {
+	n := event.(testEvent).N
+	h.started <- n
+	<-h.gate
+	h.received <- n
+}
+
+func (h *testHandler) open() {
+	close(h.gate)
+}
+
+func waitStarted(t *testing.T, h *testHandler, n int) {
+	t.Helper()
+	select {
+	case got := <-h.started:
+		if got != n {
+			t.Fatalf("expected handling of %d, got %d", n, got)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("event %d was not handled", n)
+	}
+}
+
+func waitReceived(t *testing.T, h *testHandler, count int) []int {
+	t.Helper()
+	received := make([]int, 0, count)
+	for len(received) < count {
+		select {
+		case n := <-h.received:
+			received = append(received, n)
+		case <-time.After(time.Second):
+			t.Fatalf("expected %d events, got %v", count, received)
+		}
+	}
+	return received
+}
+
+func newTestAsync(t *testing.T, options AsyncOptions[int]) *asyncPubSub[int] {
+	t.Helper()
+	p, err := NewAsync(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func publish(t *testing.T, p *asyncPubSub[int], numbers ...int) {
+	t.Helper()
+	for _, n := range numbers {
+		if err := p.Publish(testEvent{N: n}); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestAsyncPubSubBlockPolicy(t *testing.T) {
+	p := newTestAsync(t, AsyncOptions[int]{
+		QueueSize:      1,
+		OverflowPolicy: BlockOverflowPolicy,
+	})
+	h := newTestHandler()
+	defer p.AddHandler(h)()
+
+	publish(t, p, 1)
+	waitStarted(t, h, 1)
+	publish(t, p, 2)
+
+	published := make(chan error, 1)
+	go func() {
+		published <- p.Publish(testEvent{N: 3})
+	}()
+	select {
+	case err := <-published:
+		t.Fatalf("expected the publisher to be blocked, got %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	h.open()
+	select {
+	case err := <-published:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("the publisher was not released")
+	}
+	if received := waitReceived(t, h, 3); !slices.Equal(received, []int{1, 2, 3}) {
+		t.Fatalf("unexpected events: %v", received)
+	}
+}
+
+func TestAsyncPubSubDropOldestPolicy(t *testing.T) {
+	p := newTestAsync(t, AsyncOptions[int]{
+		QueueSize:      2,
+		OverflowPolicy: DropOldestOverflowPolicy,
+	})
+	h := newTestHandler()
+	defer p.AddHandler(h)()
+
+	publish(t, p, 1)
+	waitStarted(t, h, 1)
+	publish(t, p, 2, 3, 4, 5)
+	h.open()
+
+	if received := waitReceived(t, h, 3); !slices.Equal(received, []int{1, 4, 5}) {
+		t.Fatalf("unexpected events: %v", received)
+	}
+	if err := p.Drain(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	m := p.Metrics()
+	if len(m) != 1 || m[0].Published != 5 || m[0].Delivered != 3 || m[0].Dropped != 2 {
+		t.Fatalf("unexpected metrics: %+v", m)
+	}
+}
+
+func TestAsyncPubSubSpillPolicyKeepsOrder(t *testing.T) {
+	dir := t.TempDir()
+	p := newTestAsync(t, AsyncOptions[int]{
+		QueueSize:      1,
+		OverflowPolicy: SpillOverflowPolicy,
+		SpillFactory: func() (Spill[int], error) {
+			return NewFileSpill(dir, GobCodec[int]{})
+		},
+	})
+	h := newTestHandler()
+	defer p.AddHandler(h)()
+
+	publish(t, p, 1)
+	waitStarted(t, h, 1)
+	publish(t, p, 2, 3, 4, 5)
+	m := p.Metrics()
+	if len(m) != 1 || m[0].Spilled != 3 || m[0].Queued != 4 {
+		t.Fatalf("unexpected metrics: %+v", m)
+	}
+	h.open()
+
+	if received := waitReceived(t, h, 5); !slices.Equal(received, []int{1, 2, 3, 4, 5}) {
+		t.Fatalf("unexpected events: %v", received)
+	}
+	// Spilled events are older than new ones
+	publish(t, p, 6)
+	if received := waitReceived(t, h, 1); received[0] != 6 {
+		t.Fatalf("unexpected events: %v", received)
+	}
+}
+
+func TestAsyncPubSubReportsSpillFactoryError(t *testing.T) {
+	spillErr := errors.New("no space left")
+	var reported []error
+	p := newTestAsync(t, AsyncOptions[int]{
+		QueueSize:      1,
+		OverflowPolicy: SpillOverflowPolicy,
+		SpillFactory: func() (Spill[int], error) {
+			return nil, spillErr
+		},
+		OnError: func(err error) {
+			reported = append(reported, err)
+		},
+	})
+	h := newTestHandler()
+	defer p.AddHandler(h)()
+
+	if len(reported) != 1 || !errors.Is(reported[0], spillErr) {
+		t.Fatalf("expected the spill error to be reported, got %v", reported)
+	}
+	h.open()
+	publish(t, p, 1, 2, 3)
+	if received := waitReceived(t, h, 3); !slices.Equal(received, []int{1, 2, 3}) {
+		t.Fatalf("unexpected events: %v", received)
+	}
+}
+
+func TestAsyncPubSubDrain(t *testing.T) {
+	p := newTestAsync(t, AsyncOptions[int]{
+		QueueSize:      3,
+		OverflowPolicy: BlockOverflowPolicy,
+	})
+	h := newTestHandler()
+	defer p.AddHandler(h)()
+
+	publish(t, p, 1, 2, 3)
+	waitStarted(t, h, 1)
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		h.open()
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := p.Drain(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if received := waitReceived(t, h, 3); !slices.Equal(received, []int{1, 2, 3}) {
+		t.Fatalf("unexpected events: %v", received)
+	}
+	if err := p.Publish(testEvent{N: 4}); !errors.Is(err, ErrClosed) {
+		t.Fatalf("expected %v, got %v", ErrClosed, err)
+	}
+}
+
+func TestAsyncPubSubDrainTimeout(t *testing.T) {
+	p := newTestAsync(t, AsyncOptions[int]{
+		QueueSize:      3,
+		OverflowPolicy: BlockOverflowPolicy,
+	})
+	h := newTestHandler()
+	remove := p.AddHandler(h)
+	defer func() {
+		h.open()
+		remove()
+	}()
+
+	publish(t, p, 1, 2)
+	waitStarted(t, h, 1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := p.Drain(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected %v, got %v", context.DeadlineExceeded, err)
+	}
+}
@@ -0,0 +1,141 @@
+package pubsub
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+var ErrSpillIsEmpty = errors.New("spill is empty")
+
+// Overflow storage of a subscriber queue
+type Spill[T EventType] interface {
+	Push(event Event[T]) error
+	Pop() (Event[T], error)
+	Close() error
+}
+
+type Codec[T EventType] interface {
+	Encode(event Event[T]) ([]byte, error)
+	Decode(data []byte) (Event[T], error)
+}
+
+// Concrete event types must be registered with `gob.Register`
+type GobCodec[T EventType] struct{}
+
+func (GobCodec[T]) Encode(event Event[T]) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(&event); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (GobCodec[T]) Decode(data []byte) (Event[T], error) {
+	var event Event[T]
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// Append only file with length prefixed frames.
+// The file is truncated once all events are read and removed on close,
+// spilled events are not recovered after a crash.
+type FileSpill[T EventType] struct {
+	codec  Codec[T]
+	mu     sync.Mutex
+	file   *os.File
+	writer *bufio.Writer
+	offset int64
+	size   int64
+}
+
+func NewFileSpill[T EventType](dir string, codec Codec[T]) (*FileSpill[T], error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	file, err := os.CreateTemp(dir, "spill-*")
+	if err != nil {
+		return nil, err
+	}
+	return &FileSpill[T]{
+		codec:  codec,
+		file:   file,
+		writer: bufio.NewWriter(file),
+	}, nil
+}
+
+func (s *FileSpill[T]) Push(event Event[T]) error {
+	data, err := s.codec.Encode(event)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var header [4]byte
+	binary.BigEndian.PutUint32(header[:], uint32(len(data)))
+	if _, err := s.writer.Write(header[:]); err != nil {
+		return err
+	}
+	if _, err := s.writer.Write(data); err != nil {
+		return err
+	}
+	s.size += int64(len(header) + len(data))
+	return nil
+}
+
+func (s *FileSpill[T]) Pop() (Event[T], error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.offset >= s.size {
+		return nil, ErrSpillIsEmpty
+	}
+	if err := s.writer.Flush(); err != nil {
+		return nil, err
+	}
+	var header [4]byte
+	if _, err := s.file.ReadAt(header[:], s.offset); err != nil {
+		return nil, err
+	}
+	data := make([]byte, binary.BigEndian.Uint32(header[:]))
+	if _, err := s.file.ReadAt(data, s.offset+int64(len(header))); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	s.offset += int64(len(header) + len(data))
+	if s.offset >= s.size {
+		if err := s.reset(); err != nil {
+			return nil, err
+		}
+	}
+	event, err := s.codec.Decode(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode spilled event: %w", err)
+	}
+	return event, nil
+}
+
+func (s *FileSpill[T]) reset() error {
+	if err := s.file.Truncate(0); err != nil {
+		return err
+	}
+	if _, err := s.file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	s.writer.Reset(s.file)
+	s.offset = 0
+	s.size = 0
+	return nil
+}
+
+func (s *FileSpill[T]) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return errors.Join(s.file.Close(), os.Remove(s.file.Name()))
+}
@@ -1,6 +1,7 @@
 package profiler_module
 
 import (
+	"expvar"
 	"net/http"
 	"net/http/pprof"
 
@@ -20,6 +21,7 @@ func UseHttpRouter(mux *http.ServeMux) *http.ServeMux {
 	mux.Handle("/debug/pprof/profile", http.HandlerFunc(pprof.Profile))
 	mux.Handle("/debug/pprof/symbol", http.HandlerFunc(pprof.Symbol))
 	mux.Handle("/debug/pprof/trace", http.HandlerFunc(pprof.Trace))
+	mux.Handle("/debug/vars", expvar.Handler())
 	return mux
 }
 
@@ -0,0 +1,65 @@
+package shared_http_adapters
+
+import "github.com/x0k/veterinary-clinic-backend/internal/shared"
+
+type TimeDTO struct {
+	Minutes int `json:"minutes"`
+	Hours   int `json:"hours"`
+}
+
+func TimeToDTO(time shared.Time) TimeDTO {
+	return TimeDTO{
+		Minutes: time.Minutes,
+		Hours:   time.Hours,
+	}
+}
+
+type TimePeriodDTO struct {
+	Start TimeDTO `json:"start"`
+	End   TimeDTO `json:"end"`
+}
+
+func TimePeriodToDTO(period shared.TimePeriod) TimePeriodDTO {
+	return TimePeriodDTO{
+		Start: TimeToDTO(period.Start),
+		End:   TimeToDTO(period.End),
+	}
+}
+
+type DateDTO struct {
+	Day   int `json:"day"`
+	Month int `json:"month"`
+	Year  int `json:"year"`
+}
+
+func DateToDTO(date shared.Date) DateDTO {
+	return DateDTO{
+		Day:   date.Day,
+		Month: date.Month,
+		Year:  date.Year,
+	}
+}
+
+type DateTimeDTO struct {
+	Date DateDTO `json:"date"`
+	Time TimeDTO `json:"time"`
+}
+
+func DateTimeToDTO(dateTime shared.DateTime) DateTimeDTO {
+	return DateTimeDTO{
+		Date: DateToDTO(dateTime.Date),
+		Time: TimeToDTO(dateTime.Time),
+	}
+}
+
+type DateTimePeriodDTO struct {
+	Start DateTimeDTO `json:"start"`
+	End   DateTimeDTO `json:"end"`
+}
+
+func DateTimePeriodToDTO(period shared.DateTimePeriod) DateTimePeriodDTO {
+	return DateTimePeriodDTO{
+		Start: DateTimeToDTO(period.Start),
+		End:   DateTimeToDTO(period.End),
+	}
+}